@@ -12,6 +12,7 @@ queries_path = {
     'buildah': os.path.join(queries_basepath, 'buildah', '*'),
     'cicd': os.path.join(queries_basepath, 'cicd', '**', '*'),
     'cloudformation': os.path.join(queries_basepath, 'cloudFormation', '**', '*'),
+    'configconnector': os.path.join(queries_basepath, 'configConnector', '**', '*'),
     'openapi': os.path.join(queries_basepath, 'openAPI', '**', '*'),
     'crossplane': os.path.join(queries_basepath, 'crossplane',"**" ,'*'),
     'k8s': os.path.join(queries_basepath, 'k8s', '*'),
@@ -31,6 +32,7 @@ samples_ext = {
     'buildah': ['sh'],
     'cicd': ['yaml'],
     'cloudformation': ['yaml', 'json'],
+    'configconnector': ['yaml'],
     'crossplane': ['yaml'],
     'openapi': ['yaml', 'json'],
     'ansible': ['yaml'],
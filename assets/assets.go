@@ -1,6 +1,9 @@
 package assets
 
-import "embed" // used for embedding KICS libraries
+import (
+	"embed" // used for embedding KICS libraries
+	"io/fs"
+)
 
 //go:embed libraries/*.rego
 var embeddedLibraries embed.FS
@@ -8,6 +11,9 @@ var embeddedLibraries embed.FS
 //go:embed libraries/*.json
 var embeddedLibraryData embed.FS
 
+//go:embed libraries/versions/*/*.rego
+var embeddedVersionedLibraries embed.FS
+
 //go:embed queries/common/passwords_and_secrets/metadata.json
 var SecretsQueryMetadataJSON string
 
@@ -27,3 +33,16 @@ func GetEmbeddedLibraryData(platform string) (string, error) {
 
 	return string(content), err
 }
+
+// GetEmbeddedVersionedLibrary returns the embedded library.rego of the version for the platform passed in the argument
+func GetEmbeddedVersionedLibrary(version, platform string) (string, error) {
+	content, err := embeddedVersionedLibraries.ReadFile("libraries/versions/" + version + "/" + platform + ".rego")
+
+	return string(content), err
+}
+
+// GetEmbeddedLibraries returns the file systems of the embedded libraries, of their versions and of their
+// input data, in all the files are in the libraries directory
+func GetEmbeddedLibraries() []fs.FS {
+	return []fs.FS{embeddedLibraries, embeddedVersionedLibraries, embeddedLibraryData}
+}
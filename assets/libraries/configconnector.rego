@@ -0,0 +1,14 @@
+package generic.configconnector
+
+# Checks if the document is a Config Connector resource of the service and the kind, e.g. storage and StorageBucket
+is_resource(document, service, kind) {
+	startswith(document.apiVersion, sprintf("%s.cnrm.cloud.google.com/", [service]))
+	document.kind == kind
+}
+
+# Gets the name of the resource on GCP, spec.resourceID when it differs from the name of the Kubernetes object
+get_resource_name(resource) = name {
+	name := resource.spec.resourceID
+} else = name {
+	name := resource.metadata.name
+}
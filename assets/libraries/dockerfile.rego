@@ -70,3 +70,14 @@ check_multi_stage(imageName, images) {
     sortedIndex := sort(unsortedIndex)
     imageName == sortedIndex[minus(count(sortedIndex), 1)].Name
 } 
+
+# get_stage_commands returns the commands of the stage preceded by the commands of the stages it is built from,
+# so the instructions inherited by the stage are taken into account
+get_stage_commands(document, name) = commands {
+	stages := [stage | stage := document.stages[_]; stage.name == name]
+	stage := stages[minus(count(stages), 1)]
+	inherited := [cmd | ancestor := stage.ancestors[_]; cmd := document.command[ancestor][_]]
+	commands := array.concat(inherited, document.command[name])
+} else = document.command[name] {
+	true
+}
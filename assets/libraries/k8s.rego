@@ -108,3 +108,14 @@ valid_pod_spec_kind_list = [
 	"Revision",
 	"ContainerSource",
 ]
+
+# getNamespace returns the namespace group (every manifest in the same namespace) the document belongs to
+getNamespace(document) = namespace {
+	namespace := input.groups.kubernetes_namespaces[_]
+	namespace.documents[_] == document.id
+}
+
+# getNamespaceResources returns the manifests of the given kind in the same namespace as the document
+getNamespaceResources(document, kind) = resources {
+	resources := getNamespace(document).resources[kind]
+} else = []
@@ -583,3 +583,22 @@ check_key_empty(disk_encryption_key) = key {
 	common_lib.emptyOrNull(disk_encryption_key.kms_key_self_link)
 	key := "kms_key_self_link"
 }
+
+# get_module returns the terraform module (every document in the same directory) the document belongs to
+get_module(doc) = module {
+	module := input.groups.terraform_modules[_]
+	module.documents[_] == doc.id
+}
+
+# get_referenced_resources returns the resources of resourceType referenced by the resource
+# identified by resourceType.name, e.g. get_referenced_resources(doc, "aws_instance.web", "aws_security_group")
+get_referenced_resources(doc, address, resourceType) = resources {
+	module := get_module(doc)
+	resources := {name: resource |
+		reference := module.references[address][_]
+		parts := split(reference, ".")
+		parts[0] == resourceType
+		name := parts[1]
+		resource := module.resource[resourceType][name]
+	}
+} else = {}
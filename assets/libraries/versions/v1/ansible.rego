@@ -0,0 +1,55 @@
+# Version 1 of the ansible library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.ansible
+package generic.v1.ansible
+
+import data.generic.ansible as lib
+
+TasksPerDocument := lib.TasksPerDocument
+
+allowsPort(allowed, port) = result {
+	result := lib.allowsPort(allowed, port)
+}
+
+checkState(task) = result {
+	result := lib.checkState(task)
+}
+
+check_database_flags_content(database_flags, flagName, flagValue) = result {
+	result := lib.check_database_flags_content(database_flags, flagName, flagValue)
+}
+
+getTasks(document) = result {
+	result := lib.getTasks(document)
+}
+
+getTasksFromBlocks(playbook) = result {
+	result := lib.getTasksFromBlocks(playbook)
+}
+
+installer_modules := lib.installer_modules
+
+isAnsibleFalse(answer) = result {
+	result := lib.isAnsibleFalse(answer)
+}
+
+isAnsibleTrue(answer) = result {
+	result := lib.isAnsibleTrue(answer)
+}
+
+isEntireNetwork(cidr) = result {
+	result := lib.isEntireNetwork(cidr)
+}
+
+isPortInRule(rule, portNumber) = result {
+	result := lib.isPortInRule(rule, portNumber)
+}
+
+tasks := lib.tasks
+
+validGroup(arg0) = result {
+	result := lib.validGroup(arg0)
+}
+
+validPath(path) = result {
+	result := lib.validPath(path)
+}
@@ -0,0 +1,43 @@
+# Version 1 of the azureresourcemanager library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.azureresourcemanager
+package generic.v1.azureresourcemanager
+
+import data.generic.azureresourcemanager as lib
+
+contains_port(properties, targetPort) = result {
+	result := lib.contains_port(properties, targetPort)
+}
+
+contains_target_port(targetPort, port) = result {
+	result := lib.contains_target_port(targetPort, port)
+}
+
+getDefaultValueFromParametersIfPresent(doc, valueToCheck) = result {
+	result := lib.getDefaultValueFromParametersIfPresent(doc, valueToCheck)
+}
+
+get_children(doc, parent, path) = result {
+	result := lib.get_children(doc, parent, path)
+}
+
+get_outer_children(doc, nameParent) = result {
+	result := lib.get_outer_children(doc, nameParent)
+}
+
+get_sg_info(value) = result {
+	result := lib.get_sg_info(value)
+}
+
+isDisabledOrUndefined(doc, resource, parametersPath) = result {
+	result := lib.isDisabledOrUndefined(doc, resource, parametersPath)
+}
+
+isParameterReference(valueToCheck) = result {
+	result := lib.isParameterReference(valueToCheck)
+}
+
+relevantSourceAddPrefix := lib.relevantSourceAddPrefix
+
+source_address_prefix_is_open(properties) = result {
+	result := lib.source_address_prefix_is_open(properties)
+}
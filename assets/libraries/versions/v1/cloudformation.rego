@@ -0,0 +1,53 @@
+# Version 1 of the cloudformation library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.cloudformation
+package generic.v1.cloudformation
+
+import data.generic.cloudformation as lib
+
+checkAction(currentAction, actionToCompare) = result {
+	result := lib.checkAction(currentAction, actionToCompare)
+}
+
+getBucketName(resource) = result {
+	result := lib.getBucketName(resource)
+}
+
+getPath(path) = result {
+	result := lib.getPath(path)
+}
+
+getResourcesByType(resources, type) = result {
+	result := lib.getResourcesByType(resources, type)
+}
+
+get_encryption(resource) = result {
+	result := lib.get_encryption(resource)
+}
+
+get_name(targetName) = result {
+	result := lib.get_name(targetName)
+}
+
+get_resource_accessibility(nameRef, type, key) = result {
+	result := lib.get_resource_accessibility(nameRef, type, key)
+}
+
+get_resource_name(resource, resourceDefinitionName) = result {
+	result := lib.get_resource_name(resource, resourceDefinitionName)
+}
+
+hasSecretManager(str, document) = result {
+	result := lib.hasSecretManager(str, document)
+}
+
+isCloudFormationFalse(answer) = result {
+	result := lib.isCloudFormationFalse(answer)
+}
+
+isLoadBalancer(resource) = result {
+	result := lib.isLoadBalancer(resource)
+}
+
+resourceFieldName := lib.resourceFieldName
+
+udpPortsMap := lib.udpPortsMap
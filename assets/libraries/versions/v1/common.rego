@@ -0,0 +1,233 @@
+# Version 1 of the common library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.common
+package generic.v1.common
+
+import data.generic.common as lib
+
+allowsAllPrincipalsToAssume(resource, statement) = result {
+	result := lib.allowsAllPrincipalsToAssume(resource, statement)
+}
+
+any_principal(statement) = result {
+	result := lib.any_principal(statement)
+}
+
+between(value, min, max) = result {
+	result := lib.between(value, min, max)
+}
+
+build_search_line(path, obj) = result {
+	result := lib.build_search_line(path, obj)
+}
+
+calc_IP_value(ip) = result {
+	result := lib.calc_IP_value(ip)
+}
+
+check_actions(statement, typeAction) = result {
+	result := lib.check_actions(statement, typeAction)
+}
+
+check_principals(statement) = result {
+	result := lib.check_principals(statement)
+}
+
+check_selector(filter, value, op, name) = result {
+	result := lib.check_selector(filter, value, op, name)
+}
+
+compareArrays(arrayOne, arrayTwo) = result {
+	result := lib.compareArrays(arrayOne, arrayTwo)
+}
+
+concat_path(path) = result {
+	result := lib.concat_path(path)
+}
+
+containsOrInArrayContains(field, value) = result {
+	result := lib.containsOrInArrayContains(field, value)
+}
+
+convert_path_item(pathItem) = result {
+	result := lib.convert_path_item(pathItem)
+}
+
+emptyOrNull(arg0) = result {
+	result := lib.emptyOrNull(arg0)
+}
+
+engines := lib.engines
+
+equalsOrInArray(field, value) = result {
+	result := lib.equalsOrInArray(field, value)
+}
+
+expired(expirationDate) = result {
+	result := lib.expired(expirationDate)
+}
+
+find_selector_by_value(filter, str) = result {
+	result := lib.find_selector_by_value(filter, str)
+}
+
+getDays(date, daysInMonth) = result {
+	result := lib.getDays(date, daysInMonth)
+}
+
+get_bom_output(bom_output, policy) = result {
+	result := lib.get_bom_output(bom_output, policy)
+}
+
+get_encryption_if_exists(resource) = result {
+	result := lib.get_encryption_if_exists(resource)
+}
+
+get_group_from_policy_attachment(attachment) = result {
+	result := lib.get_group_from_policy_attachment(attachment)
+}
+
+get_latest_software_version(name) = result {
+	result := lib.get_latest_software_version(name)
+}
+
+get_module_equivalent_key(provider, moduleName, resource, key) = result {
+	result := lib.get_module_equivalent_key(provider, moduleName, resource, key)
+}
+
+get_nested_values_info(object, array_vals) = result {
+	result := lib.get_nested_values_info(object, array_vals)
+}
+
+get_policy(p) = result {
+	result := lib.get_policy(p)
+}
+
+get_role_from_policy_attachment(attachment) = result {
+	result := lib.get_role_from_policy_attachment(attachment)
+}
+
+get_statement(policy) = result {
+	result := lib.get_statement(policy)
+}
+
+get_tag_name_if_exists(resource) = result {
+	result := lib.get_tag_name_if_exists(resource)
+}
+
+get_user_from_policy_attachment(attachment) = result {
+	result := lib.get_user_from_policy_attachment(attachment)
+}
+
+get_version(name) = result {
+	result := lib.get_version(name)
+}
+
+group_unrecommended_permission_policy_scenarios(targetGroup, permission) = result {
+	result := lib.group_unrecommended_permission_policy_scenarios(targetGroup, permission)
+}
+
+has_external_id(statement) = result {
+	result := lib.has_external_id(statement)
+}
+
+has_mfa(statement) = result {
+	result := lib.has_mfa(statement)
+}
+
+has_wildcard(statement, typeAction) = result {
+	result := lib.has_wildcard(statement, typeAction)
+}
+
+inArray(list, item) = result {
+	result := lib.inArray(list, item)
+}
+
+isCommonKey(p) = result {
+	result := lib.isCommonKey(p)
+}
+
+isOSDir(mountPath) = result {
+	result := lib.isOSDir(mountPath)
+}
+
+isPrivateIP(ipVal) = result {
+	result := lib.isPrivateIP(ipVal)
+}
+
+is_allow_effect(statement) = result {
+	result := lib.is_allow_effect(statement)
+}
+
+is_assume_role(statement) = result {
+	result := lib.is_assume_role(statement)
+}
+
+is_aws_ebs_optimized_by_default(instanceType) = result {
+	result := lib.is_aws_ebs_optimized_by_default(instanceType)
+}
+
+is_cross_account(statement) = result {
+	result := lib.is_cross_account(statement)
+}
+
+is_ingress(firewall) = result {
+	result := lib.is_ingress(firewall)
+}
+
+is_recommended_tls(field) = result {
+	result := lib.is_recommended_tls(field)
+}
+
+is_unrestricted(sourceRange) = result {
+	result := lib.is_unrestricted(sourceRange)
+}
+
+json_unmarshal(s) = result {
+	result := lib.json_unmarshal(s)
+}
+
+list_contains(dirs, elem) = result {
+	result := lib.list_contains(dirs, elem)
+}
+
+remove_last_point(searchKey) = result {
+	result := lib.remove_last_point(searchKey)
+}
+
+resolve_path(pathItem) = result {
+	result := lib.resolve_path(pathItem)
+}
+
+role_unrecommended_permission_policy_scenarios(targetRole, permission) = result {
+	result := lib.role_unrecommended_permission_policy_scenarios(targetRole, permission)
+}
+
+tcpPortsMap := lib.tcpPortsMap
+
+unrecommended_permission_policy(resourcePolicy, permission) = result {
+	result := lib.unrecommended_permission_policy(resourcePolicy, permission)
+}
+
+unsecured_cors_rule(methods, headers, origins) = result {
+	result := lib.unsecured_cors_rule(methods, headers, origins)
+}
+
+user_unrecommended_permission_policy_scenarios(targetUser, permission) = result {
+	result := lib.user_unrecommended_permission_policy_scenarios(targetUser, permission)
+}
+
+valid_for_iam_engine_and_version_check(resource, engineVar, engineVersionVar, instanceClassVar) = result {
+	result := lib.valid_for_iam_engine_and_version_check(resource, engineVar, engineVersionVar, instanceClassVar)
+}
+
+valid_key(obj, key) = result {
+	result := lib.valid_key(obj, key)
+}
+
+valid_non_empty_key(field, key) = result {
+	result := lib.valid_non_empty_key(field, key)
+}
+
+weakCipher(aux) = result {
+	result := lib.weakCipher(aux)
+}
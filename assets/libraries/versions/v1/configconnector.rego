@@ -0,0 +1,13 @@
+# Version 1 of the configconnector library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.configconnector
+package generic.v1.configconnector
+
+import data.generic.configconnector as lib
+
+get_resource_name(resource) = result {
+	result := lib.get_resource_name(resource)
+}
+
+is_resource(document, service, kind) = result {
+	result := lib.is_resource(document, service, kind)
+}
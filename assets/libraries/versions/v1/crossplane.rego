@@ -0,0 +1,15 @@
+# Version 1 of the crossplane library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.crossplane
+package generic.v1.crossplane
+
+import data.generic.crossplane as lib
+
+crossplaneResourcesWithName := lib.crossplaneResourcesWithName
+
+getPath(path) = result {
+	result := lib.getPath(path)
+}
+
+getResourceName(resource) = result {
+	result := lib.getResourceName(resource)
+}
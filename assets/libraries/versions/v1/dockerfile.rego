@@ -0,0 +1,29 @@
+# Version 1 of the dockerfile library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.dockerfile
+package generic.v1.dockerfile
+
+import data.generic.dockerfile as lib
+
+arrayContains(array, list) = result {
+	result := lib.arrayContains(array, list)
+}
+
+check_multi_stage(imageName, images) = result {
+	result := lib.check_multi_stage(imageName, images)
+}
+
+getCommands(commands) = result {
+	result := lib.getCommands(commands)
+}
+
+getPackages(commands, command) = result {
+	result := lib.getPackages(commands, command)
+}
+
+get_stage_commands(document, name) = result {
+	result := lib.get_stage_commands(document, name)
+}
+
+withVersion(pack) = result {
+	result := lib.withVersion(pack)
+}
@@ -0,0 +1,67 @@
+# Version 1 of the k8s library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.k8s
+package generic.v1.k8s
+
+import data.generic.k8s as lib
+
+betweenValues(value, higher, lower) = result {
+	result := lib.betweenValues(value, higher, lower)
+}
+
+checkKind(currentKind, listKinds) = result {
+	result := lib.checkKind(currentKind, listKinds)
+}
+
+checkKindWithKnative(doc, listKinds, knativeKinds) = result {
+	result := lib.checkKindWithKnative(doc, listKinds, knativeKinds)
+}
+
+getNamespace(document) = result {
+	result := lib.getNamespace(document)
+}
+
+getNamespaceResources(document, kind) = result {
+	result := lib.getNamespaceResources(document, kind)
+}
+
+getSpecInfo(document) = result {
+	result := lib.getSpecInfo(document)
+}
+
+hasFlag(container, flag) = result {
+	result := lib.hasFlag(container, flag)
+}
+
+hasFlagBetweenValues(container, flag, higher, lower) = result {
+	result := lib.hasFlagBetweenValues(container, flag, higher, lower)
+}
+
+hasFlagEqualOrGreaterThanValue(container, flag, value) = result {
+	result := lib.hasFlagEqualOrGreaterThanValue(container, flag, value)
+}
+
+hasFlagWithValue(container, flag, value) = result {
+	result := lib.hasFlagWithValue(container, flag, value)
+}
+
+hasValue(values, value) = result {
+	result := lib.hasValue(values, value)
+}
+
+startAndEndWithFlag(container, flag, ext) = result {
+	result := lib.startAndEndWithFlag(container, flag, ext)
+}
+
+startWithAndEndWithArray(arr, item, ext) = result {
+	result := lib.startWithAndEndWithArray(arr, item, ext)
+}
+
+startWithFlag(container, flag) = result {
+	result := lib.startWithFlag(container, flag)
+}
+
+startsWithArray(arr, item) = result {
+	result := lib.startsWithArray(arr, item)
+}
+
+valid_pod_spec_kind_list := lib.valid_pod_spec_kind_list
@@ -0,0 +1,135 @@
+# Version 1 of the openapi library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.openapi
+package generic.v1.openapi
+
+import data.generic.openapi as lib
+
+api_key_exposed(doc, version, s) = result {
+	result := lib.api_key_exposed(doc, version, s)
+}
+
+check_content(s, field, key) = result {
+	result := lib.check_content(s, field, key)
+}
+
+check_definitions(doc, object, name) = result {
+	result := lib.check_definitions(doc, object, name)
+}
+
+check_openapi(doc) = result {
+	result := lib.check_openapi(doc)
+}
+
+check_reference_unexisting(doc, reference, type) = result {
+	result := lib.check_reference_unexisting(doc, reference, type)
+}
+
+check_reference_unexisting_swagger(doc, reference, type) = result {
+	result := lib.check_reference_unexisting_swagger(doc, reference, type)
+}
+
+check_scheme(doc, schemeKey, scope, version) = result {
+	result := lib.check_scheme(doc, schemeKey, scope, version)
+}
+
+check_unused_reference(doc, referenceName, type) = result {
+	result := lib.check_unused_reference(doc, referenceName, type)
+}
+
+concat_default_value(path, defaultValue) = result {
+	result := lib.concat_default_value(path, defaultValue)
+}
+
+concat_path(path) = result {
+	result := lib.concat_path(path)
+}
+
+content_allowed(operation, code) = result {
+	result := lib.content_allowed(operation, code)
+}
+
+get_complete_search_key(n, parcialSk, property) = result {
+	result := lib.get_complete_search_key(n, parcialSk, property)
+}
+
+get_discriminator(schema, version) = result {
+	result := lib.get_discriminator(schema, version)
+}
+
+get_name(p, name) = result {
+	result := lib.get_name(p, name)
+}
+
+get_schema_info(doc, version) = result {
+	result := lib.get_schema_info(doc, version)
+}
+
+improperly_defined(params, value) = result {
+	result := lib.improperly_defined(params, value)
+}
+
+incorrect_ref(ref, object) = result {
+	result := lib.incorrect_ref(ref, object)
+}
+
+incorrect_ref_swagger(ref, object) = result {
+	result := lib.incorrect_ref_swagger(ref, object)
+}
+
+invalid_field(field, type) = result {
+	result := lib.invalid_field(field, type)
+}
+
+is_mimetype_valid(content) = result {
+	result := lib.is_mimetype_valid(content)
+}
+
+is_missing_attribute_and_ref(obj, attr) = result {
+	result := lib.is_missing_attribute_and_ref(obj, attr)
+}
+
+is_numeric_type(type) = result {
+	result := lib.is_numeric_type(type)
+}
+
+is_operation(path) = result {
+	result := lib.is_operation(path)
+}
+
+is_path_template(path) = result {
+	result := lib.is_path_template(path)
+}
+
+is_valid_mime(mime) = result {
+	result := lib.is_valid_mime(mime)
+}
+
+is_valid_url(url) = result {
+	result := lib.is_valid_url(url)
+}
+
+require_objects_v2 := lib.require_objects_v2
+
+require_objects_v3 := lib.require_objects_v3
+
+resolve_path(pathItem) = result {
+	result := lib.resolve_path(pathItem)
+}
+
+shared := lib.shared
+
+undefined_field_in_json_object(doc, schema_ref, field, version) = result {
+	result := lib.undefined_field_in_json_object(doc, schema_ref, field, version)
+}
+
+undefined_field_in_numeric_schema(value, field) = result {
+	result := lib.undefined_field_in_numeric_schema(value, field)
+}
+
+undefined_field_in_string_type(value, field) = result {
+	result := lib.undefined_field_in_string_type(value, field)
+}
+
+valid_key(obj, key) = result {
+	result := lib.valid_key(obj, key)
+}
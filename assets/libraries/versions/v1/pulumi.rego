@@ -0,0 +1,11 @@
+# Version 1 of the pulumi library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.pulumi
+package generic.v1.pulumi
+
+import data.generic.pulumi as lib
+
+getResourceName(resource, logicName) = result {
+	result := lib.getResourceName(resource, logicName)
+}
+
+pulumiResourcesWithName := lib.pulumiResourcesWithName
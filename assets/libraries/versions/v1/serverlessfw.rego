@@ -0,0 +1,15 @@
+# Version 1 of the serverlessfw library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.serverlessfw
+package generic.v1.serverlessfw
+
+import data.generic.serverlessfw as lib
+
+get_service_name(document) = result {
+	result := lib.get_service_name(document)
+}
+
+resourceTypeMapping(resourceType, provider) = result {
+	result := lib.resourceTypeMapping(resourceType, provider)
+}
+
+resourcesMap := lib.resourcesMap
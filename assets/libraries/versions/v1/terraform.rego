@@ -0,0 +1,95 @@
+# Version 1 of the terraform library, its functions keep their signature and behaviour across the KICS
+# releases, the custom queries import it with: import data.generic.v1.terraform
+package generic.v1.terraform
+
+import data.generic.terraform as lib
+
+allows_action_from_all_principals(json_policy, action) = result {
+	result := lib.allows_action_from_all_principals(json_policy, action)
+}
+
+anyPrincipal(statement) = result {
+	result := lib.anyPrincipal(statement)
+}
+
+check_cidr(rule) = result {
+	result := lib.check_cidr(rule)
+}
+
+check_key_empty(disk_encryption_key) = result {
+	result := lib.check_key_empty(disk_encryption_key)
+}
+
+check_member(attribute, search) = result {
+	result := lib.check_member(attribute, search)
+}
+
+check_resource_tags(p) = result {
+	result := lib.check_resource_tags(p)
+}
+
+containsPort(rule, port) = result {
+	result := lib.containsPort(rule, port)
+}
+
+empty_array(arr) = result {
+	result := lib.empty_array(arr)
+}
+
+getProtocolList(arg0) = result {
+	result := lib.getProtocolList(arg0)
+}
+
+getSpecInfo(resource) = result {
+	result := lib.getSpecInfo(resource)
+}
+
+getStatement(policy) = result {
+	result := lib.getStatement(policy)
+}
+
+get_accessibility(resource, name, resourcePolicyName, resourceTarget) = result {
+	result := lib.get_accessibility(resource, name, resourcePolicyName, resourceTarget)
+}
+
+get_module(doc) = result {
+	result := lib.get_module(doc)
+}
+
+get_referenced_resources(doc, address, resourceType) = result {
+	result := lib.get_referenced_resources(doc, address, resourceType)
+}
+
+get_resource_name(resource, resourceDefinitionName) = result {
+	result := lib.get_resource_name(resource, resourceDefinitionName)
+}
+
+get_specific_resource_name(resource, resourceType, resourceDefinitionName) = result {
+	result := lib.get_specific_resource_name(resource, resourceType, resourceDefinitionName)
+}
+
+has_target_resource(bucketName, resourceName) = result {
+	result := lib.has_target_resource(bucketName, resourceName)
+}
+
+is_default_password(password) = result {
+	result := lib.is_default_password(password)
+}
+
+is_publicly_accessible(policy) = result {
+	result := lib.is_publicly_accessible(policy)
+}
+
+matches(target, name) = result {
+	result := lib.matches(target, name)
+}
+
+portOpenToInternet(rule, port) = result {
+	result := lib.portOpenToInternet(rule, port)
+}
+
+resourceFieldName := lib.resourceFieldName
+
+uses_aws_managed_key(key, awsManagedKey) = result {
+	result := lib.uses_aws_managed_key(key, awsManagedKey)
+}
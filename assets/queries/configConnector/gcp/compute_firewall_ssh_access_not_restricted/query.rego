@@ -0,0 +1,51 @@
+package Cx
+
+import data.generic.common as common_lib
+import data.generic.configconnector as cc_lib
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "compute", "ComputeFirewall")
+	spec := resource.spec
+
+	common_lib.is_ingress(spec)
+	common_lib.is_unrestricted(spec.sourceRanges[_])
+	allow := spec.allow[a]
+	ports := ssh_ports(allow)
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.allow", [resource.metadata.name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": sprintf("'spec.allow[%d]' should not allow SSH port 22 from the Internet", [a]),
+		"keyActualValue": sprintf("'spec.allow[%d]' allows the ports %s from the Internet", [a, ports]),
+		"searchLine": common_lib.build_search_line(["spec", "allow", a], []),
+	}
+}
+
+ssh_ports(allow) = ports {
+	is_tcp_or_all(allow.protocol)
+	not common_lib.valid_key(allow, "ports")
+	ports := "0-65535"
+} else = ports {
+	is_tcp_or_all(allow.protocol)
+	ports := allow.ports[_]
+	includes_ssh(ports)
+}
+
+is_tcp_or_all(protocol) {
+	protocols := {"tcp", "all"}
+	lower(protocol) == protocols[_]
+}
+
+includes_ssh(ports) {
+	contains(ports, "-")
+	bounds := split(ports, "-")
+	to_number(bounds[0]) <= 22
+	to_number(bounds[1]) >= 22
+} else {
+	not contains(ports, "-")
+	to_number(ports) == 22
+}
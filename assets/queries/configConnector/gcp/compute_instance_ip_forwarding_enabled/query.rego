@@ -0,0 +1,22 @@
+package Cx
+
+import data.generic.common as common_lib
+import data.generic.configconnector as cc_lib
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "compute", "ComputeInstance")
+
+	resource.spec.canIpForward == true
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.canIpForward", [resource.metadata.name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "'spec.canIpForward' should be undefined or set to false",
+		"keyActualValue": "'spec.canIpForward' is set to true",
+		"searchLine": common_lib.build_search_line(["spec", "canIpForward"], []),
+	}
+}
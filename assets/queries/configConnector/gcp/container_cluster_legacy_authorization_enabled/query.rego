@@ -0,0 +1,22 @@
+package Cx
+
+import data.generic.common as common_lib
+import data.generic.configconnector as cc_lib
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "container", "ContainerCluster")
+
+	resource.spec.enableLegacyAbac == true
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.enableLegacyAbac", [resource.metadata.name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "'spec.enableLegacyAbac' should be undefined or set to false",
+		"keyActualValue": "'spec.enableLegacyAbac' is set to true",
+		"searchLine": common_lib.build_search_line(["spec", "enableLegacyAbac"], []),
+	}
+}
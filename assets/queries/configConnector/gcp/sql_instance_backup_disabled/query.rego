@@ -0,0 +1,42 @@
+package Cx
+
+import data.generic.common as common_lib
+import data.generic.configconnector as cc_lib
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "sql", "SQLInstance")
+	settings := resource.spec.settings
+
+	not common_lib.valid_key(settings, "backupConfiguration")
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.settings", [resource.metadata.name]),
+		"issueType": "MissingAttribute",
+		"keyExpectedValue": "'spec.settings.backupConfiguration.enabled' should be defined and set to true",
+		"keyActualValue": "'spec.settings.backupConfiguration' is undefined",
+		"searchLine": common_lib.build_search_line(["spec", "settings"], []),
+	}
+}
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "sql", "SQLInstance")
+	backup := resource.spec.settings.backupConfiguration
+
+	not backup.enabled == true
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.settings.backupConfiguration", [resource.metadata.name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "'spec.settings.backupConfiguration.enabled' should be set to true",
+		"keyActualValue": "'spec.settings.backupConfiguration.enabled' is not set to true",
+		"searchLine": common_lib.build_search_line(["spec", "settings", "backupConfiguration"], []),
+	}
+}
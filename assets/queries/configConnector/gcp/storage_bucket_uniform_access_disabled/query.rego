@@ -0,0 +1,40 @@
+package Cx
+
+import data.generic.common as common_lib
+import data.generic.configconnector as cc_lib
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "storage", "StorageBucket")
+
+	not common_lib.valid_key(resource.spec, "uniformBucketLevelAccess")
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec", [resource.metadata.name]),
+		"issueType": "MissingAttribute",
+		"keyExpectedValue": "'spec.uniformBucketLevelAccess' should be defined and set to true",
+		"keyActualValue": "'spec.uniformBucketLevelAccess' is undefined",
+		"searchLine": common_lib.build_search_line(["spec"], []),
+	}
+}
+
+CxPolicy[result] {
+	resource := input.document[i]
+	cc_lib.is_resource(resource, "storage", "StorageBucket")
+
+	resource.spec.uniformBucketLevelAccess == false
+
+	result := {
+		"documentId": resource.id,
+		"resourceType": resource.kind,
+		"resourceName": cc_lib.get_resource_name(resource),
+		"searchKey": sprintf("metadata.name={{%s}}.spec.uniformBucketLevelAccess", [resource.metadata.name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "'spec.uniformBucketLevelAccess' should be set to true",
+		"keyActualValue": "'spec.uniformBucketLevelAccess' is set to false",
+		"searchLine": common_lib.build_search_line(["spec", "uniformBucketLevelAccess"], []),
+	}
+}
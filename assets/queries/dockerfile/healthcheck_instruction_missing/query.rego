@@ -3,10 +3,10 @@ package Cx
 import data.generic.dockerfile as dockerLib
 
 CxPolicy[result] {
-	resource := input.document[i].command[name]
+	input.document[i].command[name]
 	dockerLib.check_multi_stage(name, input.document[i].command)
 
-	not contains(resource, "healthcheck")
+	not contains(dockerLib.get_stage_commands(input.document[i], name), "healthcheck")
 
 	result := {
 		"documentId": input.document[i].id,
@@ -3,11 +3,11 @@ package Cx
 import data.generic.dockerfile as dockerLib
 
 CxPolicy[result] {
-	resource := input.document[i].command[name]
+	input.document[i].command[name]
 	dockerLib.check_multi_stage(name, input.document[i].command)
 
 	not name == "scratch"
-	not has_user_instruction(resource)
+	not has_user_instruction(dockerLib.get_stage_commands(input.document[i], name))
 
 	result := {
 		"documentId": input.document[i].id,
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/Checkmarx/kics/internal/console"
@@ -10,6 +11,10 @@ import (
 
 func main() {
 	if err := console.Execute(); err != nil {
+		var exitErr *helpers.ExitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		if helpers.ShowError("errors") {
 			os.Exit(constants.EngineErrorCode)
 		}
@@ -2,7 +2,7 @@
 package testcases
 
 // E2E-CLI-068 - KICS  scan but recover from corrupted dockerfile
-// should perform the scan successfully and return exit code 50
+// should perform the scan successfully and return exit code 70 since a query fails on the corrupted dockerfile
 func init() { //nolint
 	testSample := TestCase{
 		Name: "should perform a valid scan and recover from a corrupted dockerfile [E2E-CLI-068]",
@@ -19,7 +19,7 @@ func init() { //nolint
 				},
 			},
 		},
-		WantStatus: []int{50},
+		WantStatus: []int{70},
 	}
 
 	Tests = append(Tests, testSample)
@@ -0,0 +1,28 @@
+package testcases
+
+// E2E-CLI-094 - KICS scan with a query that fails to execute
+// should return the query error status code, which takes precedence over the parse failure status code,
+// unless errors are ignored on exit
+func init() { //nolint
+	testSample := TestCase{
+		Name: "should return the query error status code when a query fails to execute [E2E-CLI-094]",
+		Args: args{
+			Args: []cmdArgs{
+				[]string{"scan",
+					"-p", "/path/test/fixtures/test_exit_codes/query_error",
+					"-q", "/path/test/fixtures/test_exit_codes/queries"},
+
+				[]string{"scan", "--strict-parsing",
+					"-p", "/path/test/fixtures/test_exit_codes",
+					"-q", "/path/test/fixtures/test_exit_codes/queries"},
+
+				[]string{"scan", "--ignore-on-exit", "errors",
+					"-p", "/path/test/fixtures/test_exit_codes/query_error",
+					"-q", "/path/test/fixtures/test_exit_codes/queries"},
+			},
+		},
+		WantStatus: []int{70, 70, 0},
+	}
+
+	Tests = append(Tests, testSample)
+}
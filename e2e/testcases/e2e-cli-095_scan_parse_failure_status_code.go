@@ -0,0 +1,23 @@
+package testcases
+
+// E2E-CLI-095 - KICS scan with a file that fails to be parsed
+// should return the parse failure status code only with --strict-parsing
+func init() { //nolint
+	testSample := TestCase{
+		Name: "should return the parse failure status code on strict parsing [E2E-CLI-095]",
+		Args: args{
+			Args: []cmdArgs{
+				[]string{"scan", "--strict-parsing",
+					"-p", "/path/test/fixtures/test_exit_codes/parse_failure",
+					"-q", "/path/assets/queries/terraform/aws/alb_deletion_protection_disabled"},
+
+				[]string{"scan",
+					"-p", "/path/test/fixtures/test_exit_codes/parse_failure",
+					"-q", "/path/assets/queries/terraform/aws/alb_deletion_protection_disabled"},
+			},
+		},
+		WantStatus: []int{80, 0},
+	}
+
+	Tests = append(Tests, testSample)
+}
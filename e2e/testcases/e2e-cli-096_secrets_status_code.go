@@ -0,0 +1,22 @@
+package testcases
+
+// E2E-CLI-096 - KICS secrets command
+// should return the HIGH results status code only when secrets are found
+func init() { //nolint
+	testSample := TestCase{
+		Name: "should return the secrets status code when secrets are found [E2E-CLI-096]",
+		Args: args{
+			Args: []cmdArgs{
+				[]string{"secrets", "-p", "/path/e2e/fixtures/samples/terraform-secret.tf"},
+
+				[]string{"secrets", "-p", "/path/e2e/fixtures/samples/positive.dockerfile"},
+
+				[]string{"secrets", "-p", "/path/e2e/fixtures/samples/terraform-secret.tf",
+					"-r", "/path/e2e/fixtures/samples/secrets/regex_rules_48_invalid_regex.json"},
+			},
+		},
+		WantStatus: []int{50, 0, 126},
+	}
+
+	Tests = append(Tests, testSample)
+}
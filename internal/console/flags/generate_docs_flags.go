@@ -0,0 +1,8 @@
+package flags
+
+// Flags constants for generate-docs
+const (
+	DocsQueriesPath = "docs-queries-path"
+	DocsOutputPath  = "docs-output-path"
+	DocsFormat      = "docs-format"
+)
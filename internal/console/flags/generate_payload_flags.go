@@ -0,0 +1,11 @@
+package flags
+
+// Flags constants for generate-payload
+const (
+	PayloadScanPathFlag        = "payload-scan-path"
+	PayloadMaxFileSizeFlag     = "payload-max-file-size"
+	PayloadOutputPathFlag      = "payload-output-path"
+	PayloadOutputRedactionFlag = "payload-output-redaction"
+	PayloadTypeFlag            = "payload-type"
+	PayloadWithLinesFlag       = "payload-with-lines"
+)
@@ -10,6 +10,7 @@ const (
 	LogLevelFlag       = "log-level"
 	LogPathFlag        = "log-path"
 	NoColorFlag        = "no-color"
+	OfflineFlag        = "offline"
 	ProfilingFlag      = "profiling"
 	SilentFlag         = "silent"
 	SilentShorthand    = "s"
@@ -0,0 +1,6 @@
+package flags
+
+// Flags constants for lint-queries
+const (
+	LintFixFlag = "fix"
+)
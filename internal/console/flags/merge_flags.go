@@ -0,0 +1,9 @@
+package flags
+
+// Flags constants for merge
+const (
+	MergeFormatsFlag    = "merge-report-formats"
+	MergeOutputNameFlag = "merge-output-name"
+	MergeOutputPathFlag = "merge-output-path"
+	MergeResultsFlag    = "merge-results"
+)
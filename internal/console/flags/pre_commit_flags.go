@@ -0,0 +1,12 @@
+package flags
+
+// Flags constants for pre-commit
+const (
+	PreCommitExcludePaths  = "pre-commit-exclude-paths"
+	PreCommitLibrariesPath = "pre-commit-libraries-path"
+	PreCommitMaxFileSize   = "pre-commit-max-file-size"
+	PreCommitParallel      = "pre-commit-parallel"
+	PreCommitQueriesPath   = "pre-commit-queries-path"
+	PreCommitSeverities    = "pre-commit-severities"
+	PreCommitTimeout       = "pre-commit-timeout"
+)
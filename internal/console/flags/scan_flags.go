@@ -2,40 +2,70 @@ package flags
 
 // Flags constants for scan
 const (
+	AttestationKeyFlag      = "attestation-key"
+	BlameFlag               = "blame"
 	BomFlag                 = "bom"
+	RiskScoreFlag           = "risk-score"
+	CategoriesFlag          = "categories"
 	CloudProviderFlag       = "cloud-provider"
+	CodeOwnersPathFlag      = "codeowners-path"
+	ComposeProfilesFlag     = "compose-profiles"
 	ConfigFlag              = "config"
+	DecisionLogFlag         = "decision-log"
 	DisableFullDescFlag     = "disable-full-descriptions"
 	ExcludeCategoriesFlag   = "exclude-categories"
+	ExcludeGeneratedFlag    = "exclude-generated"
 	ExcludePathsFlag        = "exclude-paths"
 	ExcludeQueriesFlag      = "exclude-queries"
 	ExcludeResultsFlag      = "exclude-results"
+	ExcludeSearchKeysFlag   = "exclude-search-keys"
 	ExcludeSeveritiesFlag   = "exclude-severities"
 	ExperimentalQueriesFlag = "experimental-queries"
 	IncludeQueriesFlag      = "include-queries"
 	InputDataFlag           = "input-data"
 	FailOnFlag              = "fail-on"
 	IgnoreOnExitFlag        = "ignore-on-exit"
+	IgnoreGeneratedFlag     = "ignore-generated-on-exit"
 	MinimalUIFlag           = "minimal-ui"
 	NoProgressFlag          = "no-progress"
 	OutputNameFlag          = "output-name"
 	OutputPathFlag          = "output-path"
 	PathFlag                = "path"
 	PayloadPathFlag         = "payload-path"
+	PayloadRedactionFlag    = "payload-redaction"
 	PreviewLinesFlag        = "preview-lines"
+	ProjectsFlag            = "projects"
 	QueriesPath             = "queries-path"
 	LibrariesPath           = "libraries-path"
 	ReportFormatsFlag       = "report-formats"
+	SarifBaselineFlag       = "sarif-baseline"
 	TypeFlag                = "type"
 	ExcludeTypeFlag         = "exclude-type"
 	TerraformVarsPathFlag   = "terraform-vars-path"
 	QueryExecTimeoutFlag    = "timeout"
+	ScanTimeoutFlag         = "scan-timeout"
 	LineInfoPayloadFlag     = "payload-lines"
 	DisableSecretsFlag      = "disable-secrets"
 	SecretsRegexesPathFlag  = "secrets-regexes-path" //nolint:gosec
+	SecretsEngineFlag       = "secrets-engine"
 	ExcludeGitIgnore        = "exclude-gitignore"
 	OpenAPIReferencesFlag   = "enable-openapi-refs"
 	ParallelScanFile        = "parallel"
 	MaxFileSizeFlag         = "max-file-size"
+	MaxFilesFlag            = "max-files"
+	ForceFlag               = "force"
+	MaxResultsPerQueryFlag  = "max-results-per-query"
 	UseNewSeveritiesFlag    = "new-severities"
+	StrictParsingFlag       = "strict-parsing"
+	DescURLFlag             = "descriptions-url"
+	DescHeaderFlag          = "descriptions-header"
+	VersionURLFlag          = "version-check-url"
+	VersionHeaderFlag       = "version-check-header"
+	DisableVersionCheckFlag = "disable-version-check"
+	HTMLPageSizeFlag        = "html-page-size"
+	EmbedQueryDocsFlag      = "embed-query-docs"
+	EncryptOutputFlag       = "encrypt-output"
+	EnrichersFlag           = "enrichers"
+	WebhookURLFlag          = "webhook-url"
+	WebhookSecretFlag       = "webhook-secret" //nolint:gosec
 )
@@ -0,0 +1,11 @@
+package flags
+
+// Flags constants for secrets
+const (
+	SecretsExcludePathsFlag   = "secrets-exclude-paths"
+	SecretsExcludeResultsFlag = "secrets-exclude-results"
+	SecretsOutputNameFlag     = "secrets-output-name"
+	SecretsOutputPathFlag     = "secrets-output-path"
+	SecretsRulesPathFlag      = "secrets-rules-path" //nolint:gosec
+	SecretsScanPathFlag       = "secrets-scan-path"
+)
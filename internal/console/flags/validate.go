@@ -11,6 +11,8 @@ var flagValidationFuncs = flagValidationFuncsMap{
 	"allQueriesID":                      allQueriesID,
 	"validateWorkersFlag":               validateWorkersFlag,
 	"validatePath":                      validatePath,
+	"validateDuration":                  validateDuration,
+	"validateNonNegativeInt":            validateNonNegativeInt,
 }
 
 func isQueryID(id string) bool {
@@ -0,0 +1,11 @@
+package flags
+
+import "fmt"
+
+func validateNonNegativeInt(flagName string) error {
+	value := GetIntFlag(flagName)
+	if value < 0 {
+		return fmt.Errorf("invalid argument --%s: value must be greater or equal to 0", flagName)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags_validateNonNegativeInt(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue int
+		wantErr   bool
+	}{
+		{
+			name:      "should execute fine when not set",
+			flagValue: 0,
+			wantErr:   false,
+		},
+		{
+			name:      "should execute fine",
+			flagValue: 10,
+			wantErr:   false,
+		},
+		{
+			name:      "should return an error when the value is negative",
+			flagValue: -1,
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		flagsIntReferences[MaxResultsPerQueryFlag] = &test.flagValue
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := validateNonNegativeInt(MaxResultsPerQueryFlag)
+			if !test.wantErr {
+				require.NoError(t, gotErr)
+			} else {
+				require.Error(t, gotErr)
+			}
+		})
+	}
+}
@@ -13,11 +13,12 @@ import (
 )
 
 var validMultiStrEnums = map[string]map[string]string{
+	CategoriesFlag:        constants.AvailableCategories,
 	CloudProviderFlag:     constants.AvailableCloudProviders,
 	ExcludeCategoriesFlag: constants.AvailableCategories,
 	ExcludeSeveritiesFlag: convertSliceToDummyMap(constants.AvailableSeverities),
 	FailOnFlag:            convertSliceToDummyMap(constants.AvailableSeverities),
-	ReportFormatsFlag:     convertSliceToDummyMap(append([]string{"all"}, helpers.ListReportFormats()...)),
+	PreCommitSeverities:   convertSliceToDummyMap(constants.AvailableSeverities),
 	TypeFlag:              constants.AvailablePlatforms,
 	ExcludeTypeFlag:       constants.AvailablePlatforms,
 }
@@ -44,11 +45,21 @@ func allQueriesID(flagName string) error {
 	return nil
 }
 
+// getValidMultiStrEnums returns the valid values of the flag, the report formats are listed when the flags are
+// validated so the formats registered by a downstream build are accepted
+func getValidMultiStrEnums(flagName string) map[string]string {
+	if flagName == ReportFormatsFlag || flagName == MergeFormatsFlag {
+		return convertSliceToDummyMap(append([]string{"all"}, helpers.ListReportFormats()...))
+	}
+	return validMultiStrEnums[flagName]
+}
+
 func validateMultiStrEnum(flagName string) error {
 	enums := GetMultiStrFlag(flagName)
 	invalidEnum := make([]string, 0)
 	caseInsensitiveMap := make(map[string]string)
-	for key, value := range validMultiStrEnums[flagName] {
+	validEnums := getValidMultiStrEnums(flagName)
+	for key, value := range validEnums {
 		caseInsensitiveMap[strings.ToLower(key)] = value
 	}
 	for _, enum := range enums {
@@ -56,7 +67,7 @@ func validateMultiStrEnum(flagName string) error {
 			invalidEnum = append(invalidEnum, enum)
 		}
 	}
-	validEnumsValues := utils.SortedKeys(validMultiStrEnums[flagName])
+	validEnumsValues := utils.SortedKeys(validEnums)
 	if len(invalidEnum) > 0 {
 		return fmt.Errorf(
 			"unknown argument(s) for --%s: %s\nvalid arguments:\n  %s",
@@ -4,6 +4,7 @@ package flags
 import (
 	"testing"
 
+	"github.com/Checkmarx/kics/pkg/report"
 	"github.com/stretchr/testify/require"
 )
 
@@ -77,7 +78,22 @@ func TestFlags_validateMultiStrEnum(t *testing.T) {
 			flagValue: &[]string{"Ansible", "Terrraform"},
 			wantErr:   true,
 		},
+		{
+			name:      "should execute registered report format fine",
+			flagName:  "report-formats",
+			flagValue: &[]string{"json", "plain"},
+			wantErr:   false,
+		},
+		{
+			name:      "should return an error when an unknown report format",
+			flagName:  "report-formats",
+			flagValue: &[]string{"json", "unknown"},
+			wantErr:   true,
+		},
 	}
+	report.RegisterWriter("plain", report.WriterFunc(func(path, filename string, body interface{}) error {
+		return nil
+	}))
 	for _, test := range tests {
 		flagsMultiStrReferences[test.flagName] = test.flagValue
 		t.Run(test.name, func(t *testing.T) {
@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Checkmarx/kics/internal/constants"
 	"github.com/Checkmarx/kics/pkg/utils"
@@ -29,3 +30,15 @@ func validateStrEnum(flagName string) error {
 	}
 	return nil
 }
+
+func validateDuration(flagName string) error {
+	value := GetStrFlag(flagName)
+	if value == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("invalid argument for --%s: %s\nvalue must be a positive duration (e.g. 90s, 10m, 1h30m)", flagName, value)
+	}
+	return nil
+}
@@ -38,3 +38,43 @@ func TestFlags_validateStrEnum(t *testing.T) {
 		})
 	}
 }
+
+func TestFlags_validateDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue string
+		wantErr   bool
+	}{
+		{
+			name:      "should execute fine when not set",
+			flagValue: "",
+			wantErr:   false,
+		},
+		{
+			name:      "should execute fine",
+			flagValue: "1h30m",
+			wantErr:   false,
+		},
+		{
+			name:      "should return an error when the value is not a duration",
+			flagValue: "10",
+			wantErr:   true,
+		},
+		{
+			name:      "should return an error when the value is negative",
+			flagValue: "-5m",
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		flagsStrReferences[ScanTimeoutFlag] = &test.flagValue
+		t.Run(test.name, func(t *testing.T) {
+			gotErr := validateDuration(ScanTimeoutFlag)
+			if !test.wantErr {
+				require.NoError(t, gotErr)
+			} else {
+				require.Error(t, gotErr)
+			}
+		})
+	}
+}
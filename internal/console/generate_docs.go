@@ -0,0 +1,57 @@
+package console
+
+import (
+	_ "embed" // Embed generate-docs flags
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	"github.com/Checkmarx/kics/pkg/catalog"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/generate-docs-flags.json
+	generateDocsFlagsListContent string
+)
+
+// NewGenerateDocsCmd creates a new instance of the generate-docs Command
+func NewGenerateDocsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate-docs",
+		Short: "Generates a documentation catalog from a queries directory",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+			if err != nil {
+				return errors.New(initError + err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generateDocs()
+		},
+	}
+}
+
+func initGenerateDocsCmd(generateDocsCmd *cobra.Command) error {
+	return flags.InitJSONFlags(
+		generateDocsCmd,
+		generateDocsFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders())
+}
+
+func generateDocs() error {
+	queriesPath := flags.GetStrFlag(flags.DocsQueriesPath)
+
+	c, err := catalog.Load(queriesPath)
+	if err != nil {
+		log.Err(err).Msgf("failed to load queries from %s", queriesPath)
+		return err
+	}
+
+	return c.Render(flags.GetStrFlag(flags.DocsOutputPath), flags.GetStrFlag(flags.DocsFormat))
+}
@@ -0,0 +1,111 @@
+package console
+
+import (
+	_ "embed" // Embed generate-payload flags
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	"github.com/Checkmarx/kics/internal/constants"
+	sentryReport "github.com/Checkmarx/kics/internal/sentry"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/report"
+	"github.com/Checkmarx/kics/pkg/scan"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/generate-payload-flags.json
+	generatePayloadFlagsListContent string
+)
+
+// NewGeneratePayloadCmd creates a new instance of the generate-payload Command
+func NewGeneratePayloadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate-payload",
+		Short: "Generates the input documents the queries are evaluated against",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+			if err != nil {
+				return errors.New(initError + err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generatePayload(cmd.OutOrStdout())
+		},
+	}
+}
+
+func initGeneratePayloadCmd(generatePayloadCmd *cobra.Command) error {
+	if err := flags.InitJSONFlags(
+		generatePayloadCmd,
+		generatePayloadFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders()); err != nil {
+		return err
+	}
+
+	if err := generatePayloadCmd.MarkFlagRequired(flags.PayloadScanPathFlag); err != nil {
+		sentryReport.ReportSentry(&sentryReport.Report{
+			Message:  "Failed to add command required flags",
+			Err:      err,
+			Location: "func initGeneratePayloadCmd()",
+		}, true)
+		log.Err(err).Msg("Failed to add command required flags")
+	}
+	return nil
+}
+
+func generatePayload(out io.Writer) error {
+	// the payload is only generated from the scanned files, so the version is not checked, and it holds no preview
+	// of the lines of the results
+	params := &scan.Parameters{
+		Path:                flags.GetMultiStrFlag(flags.PayloadScanPathFlag),
+		Platform:            flags.GetMultiStrFlag(flags.PayloadTypeFlag),
+		ExcludePlatform:     []string{""},
+		LineInfoPayload:     flags.GetBoolFlag(flags.PayloadWithLinesFlag),
+		PayloadRedaction:    flags.GetStrFlag(flags.PayloadOutputRedactionFlag),
+		MaxFileSizeFlag:     flags.GetIntFlag(flags.PayloadMaxFileSizeFlag),
+		PreviewLines:        constants.MinimumPreviewLines,
+		Offline:             flags.GetBoolFlag(flags.OfflineFlag),
+		DisableVersionCheck: true,
+		ScanID:              scanID,
+	}
+
+	client, err := scan.NewClient(params, progress.InitializePbBuilder(true, false, true), internalPrinter.NewPrinter(true))
+	if err != nil {
+		log.Err(err)
+		return err
+	}
+
+	documents, err := client.GeneratePayload(ctx)
+	if err != nil {
+		log.Err(err).Msg("failed to generate payload")
+		return err
+	}
+
+	outputPath := flags.GetStrFlag(flags.PayloadOutputPathFlag)
+	if outputPath == "" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(documents)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+		return err
+	}
+	if err := report.ExportJSONReport(filepath.Dir(outputPath), filepath.Base(outputPath), documents); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Payload with %d documents saved to %s\n", len(documents.Documents), outputPath)
+	return nil
+}
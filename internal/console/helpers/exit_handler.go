@@ -4,20 +4,98 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Checkmarx/kics/internal/constants"
 	"github.com/Checkmarx/kics/pkg/model"
 )
 
 var shouldIgnore string
 var shouldFail map[string]struct{}
+var ignoreGenerated bool
+
+// severityArr is needed to make sure 'for' cycle is made in an ordered fashion
+var severityArr = []model.Severity{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "TRACE"}
+var codeMap = map[model.Severity]int{"CRITICAL": 60, "HIGH": 50, "MEDIUM": 40, "LOW": 30, "INFO": 20, "TRACE": 0}
+
+// ExitCodeError is returned by commands that finished but must exit with a non-zero code,
+// it is not an execution failure so it is neither printed nor reported
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", e.Code)
+}
 
 // ResultsExitCode calculate exit code base on severity of results, returns 0 if no results was reported
+// when the results are rolled up by project, the highest exit code of the projects and of the results of no
+// project is returned
 func ResultsExitCode(summary *model.Summary) int {
-	// severityArr is needed to make sure 'for' cycle is made in an ordered fashion
-	severityArr := []model.Severity{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "TRACE"}
-	codeMap := map[model.Severity]int{"CRITICAL": 60, "HIGH": 50, "MEDIUM": 40, "LOW": 30, "INFO": 20, "TRACE": 0}
+	if len(summary.Projects) > 0 {
+		return projectsExitCode(summary)
+	}
 	exitMap := summary.SeveritySummary.SeverityCounters
+	if ignoreGenerated {
+		exitMap = nonGeneratedCounters(summary, nil)
+	}
+	return severityExitCode(exitMap, shouldFail)
+}
+
+// SetProjectsExitCode sets the exit code of each project of the summary, the severities of the fail_on of a project
+// replace the ones of --fail-on for its results
+func SetProjectsExitCode(summary *model.Summary) {
+	for i := range summary.Projects {
+		project := &summary.Projects[i]
+		exitMap := project.SeverityCounters
+		if ignoreGenerated {
+			exitMap = nonGeneratedCounters(summary, &project.Name)
+		}
+		project.ExitCode = severityExitCode(exitMap, projectShouldFail(project))
+	}
+}
+
+// projectsExitCode returns the highest exit code of the projects and of the results that belong to no project
+func projectsExitCode(summary *model.Summary) int {
+	SetProjectsExitCode(summary)
+	// the summary counters are not limited, so the results of no project are the ones not counted in a project
+	noProject := ""
+	exitMap := make(map[model.Severity]int, len(summary.SeveritySummary.SeverityCounters))
+	if ignoreGenerated {
+		exitMap = nonGeneratedCounters(summary, &noProject)
+	} else {
+		for severity, counter := range summary.SeveritySummary.SeverityCounters {
+			exitMap[severity] = counter
+		}
+		for i := range summary.Projects {
+			for severity, counter := range summary.Projects[i].SeverityCounters {
+				exitMap[severity] -= counter
+			}
+		}
+	}
+
+	exitCode := severityExitCode(exitMap, shouldFail)
+	for i := range summary.Projects {
+		if summary.Projects[i].ExitCode > exitCode {
+			exitCode = summary.Projects[i].ExitCode
+		}
+	}
+	return exitCode
+}
+
+func projectShouldFail(project *model.ProjectSummary) map[string]struct{} {
+	if len(project.FailOn) == 0 {
+		return shouldFail
+	}
+	failOn := make(map[string]struct{}, len(project.FailOn))
+	for _, severity := range project.FailOn {
+		failOn[strings.ToLower(severity)] = struct{}{}
+	}
+	return failOn
+}
+
+// severityExitCode returns the exit code of the highest severity with results among the severities that fail
+func severityExitCode(exitMap map[model.Severity]int, failOn map[string]struct{}) int {
 	for _, severity := range severityArr {
-		if _, reportSeverity := shouldFail[strings.ToLower(string(severity))]; !reportSeverity {
+		if _, reportSeverity := failOn[strings.ToLower(string(severity))]; !reportSeverity {
 			continue
 		}
 		if exitMap[severity] > 0 {
@@ -27,6 +105,55 @@ func ResultsExitCode(summary *model.Summary) int {
 	return 0
 }
 
+// nonGeneratedCounters counts the results of each severity that were not found in generated files, only the
+// results of the project are counted when it is given, the results omitted by the limit of results per query are
+// counted too
+func nonGeneratedCounters(summary *model.Summary, project *string) map[model.Severity]int {
+	counters := make(map[model.Severity]int)
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		for _, files := range [][]model.VulnerableFile{query.Files, query.OmittedFiles} {
+			for j := range files {
+				if !files[j].Generated && (project == nil || files[j].Project == *project) {
+					counters[query.Severity]++
+				}
+			}
+		}
+	}
+	return counters
+}
+
+// ErrorsExitCode calculate exit code base on the errors that happened during the scan, returns 0 if none was reported
+// queries that failed to execute take precedence over files that failed to be parsed, which are only considered on strict parsing
+func ErrorsExitCode(summary *model.Summary, strictParsing bool) int {
+	if exitCode := QueryErrorsExitCode(summary); exitCode != 0 {
+		return exitCode
+	}
+	if strictParsing {
+		return ParseFailuresExitCode(summary)
+	}
+
+	return 0
+}
+
+// QueryErrorsExitCode calculate exit code base on the queries that failed to execute, returns 0 if none was reported
+func QueryErrorsExitCode(summary *model.Summary) int {
+	if summary.FailedToExecuteQueries > 0 {
+		return constants.QueryErrorCode
+	}
+
+	return 0
+}
+
+// ParseFailuresExitCode calculate exit code base on the files that failed to be parsed, returns 0 if none was reported
+func ParseFailuresExitCode(summary *model.Summary) int {
+	if len(summary.ParseFailures) > 0 {
+		return constants.ParseFailureCode
+	}
+
+	return 0
+}
+
 // InitShouldIgnoreArg initializes what kind of errors should be used on exit codes
 func InitShouldIgnoreArg(arg string) error {
 	validArgs := []string{"none", "all", "results", "errors"}
@@ -39,6 +166,11 @@ func InitShouldIgnoreArg(arg string) error {
 	return fmt.Errorf("unknown argument for --ignore-on-exit: %s\nvalid arguments:\n  %s", arg, strings.Join(validArgs, "\n  "))
 }
 
+// InitIgnoreGeneratedArg initializes whether the results found in generated files change the exit code
+func InitIgnoreGeneratedArg(ignore bool) {
+	ignoreGenerated = ignore
+}
+
 // InitShouldFailArg initializes which kind of vulnerability severity should changes exit code
 func InitShouldFailArg(args []string) error {
 	possibleArgs := map[string]struct{}{
@@ -81,3 +213,39 @@ func RemediateExitCode(selectedRemediationNumber, actualRemediationDoneNumber in
 
 	return 0
 }
+
+// LintExitCode calculate exit code base on the number of query lint issues that were not fixed
+func LintExitCode(remainingIssues int) int {
+	statusCode := 1
+	if remainingIssues > 0 {
+		return statusCode
+	}
+
+	return 0
+}
+
+// SecretsExitCode calculate exit code base on the number of secrets found, returns 0 if no secret was found
+// the secrets command ignores --fail-on and --ignore-on-exit so any secret fails the gate
+func SecretsExitCode(secretsFound int) int {
+	statusCode := 50
+	if secretsFound > 0 {
+		return statusCode
+	}
+
+	return 0
+}
+
+// PreCommitExitCode calculate exit code base on the highest severity of the results, returns 0 if no result was found
+// the pre-commit command only runs the queries of the selected severities so any result fails the hook, the TRACE
+// results, which have no status code, return the status code of the INFO results
+func PreCommitExitCode(summary *model.Summary) int {
+	for _, severity := range severityArr {
+		if summary.SeveritySummary.SeverityCounters[severity] > 0 {
+			if codeMap[severity] == 0 {
+				return codeMap[model.SeverityInfo]
+			}
+			return codeMap[severity]
+		}
+	}
+	return 0
+}
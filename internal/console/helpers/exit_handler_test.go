@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -74,6 +75,83 @@ func TestExitHandler_ResultsExitCode(t *testing.T) {
 	}
 }
 
+func TestExitHandler_ResultsExitCodeIgnoreGenerated(t *testing.T) {
+	shouldFail = map[string]struct{}{"high": {}, "medium": {}}
+	defer InitIgnoreGeneratedArg(false)
+
+	summary := model.Summary{
+		Queries: model.QueryResultSlice{
+			{Severity: model.SeverityHigh, Files: []model.VulnerableFile{{FileName: "vendor/main.tf", Generated: true}}},
+			{Severity: model.SeverityMedium, Files: []model.VulnerableFile{{FileName: "main.tf"}}},
+		},
+		SeveritySummary: model.SeveritySummary{
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1, model.SeverityMedium: 1},
+		},
+	}
+
+	InitIgnoreGeneratedArg(false)
+	require.Equal(t, 50, ResultsExitCode(&summary))
+	InitIgnoreGeneratedArg(true)
+	require.Equal(t, 40, ResultsExitCode(&summary))
+}
+
+func TestExitHandler_ResultsExitCodeIgnoreGeneratedLimited(t *testing.T) {
+	shouldFail = map[string]struct{}{"high": {}}
+	defer InitIgnoreGeneratedArg(false)
+
+	summary := model.Summary{
+		Queries: model.QueryResultSlice{
+			{Severity: model.SeverityHigh, Files: []model.VulnerableFile{
+				{FileName: "main.tf"},
+				{FileName: "generated/b.tf", Generated: true},
+				{FileName: "generated/a.tf", Generated: true},
+			}},
+		},
+		SeveritySummary: model.SeveritySummary{
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 3},
+		},
+	}
+	// the result of main.tf is omitted from the reports but still fails the scan
+	model.LimitResultsPerQuery(&summary, 2)
+	require.Len(t, summary.Queries[0].Files, 2)
+
+	InitIgnoreGeneratedArg(true)
+	require.Equal(t, 50, ResultsExitCode(&summary))
+}
+
+func TestExitHandler_ResultsExitCodeProjects(t *testing.T) {
+	shouldFail = map[string]struct{}{"high": {}, "medium": {}}
+	defer InitIgnoreGeneratedArg(false)
+
+	summary := model.Summary{
+		Queries: model.QueryResultSlice{
+			{Severity: model.SeverityHigh, Files: []model.VulnerableFile{{FileName: "api/main.tf", Project: "api"}}},
+			{Severity: model.SeverityMedium, Files: []model.VulnerableFile{{FileName: "web/main.tf", Project: "web"}}},
+			{Severity: model.SeverityLow, Files: []model.VulnerableFile{{FileName: "main.tf"}}},
+		},
+		SeveritySummary: model.SeveritySummary{
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1, model.SeverityMedium: 1, model.SeverityLow: 1},
+		},
+		Projects: []model.ProjectSummary{
+			{Name: "api", FailOn: []string{"critical"}, SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1}},
+			{Name: "web", SeverityCounters: map[model.Severity]int{model.SeverityMedium: 1}},
+		},
+	}
+
+	require.Equal(t, 40, ResultsExitCode(&summary))
+	require.Equal(t, 0, summary.Projects[0].ExitCode)
+	require.Equal(t, 40, summary.Projects[1].ExitCode)
+
+	shouldFail = map[string]struct{}{"low": {}}
+	require.Equal(t, 30, ResultsExitCode(&summary))
+	require.Equal(t, 0, summary.Projects[1].ExitCode)
+
+	summary.Queries[1].Files[0].Generated = true
+	shouldFail = map[string]struct{}{"medium": {}}
+	InitIgnoreGeneratedArg(true)
+	require.Equal(t, 0, ResultsExitCode(&summary))
+}
+
 type initIgnoreResult struct {
 	wantErr bool
 	want    string
@@ -262,3 +340,113 @@ func Test_RemediateNotAll(t *testing.T) {
 		require.Equal(t, statusCode, 70)
 	})
 }
+
+func Test_LintExitCode(t *testing.T) {
+	t.Run("LintNoRemainingIssues", func(t *testing.T) {
+		require.Equal(t, 0, LintExitCode(0))
+	})
+	t.Run("LintRemainingIssues", func(t *testing.T) {
+		require.Equal(t, 1, LintExitCode(3))
+	})
+}
+
+func Test_SecretsExitCode(t *testing.T) {
+	t.Run("NoSecretsFound", func(t *testing.T) {
+		require.Equal(t, 0, SecretsExitCode(0))
+	})
+	t.Run("SecretsFound", func(t *testing.T) {
+		require.Equal(t, 50, SecretsExitCode(2))
+	})
+}
+
+func Test_PreCommitExitCode(t *testing.T) {
+	t.Run("NoResultsFound", func(t *testing.T) {
+		require.Equal(t, 0, PreCommitExitCode(&model.Summary{}))
+	})
+	t.Run("ResultsFound", func(t *testing.T) {
+		require.Equal(t, 50, PreCommitExitCode(&test.SummaryMock))
+	})
+	t.Run("TraceResultsFound", func(t *testing.T) {
+		summary := &model.Summary{SeveritySummary: model.SeveritySummary{
+			SeverityCounters: map[model.Severity]int{model.SeverityTrace: 1},
+		}}
+		require.Equal(t, 20, PreCommitExitCode(summary))
+	})
+}
+
+func Test_ParseFailuresExitCode(t *testing.T) {
+	t.Run("NoParseFailures", func(t *testing.T) {
+		require.Equal(t, 0, ParseFailuresExitCode(&model.Summary{}))
+	})
+	t.Run("ParseFailures", func(t *testing.T) {
+		summary := &model.Summary{
+			ParseFailures: []model.ParseFailure{
+				{FilePath: "main.tf", Error: "main.tf:3,9-4,1: Invalid expression", Line: 3},
+			},
+		}
+		require.Equal(t, 80, ParseFailuresExitCode(summary))
+	})
+}
+
+func Test_QueryErrorsExitCode(t *testing.T) {
+	t.Run("NoQueryErrors", func(t *testing.T) {
+		require.Equal(t, 0, QueryErrorsExitCode(&model.Summary{}))
+	})
+	t.Run("QueryErrors", func(t *testing.T) {
+		summary := &model.Summary{
+			Counters: model.Counters{FailedToExecuteQueries: 2},
+		}
+		require.Equal(t, 70, QueryErrorsExitCode(summary))
+	})
+}
+
+func Test_ErrorsExitCode(t *testing.T) {
+	parseFailures := []model.ParseFailure{
+		{FilePath: "main.tf", Error: "main.tf:3,9-4,1: Invalid expression", Line: 3},
+	}
+	tests := []struct {
+		name          string
+		summary       model.Summary
+		strictParsing bool
+		want          int
+	}{
+		{
+			name:    "no errors",
+			summary: model.Summary{},
+			want:    0,
+		},
+		{
+			name:    "parse failures without strict parsing",
+			summary: model.Summary{ParseFailures: parseFailures},
+			want:    0,
+		},
+		{
+			name:          "parse failures with strict parsing",
+			summary:       model.Summary{ParseFailures: parseFailures},
+			strictParsing: true,
+			want:          80,
+		},
+		{
+			name: "query errors take precedence over parse failures",
+			summary: model.Summary{
+				Counters:      model.Counters{FailedToExecuteQueries: 1},
+				ParseFailures: parseFailures,
+			},
+			strictParsing: true,
+			want:          70,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ErrorsExitCode(&tt.summary, tt.strictParsing))
+		})
+	}
+}
+
+func Test_ExitCodeError(t *testing.T) {
+	var err error = fmt.Errorf("lint failed: %w", &ExitCodeError{Code: 1})
+	var exitErr *ExitCodeError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, 1, exitErr.Code)
+	require.Equal(t, "lint failed: exit code 1", err.Error())
+}
@@ -9,9 +9,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/Checkmarx/kics/internal/metrics"
@@ -25,20 +25,6 @@ import (
 
 const divisor = float32(100000)
 
-var reportGenerators = map[string]func(path, filename string, body interface{}) error{
-	"json":        report.PrintJSONReport,
-	"sarif":       report.PrintSarifReport,
-	"html":        report.PrintHTMLReport,
-	"glsast":      report.PrintGitlabSASTReport,
-	"pdf":         report.PrintPdfReport,
-	"sonarqube":   report.PrintSonarQubeReport,
-	"cyclonedx":   report.PrintCycloneDxReport,
-	"junit":       report.PrintJUnitReport,
-	"asff":        report.PrintASFFReport,
-	"csv":         report.PrintCSVReport,
-	"codeclimate": report.PrintCodeClimateReport,
-}
-
 // CustomConsoleWriter creates an output to print log in a files
 func CustomConsoleWriter(fileLogger *zerolog.ConsoleWriter) zerolog.ConsoleWriter {
 	fileLogger.FormatLevel = func(i interface{}) string {
@@ -97,26 +83,53 @@ func FileAnalyzer(path string) (string, error) {
 	return "", errors.New("invalid configuration file format")
 }
 
-// GenerateReport execute each report function to generate report
+// GenerateReport execute each report function to generate report, the formats are generated concurrently
+// and a format that fails to be generated does not prevent the others from being generated
 func GenerateReport(path, filename string, body interface{}, formats []string, proBarBuilder progress.PbBuilder) error {
 	log.Debug().Msgf("helpers.GenerateReport()")
 	metrics.Metric.Start("generate_report")
 
 	progressBar := proBarBuilder.BuildCircle("Generating Reports: ")
 
-	var err error = nil
 	go progressBar.Start()
 	defer progressBar.Close()
 
+	formats = uniqueFormats(formats)
+	errs := make([]error, len(formats))
+	var wg sync.WaitGroup
+	for idx := range formats {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			writer, ok := report.LookupWriter(formats[idx])
+			if !ok {
+				errs[idx] = fmt.Errorf("unknown report format %s", formats[idx])
+				return
+			}
+			if err := writer.Write(path, filename, body); err != nil {
+				log.Error().Msgf("Failed to generate %s report: %s", formats[idx], err)
+				errs[idx] = fmt.Errorf("failed to generate %s report: %w", formats[idx], err)
+			}
+		}(idx)
+	}
+	wg.Wait()
+
+	metrics.Metric.Stop()
+	return errors.Join(errs...)
+}
+
+// uniqueFormats returns the lower case formats without duplicates, so each report is only written once
+func uniqueFormats(formats []string) []string {
+	unique := make([]string, 0, len(formats))
+	seen := make(map[string]bool, len(formats))
 	for _, format := range formats {
 		format = strings.ToLower(format)
-		if err = reportGenerators[format](path, filename, body); err != nil {
-			log.Error().Msgf("Failed to generate %s report", format)
-			break
+		if !seen[format] {
+			seen[format] = true
+			unique = append(unique, format)
 		}
 	}
-	metrics.Metric.Stop()
-	return err
+	return unique
 }
 
 // GetExecutableDirectory - returns the path to the directory containing KICS executable
@@ -162,14 +175,9 @@ func GetFullPath(partialPath string) (string, error) {
 	return fullPath, nil
 }
 
-// ListReportFormats return a slice with all supported report formats
+// ListReportFormats return a slice with all supported report formats, including the registered ones
 func ListReportFormats() []string {
-	supportedFormats := make([]string, 0, len(reportGenerators))
-	for reportFormats := range reportGenerators {
-		supportedFormats = append(supportedFormats, reportFormats)
-	}
-	sort.Strings(supportedFormats)
-	return supportedFormats
+	return report.ListFormats()
 }
 
 // GetNumCPU return the number of cpus available
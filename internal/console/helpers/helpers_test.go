@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/report"
 	"github.com/Checkmarx/kics/test"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -183,6 +184,20 @@ func TestHelpers_GenerateReport(t *testing.T) {
 	}
 }
 
+func TestHelpers_GenerateReport_IndependentFormats(t *testing.T) {
+	dir := t.TempDir()
+	err := GenerateReport(dir, "result", "", []string{"html", "JSON", "json", "sarif"}, progress.PbBuilder{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to generate html report")
+	require.NotContains(t, err.Error(), "json")
+	require.FileExists(t, filepath.Join(dir, "result.json"))
+	require.FileExists(t, filepath.Join(dir, "result.sarif"))
+}
+
+func TestHelpers_uniqueFormats(t *testing.T) {
+	require.Equal(t, []string{"json", "html"}, uniqueFormats([]string{"json", "HTML", "Json", "html"}))
+}
+
 func TestHelpers_GetDefaultQueryPath(t *testing.T) {
 	if err := test.ChangeCurrentDir("kics"); err != nil {
 		t.Fatal(err)
@@ -227,7 +242,7 @@ func TestHelpers_GetDefaultQueryPath(t *testing.T) {
 func TestHelpers_ListReportFormats(t *testing.T) {
 	formats := ListReportFormats()
 	for _, format := range formats {
-		_, ok := reportGenerators[format]
+		_, ok := report.LookupWriter(format)
 		require.True(t, ok)
 	}
 }
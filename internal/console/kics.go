@@ -3,10 +3,12 @@ package console
 import (
 	"context"
 	_ "embed" // Embed kics flags
+	"errors"
 	"os"
 	"time"
 
 	"github.com/Checkmarx/kics/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
 	"github.com/Checkmarx/kics/internal/constants"
 	sentryReport "github.com/Checkmarx/kics/internal/sentry"
 	"github.com/Checkmarx/kics/pkg/engine/source"
@@ -34,22 +36,49 @@ var (
 
 // NewKICSCmd creates a new instance of the kics Command
 func NewKICSCmd() *cobra.Command {
+	// the root persistent hooks run before the ones of the subcommands
+	cobra.EnableTraverseRunHooks = true
 	return &cobra.Command{
 		Use:   "kics",
 		Short: constants.Fullname,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRunRoot()
+		},
 	}
 }
 
+// preRunRoot applies the global flags that must be handled before any command runs,
+// crash reports are the only network access not made by the commands themselves
+func preRunRoot() error {
+	if flags.GetBoolFlag(flags.OfflineFlag) && isCrashReportEnabled() {
+		initSentry("")
+	}
+	return nil
+}
+
 func initialize(rootCmd *cobra.Command) error {
 	scanCmd := NewScanCmd()
 	remediateCmd := NewRemediateCmd()
 	analyzeCmd := NewAnalyzeCmd()
+	lintQueriesCmd := NewLintQueriesCmd()
+	generateDocsCmd := NewGenerateDocsCmd()
+	generatePayloadCmd := NewGeneratePayloadCmd()
+	secretsCmd := NewSecretsCmd()
+	mergeCmd := NewMergeCmd()
+	preCommitCmd := NewPreCommitCmd()
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewGenerateIDCmd())
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(NewListPlatformsCmd())
+	rootCmd.AddCommand(NewSchemaCmd())
 	rootCmd.AddCommand(remediateCmd)
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(lintQueriesCmd)
+	rootCmd.AddCommand(generateDocsCmd)
+	rootCmd.AddCommand(generatePayloadCmd)
+	rootCmd.AddCommand(secretsCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(preCommitCmd)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	if err := flags.InitJSONFlags(
@@ -73,6 +102,30 @@ func initialize(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	if err := initLintQueriesCmd(lintQueriesCmd); err != nil {
+		return err
+	}
+
+	if err := initGenerateDocsCmd(generateDocsCmd); err != nil {
+		return err
+	}
+
+	if err := initGeneratePayloadCmd(generatePayloadCmd); err != nil {
+		return err
+	}
+
+	if err := initSecretsCmd(secretsCmd); err != nil {
+		return err
+	}
+
+	if err := initMergeCmd(mergeCmd); err != nil {
+		return err
+	}
+
+	if err := initPreCommitCmd(preCommitCmd); err != nil {
+		return err
+	}
+
 	return initScanCmd(scanCmd)
 }
 
@@ -94,6 +147,10 @@ func Execute() error {
 	}
 
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		var exitErr *consoleHelpers.ExitCodeError
+		if errors.As(err, &exitErr) {
+			return err
+		}
 		sentryReport.ReportSentry(&sentryReport.Report{
 			Message:  "Failed to run application",
 			Err:      err,
@@ -114,6 +171,11 @@ func enableCrashReport() {
 	}
 }
 
+func isCrashReportEnabled() bool {
+	client := sentry.CurrentHub().Client()
+	return client != nil && client.Options().Dsn != ""
+}
+
 func initSentry(dsn string) {
 	var err error
 	if dsn == "" {
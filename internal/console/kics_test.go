@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Checkmarx/kics/internal/console/flags"
 	"github.com/stretchr/testify/require"
 )
 
@@ -320,3 +321,44 @@ func rewriteRemediateTestFile() error {
 	err := os.WriteFile(filepath.FromSlash("../../test/assets/auto_remediation_sample.tf"), d1, 0666)
 	return err
 }
+
+func TestConsole_OfflineDisablesCrashReport(t *testing.T) {
+	initSentry("https://public@sentry.example.com/1")
+	require.True(t, isCrashReportEnabled())
+
+	rootCmd := NewKICSCmd()
+	require.NoError(t, initialize(rootCmd))
+	rootCmd.SetArgs([]string{"version", "--offline"})
+	require.NoError(t, rootCmd.Execute())
+
+	require.False(t, isCrashReportEnabled())
+}
+
+func TestConsole_OfflineGeneratePayload(t *testing.T) {
+	rootCmd := NewKICSCmd()
+	require.NoError(t, initialize(rootCmd))
+	rootCmd.SetArgs([]string{"generate-payload", "--offline", "-p", "git::https://github.com/Checkmarx/kics.git"})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "offline mode is enabled")
+}
+
+func TestConsole_PreCommitFlags(t *testing.T) {
+	rootCmd := NewKICSCmd()
+	require.NoError(t, initialize(rootCmd))
+
+	preCommitCmd, _, err := rootCmd.Find([]string{"pre-commit"})
+	require.NoError(t, err)
+	// the scan flags used by the pre-commit command are registered on the command itself
+	require.NoError(t, preCommitCmd.ParseFlags([]string{
+		"-q", "./custom", "-b", "./libraries", "--pre-commit-max-file-size", "10",
+		"--pre-commit-timeout", "30", "--pre-commit-parallel", "2",
+	}))
+	require.True(t, preCommitCmd.Flags().Lookup(flags.PreCommitQueriesPath).Changed)
+	require.Equal(t, []string{"./custom"}, flags.GetMultiStrFlag(flags.PreCommitQueriesPath))
+	require.Equal(t, "./libraries", flags.GetStrFlag(flags.PreCommitLibrariesPath))
+	require.Equal(t, 10, flags.GetIntFlag(flags.PreCommitMaxFileSize))
+	require.Equal(t, 30, flags.GetIntFlag(flags.PreCommitTimeout))
+	require.Equal(t, 2, flags.GetIntFlag(flags.PreCommitParallel))
+}
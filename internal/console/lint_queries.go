@@ -0,0 +1,89 @@
+package console
+
+import (
+	_ "embed" // Embed lint-queries flags
+	"fmt"
+	"io"
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/linter"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/lint-queries-flags.json
+	lintQueriesFlagsListContent string
+)
+
+// NewLintQueriesCmd creates a new instance of the lint-queries Command
+func NewLintQueriesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint-queries <dir>",
+		Short: "Applies static checks to a queries directory",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return preLintQueries(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := lintQueries(cmd.OutOrStdout(), args[0])
+			var exitErr *consoleHelpers.ExitCodeError
+			if errors.As(err, &exitErr) {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+}
+
+func initLintQueriesCmd(lintQueriesCmd *cobra.Command) error {
+	return flags.InitJSONFlags(
+		lintQueriesCmd,
+		lintQueriesFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders())
+}
+
+func preLintQueries(cmd *cobra.Command) error {
+	err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+	if err != nil {
+		return errors.New(initError + err.Error())
+	}
+	return nil
+}
+
+func lintQueries(out io.Writer, queriesPath string) error {
+	report, err := linter.Lint(queriesPath, flags.GetBoolFlag(flags.LintFixFlag))
+	if err != nil {
+		log.Err(err).Msgf("failed to lint queries in %s", queriesPath)
+		return err
+	}
+
+	for i := range report.Issues {
+		issue := report.Issues[i]
+		status := ""
+		if issue.Fixed {
+			status = " (fixed)"
+		} else if issue.Fixable {
+			status = " (fixable)"
+		}
+		fmt.Fprintf(out, "%s:%d: [%s] %s%s\n", issue.File, issue.Line, issue.Rule, issue.Message, status)
+	}
+
+	remaining := report.Remaining()
+	fmt.Fprintf(out, "\nQueries checked: %d\n", report.Queries)
+	fmt.Fprintf(out, "Issues found: %d\n", len(report.Issues))
+	fmt.Fprintf(out, "Issues remaining: %d\n", remaining)
+
+	if exitCode := consoleHelpers.LintExitCode(remaining); exitCode != 0 {
+		return &consoleHelpers.ExitCodeError{Code: exitCode}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package console
+
+import (
+	_ "embed" // Embed merge flags
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
+	sentryReport "github.com/Checkmarx/kics/internal/sentry"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/merge-flags.json
+	mergeFlagsListContent string
+)
+
+// NewMergeCmd creates a new instance of the merge Command
+func NewMergeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge",
+		Short: "Merges the JSON results of several scans into a single report",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Validate(); err != nil {
+				return err
+			}
+			err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+			if err != nil {
+				return errors.New(initError + err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return merge(cmd.OutOrStdout())
+		},
+	}
+}
+
+func initMergeCmd(mergeCmd *cobra.Command) error {
+	if err := flags.InitJSONFlags(
+		mergeCmd,
+		mergeFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders()); err != nil {
+		return err
+	}
+
+	for _, flag := range []string{flags.MergeResultsFlag, flags.MergeOutputPathFlag} {
+		if err := mergeCmd.MarkFlagRequired(flag); err != nil {
+			sentryReport.ReportSentry(&sentryReport.Report{
+				Message:  "Failed to add command required flags",
+				Err:      err,
+				Location: "func initMergeCmd()",
+			}, true)
+			log.Err(err).Msg("Failed to add command required flags")
+		}
+	}
+	return nil
+}
+
+func merge(out io.Writer) error {
+	resultsPaths := flags.GetMultiStrFlag(flags.MergeResultsFlag)
+	summaries := make([]model.Summary, 0, len(resultsPaths))
+	for _, resultsPath := range resultsPaths {
+		summary, err := readResults(resultsPath)
+		if err != nil {
+			log.Err(err).Msgf("Failed to read the results %s", resultsPath)
+			return err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	merged := model.MergeSummaries(summaries)
+
+	formats := flags.GetMultiStrFlag(flags.MergeFormatsFlag)
+	for _, format := range formats {
+		if strings.EqualFold(format, "all") {
+			formats = consoleHelpers.ListReportFormats()
+			break
+		}
+	}
+
+	outputPath := flags.GetStrFlag(flags.MergeOutputPathFlag)
+	if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+		return err
+	}
+	if err := consoleHelpers.GenerateReport(
+		outputPath,
+		flags.GetStrFlag(flags.MergeOutputNameFlag),
+		&merged,
+		formats,
+		*progress.InitializePbBuilder(true, false, true)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Reports merged: %d\n", len(summaries))
+	fmt.Fprintf(out, "Files scanned: %d\n", merged.ScannedFiles)
+	fmt.Fprintf(out, "Results: %d\n", merged.TotalCounter)
+	return nil
+}
+
+// readResults reads the summary of a JSON results file written by a scan
+func readResults(resultsPath string) (model.Summary, error) {
+	var summary model.Summary
+	content, err := os.ReadFile(filepath.Clean(resultsPath))
+	if err != nil {
+		return summary, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return summary, fmt.Errorf("failed to parse the results %s: %w", resultsPath, err)
+	}
+	if fields["kics_version"] == nil {
+		return summary, fmt.Errorf("the results %s are not a KICS JSON report", resultsPath)
+	}
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return summary, fmt.Errorf("failed to parse the results %s: %w", resultsPath, err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,177 @@
+package console
+
+import (
+	_ "embed" // Embed pre-commit flags
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
+	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/scan"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/pre-commit-flags.json
+	preCommitFlagsListContent string
+)
+
+// preCommitPreviewLines is the number of lines of the code of the results, which the command does not print
+const preCommitPreviewLines = 3
+
+// NewPreCommitCmd creates a new instance of the pre-commit Command
+func NewPreCommitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pre-commit",
+		Short: "Scans the staged files with the queries of the resources they declare",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Validate(); err != nil {
+				return err
+			}
+			err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+			if err != nil {
+				return errors.New(initError + err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := scanPreCommit(cmd, cmd.OutOrStdout())
+			var exitErr *consoleHelpers.ExitCodeError
+			if errors.As(err, &exitErr) {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+}
+
+func initPreCommitCmd(preCommitCmd *cobra.Command) error {
+	return flags.InitJSONFlags(
+		preCommitCmd,
+		preCommitFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders())
+}
+
+func scanPreCommit(cmd *cobra.Command, out io.Writer) error {
+	root, staged, err := scan.StagedFiles(ctx)
+	if err != nil {
+		return err
+	}
+	if len(staged) == 0 {
+		fmt.Fprintln(out, "No staged files to scan")
+		return nil
+	}
+
+	// the hook must be fast, so the version is not checked, the descriptions are not requested and the terraform
+	// queries referencing no type declared in the staged files are pruned
+	params := &scan.Parameters{
+		Path:                        staged,
+		ExcludePaths:                flags.GetMultiStrFlag(flags.PreCommitExcludePaths),
+		ExcludeSeverities:           excludedSeverities(flags.GetMultiStrFlag(flags.PreCommitSeverities)),
+		QueriesPath:                 flags.GetMultiStrFlag(flags.PreCommitQueriesPath),
+		LibrariesPath:               flags.GetStrFlag(flags.PreCommitLibrariesPath),
+		ChangedDefaultQueryPath:     cmd.Flags().Lookup(flags.PreCommitQueriesPath).Changed,
+		ChangedDefaultLibrariesPath: cmd.Flags().Lookup(flags.PreCommitLibrariesPath).Changed,
+		Platform:                    []string{""},
+		ExcludePlatform:             []string{""},
+		CloudProvider:               []string{""},
+		MaxFileSizeFlag:             flags.GetIntFlag(flags.PreCommitMaxFileSize),
+		PreviewLines:                preCommitPreviewLines,
+		QueryExecTimeout:            flags.GetIntFlag(flags.PreCommitTimeout),
+		ParallelScanFlag:            flags.GetIntFlag(flags.PreCommitParallel),
+		PruneQueries:                true,
+		DisableFullDesc:             true,
+		Offline:                     flags.GetBoolFlag(flags.OfflineFlag),
+		DisableVersionCheck:         true,
+		ScanID:                      scanID,
+	}
+
+	client, err := scan.NewClient(params, progress.InitializePbBuilder(true, false, true), internalPrinter.NewPrinter(true))
+	if err != nil {
+		log.Err(err).Msg("failed to create the pre-commit scan client")
+		return err
+	}
+
+	summary, err := client.ScanPreCommit(ctx)
+	if err != nil {
+		return err
+	}
+
+	printPreCommitResults(out, root, summary)
+
+	if exitCode := consoleHelpers.PreCommitExitCode(summary); exitCode != 0 {
+		return &consoleHelpers.ExitCodeError{Code: exitCode}
+	}
+
+	return nil
+}
+
+// excludedSeverities returns the severities that were not selected
+func excludedSeverities(selected []string) []string {
+	excluded := make([]string, 0)
+	for _, severity := range constants.AvailableSeverities {
+		isSelected := false
+		for _, value := range selected {
+			isSelected = isSelected || strings.EqualFold(strings.TrimSpace(value), severity)
+		}
+		if !isSelected {
+			excluded = append(excluded, severity)
+		}
+	}
+	return excluded
+}
+
+// preCommitResult is a result printed by the pre-commit command
+type preCommitResult struct {
+	fileName string
+	line     int
+	text     string
+}
+
+// printPreCommitResults prints a line by result, sorted by file and line, followed by the scan counters, the files
+// are relative to the root of the repository
+func printPreCommitResults(out io.Writer, root string, summary *model.Summary) {
+	results := make([]preCommitResult, 0, summary.SeveritySummary.TotalCounter)
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		for j := range query.Files {
+			fileName := query.Files[j].FileName
+			if relative, err := filepath.Rel(root, fileName); err == nil && !strings.HasPrefix(relative, "..") {
+				fileName = filepath.ToSlash(relative)
+			}
+			results = append(results, preCommitResult{
+				fileName: fileName,
+				line:     query.Files[j].Line,
+				text:     fmt.Sprintf("[%s] %s (%s)", query.Severity, query.QueryName, query.QueryID),
+			})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].fileName != results[j].fileName {
+			return results[i].fileName < results[j].fileName
+		}
+		return results[i].line < results[j].line
+	})
+
+	for i := range results {
+		fmt.Fprintf(out, "%s:%d: %s\n", results[i].fileName, results[i].line, results[i].text)
+	}
+	fmt.Fprintf(out, "\nFiles scanned: %d\n", summary.ScannedFiles)
+	fmt.Fprintf(out, "Queries loaded: %d\n", summary.TotalQueries)
+	fmt.Fprintf(out, "Results found: %d\n", len(results))
+	fmt.Fprintf(out, "Duration: %s\n", summary.Times.End.Sub(summary.Times.Start).Round(time.Millisecond))
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/Checkmarx/kics/internal/console/flags"
 	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
@@ -16,6 +17,7 @@ import (
 	"github.com/Checkmarx/kics/pkg/scan"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -75,6 +77,7 @@ func run(cmd *cobra.Command) error {
 	if err := consoleHelpers.InitShouldFailArg(flags.GetMultiStrFlag(flags.FailOnFlag)); err != nil {
 		return err
 	}
+	consoleHelpers.InitIgnoreGeneratedArg(flags.GetBoolFlag(flags.IgnoreGeneratedFlag))
 	if flags.GetStrFlag(flags.OutputPathFlag) != "" {
 		updateReportFormats()
 		flags.SetStrFlag(flags.OutputNameFlag, filepath.Base(flags.GetStrFlag(flags.OutputNameFlag)))
@@ -94,10 +97,26 @@ func run(cmd *cobra.Command) error {
 
 	// save the scan parameters into the ScanParameters struct
 	scanParams := getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath)
+	scanParams.Flags = getChangedFlags(cmd)
 
 	return executeScan(scanParams)
 }
 
+// getChangedFlags returns the flags set through the command line, the configuration file or environment variables,
+// authentication headers, the attestation key path, the output encryption and the webhook are left out since
+// they point to credentials
+func getChangedFlags(cmd *cobra.Command) map[string]string {
+	changedFlags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if strings.HasSuffix(f.Name, "auth-header") || strings.HasPrefix(f.Name, "webhook-") ||
+			f.Name == flags.AttestationKeyFlag || f.Name == flags.EncryptOutputFlag {
+			return
+		}
+		changedFlags[f.Name] = f.Value.String()
+	})
+	return changedFlags
+}
+
 func updateReportFormats() {
 	for _, format := range flags.GetMultiStrFlag(flags.ReportFormatsFlag) {
 		if strings.EqualFold(format, "all") {
@@ -108,13 +127,22 @@ func updateReportFormats() {
 }
 
 func getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath bool) *scan.Parameters {
+	// the value is validated with the flags, an empty value leaves the scan without timeout
+	scanTimeout, _ := time.ParseDuration(flags.GetStrFlag(flags.ScanTimeoutFlag))
+
 	scanParams := scan.Parameters{
+		Blame:                       flags.GetBoolFlag(flags.BlameFlag),
+		Categories:                  flags.GetMultiStrFlag(flags.CategoriesFlag),
 		CloudProvider:               flags.GetMultiStrFlag(flags.CloudProviderFlag),
+		CodeOwnersPath:              flags.GetStrFlag(flags.CodeOwnersPathFlag),
+		ComposeProfiles:             flags.GetMultiStrFlag(flags.ComposeProfilesFlag),
+		DecisionLogPath:             flags.GetStrFlag(flags.DecisionLogFlag),
 		DisableFullDesc:             flags.GetBoolFlag(flags.DisableFullDescFlag),
 		ExcludeCategories:           flags.GetMultiStrFlag(flags.ExcludeCategoriesFlag),
 		ExcludePaths:                flags.GetMultiStrFlag(flags.ExcludePathsFlag),
 		ExcludeQueries:              flags.GetMultiStrFlag(flags.ExcludeQueriesFlag),
 		ExcludeResults:              flags.GetMultiStrFlag(flags.ExcludeResultsFlag),
+		ExcludeSearchKeys:           flags.GetMultiStrFlag(flags.ExcludeSearchKeysFlag),
 		ExcludeSeverities:           flags.GetMultiStrFlag(flags.ExcludeSeveritiesFlag),
 		ExperimentalQueries:         flags.GetBoolFlag(flags.ExperimentalQueriesFlag),
 		IncludeQueries:              flags.GetMultiStrFlag(flags.IncludeQueriesFlag),
@@ -123,26 +151,50 @@ func getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath bool
 		OutputPath:                  flags.GetStrFlag(flags.OutputPathFlag),
 		Path:                        flags.GetMultiStrFlag(flags.PathFlag),
 		PayloadPath:                 flags.GetStrFlag(flags.PayloadPathFlag),
+		PayloadRedaction:            flags.GetStrFlag(flags.PayloadRedactionFlag),
 		PreviewLines:                flags.GetIntFlag(flags.PreviewLinesFlag),
+		Projects:                    flags.GetStrFlag(flags.ProjectsFlag),
 		QueriesPath:                 flags.GetMultiStrFlag(flags.QueriesPath),
 		LibrariesPath:               flags.GetStrFlag(flags.LibrariesPath),
 		ReportFormats:               flags.GetMultiStrFlag(flags.ReportFormatsFlag),
+		SarifBaselinePath:           flags.GetStrFlag(flags.SarifBaselineFlag),
 		Platform:                    flags.GetMultiStrFlag(flags.TypeFlag),
 		ExcludePlatform:             flags.GetMultiStrFlag(flags.ExcludeTypeFlag),
 		TerraformVarsPath:           flags.GetStrFlag(flags.TerraformVarsPathFlag),
 		QueryExecTimeout:            flags.GetIntFlag(flags.QueryExecTimeoutFlag),
+		ScanTimeout:                 scanTimeout,
 		LineInfoPayload:             flags.GetBoolFlag(flags.LineInfoPayloadFlag),
 		DisableSecrets:              flags.GetBoolFlag(flags.DisableSecretsFlag),
 		SecretsRegexesPath:          flags.GetStrFlag(flags.SecretsRegexesPathFlag),
+		SecretsEngine:               flags.GetStrFlag(flags.SecretsEngineFlag),
 		ScanID:                      scanID,
 		ChangedDefaultLibrariesPath: changedDefaultLibrariesPath,
 		ChangedDefaultQueryPath:     changedDefaultQueryPath,
 		BillOfMaterials:             flags.GetBoolFlag(flags.BomFlag),
+		RiskScore:                   flags.GetBoolFlag(flags.RiskScoreFlag),
 		ExcludeGitIgnore:            flags.GetBoolFlag(flags.ExcludeGitIgnore),
+		ExcludeGenerated:            flags.GetBoolFlag(flags.ExcludeGeneratedFlag),
 		OpenAPIResolveReferences:    flags.GetBoolFlag(flags.OpenAPIReferencesFlag),
 		ParallelScanFlag:            flags.GetIntFlag(flags.ParallelScanFile),
 		MaxFileSizeFlag:             flags.GetIntFlag(flags.MaxFileSizeFlag),
+		MaxFilesFlag:                flags.GetIntFlag(flags.MaxFilesFlag),
+		Force:                       flags.GetBoolFlag(flags.ForceFlag),
+		MaxResultsPerQuery:          flags.GetIntFlag(flags.MaxResultsPerQueryFlag),
 		UseNewSeverities:            flags.GetBoolFlag(flags.UseNewSeveritiesFlag),
+		StrictParsing:               flags.GetBoolFlag(flags.StrictParsingFlag),
+		Offline:                     flags.GetBoolFlag(flags.OfflineFlag),
+		DescriptionsEndpoint:        flags.GetStrFlag(flags.DescURLFlag),
+		DescriptionsAuthHeader:      flags.GetStrFlag(flags.DescHeaderFlag),
+		VersionCheckEndpoint:        flags.GetStrFlag(flags.VersionURLFlag),
+		VersionCheckAuthHeader:      flags.GetStrFlag(flags.VersionHeaderFlag),
+		DisableVersionCheck:         flags.GetBoolFlag(flags.DisableVersionCheckFlag),
+		AttestationKeyPath:          flags.GetStrFlag(flags.AttestationKeyFlag),
+		HTMLPageSize:                flags.GetIntFlag(flags.HTMLPageSizeFlag),
+		EmbedQueryDocs:              flags.GetBoolFlag(flags.EmbedQueryDocsFlag),
+		EncryptOutput:               flags.GetStrFlag(flags.EncryptOutputFlag),
+		Enrichers:                   flags.GetMultiStrFlag(flags.EnrichersFlag),
+		WebhookURL:                  flags.GetStrFlag(flags.WebhookURLFlag),
+		WebhookSecret:               flags.GetStrFlag(flags.WebhookSecretFlag),
 	}
 
 	return &scanParams
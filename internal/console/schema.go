@@ -0,0 +1,24 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/Checkmarx/kics/pkg/report"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// NewSchemaCmd creates a new instance of the schema Command
+func NewSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the JSON report",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := fmt.Fprint(cmd.OutOrStdout(), report.GetJSONSchema())
+			if err != nil {
+				log.Err(err).Msg("failed to print the JSON report schema")
+			}
+			return err
+		},
+	}
+}
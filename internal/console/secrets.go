@@ -0,0 +1,125 @@
+package console
+
+import (
+	_ "embed" // Embed secrets flags
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Checkmarx/kics/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
+	sentryReport "github.com/Checkmarx/kics/internal/sentry"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	internalPrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/report"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+	"github.com/Checkmarx/kics/pkg/scan"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/secrets-flags.json
+	secretsFlagsListContent string
+)
+
+// NewSecretsCmd creates a new instance of the secrets Command
+func NewSecretsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "secrets",
+		Short: "Scans files only for hardcoded secrets",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := internalPrinter.SetupPrinter(cmd.InheritedFlags())
+			if err != nil {
+				return errors.New(initError + err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := scanSecrets(cmd.OutOrStdout())
+			var exitErr *consoleHelpers.ExitCodeError
+			if errors.As(err, &exitErr) {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+}
+
+func initSecretsCmd(secretsCmd *cobra.Command) error {
+	if err := flags.InitJSONFlags(
+		secretsCmd,
+		secretsFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders()); err != nil {
+		return err
+	}
+
+	if err := secretsCmd.MarkFlagRequired(flags.SecretsScanPathFlag); err != nil {
+		sentryReport.ReportSentry(&sentryReport.Report{
+			Message:  "Failed to add command required flags",
+			Err:      err,
+			Location: "func initSecretsCmd()",
+		}, true)
+		log.Err(err).Msg("Failed to add command required flags")
+	}
+	return nil
+}
+
+func scanSecrets(out io.Writer) error {
+	// the secrets command is meant to be a fast local gate, so the version is not checked
+	params := &scan.Parameters{
+		Path:                flags.GetMultiStrFlag(flags.SecretsScanPathFlag),
+		ExcludePaths:        flags.GetMultiStrFlag(flags.SecretsExcludePathsFlag),
+		ExcludeResults:      flags.GetMultiStrFlag(flags.SecretsExcludeResultsFlag),
+		SecretsRegexesPath:  flags.GetStrFlag(flags.SecretsRulesPathFlag),
+		MaxFileSizeFlag:     flags.GetIntFlag(flags.MaxFileSizeFlag),
+		PreviewLines:        flags.GetIntFlag(flags.PreviewLinesFlag),
+		QueryExecTimeout:    flags.GetIntFlag(flags.QueryExecTimeoutFlag),
+		Offline:             flags.GetBoolFlag(flags.OfflineFlag),
+		DisableVersionCheck: true,
+		ScanID:              scanID,
+	}
+
+	client, err := scan.NewClient(params, progress.InitializePbBuilder(true, false, true), internalPrinter.NewPrinter(true))
+	if err != nil {
+		log.Err(err).Msg("failed to create the secrets scan client")
+		return err
+	}
+
+	results, err := client.ScanSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
+	secretsReport := reportModel.BuildSecretsReport(
+		results.Results, results.FilesScanned, results.LinesScanned, results.Start, results.End)
+
+	for i := range secretsReport.Secrets {
+		secret := secretsReport.Secrets[i]
+		fmt.Fprintf(out, "%s:%d: [%s] %s\n", secret.FileName, secret.Line, secret.RuleName, secret.SimilarityID)
+	}
+	fmt.Fprintf(out, "\nFiles scanned: %d\n", secretsReport.FilesScanned)
+	fmt.Fprintf(out, "Lines scanned: %d\n", secretsReport.LinesScanned)
+	fmt.Fprintf(out, "Secrets found: %d\n", secretsReport.SecretsFound)
+
+	if outputPath := flags.GetStrFlag(flags.SecretsOutputPathFlag); outputPath != "" {
+		if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+			return err
+		}
+		if err := report.ExportJSONReport(outputPath, flags.GetStrFlag(flags.SecretsOutputNameFlag), secretsReport); err != nil {
+			log.Err(err).Msg("failed to write the secrets report")
+			return err
+		}
+	}
+
+	if exitCode := consoleHelpers.SecretsExitCode(secretsReport.SecretsFound); exitCode != 0 {
+		return &consoleHelpers.ExitCodeError{Code: exitCode}
+	}
+
+	return nil
+}
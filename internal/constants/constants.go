@@ -43,6 +43,7 @@ var (
 		"Ansible":                 "ansible",
 		"CICD":                    "cicd",
 		"CloudFormation":          "cloudFormation",
+		"ConfigConnector":         "configConnector",
 		"Crossplane":              "crossplane",
 		"Dockerfile":              "dockerfile",
 		"DockerCompose":           "dockerCompose",
@@ -108,6 +109,12 @@ const (
 	// MaximumPreviewLines - default maximum preview lines number
 	MaximumPreviewLines = 30
 
+	// QueryErrorCode - Exit Status code for queries that failed to execute
+	QueryErrorCode = 70
+
+	// ParseFailureCode - Exit Status code for files that failed to be parsed or resolved
+	ParseFailureCode = 80
+
 	// EngineErrorCode - Exit Status code for error in engine
 	EngineErrorCode = 126
 
@@ -31,6 +31,10 @@ type CITracker struct {
 	Version            model.Version
 	BagOfFilesParse    map[string]int
 	BagOfFilesFound    map[string]int
+	ParseFailures      []model.ParseFailure
+	SkippedQueries     []model.SkippedQuery
+	FailedQueries      []model.FailedQuery
+	BagOfFilesSkipped  map[string]bool
 	syncFileMutex      sync.Mutex
 }
 
@@ -42,9 +46,10 @@ func NewTracker(previewLines int) (*CITracker, error) {
 			fmt.Errorf("output lines minimum is %v and maximum is %v", constants.MinimumPreviewLines, constants.MaximumPreviewLines)
 	}
 	return &CITracker{
-		lines:           previewLines,
-		BagOfFilesParse: make(map[string]int),
-		BagOfFilesFound: make(map[string]int),
+		lines:             previewLines,
+		BagOfFilesParse:   make(map[string]int),
+		BagOfFilesFound:   make(map[string]int),
+		BagOfFilesSkipped: make(map[string]bool),
 	}, nil
 }
 
@@ -96,6 +101,36 @@ func (c *CITracker) TrackFileParse(path string) {
 	}
 }
 
+// TrackFileParseFailure adds a file that failed to be parsed or resolved
+func (c *CITracker) TrackFileParseFailure(failure model.ParseFailure) {
+	c.syncFileMutex.Lock()
+	defer c.syncFileMutex.Unlock()
+	c.ParseFailures = append(c.ParseFailures, failure)
+}
+
+// TrackQuerySkipped adds a query not executed because the scan timeout expired,
+// skipped queries are not counted as queries failed to execute
+func (c *CITracker) TrackQuerySkipped(query model.SkippedQuery, queryAggregation int) {
+	trackerMu.Lock()
+	defer trackerMu.Unlock()
+	c.ExecutingQueries -= queryAggregation
+	c.SkippedQueries = append(c.SkippedQueries, query)
+}
+
+// TrackQueryFailure adds the error of a query that failed to execute
+func (c *CITracker) TrackQueryFailure(failure model.FailedQuery) {
+	trackerMu.Lock()
+	defer trackerMu.Unlock()
+	c.FailedQueries = append(c.FailedQueries, failure)
+}
+
+// TrackFileSkipped adds a file not parsed because the scan timeout expired
+func (c *CITracker) TrackFileSkipped(path string) {
+	c.syncFileMutex.Lock()
+	defer c.syncFileMutex.Unlock()
+	c.BagOfFilesSkipped[path] = true
+}
+
 // FailedDetectLine - queries that fail to detect line are counted as failed to execute queries
 func (c *CITracker) FailedDetectLine() {
 	c.ExecutedQueries--
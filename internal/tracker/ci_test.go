@@ -154,9 +154,10 @@ func TestNewTracker(t *testing.T) {
 				outputLines: 3,
 			},
 			want: CITracker{
-				lines:           3,
-				BagOfFilesFound: make(map[string]int),
-				BagOfFilesParse: make(map[string]int),
+				lines:             3,
+				BagOfFilesFound:   make(map[string]int),
+				BagOfFilesParse:   make(map[string]int),
+				BagOfFilesSkipped: make(map[string]bool),
 			},
 			wantErr: false,
 		},
@@ -187,3 +188,38 @@ func TestNewTracker(t *testing.T) {
 		})
 	}
 }
+
+func TestCITracker_TrackFileParseFailure(t *testing.T) {
+	c := &CITracker{}
+	failures := []model.ParseFailure{
+		{FilePath: "main.tf", Platform: "terraform", Error: "main.tf:3,9-4,1: Invalid expression", Line: 3, Code: "acl ="},
+		{FilePath: "values.yaml", Error: "yaml: line 2: found character that cannot start any token", Line: 2},
+	}
+	for i := range failures {
+		c.TrackFileParseFailure(failures[i])
+	}
+
+	require.Equal(t, failures, c.ParseFailures)
+}
+
+func TestCITracker_TrackQuerySkipped(t *testing.T) {
+	c := &CITracker{}
+	c.TrackQueryExecuting(3)
+	c.TrackQueryExecution(1)
+
+	skipped := model.SkippedQuery{QueryID: "1", QueryName: "Generic Query", Platform: "Terraform"}
+	c.TrackQuerySkipped(skipped, 2)
+
+	require.Equal(t, []model.SkippedQuery{skipped}, c.SkippedQueries)
+	// skipped queries are not counted as failed to execute
+	require.Equal(t, 0, c.ExecutingQueries-c.ExecutedQueries)
+}
+
+func TestCITracker_TrackFileSkipped(t *testing.T) {
+	c, err := NewTracker(3)
+	require.NoError(t, err)
+	c.TrackFileSkipped("main.tf")
+	c.TrackFileSkipped("main.tf")
+
+	require.Equal(t, map[string]bool{"main.tf": true}, c.BagOfFilesSkipped)
+}
@@ -8,9 +8,11 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Checkmarx/kics/internal/metrics"
 	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/generated"
 	"github.com/Checkmarx/kics/pkg/model"
 	"github.com/Checkmarx/kics/pkg/utils"
 	"github.com/pkg/errors"
@@ -35,6 +37,9 @@ var (
 	OpenAPIRegexPath                                = regexp.MustCompile(`("(paths|components|webhooks)"|(paths|components|webhooks))\s*:`)
 	armRegexContentVersion                          = regexp.MustCompile(`"contentVersion"\s*:`)
 	armRegexResources                               = regexp.MustCompile(`"resources"\s*:`)
+	armWhatIfRegexChanges                           = regexp.MustCompile(`"changes"\s*:`)
+	armWhatIfRegexChangeType                        = regexp.MustCompile(`"changeType"\s*:`)
+	armWhatIfRegexResourceID                        = regexp.MustCompile(`"resourceId"\s*:`)
 	cloudRegex                                      = regexp.MustCompile(`("Resources"|Resources)\s*:`)
 	k8sRegex                                        = regexp.MustCompile(`("apiVersion"|apiVersion)\s*:`)
 	k8sRegexKind                                    = regexp.MustCompile(`("kind"|kind)\s*:`)
@@ -58,6 +63,7 @@ var (
 	dockerComposeServicesRegex                      = regexp.MustCompile(`services\s*:[\w\W]+(image|build)\s*:`)
 	crossPlaneRegex                                 = regexp.MustCompile(`"?apiVersion"?\s*:\s*(\w+\.)+crossplane\.io/v\w+\s*`)
 	knativeRegex                                    = regexp.MustCompile(`"?apiVersion"?\s*:\s*(\w+\.)+knative\.dev/v\w+\s*`)
+	configConnectorRegex                            = regexp.MustCompile(`"?apiVersion"?\s*:\s*(\w+\.)+cnrm\.cloud\.google\.com/v\w+\s*`)
 	pulumiNameRegex                                 = regexp.MustCompile(`name\s*:`)
 	pulumiRuntimeRegex                              = regexp.MustCompile(`runtime\s*:`)
 	pulumiResourcesRegex                            = regexp.MustCompile(`resources\s*:`)
@@ -71,7 +77,7 @@ var (
 
 var (
 	listKeywordsGoogleDeployment = []string{"resources"}
-	armRegexTypes                = []string{"blueprint", "templateArtifact", "roleAssignmentArtifact", "policyAssignmentArtifact"}
+	armRegexTypes                = []string{"blueprint", "templateArtifact", "roleAssignmentArtifact", "policyAssignmentArtifact", armWhatIf}
 	possibleFileTypes            = map[string]bool{
 		".yml":               true,
 		".yaml":              true,
@@ -83,17 +89,20 @@ var (
 		".ubi8":              true,
 		".tf":                true,
 		"tfvars":             true,
+		".tfstate":           true,
 		".proto":             true,
 		".sh":                true,
 		".cfg":               true,
 		".conf":              true,
 		".ini":               true,
+		".jinja":             true,
 	}
 	supportedRegexes = map[string][]string{
 		"azureresourcemanager": append(armRegexTypes, arm),
 		"buildah":              {"buildah"},
 		"cicd":                 {"cicd"},
 		"cloudformation":       {"cloudformation"},
+		"configconnector":      {"configconnector"},
 		"crossplane":           {"crossplane"},
 		"dockercompose":        {"dockercompose"},
 		"knative":              {"knative"},
@@ -117,6 +126,7 @@ const (
 	json       = ".json"
 	sh         = ".sh"
 	arm        = "azureresourcemanager"
+	armWhatIf  = "armWhatIf"
 	kubernetes = "kubernetes"
 	terraform  = "terraform"
 	gdm        = "googledeploymentmanager"
@@ -125,6 +135,7 @@ const (
 	dockerfile = "dockerfile"
 	crossplane = "crossplane"
 	knative    = "knative"
+	cnrm       = "configconnector"
 	sizeMb     = 1048576
 )
 
@@ -153,7 +164,12 @@ type Analyzer struct {
 	Exc               []string
 	GitIgnoreFileName string
 	ExcludeGitIgnore  bool
+	ExcludeGenerated  bool
 	MaxFileSize       int
+	// MaxFiles is the maximum number of files to scan, the files found beyond it are left out, 0 sets no limit
+	MaxFiles int
+	guarded  []model.GuardedFile
+	excluded map[string]bool
 }
 
 // types is a map that contains the regex by type
@@ -183,6 +199,12 @@ var types = map[string]regexSlice{
 			k8sRegexKind,
 		},
 	},
+	"configconnector": {
+		regex: []*regexp.Regexp{
+			configConnectorRegex,
+			k8sRegexKind,
+		},
+	},
 	"cloudformation": {
 		regex: []*regexp.Regexp{
 			cloudRegex,
@@ -194,6 +216,13 @@ var types = map[string]regexSlice{
 			armRegexResources,
 		},
 	},
+	armWhatIf: {
+		[]*regexp.Regexp{
+			armWhatIfRegexChanges,
+			armWhatIfRegexChangeType,
+			armWhatIfRegexResourceID,
+		},
+	},
 	"terraform": {
 		[]*regexp.Regexp{
 			tfPlanRegexConf,
@@ -293,52 +322,89 @@ func Analyze(a *Analyzer) (model.AnalyzedPaths, error) {
 	projectConfigFiles := make([]string, 0)
 	done := make(chan bool)
 	hasGitIgnoreFile, gitIgnore := shouldConsiderGitIgnoreFile(a.Paths[0], a.GitIgnoreFileName, a.ExcludeGitIgnore)
+	var detector *generated.Detector
+	if a.ExcludeGenerated {
+		detector = generated.NewDetector(a.Paths)
+	}
+	a.loadExcluded()
+	workers := defaultWorkers()
+	discovery := model.DiscoveryPerformance{Workers: workers}
+
 	// get all the files inside the given paths
+	walkStart := time.Now()
 	for _, path := range a.Paths {
 		if _, err := os.Stat(path); err != nil {
 			return returnAnalyzedPaths, errors.Wrap(err, "failed to analyze path")
 		}
-		if err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
+		w := newWalker(workers, detector, a.excluded)
+		entries, err := w.walk(path)
+		if err != nil {
+			log.Error().Msgf("failed to analyze path %s: %s", path, err)
+		}
+		discovery.Directories += w.directories
 
-			ext := utils.GetExtension(path)
+		// the entries are filtered in the order of the walk, as the exclusions they add apply to the next entries
+		for i := range entries {
+			entry := &entries[i]
+			if !entry.isDir {
+				discovery.Files++
+			}
 
-			trimmedPath := strings.ReplaceAll(path, a.Paths[0], filepath.Base(a.Paths[0]))
-			ignoreFiles = a.checkIgnore(info.Size(), hasGitIgnoreFile, gitIgnore, path, trimmedPath, ignoreFiles)
+			trimmedPath := strings.ReplaceAll(entry.path, a.Paths[0], filepath.Base(a.Paths[0]))
+			ignoreFiles = a.checkIgnore(entry, hasGitIgnoreFile, gitIgnore, trimmedPath, ignoreFiles)
 
-			if isConfigFile(path, defaultConfigFiles) {
-				projectConfigFiles = append(projectConfigFiles, path)
-				a.Exc = append(a.Exc, path)
+			// the vendored directories are skipped as a whole, the other generated files are only read when
+			// they could be scanned
+			if entry.vendored {
+				ignoreFiles = a.excludeGenerated(entry.path, ignoreFiles)
+				continue
+			}
+			if entry.isDir {
+				continue
 			}
 
-			if _, ok := possibleFileTypes[ext]; ok && !isExcludedFile(path, a.Exc) {
-				files = append(files, path)
+			if isConfigFile(entry.path, defaultConfigFiles) {
+				projectConfigFiles = append(projectConfigFiles, entry.path)
+				a.exclude(entry.path)
 			}
 
-			return nil
-		}); err != nil {
-			log.Error().Msgf("failed to analyze path %s: %s", path, err)
+			if _, ok := possibleFileTypes[entry.ext]; ok && !a.isExcluded(entry.path) {
+				if entry.generated {
+					ignoreFiles = a.excludeGenerated(entry.path, ignoreFiles)
+					continue
+				}
+				files = append(files, entry.path)
+			}
 		}
 	}
+	discovery.WalkMs = durationMs(time.Since(walkStart))
+
+	files, ignoreFiles = a.limitFiles(files, ignoreFiles)
+	discovery.ClassifiedFiles = len(files)
 
 	// unwanted is the channel shared by the workers that contains the unwanted files that the parser will ignore
 	unwanted := make(chan string, len(files))
 
 	a.Types, a.ExcludeTypes = typeLower(a.Types, a.ExcludeTypes)
 
-	// Start the workers
-	for _, file := range files {
-		wg.Add(1)
-		// analyze the files concurrently
-		a := &analyzerInfo{
-			typesFlag:        a.Types,
-			excludeTypesFlag: a.ExcludeTypes,
-			filePath:         file,
+	// Start the workers, the files are analyzed concurrently by up to the number of workers
+	classificationStart := time.Now()
+	wg.Add(len(files))
+	go func() {
+		sem := make(chan struct{}, workers)
+		for _, file := range files {
+			sem <- struct{}{}
+			a := &analyzerInfo{
+				typesFlag:        a.Types,
+				excludeTypesFlag: a.ExcludeTypes,
+				filePath:         file,
+			}
+			go func() {
+				defer func() { <-sem }()
+				a.worker(results, unwanted, locCount, &wg)
+			}()
 		}
-		go a.worker(results, unwanted, locCount, &wg)
-	}
+	}()
 
 	go func() {
 		// close channel results when the worker has finished writing into it
@@ -358,11 +424,39 @@ func Analyze(a *Analyzer) (model.AnalyzedPaths, error) {
 	returnAnalyzedPaths.Types = availableTypes
 	returnAnalyzedPaths.Exc = unwantedPaths
 	returnAnalyzedPaths.ExpectedLOC = loc
+	returnAnalyzedPaths.Guarded = a.guarded
+	discovery.ClassificationMs = durationMs(time.Since(classificationStart))
+	returnAnalyzedPaths.Discovery = discovery
+	log.Info().Msgf("Discovered %d files in %d directories in %.0fms, %d files classified in %.0fms",
+		discovery.Files, discovery.Directories, discovery.WalkMs, discovery.ClassifiedFiles, discovery.ClassificationMs)
 	// stop metrics for file analyzer
 	metrics.Metric.Stop()
 	return returnAnalyzedPaths, nil
 }
 
+// GuessPlatform returns the platform a single file most likely belongs to,
+// an empty string is returned when the platform can not be determined
+func GuessPlatform(filePath string) string {
+	var wg sync.WaitGroup
+	results := make(chan string, 1)
+	unwanted := make(chan string, 1)
+	locCount := make(chan int, 1)
+
+	a := &analyzerInfo{
+		typesFlag:        []string{""},
+		excludeTypesFlag: []string{""},
+		filePath:         filePath,
+	}
+	wg.Add(1)
+	a.worker(results, unwanted, locCount, &wg)
+	close(results)
+
+	for platform := range results {
+		return platform
+	}
+	return ""
+}
+
 // worker determines the type of the file by ext (dockerfile and terraform)/content and
 // writes the answer to the results channel
 // if no types were found, the worker will write the path of the file in the unwanted channel
@@ -388,7 +482,7 @@ func (a *analyzerInfo) worker(results, unwanted chan<- string, locCount chan<- i
 			unwanted <- a.filePath
 		}
 	// Terraform
-	case ".tf", "tfvars":
+	case ".tf", "tfvars", ".tfstate":
 		if a.isAvailableType(terraform) {
 			results <- terraform
 			locCount <- linesCount
@@ -405,8 +499,14 @@ func (a *analyzerInfo) worker(results, unwanted chan<- string, locCount chan<- i
 			results <- ansible
 			locCount <- linesCount
 		}
-	/* It could be Ansible, Buildah, CICD, CloudFormation, Crossplane, OpenAPI, Azure Resource Manager
-	Docker Compose, Knative, Kubernetes, Pulumi, ServerlessFW or Google Deployment Manager*/
+	// Google Deployment Manager templates
+	case ".jinja":
+		if a.isAvailableType(gdm) {
+			results <- gdm
+			locCount <- linesCount
+		}
+	/* It could be Ansible, Buildah, CICD, CloudFormation, Config Connector, Crossplane, OpenAPI, Azure Resource
+	Manager, Docker Compose, Knative, Kubernetes, Pulumi, ServerlessFW or Google Deployment Manager*/
 	case yaml, yml, json, sh:
 		a.checkContent(results, unwanted, locCount, linesCount, ext)
 	}
@@ -436,11 +536,11 @@ func isDockerfile(path string) bool {
 	return check
 }
 
-// overrides k8s match when all regexs passes for azureresourcemanager key and extension is set to json
+// overrides k8s match when all regexs passes for azureresourcemanager (or ARM what-if) key and extension is set to json
 func needsOverride(check bool, returnType, key, ext string) bool {
-	if check && returnType == kubernetes && key == arm && ext == json {
+	if check && returnType == kubernetes && (key == arm || key == armWhatIf) && ext == json {
 		return true
-	} else if check && returnType == kubernetes && (key == knative || key == crossplane) && (ext == yaml || ext == yml) {
+	} else if check && returnType == kubernetes && (key == knative || key == crossplane || key == cnrm) && (ext == yaml || ext == yml) {
 		return true
 	}
 	return false
@@ -650,26 +750,36 @@ func getKeysFromExcludeTypesFlag(excludeTypesFlag []string) []string {
 	return ks
 }
 
-// isExcludedFile verifies if the path is pointed in the --exclude-paths flag
-func isExcludedFile(path string, exc []string) bool {
-	for i := range exc {
-		exclude, err := provider.GetExcludePaths(exc[i])
+// loadExcluded expands the paths of the --exclude-paths flag once, so the exclusions are not resolved again for each
+// file found
+func (a *Analyzer) loadExcluded() {
+	a.excluded = make(map[string]bool, len(a.Exc))
+	for i := range a.Exc {
+		exclude, err := provider.GetExcludePaths(a.Exc[i])
 		if err != nil {
 			log.Err(err).Msg("failed to get exclude paths")
 		}
 		for j := range exclude {
-			if exclude[j] == path {
-				log.Info().Msgf("Excluded file %s from analyzer", path)
-				return true
-			}
+			a.excluded[exclude[j]] = true
 		}
 	}
-	return false
 }
 
-func isDeadSymlink(path string) bool {
-	fileInfo, _ := os.Stat(path)
-	return fileInfo == nil
+// exclude excludes a path from the scan, the next checks of the path consider it excluded
+func (a *Analyzer) exclude(path string) {
+	a.Exc = append(a.Exc, path)
+	if a.excluded != nil {
+		a.excluded[path] = true
+	}
+}
+
+// isExcluded verifies if the path is pointed in the --exclude-paths flag or was excluded by the analyzer
+func (a *Analyzer) isExcluded(path string) bool {
+	if a.excluded[path] {
+		log.Info().Msgf("Excluded file %s from analyzer", path)
+		return true
+	}
+	return false
 }
 
 func isConfigFile(path string, exc []string) bool {
@@ -679,11 +789,6 @@ func isConfigFile(path string, exc []string) bool {
 			log.Err(err).Msg("failed to get exclude paths")
 		}
 		for j := range exclude {
-			fileInfo, _ := os.Stat(path)
-			if fileInfo != nil && fileInfo.IsDir() {
-				continue
-			}
-
 			if len(path)-len(exclude[j]) > 0 && path[len(path)-len(exclude[j]):] == exclude[j] && exclude[j] != "" {
 				log.Info().Msgf("Excluded file %s from analyzer", path)
 				return true
@@ -733,22 +838,56 @@ func (a *analyzerInfo) isAvailableType(typeName string) bool {
 	return false
 }
 
-func (a *Analyzer) checkIgnore(fileSize int64, hasGitIgnoreFile bool,
+func (a *Analyzer) checkIgnore(entry *walkEntry, hasGitIgnoreFile bool,
 	gitIgnore *ignore.GitIgnore,
-	fullPath string, trimmedPath string, ignoreFiles []string) []string {
+	trimmedPath string, ignoreFiles []string) []string {
+	fullPath, fileSize := entry.path, entry.size
 	exceededFileSize := a.MaxFileSize >= 0 && float64(fileSize)/float64(sizeMb) > float64(a.MaxFileSize)
+	gitIgnored := hasGitIgnoreFile && gitIgnore.MatchesPath(trimmedPath)
+	// only the files that could be scanned are guarded, not the excluded files or the data files of other formats
+	_, scannable := possibleFileTypes[entry.ext]
+	guarded := exceededFileSize && scannable && !gitIgnored && !a.isExcluded(fullPath)
 
-	if (hasGitIgnoreFile && gitIgnore.MatchesPath(trimmedPath)) || isDeadSymlink(fullPath) || exceededFileSize {
+	if gitIgnored || entry.dead || exceededFileSize {
 		ignoreFiles = append(ignoreFiles, fullPath)
-		a.Exc = append(a.Exc, fullPath)
+		a.exclude(fullPath)
 
 		if exceededFileSize {
 			log.Error().Msgf("file %s exceeds maximum file size of %d Mb", fullPath, a.MaxFileSize)
 		}
+		if guarded {
+			a.guarded = append(a.guarded, model.GuardedFile{
+				FilePath: fullPath,
+				Reason:   model.GuardMaxFileSize,
+				Size:     fileSize,
+			})
+		}
 	}
 	return ignoreFiles
 }
 
+// limitFiles keeps the first files found up to the maximum number of files, the files found beyond it are
+// excluded from the scan before their content is read
+func (a *Analyzer) limitFiles(files, ignoreFiles []string) (limitedFiles, limitedIgnoreFiles []string) {
+	if a.MaxFiles <= 0 || len(files) <= a.MaxFiles {
+		return files, ignoreFiles
+	}
+	log.Error().Msgf("%d files were found, the %d files beyond the maximum of %d files are not scanned",
+		len(files), len(files)-a.MaxFiles, a.MaxFiles)
+	for _, file := range files[a.MaxFiles:] {
+		a.guarded = append(a.guarded, model.GuardedFile{FilePath: file, Reason: model.GuardMaxFiles})
+		ignoreFiles = append(ignoreFiles, file)
+	}
+	return files[:a.MaxFiles], ignoreFiles
+}
+
+// excludeGenerated excludes a generated file or a vendored directory from the scan
+func (a *Analyzer) excludeGenerated(path string, ignoreFiles []string) []string {
+	log.Debug().Msgf("Excluded the generated path %s", path)
+	a.exclude(path)
+	return append(ignoreFiles, path)
+}
+
 func typeLower(types, exclTypes []string) (typesRes, exclTypesRes []string) {
 	for i := range types {
 		types[i] = strings.ToLower(types[i])
@@ -760,3 +899,7 @@ func typeLower(types, exclTypes []string) (typesRes, exclTypesRes []string) {
 
 	return types, exclTypes
 }
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / float64(time.Millisecond/time.Microsecond)
+}
@@ -1,10 +1,12 @@
 package analyzer
 
 import (
+	"os"
 	"path/filepath"
 	"sort"
 	"testing"
 
+	"github.com/Checkmarx/kics/pkg/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,6 +70,21 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			excludeGitIgnore:     false,
 			MaxFileSize:          -1,
 		},
+		{
+			name: "analyze_test_gcp_path",
+			paths: []string{
+				filepath.FromSlash("../../test/fixtures/analyzer_test_gcp"),
+			},
+			wantTypes:            []string{"configconnector", "googledeploymentmanager"},
+			wantExclude:          []string{},
+			typesFromFlag:        []string{""},
+			excludeTypesFromFlag: []string{""},
+			wantLOC:              26,
+			wantErr:              false,
+			gitIgnoreFileName:    "",
+			excludeGitIgnore:     false,
+			MaxFileSize:          -1,
+		},
 		{
 			name: "analyze_test_multi_checks_path",
 			paths: []string{
@@ -143,6 +160,21 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			excludeGitIgnore:     false,
 			MaxFileSize:          -1,
 		},
+		{
+			name: "analyze_test_arm_what_if",
+			paths: []string{
+				filepath.FromSlash("../../test/fixtures/arm_what_if"),
+			},
+			wantTypes:            []string{"azureresourcemanager"},
+			wantExclude:          []string{},
+			typesFromFlag:        []string{""},
+			excludeTypesFromFlag: []string{""},
+			wantLOC:              87,
+			wantErr:              false,
+			gitIgnoreFileName:    "",
+			excludeGitIgnore:     false,
+			MaxFileSize:          -1,
+		},
 		{
 			name: "analyze_test_considering_ignore_file",
 			paths: []string{
@@ -458,3 +490,169 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzer_AnalyzeExcludeGenerated(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.tf":                      "resource \"aws_s3_bucket\" \"b\" {}\n",
+		".terraform/modules/m/main.tf": "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"generated.tf":                 "# Code generated by cdktf. DO NOT EDIT.\nresource \"aws_s3_bucket\" \"b\" {}\n",
+		"Dockerfile":                   "FROM alpine\n",
+		".gitattributes":               "Dockerfile linguist-generated\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	analyzer := &Analyzer{
+		Paths:            []string{root},
+		Types:            []string{""},
+		ExcludeTypes:     []string{""},
+		Exc:              []string{""},
+		ExcludeGenerated: true,
+		MaxFileSize:      -1,
+	}
+	got, err := Analyze(analyzer)
+	require.NoError(t, err)
+
+	sort.Strings(got.Exc)
+	require.Equal(t, []string{"terraform"}, got.Types)
+	require.Equal(t, []string{
+		filepath.Join(root, ".terraform"),
+		filepath.Join(root, "Dockerfile"),
+		filepath.Join(root, "generated.tf"),
+	}, got.Exc)
+	require.Equal(t, 1, got.ExpectedLOC)
+}
+
+func TestAnalyzer_AnalyzeGuards(t *testing.T) {
+	root := t.TempDir()
+	bigContent := make([]byte, sizeMb+1)
+	files := map[string][]byte{
+		"a.tf":     []byte("resource \"aws_s3_bucket\" \"a\" {}\n"),
+		"b.tf":     []byte("resource \"aws_s3_bucket\" \"b\" {}\n"),
+		"c.tf":     []byte("resource \"aws_s3_bucket\" \"c\" {}\n"),
+		"big.json": bigContent,
+		"data.csv": bigContent,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), content, 0600))
+	}
+
+	analyzer := &Analyzer{
+		Paths:        []string{root},
+		Types:        []string{""},
+		ExcludeTypes: []string{""},
+		Exc:          []string{""},
+		MaxFileSize:  1,
+		MaxFiles:     2,
+	}
+	got, err := Analyze(analyzer)
+	require.NoError(t, err)
+
+	require.Equal(t, []model.GuardedFile{
+		{FilePath: filepath.Join(root, "big.json"), Reason: model.GuardMaxFileSize, Size: sizeMb + 1},
+		{FilePath: filepath.Join(root, "c.tf"), Reason: model.GuardMaxFiles},
+	}, got.Guarded)
+	require.Contains(t, got.Exc, filepath.Join(root, "c.tf"))
+	require.Contains(t, got.Exc, filepath.Join(root, "data.csv"))
+	require.Equal(t, 2, got.ExpectedLOC)
+
+	unlimited := &Analyzer{
+		Paths:        []string{root},
+		Types:        []string{""},
+		ExcludeTypes: []string{""},
+		Exc:          []string{""},
+		MaxFileSize:  -1,
+	}
+	got, err = Analyze(unlimited)
+	require.NoError(t, err)
+	require.Empty(t, got.Guarded)
+}
+
+func TestAnalyzer_AnalyzeDiscovery(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.tf":              "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"a/b/c/deep.tf":        "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"a/b/notes.txt":        "notes\n",
+		"a/Dockerfile":         "FROM alpine\n",
+		"z/charts/values.yaml": "replicas: 1\n",
+		"z/empty/.keep":        "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	// the concurrent walk returns the entries in the order of a sequential walk
+	want := make([]string, 0)
+	require.NoError(t, filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		want = append(want, path)
+		return err
+	}))
+	entries, err := newWalker(2, nil, map[string]bool{}).walk(root)
+	require.NoError(t, err)
+	got := make([]string, 0, len(entries))
+	for i := range entries {
+		got = append(got, entries[i].path)
+	}
+	require.Equal(t, want, got)
+
+	analyzer := &Analyzer{
+		Paths:        []string{root},
+		Types:        []string{""},
+		ExcludeTypes: []string{""},
+		Exc:          []string{""},
+		MaxFileSize:  -1,
+	}
+	analyzed, err := Analyze(analyzer)
+	require.NoError(t, err)
+	require.Equal(t, 7, analyzed.Discovery.Directories)
+	require.Equal(t, 6, analyzed.Discovery.Files)
+	require.Equal(t, 4, analyzed.Discovery.ClassifiedFiles)
+	require.Equal(t, defaultWorkers(), analyzed.Discovery.Workers)
+}
+
+func TestAnalyzer_GuessPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{
+			name:     "guess_terraform",
+			filePath: filepath.FromSlash("../../test/fixtures/analyzer_test/terraform.tf"),
+			want:     "terraform",
+		},
+		{
+			name:     "guess_dockerfile",
+			filePath: filepath.FromSlash("../../test/fixtures/analyzer_test/Dockerfile"),
+			want:     "dockerfile",
+		},
+		{
+			name:     "guess_kubernetes",
+			filePath: filepath.FromSlash("../../test/fixtures/analyzer_test/k8s.yaml"),
+			want:     "kubernetes",
+		},
+		{
+			name:     "guess_undetected",
+			filePath: filepath.FromSlash("../../test/fixtures/analyzer_test/undetected.yaml"),
+			want:     "",
+		},
+		{
+			name:     "guess_unknown_extension",
+			filePath: filepath.FromSlash("../../test/fixtures/analyzer_test/dead_symlink"),
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GuessPlatform(tt.filePath))
+		})
+	}
+}
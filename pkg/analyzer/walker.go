@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/Checkmarx/kics/pkg/generated"
+	"github.com/Checkmarx/kics/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// workersPerCPU is the number of directories listed, and of files classified, concurrently by CPU, the walk is
+// bound by the latency of the filesystem rather than by the CPU, mostly on network filesystems
+const workersPerCPU = 4
+
+// walkEntry is a file or a directory found by the walker, the type of the entries comes from the listing of their
+// directory so only the files are stat'ed, and only the symlinks are resolved
+type walkEntry struct {
+	path      string
+	ext       string
+	isDir     bool
+	size      int64
+	dead      bool
+	vendored  bool
+	generated bool
+}
+
+// walker lists the directories of a path concurrently, each worker reads the entries of a directory at once and
+// stats its files in the same batch before releasing it
+type walker struct {
+	workers     int
+	detector    *generated.Detector
+	excluded    map[string]bool
+	sem         chan struct{}
+	wg          sync.WaitGroup
+	mutex       sync.Mutex
+	dirs        map[string][]walkEntry
+	directories int
+}
+
+func newWalker(workers int, detector *generated.Detector, excluded map[string]bool) *walker {
+	return &walker{
+		workers:  workers,
+		detector: detector,
+		excluded: excluded,
+		sem:      make(chan struct{}, workers),
+		dirs:     make(map[string][]walkEntry),
+	}
+}
+
+// defaultWorkers returns the number of workers of the discovery of the files
+func defaultWorkers() int {
+	return workersPerCPU * runtime.GOMAXPROCS(0)
+}
+
+// walk returns the entries of the path in the order of filepath.Walk, the lexical order of a depth-first walk, so
+// the results of the scan, and the files kept by --max-files, do not depend on the scheduling of the workers
+func (w *walker) walk(root string) ([]walkEntry, error) {
+	info, err := os.Lstat(root)
+	if err != nil {
+		return nil, err
+	}
+	rootEntry := w.entry(root, info.IsDir(), info.Mode()&os.ModeSymlink != 0, func() (os.FileInfo, error) {
+		return info, nil
+	})
+	if !rootEntry.isDir {
+		return []walkEntry{rootEntry}, nil
+	}
+	// the scanned path is walked even when it is a vendored directory
+	rootEntry.vendored = false
+
+	w.wg.Add(1)
+	go w.readDir(root)
+	w.wg.Wait()
+
+	entries := []walkEntry{rootEntry}
+	return w.appendDir(entries, root), nil
+}
+
+func (w *walker) appendDir(entries []walkEntry, dir string) []walkEntry {
+	for _, entry := range w.dirs[dir] {
+		entries = append(entries, entry)
+		if entry.isDir && !entry.vendored {
+			entries = w.appendDir(entries, entry.path)
+		}
+	}
+	return entries
+}
+
+// readDir reads the entries of a directory and walks its subdirectories concurrently, the vendored directories are
+// not walked when the generated files are excluded
+func (w *walker) readDir(dir string) {
+	defer w.wg.Done()
+
+	w.sem <- struct{}{}
+	dirEntries, err := os.ReadDir(dir)
+	entries := make([]walkEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		entries = append(entries, w.entry(
+			filepath.Join(dir, dirEntry.Name()),
+			dirEntry.IsDir(),
+			dirEntry.Type()&os.ModeSymlink != 0,
+			dirEntry.Info))
+	}
+	<-w.sem
+
+	if err != nil {
+		log.Error().Msgf("failed to analyze path %s: %s", dir, err)
+	}
+
+	w.mutex.Lock()
+	w.dirs[dir] = entries
+	w.directories++
+	w.mutex.Unlock()
+
+	for _, entry := range entries {
+		if entry.isDir && !entry.vendored {
+			w.wg.Add(1)
+			go w.readDir(entry.path)
+		}
+	}
+}
+
+func (w *walker) entry(path string, isDir, isSymlink bool, info func() (os.FileInfo, error)) walkEntry {
+	entry := walkEntry{path: path, isDir: isDir}
+	if isDir {
+		entry.vendored = w.detector != nil && generated.IsVendoredDir(filepath.Base(path))
+		return entry
+	}
+
+	if isSymlink {
+		_, err := os.Stat(path)
+		entry.dead = err != nil
+	}
+	if fileInfo, err := info(); err == nil {
+		entry.size = fileInfo.Size()
+	}
+	entry.ext = utils.GetExtension(path)
+	if _, ok := possibleFileTypes[entry.ext]; !ok {
+		return entry
+	}
+	// the generated markers are read by the workers, only for the files that are not excluded by the user
+	if w.detector != nil && !entry.dead && !w.excluded[path] {
+		entry.generated = w.detector.IsGenerated(path)
+	}
+	return entry
+}
@@ -0,0 +1,128 @@
+package blame
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// notCommitted is the commit git blame reports for the lines that are not committed yet
+const notCommitted = "0000000000000000000000000000000000000000"
+
+// runGit runs a git command and returns its output
+var runGit = func(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, "git", args...).Output() //nolint:gosec
+}
+
+// SetBlame sets the last commit that changed the line of each result, git blame is run once per file for the lines
+// of all of its results, the files outside of a git repository and the lines not committed yet are left unset
+func SetBlame(ctx context.Context, summary *model.Summary) {
+	filesLines := make(map[string]map[int][]*model.VulnerableFile)
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			file := &summary.Queries[i].Files[j]
+			if file.Line < 1 {
+				continue
+			}
+			fileName := file.FileName
+			if originalPath, ok := summary.FilePaths[fileName]; ok {
+				fileName = originalPath
+			}
+			if filesLines[fileName] == nil {
+				filesLines[fileName] = make(map[int][]*model.VulnerableFile)
+			}
+			filesLines[fileName][file.Line] = append(filesLines[fileName][file.Line], file)
+		}
+	}
+
+	for fileName, lines := range filesLines {
+		blames, err := blameLines(ctx, fileName, lines)
+		if err != nil {
+			log.Debug().Msgf("Failed to blame %s, its results are not annotated: %s", fileName, err)
+			continue
+		}
+		for line, files := range lines {
+			blame, ok := blames[line]
+			if !ok {
+				continue
+			}
+			for _, file := range files {
+				file.Blame = blame
+			}
+		}
+	}
+}
+
+// blameLines runs git blame on the lines of a file and returns the commit of each line
+func blameLines(ctx context.Context, fileName string, lines map[int][]*model.VulnerableFile) (map[int]*model.Blame, error) {
+	numbers := make([]int, 0, len(lines))
+	for line := range lines {
+		numbers = append(numbers, line)
+	}
+	sort.Ints(numbers)
+
+	args := []string{"-C", filepath.Dir(fileName), "blame", "--line-porcelain"}
+	for _, line := range numbers {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", line, line))
+	}
+	args = append(args, "--", filepath.Base(fileName))
+
+	output, err := runGit(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLinePorcelain(output)
+}
+
+// parseLinePorcelain parses the output of git blame --line-porcelain, each line of the file is described by a
+// header with its commit and its final line number, the information of the commit, and the content of the line
+func parseLinePorcelain(output []byte) (map[int]*model.Blame, error) {
+	blames := make(map[int]*model.Blame)
+	var current *model.Blame
+	line := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// the content of the line ends the description of the line
+			if current != nil && current.Commit != notCommitted {
+				blames[line] = current
+			}
+			current = nil
+		case current == nil:
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("unexpected git blame header %q", text)
+			}
+			finalLine, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("unexpected git blame header %q", text)
+			}
+			current = &model.Blame{Commit: fields[0]}
+			line = finalLine
+		case strings.HasPrefix(text, "author "):
+			current.Author = strings.TrimPrefix(text, "author ")
+		case strings.HasPrefix(text, "author-mail "):
+			current.AuthorEmail = strings.Trim(strings.TrimPrefix(text, "author-mail "), "<>")
+		case strings.HasPrefix(text, "author-time "):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64)
+			if err == nil {
+				current.Date = time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+			}
+		}
+	}
+	return blames, scanner.Err()
+}
@@ -0,0 +1,103 @@
+package blame
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const linePorcelain = `3f6c2a1b9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a 2 4 1
+author Jane Doe
+author-mail <jane@example.com>
+author-time 1700000000
+author-tz +0000
+committer Jane Doe
+committer-mail <jane@example.com>
+committer-time 1700000000
+committer-tz +0000
+summary Make the bucket public
+filename main.tf
+	  acl = "public-read"
+0000000000000000000000000000000000000000 9 9 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 1700000100
+author-tz +0000
+committer Not Committed Yet
+committer-mail <not.committed.yet>
+committer-time 1700000100
+committer-tz +0000
+summary Version of main.tf from main.tf
+filename main.tf
+	  versioning {}
+`
+
+func TestBlame_SetBlame(t *testing.T) {
+	defer func(original func(ctx context.Context, args ...string) ([]byte, error)) { runGit = original }(runGit)
+
+	root := filepath.Join("scanned", "repo")
+	calls := make([][]string, 0)
+	runGit = func(ctx context.Context, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		if args[len(args)-1] == "other.tf" {
+			return nil, errors.New("fatal: not a git repository")
+		}
+		return []byte(linePorcelain), nil
+	}
+
+	summary := &model.Summary{
+		Queries: []model.QueryResult{
+			{
+				Files: []model.VulnerableFile{
+					{FileName: "main.tf", Line: 4},
+					{FileName: "main.tf", Line: 9},
+					{FileName: "other.tf", Line: 1},
+				},
+			},
+			{
+				Files: []model.VulnerableFile{
+					{FileName: "main.tf", Line: 4},
+				},
+			},
+		},
+		FilePaths: map[string]string{
+			"main.tf":  filepath.Join(root, "main.tf"),
+			"other.tf": filepath.Join(root, "other.tf"),
+		},
+	}
+
+	SetBlame(context.Background(), summary)
+
+	want := &model.Blame{
+		Author:      "Jane Doe",
+		AuthorEmail: "jane@example.com",
+		Commit:      "3f6c2a1b9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a",
+		Date:        "2023-11-14T22:13:20Z",
+	}
+	require.Equal(t, want, summary.Queries[0].Files[0].Blame)
+	require.Equal(t, want, summary.Queries[1].Files[0].Blame)
+	// the lines not committed yet and the files outside of a repository are not annotated
+	require.Nil(t, summary.Queries[0].Files[1].Blame)
+	require.Nil(t, summary.Queries[0].Files[2].Blame)
+
+	// git blame is run once per file with the lines of all of its results
+	require.Len(t, calls, 2)
+	for _, args := range calls {
+		if args[len(args)-1] == "main.tf" {
+			require.Equal(t, []string{"-C", root, "blame", "--line-porcelain", "-L", "4,4", "-L", "9,9", "--", "main.tf"}, args)
+		}
+	}
+}
+
+func TestBlame_parseLinePorcelain(t *testing.T) {
+	_, err := parseLinePorcelain([]byte("unexpected\n"))
+	require.Error(t, err)
+
+	blames, err := parseLinePorcelain([]byte{})
+	require.NoError(t, err)
+	require.Empty(t, blames)
+}
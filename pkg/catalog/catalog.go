@@ -0,0 +1,189 @@
+// Package catalog renders a browsable documentation catalog from a queries directory
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	testDirName           = "test"
+	positivePrefix        = "positive"
+	negativePrefix        = "negative"
+	expectedResultsPrefix = "positive_expected_result"
+)
+
+// Sample is a code sample of a query test directory
+type Sample struct {
+	Name     string
+	Language string
+	Content  string
+}
+
+// Query contains all the information about a query that is displayed in the catalog
+type Query struct {
+	ID              string
+	Name            string
+	Severity        string
+	Category        string
+	Platform        string
+	CloudProvider   string
+	CWE             string
+	Description     string
+	DescriptionURL  string
+	Experimental    bool
+	Dir             string
+	PositiveSamples []Sample
+	NegativeSamples []Sample
+}
+
+// Catalog is a group of queries organized by platform
+type Catalog struct {
+	Queries   []Query
+	Platforms []string
+}
+
+// Load reads every query found in queriesPath and builds the catalog
+func Load(queriesPath string) (*Catalog, error) {
+	queryDirs := make([]string, 0)
+	err := filepath.Walk(queriesPath, func(p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !f.IsDir() && f.Name() == source.QueryFileName {
+			queryDirs = append(queryDirs, filepath.Dir(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get query directories")
+	}
+
+	queries := make([]Query, 0, len(queryDirs))
+	platforms := make(map[string]bool)
+	for _, queryDir := range queryDirs {
+		query, err := readQuery(queryDir)
+		if err != nil {
+			log.Warn().Msgf("Skipping query %s: %s", queryDir, err)
+			continue
+		}
+		platforms[query.Platform] = true
+		queries = append(queries, query)
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].Platform == queries[j].Platform {
+			return queries[i].Name < queries[j].Name
+		}
+		return queries[i].Platform < queries[j].Platform
+	})
+
+	platformList := make([]string, 0, len(platforms))
+	for platform := range platforms {
+		platformList = append(platformList, platform)
+	}
+	sort.Strings(platformList)
+
+	return &Catalog{
+		Queries:   queries,
+		Platforms: platformList,
+	}, nil
+}
+
+// ByPlatform returns the queries of a given platform
+func (c *Catalog) ByPlatform(platform string) []Query {
+	queries := make([]Query, 0)
+	for i := range c.Queries {
+		if c.Queries[i].Platform == platform {
+			queries = append(queries, c.Queries[i])
+		}
+	}
+	return queries
+}
+
+func readQuery(queryDir string) (Query, error) {
+	metadata, err := source.ReadMetadata(queryDir)
+	if err != nil {
+		return Query{}, err
+	}
+	id, ok := metadata["id"].(string)
+	if !ok || id == "" {
+		return Query{}, fmt.Errorf("failed to read metadata field: id")
+	}
+
+	positive, negative, err := readSamples(filepath.Join(queryDir, testDirName))
+	if err != nil {
+		return Query{}, err
+	}
+
+	return Query{
+		ID:              id,
+		Name:            getString(metadata, "queryName"),
+		Severity:        strings.ToUpper(getString(metadata, "severity")),
+		Category:        getString(metadata, "category"),
+		Platform:        getString(metadata, "platform"),
+		CloudProvider:   getString(metadata, "cloudProvider"),
+		CWE:             getString(metadata, "cwe"),
+		Description:     getString(metadata, "descriptionText"),
+		DescriptionURL:  getString(metadata, "descriptionUrl"),
+		Experimental:    getString(metadata, "experimental") == "true",
+		Dir:             queryDir,
+		PositiveSamples: positive,
+		NegativeSamples: negative,
+	}, nil
+}
+
+// readSamples reads the positive and negative samples of a query test directory
+func readSamples(testDir string) (positive, negative []Sample, err error) {
+	positive = make([]Sample, 0)
+	negative = make([]Sample, 0)
+
+	entries, err := os.ReadDir(testDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return positive, negative, nil
+		}
+		return nil, nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, expectedResultsPrefix) {
+			continue
+		}
+		if !strings.HasPrefix(name, positivePrefix) && !strings.HasPrefix(name, negativePrefix) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Clean(filepath.Join(testDir, name)))
+		if err != nil {
+			return nil, nil, err
+		}
+		sample := Sample{
+			Name:     name,
+			Language: strings.TrimPrefix(filepath.Ext(name), "."),
+			Content:  string(content),
+		}
+		if strings.HasPrefix(name, positivePrefix) {
+			positive = append(positive, sample)
+		} else {
+			negative = append(negative, sample)
+		}
+	}
+
+	return positive, negative, nil
+}
+
+func getString(metadata map[string]interface{}, key string) string {
+	value, ok := metadata[key].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,31 @@
+package catalog
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	c, err := Load(filepath.FromSlash("../../assets/queries/terraform/aws/alb_is_not_integrated_with_waf"))
+	require.NoError(t, err)
+	require.Len(t, c.Queries, 1)
+	require.Equal(t, []string{"Terraform"}, c.Platforms)
+
+	query := c.Queries[0]
+	require.Equal(t, "0afa6ab8-a047-48cf-be07-93a2f8c34cf7", query.ID)
+	require.Equal(t, "ALB Is Not Integrated With WAF", query.Name)
+	require.Equal(t, "MEDIUM", query.Severity)
+	require.Equal(t, "aws", query.CloudProvider)
+	require.Len(t, query.PositiveSamples, 2)
+	require.Len(t, query.NegativeSamples, 2)
+	require.Equal(t, "tf", query.PositiveSamples[0].Language)
+	require.Len(t, c.ByPlatform("Terraform"), 1)
+	require.Empty(t, c.ByPlatform("Ansible"))
+}
+
+func TestLoad_InvalidPath(t *testing.T) {
+	_, err := Load(filepath.FromSlash("../../assets/queries/not_found"))
+	require.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	_ "embed" // used for embedding catalog templates
+	htmlTemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Supported catalog formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+var (
+	//go:embed template/index.md.tmpl
+	indexMarkdownTemplate string
+	//go:embed template/query.md.tmpl
+	queryMarkdownTemplate string
+	//go:embed template/catalog.html.tmpl
+	catalogHTMLTemplate string
+)
+
+// ListFormats returns the supported catalog formats
+func ListFormats() []string {
+	return []string{FormatHTML, FormatMarkdown}
+}
+
+// Render writes the catalog to outputPath in the given format
+func (c *Catalog) Render(outputPath, format string) error {
+	if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case FormatMarkdown:
+		return c.renderMarkdown(outputPath)
+	case FormatHTML:
+		return c.renderHTML(outputPath)
+	default:
+		return errors.Errorf("unknown catalog format '%s', supported formats: %s", format, strings.Join(ListFormats(), ", "))
+	}
+}
+
+// queryPage returns the relative path of the markdown page of a query
+func queryPage(query Query) string {
+	return filepath.ToSlash(filepath.Join(strings.ToLower(query.Platform), query.ID+".md"))
+}
+
+func trimNewLine(content string) string {
+	return strings.TrimRight(content, "\n")
+}
+
+func (c *Catalog) renderMarkdown(outputPath string) error {
+	funcs := template.FuncMap{
+		"queryPage":   queryPage,
+		"trimNewLine": trimNewLine,
+	}
+	index := template.Must(template.New("index.md.tmpl").Funcs(funcs).Parse(indexMarkdownTemplate))
+	page := template.Must(template.New("query.md.tmpl").Funcs(funcs).Parse(queryMarkdownTemplate))
+
+	if err := writeTemplate(filepath.Join(outputPath, "index.md"), func(f *os.File) error {
+		return index.Execute(f, c)
+	}); err != nil {
+		return err
+	}
+
+	for i := range c.Queries {
+		query := c.Queries[i]
+		pagePath := filepath.Join(outputPath, filepath.FromSlash(queryPage(query)))
+		if err := os.MkdirAll(filepath.Dir(pagePath), os.ModePerm); err != nil {
+			return err
+		}
+		if err := writeTemplate(pagePath, func(f *os.File) error {
+			return page.Execute(f, query)
+		}); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msgf("Catalog with %d queries saved to %s", len(c.Queries), outputPath)
+	return nil
+}
+
+func (c *Catalog) renderHTML(outputPath string) error {
+	t := htmlTemplate.Must(htmlTemplate.New("catalog.html.tmpl").Parse(catalogHTMLTemplate))
+
+	fullPath := filepath.Join(outputPath, "index.html")
+	if err := writeTemplate(fullPath, func(f *os.File) error {
+		return t.Execute(f, c)
+	}); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Catalog with %d queries saved to %s", len(c.Queries), fullPath)
+	return nil
+}
+
+func writeTemplate(path string, execute func(f *os.File) error) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Err(err).Msgf("Failed to close file %s", path)
+		}
+	}()
+
+	return execute(f)
+}
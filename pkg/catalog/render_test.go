@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	c, err := Load(filepath.FromSlash("../../assets/queries/terraform/aws/alb_is_not_integrated_with_waf"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		format  string
+		files   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "markdown",
+			format: FormatMarkdown,
+			files:  []string{"index.md", filepath.Join("terraform", "0afa6ab8-a047-48cf-be07-93a2f8c34cf7.md")},
+			want:   "## Remediation",
+		},
+		{
+			name:   "html",
+			format: FormatHTML,
+			files:  []string{"index.html"},
+			want:   `<article class="query" id="0afa6ab8-a047-48cf-be07-93a2f8c34cf7">`,
+		},
+		{
+			name:    "unknown format",
+			format:  "pdf",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := t.TempDir()
+			err := c.Render(outputPath, tt.format)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, file := range tt.files {
+				require.FileExists(t, filepath.Join(outputPath, file))
+			}
+			content, err := os.ReadFile(filepath.Join(outputPath, tt.files[len(tt.files)-1]))
+			require.NoError(t, err)
+			require.Contains(t, string(content), tt.want)
+			require.Contains(t, string(content), "ALB Is Not Integrated With WAF")
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Checkmarx/kics/internal/constants"
@@ -45,17 +46,38 @@ type HTTPClient interface {
 // HTTPDescription - HTTP client interface to use for requesting descriptions
 type HTTPDescription interface {
 	CheckConnection() error
+	CheckVersionConnection() error
 	RequestDescriptions(descriptionIDs []string) (map[string]descModel.CISDescriptions, error)
 	CheckLatestVersion(version string) (model.Version, error)
 }
 
-// Client - client for making descriptions requests
+// Client - client for making descriptions requests, DescriptionsEndpoint is used to request the
+// descriptions of the queries and VersionEndpoint to check if the latest version of KICS is used
 type Client struct {
+	DescriptionsEndpoint descModel.Endpoint
+	VersionEndpoint      descModel.Endpoint
 }
 
-// CheckConnection - checks if the endpoint is reachable
+// NewClient creates a descriptions client requesting the given endpoints
+func NewClient(descriptionsEndpoint, versionEndpoint descModel.Endpoint) *Client {
+	return &Client{
+		DescriptionsEndpoint: descriptionsEndpoint,
+		VersionEndpoint:      versionEndpoint,
+	}
+}
+
+// CheckConnection - checks if the descriptions endpoint is reachable
 func (c *Client) CheckConnection() error {
-	baseURL, err := getBaseURL()
+	return checkConnection(c.DescriptionsEndpoint)
+}
+
+// CheckVersionConnection - checks if the version check endpoint is reachable
+func (c *Client) CheckVersionConnection() error {
+	return checkConnection(c.VersionEndpoint)
+}
+
+func checkConnection(endpoint descModel.Endpoint) error {
+	baseURL, err := getBaseURL(endpoint)
 	if err != nil {
 		return err
 	}
@@ -65,6 +87,9 @@ func (c *Client) CheckConnection() error {
 	if err != nil {
 		return err
 	}
+	if endpoint.AuthHeader != "" {
+		addAuthorization(req, endpoint)
+	}
 
 	resp, err := doRequest(req)
 	if err != nil {
@@ -80,7 +105,7 @@ func (c *Client) CheckConnection() error {
 
 // CheckLatestVersion - Check if using KICS latest version from endpoint
 func (c *Client) CheckLatestVersion(version string) (model.Version, error) {
-	baseURL, err := getBaseURL()
+	baseURL, err := getBaseURL(c.VersionEndpoint)
 	if err != nil {
 		return model.Version{}, err
 	}
@@ -100,7 +125,7 @@ func (c *Client) CheckLatestVersion(version string) (model.Version, error) {
 		return model.Version{}, err
 	}
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(getBasicAuth()))))
+	addAuthorization(req, c.VersionEndpoint)
 
 	resp, err := doRequest(req)
 	if err != nil {
@@ -128,7 +153,7 @@ func (c *Client) CheckLatestVersion(version string) (model.Version, error) {
 
 // RequestDescriptions - gets descriptions from endpoint
 func (c *Client) RequestDescriptions(descriptionIDs []string) (map[string]descModel.CISDescriptions, error) {
-	baseURL, err := getBaseURL()
+	baseURL, err := getBaseURL(c.DescriptionsEndpoint)
 	if err != nil {
 		log.Debug().Msg("Unable to get baseURL")
 		return nil, err
@@ -152,7 +177,7 @@ func (c *Client) RequestDescriptions(descriptionIDs []string) (map[string]descMo
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(getBasicAuth()))))
+	addAuthorization(req, c.DescriptionsEndpoint)
 
 	log.Debug().Msgf("HTTP POST to descriptions endpoint")
 	startTime := time.Now()
@@ -190,7 +215,11 @@ func doRequest(request *http.Request) (*http.Response, error) {
 	return HTTPRequestClient.Do(request)
 }
 
-func getBaseURL() (string, error) {
+func getBaseURL(endpoint descModel.Endpoint) (string, error) {
+	if endpoint.BaseURL != "" {
+		return strings.TrimSuffix(endpoint.BaseURL, "/"), nil
+	}
+
 	var rtnBaseURL string
 	urlFromEnv := os.Getenv("KICS_DESCRIPTIONS_ENDPOINT")
 	if constants.BaseURL == "" && urlFromEnv == "" {
@@ -205,6 +234,20 @@ func getBaseURL() (string, error) {
 	return rtnBaseURL, nil
 }
 
+// addAuthorization adds the authentication header of the endpoint, given as "Name: value" or as the
+// value of the Authorization header, or the basic authentication of the KICS endpoint when not set
+func addAuthorization(req *http.Request, endpoint descModel.Endpoint) {
+	if endpoint.AuthHeader == "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(getBasicAuth()))))
+		return
+	}
+	name, value, found := strings.Cut(endpoint.AuthHeader, ":")
+	if !found || strings.ContainsAny(name, " \t") {
+		name, value = "Authorization", endpoint.AuthHeader
+	}
+	req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+}
+
 func getBasicAuth() string {
 	auth := os.Getenv("KICS_BASIC_AUTH_PASS")
 	if auth == "" {
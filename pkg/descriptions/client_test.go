@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	mockclient "github.com/Checkmarx/kics/pkg/descriptions/mock"
+	descModel "github.com/Checkmarx/kics/pkg/descriptions/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,3 +131,38 @@ func TestClient_CheckLatestVersion(t *testing.T) {
 		os.Setenv("KICS_DESCRIPTIONS_ENDPOINT", "")
 	})
 }
+
+func TestClient_CustomEndpoints(t *testing.T) {
+	os.Setenv("KICS_DESCRIPTIONS_ENDPOINT", "http://example.com")
+
+	requests := make([]*http.Request, 0)
+	HTTPRequestClient = &mockclient.MockHTTPClient{}
+	mockclient.GetDoFunc = func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request)
+		r := ioutil.NopCloser(bytes.NewReader([]byte(responseJSON)))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}
+
+	descClient := NewClient(
+		descModel.Endpoint{BaseURL: "https://descriptions.internal/", AuthHeader: "X-Api-Key: secret"},
+		descModel.Endpoint{BaseURL: "https://version.internal", AuthHeader: "Bearer token"},
+	)
+	_, err := descClient.RequestDescriptions([]string{"foo1"})
+	require.NoError(t, err)
+	_, err = descClient.CheckLatestVersion("1.4.0")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	require.Equal(t, "https://descriptions.internal/api/descriptions", requests[0].URL.String())
+	require.Equal(t, "secret", requests[0].Header.Get("X-Api-Key"))
+	require.Empty(t, requests[0].Header.Get("Authorization"))
+	require.Equal(t, "https://version.internal/api/version", requests[1].URL.String())
+	require.Equal(t, "Bearer token", requests[1].Header.Get("Authorization"))
+
+	t.Cleanup(func() {
+		os.Setenv("KICS_DESCRIPTIONS_ENDPOINT", "")
+	})
+}
@@ -6,12 +6,8 @@ import (
 	"github.com/Checkmarx/kics/pkg/model"
 )
 
-var (
-	descClient HTTPDescription = &Client{}
-)
-
 // RequestAndOverrideDescriptions - Requests descriptions and override default descriptions
-func RequestAndOverrideDescriptions(summary *model.Summary) error {
+func RequestAndOverrideDescriptions(descClient HTTPDescription, summary *model.Summary) error {
 	descriptionIDs := make([]string, 0)
 	for idx := range summary.Queries {
 		descriptionIDs = append(descriptionIDs, summary.Queries[idx].DescriptionID)
@@ -12,7 +12,7 @@ import (
 
 func TestRequestAndOverrideDescriptions_NoBaseURL(t *testing.T) {
 	mock := test.SummaryMock
-	descClient = &mockclient.MockDescriptionsClient{}
+	descClient := &mockclient.MockDescriptionsClient{}
 	mockclient.CheckConnection = func() error {
 		return nil
 	}
@@ -25,7 +25,7 @@ func TestRequestAndOverrideDescriptions_NoBaseURL(t *testing.T) {
 			},
 		}, nil
 	}
-	err := RequestAndOverrideDescriptions(&mock)
+	err := RequestAndOverrideDescriptions(descClient, &mock)
 	require.NoError(t, err, "Expected error")
 	for _, query := range mock.Queries {
 		if query.DescriptionID == "504b1d43" {
@@ -32,6 +32,11 @@ func (m *MockDescriptionsClient) CheckConnection() error {
 	return CheckConnection()
 }
 
+// CheckVersionConnection - mock descriptions client check version connection function
+func (m *MockDescriptionsClient) CheckVersionConnection() error {
+	return CheckConnection()
+}
+
 // CheckLatestVersion - mock client request version function
 func (m *MockDescriptionsClient) CheckLatestVersion(version string) (genModel.Version, error) {
 	return CheckVersion(version)
@@ -27,3 +27,10 @@ type DescriptionResponse struct {
 type VersionRequest struct {
 	Version string `json:"version"`
 }
+
+// Endpoint - is the configuration of a service used by KICS, the default endpoint or the one
+// of the KICS_DESCRIPTIONS_ENDPOINT environment variable is used when BaseURL is empty
+type Endpoint struct {
+	BaseURL    string
+	AuthHeader string
+}
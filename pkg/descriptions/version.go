@@ -7,12 +7,12 @@ import (
 )
 
 // CheckVersion - checks if using the latest version and saves that information in the tracker
-func CheckVersion(t *tracker.CITracker) {
+func CheckVersion(descClient HTTPDescription, t *tracker.CITracker) {
 	baseVersionInfo := model.Version{
 		Latest: true,
 	}
 
-	if err := descClient.CheckConnection(); err != nil {
+	if err := descClient.CheckVersionConnection(); err != nil {
 		t.TrackVersion(baseVersionInfo)
 		return
 	}
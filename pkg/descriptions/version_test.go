@@ -12,7 +12,7 @@ import (
 
 func TestDescriptions_CheckVersion(t *testing.T) {
 	mt := &tracker.CITracker{}
-	descClient = &mockclient.MockDescriptionsClient{}
+	descClient := &mockclient.MockDescriptionsClient{}
 	mockclient.CheckConnection = func() error {
 		return nil
 	}
@@ -28,7 +28,7 @@ func TestDescriptions_CheckVersion(t *testing.T) {
 		LatestVersionTag: "1.4.5",
 	}
 
-	CheckVersion(mt)
+	CheckVersion(descClient, mt)
 	require.Equal(t, want, mt.Version)
 
 	mockclient.CheckVersion = func(version string) (model.Version, error) {
@@ -39,13 +39,13 @@ func TestDescriptions_CheckVersion(t *testing.T) {
 		Latest: true,
 	}
 
-	CheckVersion(mt)
+	CheckVersion(descClient, mt)
 	require.Equal(t, want, mt.Version)
 
 	mockclient.CheckConnection = func() error {
 		return errors.New("Check connection mock error")
 	}
 
-	CheckVersion(mt)
+	CheckVersion(descClient, mt)
 	require.Equal(t, want, mt.Version)
 }
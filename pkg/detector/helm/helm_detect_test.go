@@ -9,82 +9,48 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var OriginalData1 = `# KICS_HELM_ID_0:
-apiVersion: v1
+var OriginalData1 = `apiVersion: v1
 kind: Pod
 metadata:
   name: "{{ include "test_helm.fullname" . }}-test-connection"
   labels:
     {{- include "test_helm.labels" . | nindent 4 }}
-  annotations:
-	"helm.sh/hook": test
 spec:
   containers:
-    - name: wget
+  {{- range .Values.containers }}
+    - name: {{ .name }}
       image: busybox
-	  command: ['wget']
-	  args: ['{{ include "test_helm.fullname" . }}:{{ .Values.service.port }}']
-    restartPolicy: Never
+      resources:
+        {{- toYaml .resources | nindent 8 }}
+  {{- end }}
+  restartPolicy: Never
 `
 
-var OriginalData2 = `# KICS_HELM_ID_0:
+var Content1 = `
+
 apiVersion: v1
 kind: Pod
 metadata:
-  name: "{{ include "test_helm.fullname" . }}-test-connection"
+  name: "RELEASE-NAME-test_helm-test-connection"
   labels:
-    {{- include "test_helm.labels" . | nindent 4 }}
-  annotations:
-	"helm.sh/hook": test
+    app.kubernetes.io/name: test_helm
+    app.kubernetes.io/instance: RELEASE-NAME
 spec:
   containers:
     - name: wget
       image: busybox
-	  command: ['wget']
-	  args: ['{{ include "test_helm.fullname" . }}:{{ .Values.service.port }}']
-    restartPolicy: Never
-  containers:
+      resources:
+        limits:
+          cpu: 100m
     - name: wget2
       image: busybox
-	  command: ['wget']
-	  args: ['{{ include "test_helm.fullname" . }}:{{ .Values.service.port }}']
-    restartPolicy: Never
+      resources:
+        limits:
+          cpu: 200m
+  restartPolicy: Never
 `
 
-var OriginalData3 = `# KICS_HELM_ID_0:
-apiVersion: v1
-kind: Pod
-metadata:
-  name: "{{ include "test_helm.fullname" . }}-test-connection"
-  labels:
-    {{- include "test_helm.labels" . | nindent 4 }}
-  annotations:
-	"helm.sh/hook": test
-spec:
-  containers:
-    - name: wget
-      image: busybox
-	  command: ['wget']
-	  args: ['{{ include "test_helm.fullname" . }}:{{ .Values.service.port }}']
-    restartPolicy: Never
----
-# KICS_HELM_ID_1:
-apiVersion: v1
-kind: Pod
-metadata:
-  name: "{{ include "test_helm.fullname" . }}-test-dups"
-  labels:
-    {{- include "test_helm.labels" . | nindent 4 }}
-  annotations:
-	"helm.sh/hook": test
-spec:
-  containers:
-    - name: wget
-      image: busybox
-	  command: ['wget']
-	  args: ['{{ include "test_helm.fullname" . }}:{{ .Values.service.port }}']
-    restartPolicy: Never
-`
+var LineMap1 = []int{-1, -1, 0, 1, 2, 3, 4, 5, 5, 6, 7, 9, 10, 11, 12, 12, 9, 10, 11, 12, 12, 14, 14}
 
 func TestEngine_detectHelmLine(t *testing.T) { //nolint
 	type args struct {
@@ -108,20 +74,20 @@ func TestEngine_detectHelmLine(t *testing.T) { //nolint
 					Document:          model.Document{},
 					Kind:              model.KindHELM,
 					FilePath:          "test-connection.yaml",
-					HelmID:            "# KICS_HELM_ID_0",
+					HelmLineMap:       LineMap1,
 					OriginalData:      OriginalData1,
 					LinesOriginalData: utils.SplitLines(OriginalData1),
-					Content:           ``,
+					Content:           Content1,
 				},
-				searchKey:     "KICS_HELM_ID_0.metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers",
+				searchKey:     "metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers",
 				logWithFields: &zerolog.Logger{},
 				outputLines:   1,
 			},
 			want: model.VulnerabilityLines{
-				Line: 10,
+				Line: 8,
 				VulnLines: &[]model.CodeLine{
 					{
-						Position: 10,
+						Position: 8,
 						Line:     "  containers:",
 					},
 				},
@@ -130,66 +96,86 @@ func TestEngine_detectHelmLine(t *testing.T) { //nolint
 			},
 		},
 		{
-			name: "test_dup_values",
+			name: "test_detect_helm_line_in_range",
 			args: args{
 				file: &model.FileMetadata{
-					ID:       "1",
-					ScanID:   "console",
-					Document: model.Document{},
-					Kind:     model.KindHELM,
-					FilePath: "test-dup_values.yaml",
-					IDInfo: map[int]interface{}{0: map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 4,
-						5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 10: 10, 11: 11, 12: 12, 13: 13, 14: 14, 15: 15, 16: 16, 17: 17,
-						18: 18, 19: 19, 21: 21, 22: 22}},
-					HelmID:            "# KICS_HELM_ID_0",
-					OriginalData:      OriginalData2,
-					LinesOriginalData: utils.SplitLines(OriginalData2),
-					Content:           ``,
+					ID:                "1",
+					ScanID:            "console",
+					Document:          model.Document{},
+					Kind:              model.KindHELM,
+					FilePath:          "test-connection.yaml",
+					HelmLineMap:       LineMap1,
+					OriginalData:      OriginalData1,
+					LinesOriginalData: utils.SplitLines(OriginalData1),
+					Content:           Content1,
 				},
-				searchKey:     "KICS_HELM_ID_0.metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers",
+				searchKey:     "metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers.name={{wget2}}.image",
 				logWithFields: &zerolog.Logger{},
 				outputLines:   1,
 			},
 			want: model.VulnerabilityLines{
-				Line: 9,
+				Line: 11,
 				VulnLines: &[]model.CodeLine{
 					{
-						Position: 9,
-						Line:     "spec:",
+						Position: 11,
+						Line:     "      image: busybox",
 					},
 				},
-				LineWithVulnerability: "spec:",
-				ResolvedFile:          "test-dup_values.yaml",
+				LineWithVulnerability: "      image",
+				ResolvedFile:          "test-connection.yaml",
 			},
 		},
 		{
-			name: "test_detect_helm_with_dups",
+			name: "test_detect_helm_line_rendered_by_action",
 			args: args{
 				file: &model.FileMetadata{
 					ID:                "1",
 					ScanID:            "console",
 					Document:          model.Document{},
 					Kind:              model.KindHELM,
-					FilePath:          "test-dups.yaml",
-					HelmID:            "# KICS_HELM_ID_1",
-					OriginalData:      OriginalData3,
-					LinesOriginalData: utils.SplitLines(OriginalData3),
-					Content:           ``,
+					FilePath:          "test-connection.yaml",
+					HelmLineMap:       LineMap1,
+					OriginalData:      OriginalData1,
+					LinesOriginalData: utils.SplitLines(OriginalData1),
+					Content:           Content1,
 				},
-				searchKey:     "KICS_HELM_ID_1.metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers",
+				searchKey:     "metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers.name={{wget2}}.resources.limits",
 				logWithFields: &zerolog.Logger{},
 				outputLines:   1,
 			},
 			want: model.VulnerabilityLines{
-				Line: 26,
+				Line: 13,
 				VulnLines: &[]model.CodeLine{
 					{
-						Position: 26,
-						Line:     "  containers:",
+						Position: 13,
+						Line:     "        {{- toYaml .resources | nindent 8 }}",
 					},
 				},
-				LineWithVulnerability: "  containers:",
-				ResolvedFile:          "test-dups.yaml",
+				LineWithVulnerability: "        {{- toYaml .resources | nindent 8 }}",
+				ResolvedFile:          "test-connection.yaml",
+			},
+		},
+		{
+			name: "test_undetected_helm_line",
+			args: args{
+				file: &model.FileMetadata{
+					ID:                "1",
+					ScanID:            "console",
+					Document:          model.Document{},
+					Kind:              model.KindHELM,
+					FilePath:          "test-connection.yaml",
+					OriginalData:      OriginalData1,
+					LinesOriginalData: utils.SplitLines(OriginalData1),
+					Content:           Content1,
+				},
+				searchKey:     "metadata.name={{RELEASE-NAME-test_helm-test-connection}}.spec.containers",
+				logWithFields: &zerolog.Logger{},
+				outputLines:   1,
+			},
+			want: model.VulnerabilityLines{
+				Line:         -1,
+				VulnLines:    &[]model.CodeLine{},
+				ResolvedFile: "test-connection.yaml",
 			},
 		},
 	}
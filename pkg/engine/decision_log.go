@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// Decisions recorded in the decision log
+const (
+	DecisionPass    = "pass"
+	DecisionFail    = "fail"
+	DecisionError   = "error"
+	DecisionSkipped = "skipped"
+)
+
+// Decision is an entry of the decision log, the decision of a query evaluation for one of its input documents,
+// Duration is the duration in milliseconds of the query evaluation against every input document
+type Decision struct {
+	Time          time.Time `json:"time"`
+	ScanID        string    `json:"scan_id"`
+	QueryID       string    `json:"query_id"`
+	QueryName     string    `json:"query_name"`
+	Platform      string    `json:"platform"`
+	DocumentID    string    `json:"document_id"`
+	FileName      string    `json:"file_name"`
+	Decision      string    `json:"decision"`
+	Results       int       `json:"results"`
+	SimilarityIDs []string  `json:"similarity_ids,omitempty"`
+	Duration      float64   `json:"duration_ms"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// DecisionLog writes the decisions of the query evaluations as JSON lines, it is safe for concurrent use
+type DecisionLog struct {
+	mu      sync.Mutex
+	writer  io.WriteCloser
+	encoder *json.Encoder
+}
+
+// NewDecisionLog creates the decision log file, truncating a previous one
+func NewDecisionLog(path string) (*DecisionLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return newDecisionLog(f), nil
+}
+
+func newDecisionLog(writer io.WriteCloser) *DecisionLog {
+	return &DecisionLog{
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// Close closes the decision log file
+func (d *DecisionLog) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.writer.Close()
+}
+
+// LogQuery records a decision for each file the query was evaluated against, the files with results
+// fail and the other files pass, unless the evaluation failed or was skipped
+func (d *DecisionLog) LogQuery(scanID string, query *model.QueryMetadata, files model.FileMetadatas,
+	vulnerabilities []model.Vulnerability, duration time.Duration, evalErr error, skipped bool) error {
+	similarityIDs := make(map[string][]string)
+	for i := range vulnerabilities {
+		similarityIDs[vulnerabilities[i].FileID] = append(similarityIDs[vulnerabilities[i].FileID], vulnerabilities[i].SimilarityID)
+	}
+
+	decision := Decision{
+		Time:      time.Now(),
+		ScanID:    scanID,
+		QueryName: query.Query,
+		Platform:  query.Platform,
+		Duration:  float64(duration) / float64(time.Millisecond),
+	}
+	if id, ok := query.Metadata["id"].(string); ok {
+		decision.QueryID = id
+	}
+	if name, ok := query.Metadata["queryName"].(string); ok {
+		decision.QueryName = name
+	}
+	if evalErr != nil {
+		decision.Error = evalErr.Error()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range files {
+		decision.DocumentID = files[i].ID
+		decision.FileName = files[i].FilePath
+		decision.SimilarityIDs = similarityIDs[files[i].ID]
+		sort.Strings(decision.SimilarityIDs)
+		decision.Results = len(decision.SimilarityIDs)
+		switch {
+		case skipped:
+			decision.Decision = DecisionSkipped
+		case evalErr != nil:
+			decision.Decision = DecisionError
+		case decision.Results > 0:
+			decision.Decision = DecisionFail
+		default:
+			decision.Decision = DecisionPass
+		}
+		if err := d.encoder.Encode(&decision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
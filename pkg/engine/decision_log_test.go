@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestDecisionLog_LogQuery(t *testing.T) {
+	query := &model.QueryMetadata{
+		Query:    "alb_deletion_protection_disabled",
+		Platform: "terraform",
+		Metadata: map[string]interface{}{
+			"id":        "afecd1f1-6378-4f7e-bb3b-60c35801fdd4",
+			"queryName": "ALB Deletion Protection Disabled",
+		},
+	}
+	files := model.FileMetadatas{
+		{ID: "doc-1", FilePath: "main.tf"},
+		{ID: "doc-2", FilePath: "other.tf"},
+	}
+	vulnerabilities := []model.Vulnerability{
+		{FileID: "doc-1", SimilarityID: "b"},
+		{FileID: "doc-1", SimilarityID: "a"},
+	}
+
+	tests := []struct {
+		name            string
+		vulnerabilities []model.Vulnerability
+		evalErr         error
+		skipped         bool
+		want            []string
+		wantResults     []int
+		wantErr         string
+	}{
+		{
+			name:            "should fail the documents with results and pass the others",
+			vulnerabilities: vulnerabilities,
+			want:            []string{DecisionFail, DecisionPass},
+			wantResults:     []int{2, 0},
+		},
+		{
+			name:        "should record the evaluation error for every document",
+			evalErr:     errors.New("failed to evaluate query"),
+			want:        []string{DecisionError, DecisionError},
+			wantResults: []int{0, 0},
+			wantErr:     "failed to evaluate query",
+		},
+		{
+			name:        "should record the skipped queries",
+			skipped:     true,
+			want:        []string{DecisionSkipped, DecisionSkipped},
+			wantResults: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			decisionLog := newDecisionLog(nopWriteCloser{&buf})
+
+			err := decisionLog.LogQuery("console", query, files, tt.vulnerabilities, 3*time.Millisecond, tt.evalErr, tt.skipped)
+			require.NoError(t, err)
+			require.NoError(t, decisionLog.Close())
+
+			decoder := json.NewDecoder(&buf)
+			for i := range files {
+				var decision Decision
+				require.NoError(t, decoder.Decode(&decision))
+				require.Equal(t, "afecd1f1-6378-4f7e-bb3b-60c35801fdd4", decision.QueryID)
+				require.Equal(t, "ALB Deletion Protection Disabled", decision.QueryName)
+				require.Equal(t, files[i].ID, decision.DocumentID)
+				require.Equal(t, files[i].FilePath, decision.FileName)
+				require.Equal(t, tt.want[i], decision.Decision)
+				require.Equal(t, tt.wantResults[i], decision.Results)
+				require.Equal(t, tt.wantErr, decision.Error)
+				require.InDelta(t, 3, decision.Duration, 0.001)
+			}
+			require.False(t, decoder.More())
+		})
+	}
+}
+
+func TestDecisionLog_SimilarityIDsSorted(t *testing.T) {
+	var buf bytes.Buffer
+	decisionLog := newDecisionLog(nopWriteCloser{&buf})
+
+	err := decisionLog.LogQuery("console", &model.QueryMetadata{}, model.FileMetadatas{{ID: "doc-1"}},
+		[]model.Vulnerability{{FileID: "doc-1", SimilarityID: "b"}, {FileID: "doc-1", SimilarityID: "a"}}, 0, nil, false)
+	require.NoError(t, err)
+
+	var decision Decision
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decision))
+	require.Equal(t, []string{"a", "b"}, decision.SimilarityIDs)
+}
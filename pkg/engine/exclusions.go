@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// AnyQuery is the query ID of the exclusions matching the results of every query
+const AnyQuery = "*"
+
+var (
+	// searchKeyBracketsRegex matches the brackets of the search keys, aws_s3_bucket[logs] is matched as
+	// aws_s3_bucket.logs
+	searchKeyBracketsRegex = regexp.MustCompile(`\[|\]`)
+	// searchKeyDotsRegex matches the consecutive dots left by the brackets
+	searchKeyDotsRegex = regexp.MustCompile(`\.{2,}`)
+)
+
+// KeyExclusion excludes the results of a query whose search key or resource matches a pattern, * matches any
+// sequence of characters and ? a single character
+type KeyExclusion struct {
+	QueryID string
+	Pattern string
+	regex   *regexp.Regexp
+}
+
+// ParseKeyExclusions parses the exclusions provided as <query ID>:<pattern>, the query ID can be * to
+// exclude the matching results of every query
+func ParseKeyExclusions(values []string) ([]KeyExclusion, error) {
+	exclusions := make([]KeyExclusion, 0, len(values))
+	for _, value := range values {
+		queryID, pattern, found := strings.Cut(value, ":")
+		queryID, pattern = strings.TrimSpace(queryID), strings.TrimSpace(pattern)
+		if !found || queryID == "" || pattern == "" {
+			return nil, fmt.Errorf("invalid search key exclusion '%s', expected <query ID>:<pattern>", value)
+		}
+		exclusions = append(exclusions, KeyExclusion{
+			QueryID: queryID,
+			Pattern: pattern,
+			regex:   globRegex(pattern),
+		})
+	}
+	return exclusions, nil
+}
+
+// globRegex compiles the pattern to a regex matching the whole value
+func globRegex(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// Matches returns true when the exclusion applies to the query of the vulnerability and the pattern matches its
+// search key, its search key with the brackets replaced by dots, or its resource as <type>.<name>
+func (e *KeyExclusion) Matches(vulnerability *model.Vulnerability) bool {
+	if e.QueryID != AnyQuery && !strings.EqualFold(e.QueryID, vulnerability.QueryID) {
+		return false
+	}
+	candidates := []string{
+		vulnerability.SearchKey,
+		normalizeSearchKey(vulnerability.SearchKey),
+	}
+	if vulnerability.ResourceType != "" && vulnerability.ResourceName != "" {
+		candidates = append(candidates, vulnerability.ResourceType+"."+vulnerability.ResourceName)
+	}
+	for _, candidate := range candidates {
+		if e.regex.MatchString(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSearchKey removes the braces of the search key and replaces its brackets by dots
+func normalizeSearchKey(searchKey string) string {
+	normalized := strings.NewReplacer("{{", "", "}}", "").Replace(searchKey)
+	normalized = searchKeyBracketsRegex.ReplaceAllString(normalized, ".")
+	return strings.Trim(searchKeyDotsRegex.ReplaceAllString(normalized, "."), ".")
+}
+
+// MatchKeyExclusion returns the first exclusion matching the vulnerability
+func MatchKeyExclusion(exclusions []KeyExclusion, vulnerability *model.Vulnerability) (*KeyExclusion, bool) {
+	for i := range exclusions {
+		if exclusions[i].Matches(vulnerability) {
+			return &exclusions[i], true
+		}
+	}
+	return nil, false
+}
+
+// ExcludeResultsByKey excludes the results matching the search key exclusions
+func (c *Inspector) ExcludeResultsByKey(exclusions []KeyExclusion) {
+	c.keyExclusions = exclusions
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseKeyExclusions tests the parsing of the search key exclusions
+func TestParseKeyExclusions(t *testing.T) {
+	exclusions, err := ParseKeyExclusions([]string{"f861041c-8c9f-4156-acfc-5e6e524f5884:aws_s3_bucket.logs*", " * : metadata.name=dev-? "})
+	require.NoError(t, err)
+	require.Len(t, exclusions, 2)
+	require.Equal(t, "f861041c-8c9f-4156-acfc-5e6e524f5884", exclusions[0].QueryID)
+	require.Equal(t, "aws_s3_bucket.logs*", exclusions[0].Pattern)
+	require.Equal(t, AnyQuery, exclusions[1].QueryID)
+	require.Equal(t, "metadata.name=dev-?", exclusions[1].Pattern)
+
+	for _, value := range []string{"aws_s3_bucket.logs*", ":aws_s3_bucket", "f861041c-8c9f-4156-acfc-5e6e524f5884:"} {
+		_, err := ParseKeyExclusions([]string{value})
+		require.Error(t, err, value)
+	}
+}
+
+// TestKeyExclusion_Matches tests the exclusions match the search keys and the resources of the results
+func TestKeyExclusion_Matches(t *testing.T) {
+	exclusions, err := ParseKeyExclusions([]string{
+		"f861041c-8c9f-4156-acfc-5e6e524f5884:aws_s3_bucket.logs*",
+		"*:metadata.name=dev-?.spec*",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		vulnerability model.Vulnerability
+		want          bool
+	}{
+		{
+			name: "normalized search key",
+			vulnerability: model.Vulnerability{
+				QueryID:   "f861041c-8c9f-4156-acfc-5e6e524f5884",
+				SearchKey: "aws_s3_bucket[logs_archive].versioning",
+			},
+			want: true,
+		},
+		{
+			name: "resource",
+			vulnerability: model.Vulnerability{
+				QueryID:      "f861041c-8c9f-4156-acfc-5e6e524f5884",
+				SearchKey:    "module[storage]",
+				ResourceType: "aws_s3_bucket",
+				ResourceName: "logs",
+			},
+			want: true,
+		},
+		{
+			name: "other resource",
+			vulnerability: model.Vulnerability{
+				QueryID:   "f861041c-8c9f-4156-acfc-5e6e524f5884",
+				SearchKey: "aws_s3_bucket[data]",
+			},
+			want: false,
+		},
+		{
+			name: "other query",
+			vulnerability: model.Vulnerability{
+				QueryID:   "e69890e6-fce5-461d-98ad-cb98318dfc96",
+				SearchKey: "aws_s3_bucket[logs]",
+			},
+			want: false,
+		},
+		{
+			name: "any query",
+			vulnerability: model.Vulnerability{
+				QueryID:   "e69890e6-fce5-461d-98ad-cb98318dfc96",
+				SearchKey: "metadata.name={{dev-1}}.spec.containers",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := MatchKeyExclusion(exclusions, &tt.vulnerability)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
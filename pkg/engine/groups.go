@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/rs/zerolog/log"
+)
+
+const groupsInputRef = "input.groups"
+
+// referencesGroups returns true when any of the queries reads input.groups, either directly or
+// through a library rule, so the document groups are only built when a query needs them
+func (q QueryLoader) referencesGroups(queries []model.QueryMetadata) bool {
+	libraryRules := make(map[string][]string)
+	for i := range queries {
+		if strings.Contains(queries[i].Content, groupsInputRef) {
+			return true
+		}
+
+		rules, ok := libraryRules[queries[i].Platform]
+		if !ok {
+			rules = getGroupsRules(q.commonLibrary.LibraryCode)
+			if platformLibrary, found := q.platformLibraries[queries[i].Platform]; found {
+				rules = append(rules, getGroupsRules(platformLibrary.LibraryCode)...)
+			}
+			libraryRules[queries[i].Platform] = rules
+		}
+		for _, rule := range rules {
+			if strings.Contains(queries[i].Content, "."+rule+"(") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getGroupsRules returns the names of the library rules that read input.groups,
+// including the rules that call other rules of the library reading it
+func getGroupsRules(libraryCode string) []string {
+	if !strings.Contains(libraryCode, groupsInputRef) {
+		return []string{}
+	}
+	module, err := ast.ParseModule("library", libraryCode)
+	if err != nil || module == nil {
+		log.Debug().Msgf("Could not parse library to look for %s references", groupsInputRef)
+		return []string{}
+	}
+
+	groupsRef := ast.MustParseRef(groupsInputRef)
+	calls := make(map[string]map[string]bool)
+	usesGroups := make(map[string]bool)
+	for _, rule := range module.Rules {
+		name := rule.Head.Name.String()
+		if calls[name] == nil {
+			calls[name] = make(map[string]bool)
+		}
+		ast.WalkRefs(rule, func(ref ast.Ref) bool {
+			if ref.HasPrefix(groupsRef) {
+				usesGroups[name] = true
+			}
+			if v, ok := ref[0].Value.(ast.Var); ok {
+				calls[name][string(v)] = true
+			}
+			return false
+		})
+	}
+
+	// propagate the usage to the callers until no new rule is found
+	for changed := true; changed; {
+		changed = false
+		for name, called := range calls {
+			if usesGroups[name] {
+				continue
+			}
+			for callee := range called {
+				if usesGroups[callee] {
+					usesGroups[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	rules := make([]string, 0, len(usesGroups))
+	for name := range usesGroups {
+		rules = append(rules, name)
+	}
+	return rules
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const groupsTestLibrary = `package generic.terraform
+
+get_module(doc) = module {
+	module := input.groups.terraform_modules[_]
+	module.documents[_] == doc.id
+}
+
+get_referenced_resources(doc, address) = resources {
+	resources := get_module(doc).references[address]
+} else = []
+
+check_cidr(rule) {
+	rule.cidr_blocks[_] == "0.0.0.0/0"
+}
+`
+
+func TestQueryLoader_referencesGroups(t *testing.T) {
+	loader := QueryLoader{
+		commonLibrary: source.RegoLibraries{LibraryCode: "package generic.common"},
+		platformLibraries: map[string]source.RegoLibraries{
+			"terraform": {LibraryCode: groupsTestLibrary},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name:    "direct reference",
+			content: "module := input.groups.terraform_modules[_]",
+			want:    true,
+		},
+		{
+			name:    "library rule reading groups",
+			content: "tf_lib.get_module(input.document[i])",
+			want:    true,
+		},
+		{
+			name:    "library rule calling a rule reading groups",
+			content: `tf_lib.get_referenced_resources(input.document[i], "aws_instance.web")`,
+			want:    true,
+		},
+		{
+			name:    "library rule not reading groups",
+			content: "tf_lib.check_cidr(input.document[i].resource.aws_security_group[_].ingress)",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := []model.QueryMetadata{{Platform: "terraform", Content: tt.content}}
+			require.Equal(t, tt.want, loader.referencesGroups(queries))
+		})
+	}
+}
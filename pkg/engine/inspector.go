@@ -74,13 +74,17 @@ type Inspector struct {
 	tracker        Tracker
 	failedQueries  map[string]error
 	excludeResults map[string]bool
+	keyExclusions  []KeyExclusion
 	detector       *detector.DetectLine
 
 	enableCoverageReport bool
 	coverageReport       cover.Report
+	decisionLog          *DecisionLog
+	suppressed           *suppressedResults
 	queryExecTimeout     time.Duration
 	useNewSeverities     bool
 	numWorkers           int
+	pruneQueries         bool
 }
 
 // QueryContext contains the context where the query is executed, which scan it belongs, basic information of query,
@@ -224,8 +228,17 @@ func (c *Inspector) performInspection(ctx context.Context, scanID string, files
 	for job := range jobs {
 		currentQuery <- 1
 
+		// the scan timeout expired, the remaining queries are skipped
+		if ctx.Err() != nil {
+			c.trackQuerySkipped(&queries[job.queryID])
+			c.logDecisions(scanID, &queries[job.queryID], files, nil, 0, nil, true)
+			results <- QueryResult{queryID: job.queryID}
+			continue
+		}
+
 		queryOpa, err := c.QueryLoader.LoadQuery(ctx, &queries[job.queryID])
 		if err != nil {
+			c.logDecisions(scanID, &queries[job.queryID], files, nil, 0, err, false)
 			continue
 		}
 
@@ -247,6 +260,14 @@ func (c *Inspector) performInspection(ctx context.Context, scanID string, files
 		}
 
 		vuls, err := c.doRun(queryContext)
+		if err != nil && ctx.Err() != nil {
+			log.Debug().Msgf("Query %s interrupted by the scan timeout", queries[job.queryID].Query)
+			c.trackQuerySkipped(&queries[job.queryID])
+			c.logDecisions(scanID, &queries[job.queryID], files, nil, time.Since(queryStartTime), nil, true)
+			results <- QueryResult{queryID: job.queryID}
+			continue
+		}
+		c.logDecisions(scanID, &queries[job.queryID], files, vuls, time.Since(queryStartTime), err, false)
 		if err == nil {
 			log.Debug().Msgf("Finished to run query %s after %v", queries[job.queryID].Query, time.Since(queryStartTime))
 			c.tracker.TrackQueryExecution(query.Metadata.Aggregation)
@@ -255,6 +276,58 @@ func (c *Inspector) performInspection(ctx context.Context, scanID string, files
 	}
 }
 
+// logDecisions records the decisions of the query evaluation when the decision log is enabled
+func (c *Inspector) logDecisions(scanID string, query *model.QueryMetadata, files model.FileMetadatas,
+	vulnerabilities []model.Vulnerability, duration time.Duration, evalErr error, skipped bool) {
+	if c.decisionLog == nil {
+		return
+	}
+	if err := c.decisionLog.LogQuery(scanID, query, files, vulnerabilities, duration, evalErr, skipped); err != nil {
+		log.Err(err).Msgf("Failed to write the decisions of the query %s", query.Query)
+	}
+}
+
+func (c *Inspector) trackQuerySkipped(query *model.QueryMetadata) {
+	skippedQuery := model.SkippedQuery{
+		QueryName: query.Query,
+		Platform:  query.Platform,
+	}
+	if id, ok := query.Metadata["id"].(string); ok {
+		skippedQuery.QueryID = id
+	}
+	if name, ok := query.Metadata["queryName"].(string); ok {
+		skippedQuery.QueryName = name
+	}
+	c.tracker.TrackQuerySkipped(skippedQuery, query.Aggregation)
+}
+
+// trackQueryFailure keeps the error of a query with the location of the Rego error and, when the error was raised by
+// a result of the query, the document and the file of the result
+func (c *Inspector) trackQueryFailure(query *model.QueryMetadata, err error, documentID, filePath string) {
+	failedQuery := model.FailedQuery{
+		QueryName:  query.Query,
+		Platform:   query.Platform,
+		Error:      err.Error(),
+		DocumentID: documentID,
+		FilePath:   filePath,
+	}
+	if id, ok := query.Metadata["id"].(string); ok {
+		failedQuery.QueryID = id
+	}
+	if name, ok := query.Metadata["queryName"].(string); ok {
+		failedQuery.QueryName = name
+	}
+	var regoErr *topdown.Error
+	if errors.As(err, &regoErr) && regoErr.Location != nil {
+		file := regoErr.Location.File
+		if file == "" {
+			file = query.Query
+		}
+		failedQuery.Location = fmt.Sprintf("%s:%d:%d", file, regoErr.Location.Row, regoErr.Location.Col)
+	}
+	c.tracker.TrackQueryFailure(failedQuery)
+}
+
 func (c *Inspector) Inspect(
 	ctx context.Context,
 	scanID string,
@@ -263,7 +336,17 @@ func (c *Inspector) Inspect(
 	platforms []string,
 	currentQuery chan<- int64) ([]model.Vulnerability, error) {
 	log.Debug().Msg("engine.Inspect()")
+	queries := c.getQueriesByPlat(platforms)
+	if c.pruneQueries {
+		var pruned []model.QueryMetadata
+		queries, pruned = pruneQueries(queries, files)
+		c.trackQueriesPruned(pruned, currentQuery)
+	}
+
 	combinedFiles := files.Combine(false)
+	if c.QueryLoader.referencesGroups(queries) {
+		combinedFiles.Groups = files.Groups()
+	}
 
 	var vulnerabilities []model.Vulnerability
 	vulnerabilities = make([]model.Vulnerability, 0)
@@ -284,8 +367,6 @@ func (c *Inspector) Inspect(
 		return vulnerabilities, err
 	}
 
-	queries := c.getQueriesByPlat(platforms)
-
 	// Create a channel to collect the results
 	results := make(chan QueryResult, len(queries))
 
@@ -328,6 +409,7 @@ func (c *Inspector) Inspect(
 			}, true)
 
 			c.failedQueries[queries[result.queryID].Query] = result.err
+			c.trackQueryFailure(&queries[result.queryID], result.err, "", "")
 
 			continue
 		}
@@ -363,6 +445,11 @@ func (c *Inspector) EnableCoverageReport() {
 	c.enableCoverageReport = true
 }
 
+// EnableDecisionLog records the decision of each query evaluation in the given decision log
+func (c *Inspector) EnableDecisionLog(decisionLog *DecisionLog) {
+	c.decisionLog = decisionLog
+}
+
 // GetCoverageReport returns the scan coverage report
 func (c *Inspector) GetCoverageReport() cover.Report {
 	return c.coverageReport
@@ -495,12 +582,18 @@ func getVulnerabilitiesFromQuery(ctx *QueryContext, c *Inspector, queryResultIte
 		if _, ok := c.failedQueries[ctx.Query.Metadata.Query]; !ok {
 			c.failedQueries[ctx.Query.Metadata.Query] = err
 		}
+		documentID := ""
+		if item, ok := queryResultItem.(map[string]interface{}); ok {
+			documentID, _ = item["documentId"].(string)
+		}
+		c.trackQueryFailure(&ctx.Query.Metadata, err, documentID, ctx.Files[documentID].FilePath)
 
 		return nil, false
 	}
 	file := ctx.Files[vulnerability.FileID]
 	if ShouldSkipVulnerability(file.Commands, vulnerability.QueryID) {
 		log.Debug().Msgf("Skipping vulnerability in file %s for query '%s':%s", file.FilePath, vulnerability.QueryName, vulnerability.QueryID)
+		c.suppressed.add(vulnerability, model.SuppressionInSource, disabledQueryJustification)
 		return nil, false
 	}
 
@@ -511,10 +604,24 @@ func getVulnerabilitiesFromQuery(ctx *QueryContext, c *Inspector, queryResultIte
 	if _, ok := c.excludeResults[vulnerability.SimilarityID]; ok {
 		log.Debug().
 			Msgf("Excluding result SimilarityID: %s", vulnerability.SimilarityID)
+		c.suppressed.add(vulnerability, model.SuppressionExternal, excludedResultJustification)
+		return nil, false
+	} else if exclusion, ok := MatchKeyExclusion(c.keyExclusions, vulnerability); ok {
+		log.Debug().
+			Msgf("Excluding result SearchKey: %s", vulnerability.SearchKey)
+		c.suppressed.add(vulnerability, model.SuppressionExternal,
+			fmt.Sprintf(excludedKeyJustification, exclusion.Pattern))
 		return nil, false
 	} else if checkComment(vulnerability.Line, file.LinesIgnore) {
 		log.Debug().
 			Msgf("Excluding result Comment: %s", vulnerability.SimilarityID)
+		c.suppressed.add(vulnerability, model.SuppressionInSource, ignoredLineJustification)
+		return nil, false
+	} else if region, ok := model.MatchIgnoreRegion(file.IgnoreRegions, vulnerability.Line, vulnerability.QueryID); ok {
+		log.Debug().
+			Msgf("Excluding result Region: %s", vulnerability.SimilarityID)
+		c.suppressed.add(vulnerability, model.SuppressionInSource,
+			fmt.Sprintf(ignoredRegionJustification, region.Start, region.End))
 		return nil, false
 	}
 
@@ -607,14 +714,19 @@ func (q QueryLoader) LoadQuery(ctx context.Context, query *model.QueryMetadata)
 			log.Debug().Msg("Could not merge common library input data")
 		}
 		store := inmem.NewFromReader(bytes.NewBufferString(mergedInputData))
-		opaQuery, err = rego.New(
+		options := []func(*rego.Rego){
 			rego.Query(regoQuery),
 			rego.Module("Common", q.commonLibrary.LibraryCode),
 			rego.Module("Generic", platformGeneralQuery.LibraryCode),
 			rego.Module(query.Query, query.Content),
 			rego.Store(store),
 			rego.UnsafeBuiltins(unsafeRegoFunctions),
-		).PrepareForEval(ctx)
+		}
+		// the versioned libraries are only compiled with the queries importing them
+		for _, library := range source.GetVersionedLibraries(query.Content) {
+			options = append(options, rego.Module(library.Name, library.Code))
+		}
+		opaQuery, err = rego.New(options...).PrepareForEval(ctx)
 
 		if err != nil {
 			sentryReport.ReportSentry(&sentryReport.Report{
@@ -28,7 +28,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/cover"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/pkg/errors"
 )
 
 // TestInspector_EnableCoverageReport tests the functions [EnableCoverageReport()] and all the methods called by them
@@ -618,6 +621,68 @@ func TestEngine_GetFailedQueries(t *testing.T) {
 	}
 }
 
+// TestInspector_trackQueryFailure tests that the failures of the queries are tracked with the location of the Rego
+// error and the document of the result that raised the error
+func TestInspector_trackQueryFailure(t *testing.T) {
+	ciTracker := &tracker.CITracker{}
+	ins := &Inspector{tracker: ciTracker}
+	query := &model.QueryMetadata{
+		Query:    "bucket_acl",
+		Platform: "terraform",
+		Metadata: map[string]interface{}{"id": "38c5ee0d-7f22-4260-ab72-5073048df100", "queryName": "Bucket ACL"},
+	}
+
+	regoErr := &topdown.Error{
+		Code:     topdown.ConflictErr,
+		Message:  "functions must not produce multiple outputs for same inputs",
+		Location: ast.NewLocation(nil, "", 12, 3),
+	}
+	ins.trackQueryFailure(query, errors.Wrap(regoErr, "failed to evaluate query"), "", "")
+	ins.trackQueryFailure(query, errors.New("invalid search line"), "3b1f", "main.tf")
+
+	require.Equal(t, []model.FailedQuery{
+		{
+			QueryID:   "38c5ee0d-7f22-4260-ab72-5073048df100",
+			QueryName: "Bucket ACL",
+			Platform:  "terraform",
+			Error:     "failed to evaluate query: 12:3: eval_conflict_error: functions must not produce multiple outputs for same inputs",
+			Location:  "bucket_acl:12:3",
+		},
+		{
+			QueryID:    "38c5ee0d-7f22-4260-ab72-5073048df100",
+			QueryName:  "Bucket ACL",
+			Platform:   "terraform",
+			Error:      "invalid search line",
+			DocumentID: "3b1f",
+			FilePath:   "main.tf",
+		},
+	}, ciTracker.FailedQueries)
+}
+
+// TestInspect_ScanTimeoutExpired tests that the queries are skipped, and not failed, when the scan context is done
+func TestInspect_ScanTimeoutExpired(t *testing.T) {
+	if err := test.ChangeCurrentDir("kics"); err != nil {
+		t.Fatal(err)
+	}
+	ins := newInspectorInstance(t, []string{
+		filepath.FromSlash("./assets/queries/terraform/aws/alb_deletion_protection_disabled"),
+		filepath.FromSlash("./assets/queries/terraform/aws/alb_is_not_integrated_with_waf"),
+	})
+	require.Equal(t, 2, ins.LenQueriesByPlat([]string{"terraform"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	currentQuery := make(chan int64, 2)
+	vulnerabilities, err := ins.Inspect(ctx, "scanID", model.FileMetadatas{}, []string{"."}, []string{"terraform"}, currentQuery)
+	require.NoError(t, err)
+	require.Empty(t, vulnerabilities)
+	require.Empty(t, ins.GetFailedQueries())
+
+	ciTracker := ins.tracker.(*tracker.CITracker)
+	require.Len(t, ciTracker.SkippedQueries, 2)
+	require.Equal(t, 0, ciTracker.ExecutingQueries-ciTracker.ExecutedQueries)
+}
+
 func TestShouldSkipFile(t *testing.T) {
 	type args struct {
 		commands model.CommentsCommands
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/alexmullins/zip"
@@ -76,7 +77,29 @@ func GetSources(source []string) (ExtractedPath, error) {
 		Path:          []string{},
 		ExtractionMap: make(map[string]model.ExtractedPathObject),
 	}
+	seen := make(map[string]bool, len(source))
 	for _, path := range source {
+		path = utils.TrimLongPathPrefix(path)
+		// the same path given twice, e.g. with another case on Windows, would report its results twice
+		key := sourceKey(path)
+		if seen[key] {
+			log.Debug().Msgf("Skipping the path %s given more than once", path)
+			continue
+		}
+		seen[key] = true
+
+		// go-getter resolves the local paths as file URLs, which do not keep the server of the UNC paths
+		if utils.IsUNCPath(path) {
+			if _, err := os.Stat(path); err == nil {
+				extrStruct.ExtractionMap[path] = model.ExtractedPathObject{
+					Path:      path,
+					LocalPath: true,
+				}
+				extrStruct.Path = append(extrStruct.Path, path)
+				continue
+			}
+		}
+
 		destination := filepath.Join(os.TempDir(), "kics-extract-"+utils.NextRandom())
 
 		mode := getter.ClientModeAny
@@ -123,6 +146,48 @@ func GetSources(source []string) (ExtractedPath, error) {
 	return extrStruct, nil
 }
 
+// sourceKey returns the key of a source to skip the sources given more than once, the local paths are compared
+// by their absolute path
+func sourceKey(source string) string {
+	if _, err := os.Stat(source); err != nil {
+		return source
+	}
+	absPath, err := filepath.Abs(source)
+	if err != nil {
+		return utils.PathKey(source)
+	}
+	return utils.PathKey(absPath)
+}
+
+// IsRemoteSource checks if a source has to be downloaded, as git repositories, buckets or URLs do,
+// sources that exist locally, including archives, are never remote
+func IsRemoteSource(source string) bool {
+	if _, err := os.Stat(source); err == nil {
+		return false
+	}
+	pwd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+
+	// the bitbucket detector requests the bitbucket API to know the repository type
+	detectors := make([]getter.Detector, 0, len(getter.Detectors))
+	for _, detector := range getter.Detectors {
+		if _, ok := detector.(*getter.BitBucketDetector); !ok {
+			detectors = append(detectors, detector)
+		}
+	}
+	if strings.HasPrefix(source, "bitbucket.org/") {
+		return true
+	}
+
+	detected, err := getter.Detect(source, pwd, detectors)
+	if err != nil {
+		return false
+	}
+	return !strings.HasPrefix(detected, "file://") && !strings.HasPrefix(detected, "file::")
+}
+
 func getPaths(g *getterStruct) (string, error) {
 	if isEncrypted(g.source) {
 		err := errors.New("zip encrypted files are not supported")
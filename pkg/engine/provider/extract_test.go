@@ -97,3 +97,18 @@ func TestProvider_GetSources(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_GetSourcesDuplicated(t *testing.T) {
+	if err := test.ChangeCurrentDir("kics"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetSources([]string{
+		"test/fixtures/all_auth_users_get_read_access",
+		"test/fixtures/all_auth_users_get_read_access/",
+		"./test/fixtures/all_auth_users_get_read_access",
+	})
+	require.NoError(t, err)
+	require.Len(t, got.Path, 1)
+	require.Len(t, got.ExtractionMap, 1)
+}
@@ -39,7 +39,7 @@ func NewFileSystemSourceProvider(paths, excludes []string) (*FileSystemSourcePro
 	ex := make(map[string][]os.FileInfo, len(excludes))
 	osPaths := make([]string, len(paths))
 	for idx, path := range paths {
-		osPaths[idx] = filepath.FromSlash(path)
+		osPaths[idx] = filepath.FromSlash(utils.TrimLongPathPrefix(path))
 	}
 	fs := &FileSystemSourceProvider{
 		paths:    osPaths,
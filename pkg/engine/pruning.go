@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"regexp"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const terraformPlatform = "terraform"
+
+// terraformTypeRegex matches the resource and data source types of the terraform providers referenced by a query,
+// either as an attribute of the document, e.g. resource.aws_s3_bucket, or as a string
+var terraformTypeRegex = regexp.MustCompile(
+	`\b((?:aws|azurerm|azuread|google|alicloud|kubernetes|nifcloud|databricks|github|tencentcloud)_[a-z0-9_]+)\b`)
+
+// PruneQueries skips the terraform queries whose referenced resource and data source types are all missing from
+// the scanned documents, so the queries looking for a resource missing from the whole project are skipped too
+func (c *Inspector) PruneQueries() {
+	c.pruneQueries = true
+}
+
+// pruneQueries splits the queries that may have results on the documents from the pruned ones, the queries of
+// other platforms and the terraform queries referencing no type are always kept
+func pruneQueries(queries []model.QueryMetadata, files model.FileMetadatas) (kept, pruned []model.QueryMetadata) {
+	declaredTypes := declaredTerraformTypes(files)
+	kept = make([]model.QueryMetadata, 0, len(queries))
+	pruned = make([]model.QueryMetadata, 0)
+	for i := range queries {
+		if queries[i].Platform != terraformPlatform || referencesAnyType(queries[i].Content, declaredTypes) {
+			kept = append(kept, queries[i])
+		} else {
+			pruned = append(pruned, queries[i])
+		}
+	}
+	return kept, pruned
+}
+
+// declaredTerraformTypes returns the resource and data source types declared in the documents
+func declaredTerraformTypes(files model.FileMetadatas) map[string]bool {
+	declaredTypes := make(map[string]bool)
+	for i := range files {
+		for _, block := range []string{"resource", "data"} {
+			types, ok := files[i].Document[block].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for declaredType := range types {
+				declaredTypes[declaredType] = true
+			}
+		}
+	}
+	return declaredTypes
+}
+
+// referencesAnyType returns true when the query references no type or at least one of the declared types
+func referencesAnyType(content string, declaredTypes map[string]bool) bool {
+	referenced := terraformTypeRegex.FindAllStringSubmatch(content, -1)
+	for _, match := range referenced {
+		if declaredTypes[match[1]] {
+			return true
+		}
+	}
+	return len(referenced) == 0
+}
+
+// trackQueriesPruned counts the pruned queries as executed without results, as they were counted as executing
+// before the documents were parsed
+func (c *Inspector) trackQueriesPruned(pruned []model.QueryMetadata, currentQuery chan<- int64) {
+	if len(pruned) == 0 {
+		return
+	}
+	log.Debug().Msgf("Pruned %d queries referencing no type declared in the scanned documents", len(pruned))
+	for i := range pruned {
+		c.tracker.TrackQueryExecution(pruned[i].Aggregation)
+	}
+	currentQuery <- int64(len(pruned))
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/internal/tracker"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneQueries(t *testing.T) {
+	files := model.FileMetadatas{
+		{
+			Kind: model.KindTerraform,
+			Document: model.Document{
+				"resource": map[string]interface{}{
+					"aws_s3_bucket": map[string]interface{}{"logs": map[string]interface{}{}},
+				},
+				"data": map[string]interface{}{
+					"aws_iam_policy_document": map[string]interface{}{"read": map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			Kind:     model.KindDOCKER,
+			Document: model.Document{"command": map[string]interface{}{}},
+		},
+	}
+	queries := []model.QueryMetadata{
+		{Query: "bucket_acl", Platform: "terraform", Content: "bucket := input.document[i].resource.aws_s3_bucket[name]"},
+		{Query: "policy", Platform: "terraform", Content: `common_lib.get_resource("aws_iam_policy_document")`},
+		{Query: "instance", Platform: "terraform", Content: "input.document[i].resource.aws_instance[name]"},
+		{Query: "vm", Platform: "terraform", Content: `types := {"azurerm_virtual_machine", "google_compute_instance"}`},
+		{Query: "module", Platform: "terraform", Content: "module := input.document[i].module[name]"},
+		{Query: "user", Platform: "dockerfile", Content: "resource := input.document[i].command[name]"},
+	}
+
+	kept, pruned := pruneQueries(queries, files)
+	keptNames := make([]string, 0, len(kept))
+	for i := range kept {
+		keptNames = append(keptNames, kept[i].Query)
+	}
+	require.Equal(t, []string{"bucket_acl", "policy", "module", "user"}, keptNames)
+	require.Len(t, pruned, 2)
+}
+
+func TestInspector_trackQueriesPruned(t *testing.T) {
+	ciTracker := &tracker.CITracker{}
+	inspector := &Inspector{tracker: ciTracker}
+	currentQuery := make(chan int64, 1)
+
+	inspector.trackQueriesPruned([]model.QueryMetadata{{Aggregation: 1}, {Aggregation: 2}}, currentQuery)
+	require.Equal(t, 3, ciTracker.ExecutedQueries)
+	require.Equal(t, int64(2), <-currentQuery)
+
+	inspector.trackQueriesPruned([]model.QueryMetadata{}, currentQuery)
+	require.Len(t, currentQuery, 0)
+}
@@ -0,0 +1,169 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// kicsEngine is the engine running the KICS regex rules
+	kicsEngine = "kics"
+	// externalEnginePrefix prefixes the path of the binary of an external engine
+	externalEnginePrefix = "exec:"
+	// externalRuleName is the rule name of the findings of an external engine naming no rule
+	externalRuleName = "External Secret"
+)
+
+// ExternalRequest is written as JSON to the stdin of the external engine, with the files to scan
+type ExternalRequest struct {
+	Files []ExternalFile `json:"files"`
+}
+
+// ExternalFile is a file to scan by the external engine
+type ExternalFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// ExternalResponse is read as JSON from the stdout of the external engine, with the secrets found
+type ExternalResponse struct {
+	Findings []ExternalFinding `json:"findings"`
+}
+
+// ExternalFinding is a secret found by the external engine, the line starts at 1 and the whole line is masked
+// when the secret is not given
+type ExternalFinding struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Rule   string `json:"rule"`
+	Secret string `json:"secret"`
+}
+
+// runExternalEngine runs the binary of the external engine with the request as input and returns its output
+var runExternalEngine = func(ctx context.Context, binary string, request []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, binary) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(request)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// ParseEngine returns the path of the binary of the external engine of the secrets engine value, the path is
+// empty when the KICS regex rules are used
+func ParseEngine(value string) (string, error) {
+	if value == "" || value == kicsEngine {
+		return "", nil
+	}
+	if !strings.HasPrefix(value, externalEnginePrefix) {
+		return "", fmt.Errorf("invalid secrets engine %s, expected %s or %s<path>", value, kicsEngine, externalEnginePrefix)
+	}
+	binary := strings.TrimSpace(strings.TrimPrefix(value, externalEnginePrefix))
+	if binary == "" {
+		return "", fmt.Errorf("invalid secrets engine %s, the path of the binary is missing", value)
+	}
+	return binary, nil
+}
+
+// UseExternalEngine delegates the secrets detection to the binary of an external engine instead of the regex
+// rules, the findings are reported and masked as the ones of the regex rules
+func (c *Inspector) UseExternalEngine(binary string) {
+	c.externalEngine = binary
+}
+
+// usesExternalEngine returns true when an external engine is set and the secrets are not disabled
+func (c *Inspector) usesExternalEngine() bool {
+	return c.externalEngine != "" && len(c.regexQueries) > 0
+}
+
+// inspectExternal sends the files to the external engine in a single run, bounded by the query timeout
+func (c *Inspector) inspectExternal(ctx context.Context, basePaths []string,
+	files model.FileMetadatas, currentQuery chan<- int64) ([]model.Vulnerability, error) {
+	currentQuery <- 1
+
+	// the scan timeout expired, the external engine is skipped
+	if ctx.Err() != nil {
+		c.tracker.TrackQuerySkipped(model.SkippedQuery{
+			QueryID:   c.secretsQueryID,
+			QueryName: SecretsQueryMetadata["queryName"] + " - " + externalRuleName,
+			Platform:  SecretsQueryMetadata["platform"],
+		}, 0)
+		return c.vulnerabilities, nil
+	}
+
+	cleanFiles := cleanFiles(files)
+	request := ExternalRequest{Files: make([]ExternalFile, 0, len(cleanFiles))}
+	filesByPath := make(map[string]*model.FileMetadata, len(cleanFiles))
+	for idx := range cleanFiles {
+		if _, ok := cleanFiles[idx].Commands["ignore"]; ok {
+			continue
+		}
+		cleanFiles[idx].LinesIgnore = model.GetIgnoreLines(&cleanFiles[idx])
+		filesByPath[cleanFiles[idx].FilePath] = &cleanFiles[idx]
+		request.Files = append(request.Files, ExternalFile{
+			Path:    cleanFiles[idx].FilePath,
+			Content: cleanFiles[idx].OriginalData,
+		})
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return c.vulnerabilities, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, c.queryExecutionTimeout)
+	defer cancel()
+
+	output, err := runExternalEngine(timeoutCtx, c.externalEngine, body)
+	if err != nil {
+		return c.vulnerabilities, fmt.Errorf("failed to run the external secrets engine %s: %w", c.externalEngine, err)
+	}
+
+	var response ExternalResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return c.vulnerabilities, fmt.Errorf("failed to read the output of the external secrets engine %s: %w",
+			c.externalEngine, err)
+	}
+
+	for _, finding := range response.Findings {
+		c.addExternalFinding(basePaths, filesByPath, finding)
+	}
+	return c.vulnerabilities, nil
+}
+
+// addExternalFinding adds the vulnerability of a finding, the secret is matched literally when it is masked
+func (c *Inspector) addExternalFinding(basePaths []string, filesByPath map[string]*model.FileMetadata,
+	finding ExternalFinding) {
+	file, ok := filesByPath[finding.File]
+	if !ok {
+		log.Warn().Msgf("Ignoring the external secret of the file %s, the file was not scanned", finding.File)
+		return
+	}
+	lines := *file.LinesOriginalData
+	if finding.Line < 1 || finding.Line > len(lines) {
+		log.Warn().Msgf("Ignoring the external secret of the file %s, the line %d is out of the file", finding.File, finding.Line)
+		return
+	}
+
+	ruleName := finding.Rule
+	if ruleName == "" {
+		ruleName = externalRuleName
+	}
+	query := &RegexQuery{
+		ID:       c.secretsQueryID,
+		Name:     ruleName,
+		RegexStr: regexp.QuoteMeta(finding.Secret),
+	}
+	c.addVulnerability(basePaths, file, query, finding.Line-1, lines[finding.Line-1], "")
+}
@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Checkmarx/kics/assets"
+	"github.com/Checkmarx/kics/internal/tracker"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const externalFileData = `api:
+  url: https://example.com
+  token: tk_live_0123456789abcdef
+`
+
+func TestParseEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", value: "", want: ""},
+		{name: "kics", value: "kics", want: ""},
+		{name: "external", value: "exec:/usr/bin/trufflehog-adapter", want: "/usr/bin/trufflehog-adapter"},
+		{name: "missing_binary", value: "exec:", wantErr: true},
+		{name: "unknown", value: "gitleaks", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEngine(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInspect_ExternalEngine(t *testing.T) {
+	file := model.FileMetadata{
+		ID:                "0d4f1d6e-8f2b-4c3a-9e5d-7a6b5c4d3e2f",
+		OriginalData:      externalFileData,
+		LinesOriginalData: utils.SplitLines(externalFileData),
+		Kind:              model.KindYAML,
+		FilePath:          "config.yaml",
+	}
+
+	tests := []struct {
+		name      string
+		output    string
+		runErr    error
+		wantNames []string
+		wantLines []int
+		wantErr   bool
+	}{
+		{
+			name: "findings",
+			output: `{"findings": [
+				{"file": "config.yaml", "line": 3, "rule": "Generic API Token", "secret": "tk_live_0123456789abcdef"},
+				{"file": "config.yaml", "line": 2},
+				{"file": "other.yaml", "line": 1, "rule": "Generic API Token"},
+				{"file": "config.yaml", "line": 42, "rule": "Generic API Token"}
+			]}`,
+			wantNames: []string{"Passwords And Secrets - Generic API Token", "Passwords And Secrets - External Secret"},
+			wantLines: []int{3, 2},
+		},
+		{
+			name:   "no_findings",
+			output: `{"findings": []}`,
+		},
+		{
+			name:    "invalid_output",
+			output:  "no secrets found",
+			wantErr: true,
+		},
+		{
+			name:    "failed_run",
+			runErr:  errors.New("exit status 2"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := runExternalEngine
+			t.Cleanup(func() { runExternalEngine = original })
+			runExternalEngine = func(ctx context.Context, binary string, request []byte) ([]byte, error) {
+				require.Equal(t, "/usr/bin/secrets-adapter", binary)
+				var gotRequest ExternalRequest
+				require.NoError(t, json.Unmarshal(request, &gotRequest))
+				require.Equal(t, []ExternalFile{{Path: "config.yaml", Content: externalFileData}}, gotRequest.Files)
+				return []byte(tt.output), tt.runErr
+			}
+
+			ctx := context.Background()
+			secretsInspector, err := NewInspector(
+				ctx,
+				map[string]bool{},
+				&tracker.CITracker{},
+				&source.QueryInspectorParameters{
+					IncludeQueries: source.IncludeQueries{ByIDs: []string{}},
+					ExcludeQueries: source.ExcludeQueries{ByIDs: []string{}},
+				},
+				false,
+				60,
+				assets.SecretsQueryRegexRulesJSON,
+				false,
+			)
+			require.NoError(t, err)
+			secretsInspector.UseExternalEngine("/usr/bin/secrets-adapter")
+			require.Equal(t, 1, secretsInspector.GetQueriesLength())
+
+			currentQuery := make(chan int64, secretsInspector.GetQueriesLength())
+			vulns, err := secretsInspector.Inspect(ctx, []string{"."}, model.FileMetadatas{file}, currentQuery)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(vulns))
+			lines := make([]int, 0, len(vulns))
+			for i := range vulns {
+				names = append(names, vulns[i].QueryName)
+				lines = append(lines, vulns[i].Line)
+				for _, line := range *vulns[i].VulnLines {
+					require.NotContains(t, line.Line, "tk_live_0123456789abcdef")
+				}
+			}
+			require.ElementsMatch(t, tt.wantNames, names)
+			require.ElementsMatch(t, tt.wantLines, lines)
+		})
+	}
+}
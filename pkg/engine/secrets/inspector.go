@@ -45,6 +45,7 @@ type Inspector struct {
 	tracker               engine.Tracker
 	detector              *detector.DetectLine
 	excludeResults        map[string]bool
+	keyExclusions         []engine.KeyExclusion
 	regexQueries          []RegexQuery
 	allowRules            []AllowRule
 	vulnerabilities       []model.Vulnerability
@@ -52,6 +53,8 @@ type Inspector struct {
 	foundLines            []int
 	mu                    sync.RWMutex
 	SecretTracker         []SecretTracker
+	secretsQueryID        string
+	externalEngine        string
 }
 
 type Entropy struct {
@@ -171,11 +174,12 @@ func NewInspector(
 		vulnerabilities:       make([]model.Vulnerability, 0),
 		queryExecutionTimeout: queryExecutionTimeout,
 		foundLines:            make([]int, 0),
+		secretsQueryID:        passwordsAndSecretsQueryID,
 	}, nil
 }
 
 func (c *Inspector) inspectQuery(ctx context.Context, basePaths []string,
-	files model.FileMetadatas, i int) ([]model.Vulnerability, error) {
+	files model.FileMetadatas, structuredDocuments map[string]*structuredDocument, i int) ([]model.Vulnerability, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, c.queryExecutionTimeout)
 	defer cancel()
 
@@ -187,7 +191,7 @@ func (c *Inspector) inspectQuery(ctx context.Context, basePaths []string,
 			case <-timeoutCtx.Done():
 				return c.vulnerabilities, timeoutCtx.Err()
 			default:
-				c.checkContent(i, idx, basePaths, cleanFiles)
+				c.checkContent(i, idx, basePaths, cleanFiles, structuredDocuments)
 			}
 		}
 	}
@@ -197,18 +201,51 @@ func (c *Inspector) inspectQuery(ctx context.Context, basePaths []string,
 // Inspect inspects the source code for passwords & secrets and returns the list of vulnerabilities
 func (c *Inspector) Inspect(ctx context.Context, basePaths []string,
 	files model.FileMetadatas, currentQuery chan<- int64) ([]model.Vulnerability, error) {
+	if c.usesExternalEngine() {
+		return c.inspectExternal(ctx, basePaths, files, currentQuery)
+	}
+
+	structuredDocuments := getStructuredDocuments(cleanFiles(files))
 	for i := range c.regexQueries {
 		currentQuery <- 1
 
-		vulns, err := c.inspectQuery(ctx, basePaths, files, i)
+		// the scan timeout expired, the remaining queries are skipped
+		if ctx.Err() != nil {
+			c.tracker.TrackQuerySkipped(model.SkippedQuery{
+				QueryID:   c.regexQueries[i].ID,
+				QueryName: SecretsQueryMetadata["queryName"] + " - " + c.regexQueries[i].Name,
+				Platform:  SecretsQueryMetadata["platform"],
+			}, 0)
+			continue
+		}
 
-		if err != nil {
+		vulns, err := c.inspectQuery(ctx, basePaths, files, structuredDocuments, i)
+
+		if err != nil && ctx.Err() == nil {
 			return vulns, err
 		}
 	}
+
+	if len(c.regexQueries) > 0 && ctx.Err() == nil {
+		c.inspectStructuredOutputs(basePaths, files, structuredDocuments)
+	}
 	return c.vulnerabilities, nil
 }
 
+func (c *Inspector) inspectStructuredOutputs(basePaths []string, files model.FileMetadatas,
+	structuredDocuments map[string]*structuredDocument) {
+	cleanFiles := cleanFiles(files)
+	for idx := range cleanFiles {
+		if _, ok := cleanFiles[idx].Commands["ignore"]; ok {
+			continue
+		}
+		if document, ok := structuredDocuments[cleanFiles[idx].FilePath]; ok {
+			cleanFiles[idx].LinesIgnore = model.GetIgnoreLines(&cleanFiles[idx])
+			c.checkSensitiveOutputs(basePaths, &cleanFiles[idx], document)
+		}
+	}
+}
+
 func compileRegexQueries(
 	queryFilter *source.QueryInspectorParameters,
 	allRegexQueries []RegexQuery,
@@ -232,6 +269,11 @@ func compileRegexQueries(
 		regexQueries = kicsRegexQueries.Rules
 	}
 
+	if len(queryFilter.IncludeQueries.ByCategories) > 0 &&
+		!isValueInArray(SecretsQueryMetadata["category"], queryFilter.IncludeQueries.ByCategories) {
+		return []RegexQuery{}, nil
+	}
+
 	for i := range allRegexQueries {
 		includeSpecificSecretQuery = isValueInArray(allRegexQueries[i].ID, queryFilter.IncludeQueries.ByIDs)
 		if len(queryFilter.IncludeQueries.ByIDs) > 0 && !allSecretsQueryAndCustom {
@@ -295,6 +337,9 @@ func CompileRegex(allowRules []AllowRule) ([]AllowRule, error) {
 }
 
 func (c *Inspector) GetQueriesLength() int {
+	if c.usesExternalEngine() {
+		return 1
+	}
 	return len(c.regexQueries)
 }
 
@@ -384,6 +429,7 @@ func (c *Inspector) checkFileContent(query *RegexQuery, basePaths []string, file
 				query,
 				lineVuln.lineNumber,
 				lineVuln.lineContent,
+				"",
 			)
 		}
 
@@ -406,6 +452,7 @@ func (c *Inspector) checkFileContent(query *RegexQuery, basePaths []string, file
 						query,
 						lineVuln.lineNumber,
 						lineVuln.lineContent,
+						"",
 					)
 				}
 			}
@@ -468,6 +515,7 @@ func (c *Inspector) checkLineByLine(wg *sync.WaitGroup, query *RegexQuery,
 			query,
 			lineNumber,
 			currentLine,
+			"",
 		)
 	}
 
@@ -492,12 +540,14 @@ func (c *Inspector) checkLineByLine(wg *sync.WaitGroup, query *RegexQuery,
 				query,
 				lineNumber,
 				currentLine,
+				"",
 			)
 		}
 	}
 }
 
-func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadata, query *RegexQuery, lineNumber int, issueLine string) {
+func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadata, query *RegexQuery,
+	lineNumber int, issueLine, searchKey string) {
 	if engine.ShouldSkipVulnerability(file.Commands, query.ID) {
 		log.Debug().Msgf("Skipping vulnerability in file %s for query '%s':%s", file.FilePath, query.Name, query.ID)
 		return
@@ -507,16 +557,22 @@ func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadat
 		file.FilePath,
 		query.ID,
 		fmt.Sprintf("%d", lineNumber),
-		"",
+		searchKey,
 	)
 	if err != nil {
 		log.Error().Msg("unable to compute similarity ID")
 	}
 
+	if _, ok := engine.MatchKeyExclusion(c.keyExclusions, &model.Vulnerability{QueryID: query.ID, SearchKey: searchKey}); ok {
+		log.Debug().Msgf("Excluding result SearchKey: %s", searchKey)
+		return
+	}
+
 	c.mu.Lock()
 	if _, ok := c.excludeResults[engine.PtrStringToString(simID)]; !ok {
 		linesVuln := c.detector.GetAdjacent(file, lineNumber+1)
-		if !ignoreLine(linesVuln.Line, file.LinesIgnore) {
+		_, inIgnoreRegion := model.MatchIgnoreRegion(file.IgnoreRegions, linesVuln.Line, query.ID)
+		if !ignoreLine(linesVuln.Line, file.LinesIgnore) && !inIgnoreRegion {
 			vuln := model.Vulnerability{
 				QueryID:          query.ID,
 				QueryName:        SecretsQueryMetadata["queryName"] + " - " + query.Name,
@@ -524,6 +580,7 @@ func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadat
 				FileID:           file.ID,
 				FileName:         file.FilePath,
 				Line:             linesVuln.Line,
+				SearchKey:        searchKey,
 				VulnLines:        hideSecret(&linesVuln, issueLine, query, &c.SecretTracker),
 				IssueType:        "RedundantAttribute",
 				Platform:         SecretsQueryMetadata["platform"],
@@ -542,6 +599,11 @@ func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadat
 	c.mu.Unlock()
 }
 
+// ExcludeResultsByKey excludes the secrets matching the search key exclusions
+func (c *Inspector) ExcludeResultsByKey(exclusions []engine.KeyExclusion) {
+	c.keyExclusions = exclusions
+}
+
 // CheckEntropyInterval - verifies if a given token's entropy is within expected bounds
 func CheckEntropyInterval(entropy Entropy, token string) (isEntropyInInterval bool, entropyLevel float64) {
 	base64Entropy := calculateEntropy(token, Base64Chars)
@@ -609,11 +671,18 @@ func validateCustomSecretsQueriesID(allRegexQueries []RegexQuery) error {
 	return nil
 }
 
-func (c *Inspector) checkContent(i, idx int, basePaths []string, files model.FileMetadatas) {
+func (c *Inspector) checkContent(i, idx int, basePaths []string, files model.FileMetadatas,
+	structuredDocuments map[string]*structuredDocument) {
 	// lines ignore can have the lines from the resolved files
 	// since inspector secrets only looks to original data, the lines ignore should be replaced
 	files[idx].LinesIgnore = model.GetIgnoreLines(&files[idx])
 
+	// terraform states and plans are checked by value, reporting the path of the secret inside the document
+	if document, ok := structuredDocuments[files[idx].FilePath]; ok {
+		c.checkStructuredContent(&c.regexQueries[i], basePaths, &files[idx], document)
+		return
+	}
+
 	wg := &sync.WaitGroup{}
 	// check file content line by line
 	if c.regexQueries[i].Multiline == (MultilineResult{}) {
@@ -80,6 +80,23 @@ var testCompileRegexesInput = []struct {
 		wantIDs:                []string{"487f4be7-3fd9-4506-a07a-eae252180c08", "4b2b5fd3-364d-4093-bac2-17391b2a5297", "c4d3b58a-e6d4-450f-9340-04f1e702eaae"},
 		isCustomSecretsRegexes: false,
 	},
+	{
+		name: "include_other_category",
+		inspectorParams: &source.QueryInspectorParameters{
+			IncludeQueries: source.IncludeQueries{ByIDs: []string{}, ByCategories: []string{"Encryption"}},
+			ExcludeQueries: source.ExcludeQueries{ByIDs: []string{}, ByCategories: []string{}},
+			InputDataPath:  "",
+		},
+		allRegexQueries: []RegexQuery{
+			{
+				ID:       "487f4be7-3fd9-4506-a07a-eae252180c08",
+				Name:     "Generic Password",
+				RegexStr: `['|"]?[p|P][a|A][s|S][s|S][w|W][o|O][r|R][d|D]['|\"]?\s*[:|=]\s*['|"]?([A-Za-z0-9/~^_!@&%()=?*+-]{4,})['|"]?`,
+			},
+		},
+		wantIDs:                []string{},
+		isCustomSecretsRegexes: false,
+	},
 	{
 		name: "include_one",
 		inspectorParams: &source.QueryInspectorParameters{
@@ -0,0 +1,345 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+const (
+	// constantValueKey is the key used by terraform plans to hold the literal value of an expression
+	constantValueKey = "constant_value"
+	sensitiveOutput  = "Sensitive Output In State"
+)
+
+// structuredDocument is the raw content of a terraform state or plan file
+type structuredDocument struct {
+	content map[string]interface{}
+}
+
+// structuredValue is a leaf of a structured document and the path used to reach it
+type structuredValue struct {
+	path  string
+	key   string
+	value string
+}
+
+// getStructuredDocuments returns, by file path, the files that are terraform states or plans
+func getStructuredDocuments(files model.FileMetadatas) map[string]*structuredDocument {
+	documents := make(map[string]*structuredDocument)
+	for i := range files {
+		if files[i].Kind != model.KindJSON {
+			continue
+		}
+		var content map[string]interface{}
+		if err := json.Unmarshal([]byte(files[i].OriginalData), &content); err != nil {
+			continue
+		}
+		if isTerraformState(content) || isTerraformPlan(content) {
+			documents[files[i].FilePath] = &structuredDocument{content: content}
+		}
+	}
+	return documents
+}
+
+func isTerraformState(content map[string]interface{}) bool {
+	_, hasVersion := content["terraform_version"]
+	_, hasLineage := content["lineage"]
+	_, hasResources := content["resources"]
+	return hasVersion && hasLineage && hasResources
+}
+
+func isTerraformPlan(content map[string]interface{}) bool {
+	_, hasVersion := content["terraform_version"]
+	_, hasPlannedValues := content["planned_values"]
+	_, hasResourceChanges := content["resource_changes"]
+	return hasVersion && (hasPlannedValues || hasResourceChanges)
+}
+
+// values returns every string leaf of the document, sorted by path
+func (d *structuredDocument) values() []structuredValue {
+	values := make([]structuredValue, 0)
+	walkStructuredValue("", "", d.content, &values)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].path < values[j].path
+	})
+	return values
+}
+
+// sensitiveOutputs returns the outputs flagged as sensitive that have their value stored in the document
+func (d *structuredDocument) sensitiveOutputs() []structuredValue {
+	outputs := make([]structuredValue, 0)
+	collect := func(prefix string, raw interface{}) {
+		outputsMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for name, output := range outputsMap {
+			outputMap, ok := output.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if sensitive, ok := outputMap["sensitive"].(bool); !ok || !sensitive {
+				continue
+			}
+			if value, ok := outputMap["value"]; !ok || value == nil || value == "" {
+				continue
+			}
+			outputs = append(outputs, structuredValue{
+				path: prefix + "outputs." + name + ".value",
+				key:  name,
+			})
+		}
+	}
+
+	collect("", d.content["outputs"])
+	if plannedValues, ok := d.content["planned_values"].(map[string]interface{}); ok {
+		collect("planned_values.", plannedValues["outputs"])
+	}
+
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].path < outputs[j].path
+	})
+	return outputs
+}
+
+func walkStructuredValue(path, key string, value interface{}, values *[]structuredValue) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childKey, child := range v {
+			walkStructuredValue(joinPath(path, childKey), childKey, child, values)
+		}
+	case []interface{}:
+		for idx, child := range v {
+			walkStructuredValue(fmt.Sprintf("%s[%s]", path, elementLabel(child, idx)), key, child, values)
+		}
+	case string:
+		if v == "" {
+			return
+		}
+		// plan expressions keep the value under constant_value, the attribute name is the parent key
+		if key == constantValueKey {
+			key = parentKey(path)
+		}
+		*values = append(*values, structuredValue{
+			path:  path,
+			key:   key,
+			value: v,
+		})
+	}
+}
+
+// elementLabel identifies an array element by its resource address when available, otherwise by index
+func elementLabel(element interface{}, idx int) string {
+	elementMap, ok := element.(map[string]interface{})
+	if !ok {
+		return strconv.Itoa(idx)
+	}
+	if address, ok := elementMap["address"].(string); ok && address != "" {
+		return address
+	}
+	resourceType, hasType := elementMap["type"].(string)
+	name, hasName := elementMap["name"].(string)
+	if !hasType || !hasName {
+		return strconv.Itoa(idx)
+	}
+	label := resourceType + "." + name
+	if mode, ok := elementMap["mode"].(string); ok && mode == "data" {
+		label = "data." + label
+	}
+	if module, ok := elementMap["module"].(string); ok && module != "" {
+		label = module + "." + label
+	}
+	return label
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func parentKey(path string) string {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return constantValueKey
+	}
+	return parts[len(parts)-2]
+}
+
+// findValueLine returns the index of the first line containing the JSON encoded value,
+// lines that also contain the JSON encoded key are preferred
+func findValueLine(lines []string, key, value string) int {
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return -1
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return -1
+	}
+	valueLine := -1
+	for idx := range lines {
+		if !strings.Contains(lines[idx], string(encodedValue)) {
+			continue
+		}
+		if strings.Contains(lines[idx], string(encodedKey)) {
+			return idx
+		}
+		if valueLine < 0 {
+			valueLine = idx
+		}
+	}
+	return valueLine
+}
+
+// findOutputLine returns the index of the line holding the value of an output, the line defining
+// the output is returned when the value is not found
+func findOutputLine(lines []string, name string) int {
+	outputLine := findValueLine(lines, name, name)
+	if outputLine < 0 {
+		return -1
+	}
+	for idx := outputLine; idx < len(lines); idx++ {
+		if strings.Contains(lines[idx], `"value"`) {
+			return idx
+		}
+	}
+	return outputLine
+}
+
+// findEnclosingLine returns the index of the line of the deepest object of the path found in the lines, the line of the
+// resource or the output holding a value that could not be found, the first line when no object of the path is found
+func findEnclosingLine(lines []string, path string) int {
+	enclosingLine, start := 0, 0
+	for _, segment := range pathSegments(path) {
+		var needles []string
+		switch {
+		case segment.label == "":
+			needles = []string{strconv.Quote(segment.key) + ":"}
+		case !isIndex(segment.label):
+			// the array elements are labeled by their address, or by their type and name
+			name := segment.label[strings.LastIndex(segment.label, ".")+1:]
+			needles = []string{strconv.Quote(segment.label), `"name": ` + strconv.Quote(name)}
+		default:
+			continue
+		}
+		for idx := start; idx < len(lines); idx++ {
+			if containsAny(lines[idx], needles) {
+				enclosingLine, start = idx, idx+1
+				break
+			}
+		}
+	}
+	return enclosingLine
+}
+
+// pathSegment is a key of the path of a structured value, or the label of an array element
+type pathSegment struct {
+	key   string
+	label string
+}
+
+// pathSegments splits the path of a structured value, the labels of the array elements, which are addresses holding
+// dots, are kept whole
+func pathSegments(path string) []pathSegment {
+	segments := make([]pathSegment, 0)
+	for len(path) > 0 {
+		switch {
+		case path[0] == '.':
+			path = path[1:]
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return segments
+			}
+			segments = append(segments, pathSegment{label: path[1:end]})
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, pathSegment{key: path[:end]})
+			path = path[end:]
+		}
+	}
+	return segments
+}
+
+func isIndex(label string) bool {
+	_, err := strconv.Atoi(label)
+	return err == nil
+}
+
+func containsAny(line string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(line, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStructuredContent checks every value of a terraform state or plan for secrets,
+// each value is evaluated as a single "key": "value" line so the regex rules can be reused
+func (c *Inspector) checkStructuredContent(query *RegexQuery, basePaths []string,
+	file *model.FileMetadata, document *structuredDocument) {
+	lines := *file.LinesOriginalData
+	for _, value := range document.values() {
+		candidate := fmt.Sprintf("%q: %q", value.key, value.value)
+		isSecret, groups := c.isSecret(candidate, query)
+		if !isSecret {
+			continue
+		}
+
+		// a value written differently in the file, e.g. with other escapes, is reported and masked on the line of its
+		// key or of its resource
+		lineNumber := findValueLine(lines, value.key, value.value)
+		if lineNumber < 0 {
+			lineNumber = findEnclosingLine(lines, value.path)
+		}
+		issueLine := lines[lineNumber]
+
+		if len(query.Entropies) == 0 {
+			c.addVulnerability(basePaths, file, query, lineNumber, issueLine, value.path)
+			continue
+		}
+
+		for i := range query.Entropies {
+			entropy := query.Entropies[i]
+			if len(groups[0]) <= entropy.Group {
+				break
+			}
+			if isMatch, _ := CheckEntropyInterval(entropy, groups[0][entropy.Group]); isMatch {
+				c.addVulnerability(basePaths, file, query, lineNumber, issueLine, value.path)
+			}
+		}
+	}
+
+}
+
+// checkSensitiveOutputs reports every output of a terraform state or plan marked as sensitive,
+// it only depends on the document so it runs once per file instead of once per regex query
+func (c *Inspector) checkSensitiveOutputs(basePaths []string, file *model.FileMetadata, document *structuredDocument) {
+	// the special mask hides the value of the output while keeping its key
+	outputQuery := &RegexQuery{
+		ID:          SecretsQueryMetadata["id"],
+		Name:        sensitiveOutput,
+		SpecialMask: `"value":\s*`,
+	}
+	lines := *file.LinesOriginalData
+	for _, output := range document.sensitiveOutputs() {
+		lineNumber := findOutputLine(lines, output.key)
+		if lineNumber < 0 {
+			lineNumber = findEnclosingLine(lines, output.path)
+		}
+		issueLine := lines[lineNumber]
+		c.addVulnerability(basePaths, file, outputQuery, lineNumber, issueLine, output.path)
+	}
+}
@@ -0,0 +1,210 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Checkmarx/kics/assets"
+	"github.com/Checkmarx/kics/internal/tracker"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	terraformStateData = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "serial": 3,
+  "lineage": "6c0b5c5e-0b1b-9e0c-2a3c-0c4b1e2f1a7d",
+  "outputs": {
+    "db_password": {
+      "value": "Sup3rS3cretValue",
+      "type": "string",
+      "sensitive": true
+    },
+    "db_endpoint": {
+      "value": "db.internal:5432",
+      "type": "string"
+    }
+  },
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "schema_version": 2,
+          "attributes": {
+            "engine": "postgres",
+            "username": "admin",
+            "password": "Sup3rS3cretValue"
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+	terraformPlanData = `{
+  "format_version": "1.2",
+  "terraform_version": "1.5.7",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_db_instance.main",
+          "type": "aws_db_instance",
+          "name": "main",
+          "values": {
+            "engine": "postgres"
+          }
+        }
+      ]
+    }
+  },
+  "resource_changes": [],
+  "configuration": {
+    "provider_config": {
+      "aws": {
+        "name": "aws",
+        "expressions": {
+          "region": {
+            "constant_value": "us-east-1"
+          },
+          "password": {
+            "constant_value": "Pr0viderS3cret"
+          }
+        }
+      }
+    }
+  }
+}
+`
+)
+
+// escapedStateData is a terraform state whose resource password is escaped, so the value is not found verbatim
+var escapedStateData = strings.Replace(terraformStateData,
+	`"password": "Sup3rS3cretValue"`, `"password": "An0therS3cr\u0065tValue"`, 1)
+
+func TestInspect_StructuredDocuments(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           model.FileMetadata
+		wantSearchKeys []string
+		wantLines      []int
+	}{
+		{
+			name: "terraform_state",
+			file: model.FileMetadata{
+				ID:                "1c6b5c1a-3f4e-4c1b-9a3d-2f5e6a7b8c9d",
+				OriginalData:      terraformStateData,
+				LinesOriginalData: utils.SplitLines(terraformStateData),
+				Kind:              model.KindJSON,
+				FilePath:          "terraform.tfstate",
+			},
+			wantSearchKeys: []string{
+				"outputs.db_password.value",
+				"resources[aws_db_instance.main].instances[0].attributes.password",
+			},
+			wantLines: []int{8, 29},
+		},
+		{
+			name: "terraform_state_escaped_value",
+			file: model.FileMetadata{
+				ID:                "7d2e4f6a-8b0c-4d1e-9f3a-5b7c9d1e3f5a",
+				OriginalData:      escapedStateData,
+				LinesOriginalData: utils.SplitLines(escapedStateData),
+				Kind:              model.KindJSON,
+				FilePath:          "escaped.tfstate",
+			},
+			wantSearchKeys: []string{
+				"outputs.db_password.value",
+				"resources[aws_db_instance.main].instances[0].attributes.password",
+			},
+			wantLines: []int{8, 29},
+		},
+		{
+			name: "terraform_plan",
+			file: model.FileMetadata{
+				ID:                "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d",
+				OriginalData:      terraformPlanData,
+				LinesOriginalData: utils.SplitLines(terraformPlanData),
+				Kind:              model.KindJSON,
+				FilePath:          "plan.json",
+			},
+			wantSearchKeys: []string{
+				"configuration.provider_config.aws.expressions.password.constant_value",
+			},
+			wantLines: []int{28},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			secretsInspector, err := NewInspector(
+				ctx,
+				map[string]bool{},
+				&tracker.CITracker{},
+				&source.QueryInspectorParameters{
+					IncludeQueries: source.IncludeQueries{ByIDs: []string{}},
+					ExcludeQueries: source.ExcludeQueries{ByIDs: []string{}},
+				},
+				false,
+				60,
+				assets.SecretsQueryRegexRulesJSON,
+				false,
+			)
+			require.NoError(t, err)
+
+			currentQuery := make(chan int64, secretsInspector.GetQueriesLength())
+			vulns, err := secretsInspector.Inspect(ctx, []string{"."}, model.FileMetadatas{tt.file}, currentQuery)
+			require.NoError(t, err)
+
+			searchKeys := make([]string, 0, len(vulns))
+			lines := make([]int, 0, len(vulns))
+			for i := range vulns {
+				searchKeys = append(searchKeys, vulns[i].SearchKey)
+				lines = append(lines, vulns[i].Line)
+				for _, line := range *vulns[i].VulnLines {
+					require.NotContains(t, line.Line, "S3cret")
+				}
+			}
+			require.ElementsMatch(t, tt.wantSearchKeys, searchKeys)
+			require.ElementsMatch(t, tt.wantLines, lines)
+		})
+	}
+}
+
+func TestFindEnclosingLine(t *testing.T) {
+	lines := *utils.SplitLines(terraformStateData)
+
+	// the password of the resource is escaped in the file
+	escapedLines := *utils.SplitLines(escapedStateData)
+	require.Equal(t, -1, findValueLine(escapedLines, "password", "An0therS3cretValue"))
+	require.Equal(t, 28, findEnclosingLine(escapedLines, "resources[aws_db_instance.main].instances[0].attributes.password"))
+
+	// the line of the deepest object found is returned when the attribute is not found
+	require.Equal(t, 25, findEnclosingLine(lines, "resources[aws_db_instance.main].instances[0].attributes.token"))
+	require.Equal(t, 7, findEnclosingLine(lines, "outputs.db_password.value"))
+	require.Equal(t, 0, findEnclosingLine(lines, "unknown.key"))
+}
+
+func TestGetStructuredDocuments(t *testing.T) {
+	files := model.FileMetadatas{
+		{FilePath: "terraform.tfstate", Kind: model.KindJSON, OriginalData: terraformStateData},
+		{FilePath: "plan.json", Kind: model.KindJSON, OriginalData: terraformPlanData},
+		{FilePath: "template.json", Kind: model.KindJSON, OriginalData: `{"Resources": {}}`},
+		{FilePath: "main.tf", Kind: model.KindTerraform, OriginalData: `resource "aws_s3_bucket" "b" {}`},
+	}
+
+	documents := getStructuredDocuments(files)
+	require.Len(t, documents, 2)
+	require.Contains(t, documents, "terraform.tfstate")
+	require.Contains(t, documents, "plan.json")
+}
@@ -0,0 +1,105 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MaxExamplesPerQuery is the maximum number of compliant samples read for a query
+	MaxExamplesPerQuery = 2
+	// MaxExampleLines is the maximum number of lines of a compliant sample, the longer samples are cut
+	MaxExampleLines = 60
+
+	queryTestDir         = "test"
+	negativeSamplePrefix = "negative"
+)
+
+// ReadQueryExamples returns the compliant samples of the tests of the queries with the IDs, by query ID, the
+// queries are searched in the sources and the aggregated queries share the samples of their directory
+func ReadQueryExamples(sources []string, ids map[string]bool) map[string][]model.CodeExample {
+	examples := make(map[string][]model.CodeExample)
+	for _, source := range sources {
+		err := filepath.Walk(source, func(p string, f os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if f.IsDir() || f.Name() != MetadataFileName {
+				return nil
+			}
+			queryIDs := metadataIDs(filepath.Dir(p), ids)
+			if len(queryIDs) == 0 {
+				return nil
+			}
+			samples := readNegativeSamples(filepath.Join(filepath.Dir(p), queryTestDir))
+			for _, id := range queryIDs {
+				examples[id] = samples
+			}
+			return nil
+		})
+		if err != nil {
+			log.Warn().Msgf("Failed to read the query samples of %s: %s", source, err)
+		}
+	}
+	return examples
+}
+
+// metadataIDs returns the IDs of the query and of its aggregated queries that are in the IDs
+func metadataIDs(queryDir string, ids map[string]bool) []string {
+	metadata, err := ReadMetadata(queryDir)
+	if err != nil {
+		return nil
+	}
+	found := make([]string, 0)
+	if id, ok := metadata["id"].(string); ok && ids[id] {
+		found = append(found, id)
+	}
+	overrides, _ := metadata["override"].(map[string]interface{})
+	for _, override := range overrides {
+		values, _ := override.(map[string]interface{})
+		if id, ok := values["id"].(string); ok && ids[id] {
+			found = append(found, id)
+		}
+	}
+	return found
+}
+
+// readNegativeSamples reads the first negative samples of the query test directory
+func readNegativeSamples(testDir string) []model.CodeExample {
+	entries, err := os.ReadDir(testDir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), negativeSamplePrefix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	samples := make([]model.CodeExample, 0, MaxExamplesPerQuery)
+	for _, name := range names {
+		if len(samples) == MaxExamplesPerQuery {
+			break
+		}
+		content, err := os.ReadFile(filepath.Clean(filepath.Join(testDir, name)))
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(strings.TrimRight(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), "\n")
+		sample := model.CodeExample{FileName: name}
+		if len(lines) > MaxExampleLines {
+			lines = lines[:MaxExampleLines]
+			sample.Truncated = true
+		}
+		sample.Code = strings.Join(lines, "\n")
+		samples = append(samples, sample)
+	}
+	return samples
+}
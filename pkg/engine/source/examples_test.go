@@ -0,0 +1,28 @@
+package source
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadQueryExamples tests the compliant samples are read for the queries and their aggregated queries
+func TestReadQueryExamples(t *testing.T) {
+	queryDir := filepath.Join("..", "..", "..", "assets", "queries", "openAPI", "general", "invalid_format")
+	examples := ReadQueryExamples([]string{queryDir}, map[string]bool{
+		"d929c031-078f-4241-b802-e224656ad890": true,
+		"caf1793e-95dd-4b18-8d90-8f3c0ab5bddf": true,
+	})
+
+	require.Len(t, examples, 2)
+	for _, samples := range examples {
+		require.Len(t, samples, MaxExamplesPerQuery)
+		require.Equal(t, "negative1.json", samples[0].FileName)
+		require.True(t, samples[0].Truncated)
+		require.Equal(t, "negative2.yaml", samples[1].FileName)
+		require.NotEmpty(t, samples[1].Code)
+	}
+
+	require.Empty(t, ReadQueryExamples([]string{queryDir}, map[string]bool{"unknown": true}))
+}
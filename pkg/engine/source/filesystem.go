@@ -91,6 +91,9 @@ func getLibraryInDir(platform, libraryDirPath string) string {
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && info.Name() == versionedLibrariesDir { // the versions delegate to the library
+			return filepath.SkipDir
+		}
 		if strings.EqualFold(filepath.Base(path), platform+".rego") { // try to find the library file <platform>.rego
 			libraryFilePath = path
 		}
@@ -208,6 +211,11 @@ func (s *FilesystemSource) CheckCloudProvider(cloudProvider interface{}) bool {
 	return false
 }
 
+// checkQueryCategory checks if the query category was passed as an argument in '--categories' flag to be loaded
+func checkQueryCategory(category interface{}, includedCategories []string) bool {
+	return len(includedCategories) == 0 || checkQueryExcludeField(category, includedCategories)
+}
+
 func checkQueryInclude(id interface{}, includedQueries []string) bool {
 	queryMetadataKey, ok := id.(string)
 	if !ok {
@@ -318,6 +326,10 @@ func (s *FilesystemSource) iterateQueryDirs(queryDirs []string, queryParameters
 			continue
 		}
 
+		if !checkQueryCategory(query.Metadata["category"], queryParameters.IncludeQueries.ByCategories) {
+			continue
+		}
+
 		customInputData, readInputErr := readInputData(filepath.Join(queryParameters.InputDataPath, query.Metadata["id"].(string)+".json"))
 		if readInputErr != nil {
 			log.Err(errRQ).
@@ -447,6 +459,7 @@ type supportedPlatforms map[string]string
 var supPlatforms = &supportedPlatforms{
 	"Ansible":                 "ansible",
 	"CloudFormation":          "cloudFormation",
+	"ConfigConnector":         "configConnector",
 	"Common":                  "common",
 	"Crossplane":              "crossplane",
 	"Dockerfile":              "dockerfile",
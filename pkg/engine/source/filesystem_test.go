@@ -285,6 +285,54 @@ func TestFilesystemSource_GetQueriesWithInclude(t *testing.T) {
 	}
 }
 
+// TestFilesystemSource_GetQueriesWithCategories tests the function GetQuery with QuerySelectionFilter set for include categories
+func TestFilesystemSource_GetQueriesWithCategories(t *testing.T) {
+	if err := test.ChangeCurrentDir("kics"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		includeCategories []string
+		includeIDs        []string
+		wantLen           int
+	}{
+		{
+			name:              "get_queries_without_categories",
+			includeCategories: []string{},
+			wantLen:           1,
+		},
+		{
+			name:              "get_queries_with_categories",
+			includeCategories: []string{"Encryption", "access control"},
+			wantLen:           1,
+		},
+		{
+			name:              "get_queries_with_categories_no_result",
+			includeCategories: []string{"Encryption"},
+			wantLen:           0,
+		},
+		{
+			name:              "get_queries_with_categories_and_include_no_result",
+			includeCategories: []string{"Encryption"},
+			includeIDs:        []string{"57b9893d-33b1-4419-bcea-b828fb87e318"},
+			wantLen:           0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewFilesystemSource([]string{source_get_queries}, []string{""}, []string{""}, "./assets/libraries", false)
+			filter := QueryInspectorParameters{
+				IncludeQueries: IncludeQueries{ByIDs: tt.includeIDs, ByCategories: tt.includeCategories},
+				ExcludeQueries: ExcludeQueries{ByIDs: []string{}, ByCategories: []string{}},
+			}
+			got, err := s.GetQueries(&filter)
+			require.NoError(t, err)
+			require.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
 // TestFilesystemSource_GetQueryLibrary tests the functions [GetQueryLibrary()] and all the methods called by them
 func TestFilesystemSource_GetQueryLibrary(t *testing.T) { //nolint
 	if err := test.ChangeCurrentDir("kics"); err != nil {
@@ -736,6 +784,7 @@ func TestListSupportedPlatforms(t *testing.T) {
 		"Buildah",
 		"CICD",
 		"CloudFormation",
+		"ConfigConnector",
 		"Crossplane",
 		"Dockerfile",
 		"DockerCompose",
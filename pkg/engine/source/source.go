@@ -27,9 +27,11 @@ type ExcludeQueries struct {
 	BySeverities []string
 }
 
-// IncludeQueries is a struct that represents the option to include queries by ID taking precedence over exclusion
+// IncludeQueries is a struct that represents the option to include queries by ID taking precedence over exclusion,
+// when categories are given only the queries of those categories are loaded
 type IncludeQueries struct {
-	ByIDs []string
+	ByIDs        []string
+	ByCategories []string
 }
 
 // RegoLibraries is a struct that contains the library code and its input data
@@ -0,0 +1,47 @@
+package source
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/Checkmarx/kics/assets"
+	"github.com/rs/zerolog/log"
+)
+
+// versionedLibrariesDir is the directory of the libraries with the versions of the embedded libraries
+const versionedLibrariesDir = "versions"
+
+// versionedImportRegex matches the references to the versioned libraries, data.generic.<version>.<library>
+var versionedImportRegex = regexp.MustCompile(`data\.generic\.(v[0-9]+)\.([a-z0-9]+)`)
+
+// VersionedLibrary is a version of an embedded library imported by a query, its functions delegate to the
+// library of the KICS release and keep the signature and the behaviour of the version
+type VersionedLibrary struct {
+	Name string
+	Code string
+}
+
+// GetVersionedLibraries returns the versions of the embedded libraries the query imports, sorted by name, the
+// versions that do not exist are left to the compilation of the query to report
+func GetVersionedLibraries(queryContent string) []VersionedLibrary {
+	found := make(map[string]VersionedLibrary)
+	for _, match := range versionedImportRegex.FindAllStringSubmatch(queryContent, -1) {
+		name := match[1] + "/" + match[2]
+		if _, ok := found[name]; ok {
+			continue
+		}
+		code, err := assets.GetEmbeddedVersionedLibrary(match[1], match[2])
+		if err != nil {
+			log.Debug().Msgf("Library %s is not embedded: %s", name, err)
+			continue
+		}
+		found[name] = VersionedLibrary{Name: name, Code: code}
+	}
+
+	libraries := make([]VersionedLibrary, 0, len(found))
+	for _, library := range found {
+		libraries = append(libraries, library)
+	}
+	sort.Slice(libraries, func(i, j int) bool { return libraries[i].Name < libraries[j].Name })
+	return libraries
+}
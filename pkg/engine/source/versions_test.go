@@ -0,0 +1,90 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Checkmarx/kics/assets"
+	"github.com/Checkmarx/kics/test"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVersionedLibraries_Compatibility tests every versioned library compiles with the libraries of the
+// release, it fails when a function the versions delegate to is renamed, removed or changes its arity
+func TestVersionedLibraries_Compatibility(t *testing.T) {
+	if err := test.ChangeCurrentDir("kics"); err != nil {
+		t.Fatal(err)
+	}
+	versionsDir := filepath.Join("assets", "libraries", versionedLibrariesDir)
+	versions, err := os.ReadDir(versionsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+
+	common, err := assets.GetEmbeddedLibrary("common")
+	require.NoError(t, err)
+	for _, version := range versions {
+		files, err := filepath.Glob(filepath.Join(versionsDir, version.Name(), "*.rego"))
+		require.NoError(t, err)
+		for _, file := range files {
+			platform := strings.TrimSuffix(filepath.Base(file), ".rego")
+			t.Run(version.Name()+"/"+platform, func(t *testing.T) {
+				code, err := assets.GetEmbeddedVersionedLibrary(version.Name(), platform)
+				require.NoError(t, err)
+				library, err := assets.GetEmbeddedLibrary(platform)
+				require.NoError(t, err)
+
+				_, err = ast.CompileModules(map[string]string{
+					"common.rego":    common,
+					"library.rego":   library,
+					"versioned.rego": code,
+				})
+				require.NoError(t, err)
+			})
+		}
+	}
+}
+
+// TestGetVersionedLibraries tests the versioned libraries imported by a query are evaluated with it
+func TestGetVersionedLibraries(t *testing.T) {
+	query := `package Cx
+
+import data.generic.v1.common as common_lib
+import data.generic.v1.terraform as tf_lib
+import data.generic.v1.unknown
+
+CxPolicy[result] {
+	common_lib.valid_key(input.document, "name")
+	tf_lib.check_cidr({"cidr_blocks": ["0.0.0.0/0"]})
+	result := {"name": input.document.name}
+}
+`
+	libraries := GetVersionedLibraries(query)
+	require.Len(t, libraries, 2)
+	require.Equal(t, "v1/common", libraries[0].Name)
+	require.Equal(t, "v1/terraform", libraries[1].Name)
+	require.Empty(t, GetVersionedLibraries("package Cx\n\nimport data.generic.common as common_lib\n"))
+
+	common, err := assets.GetEmbeddedLibrary("common")
+	require.NoError(t, err)
+	terraform, err := assets.GetEmbeddedLibrary("terraform")
+	require.NoError(t, err)
+	options := []func(*rego.Rego){
+		rego.Query("data.Cx.CxPolicy"),
+		rego.Module("Common", common),
+		rego.Module("Generic", terraform),
+		rego.Module("query", query),
+		rego.Input(map[string]interface{}{"document": map[string]interface{}{"name": "bucket"}}),
+	}
+	for _, library := range libraries {
+		options = append(options, rego.Module(library.Name, library.Code))
+	}
+	results, err := rego.New(options...).Eval(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Expressions[0].Value, 1)
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// Justifications of the suppressed results
+const (
+	excludedResultJustification = "excluded by its similarity ID"
+	excludedKeyJustification    = "excluded by the search key pattern %s"
+	ignoredLineJustification    = "ignored by a kics-scan comment"
+	ignoredRegionJustification  = "ignored by the kics-scan region-ignore comment of lines %d to %d"
+	disabledQueryJustification  = "query disabled by a kics-scan comment"
+)
+
+// suppressedResults keeps the results not reported because of a suppression, it is safe for concurrent use
+type suppressedResults struct {
+	mu              sync.Mutex
+	vulnerabilities []model.Vulnerability
+	suppressions    map[string]model.Suppression
+}
+
+func (s *suppressedResults) add(vulnerability *model.Vulnerability, kind, justification string) {
+	if s == nil || vulnerability.Line == UndetectedVulnerabilityLine {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vulnerabilities = append(s.vulnerabilities, *vulnerability)
+	s.suppressions[vulnerability.SimilarityID] = model.Suppression{
+		Kind:          kind,
+		Justification: justification,
+	}
+}
+
+// KeepSuppressedResults keeps the results suppressed by an excluded similarity ID or a kics-scan comment
+// instead of discarding them, so they can be reported as suppressed
+func (c *Inspector) KeepSuppressedResults() {
+	c.suppressed = &suppressedResults{
+		suppressions: make(map[string]model.Suppression),
+	}
+}
+
+// GetSuppressedResults returns the suppressed results and their suppressions by similarity ID
+func (c *Inspector) GetSuppressedResults() ([]model.Vulnerability, map[string]model.Suppression) {
+	if c.suppressed == nil {
+		return nil, nil
+	}
+	c.suppressed.mu.Lock()
+	defer c.suppressed.mu.Unlock()
+	return c.suppressed.vulnerabilities, c.suppressed.suppressions
+}
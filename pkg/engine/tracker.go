@@ -1,14 +1,20 @@
 package engine
 
+import "github.com/Checkmarx/kics/pkg/model"
+
 // Tracker wraps an interface that contain basic methods: TrackQueryLoad, TrackQueryExecution and FailedDetectLine
 // TrackQueryLoad increments the number of loaded queries
 // TrackQueryExecution increments the number of queries executed
+// TrackQuerySkipped keeps the queries not executed because the scan timeout expired
+// TrackQueryFailure keeps the errors of the queries that failed to execute
 // FailedDetectLine decrements the number of queries executed
 // GetOutputLines returns the number of lines to be displayed in results outputs
 type Tracker interface {
 	TrackQueryLoad(queryAggregation int)
 	TrackQueryExecuting(queryAggregation int)
 	TrackQueryExecution(queryAggregation int)
+	TrackQuerySkipped(query model.SkippedQuery, queryAggregation int)
+	TrackQueryFailure(failure model.FailedQuery)
 	TrackScanPath()
 	TrackScanSecret()
 	FailedDetectLine()
@@ -0,0 +1,125 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// runCommand runs a command of the CLI of a cloud provider and returns its output
+var runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output() //nolint:gosec
+}
+
+// cloudAccount is the account of the credentials of a cloud provider CLI
+type cloudAccount struct {
+	id         string
+	attributes map[string]string
+}
+
+// cloudEnricher sets the account of the credentials of the cloud provider CLI on the results of the queries of the
+// cloud provider, the account is read once per scan
+type cloudEnricher struct {
+	provider string
+	read     func(ctx context.Context) (*cloudAccount, error)
+	once     sync.Once
+	account  *cloudAccount
+	err      error
+}
+
+// newCloudEnricher returns the factory of the enricher of the cloud provider, named after it
+func newCloudEnricher(provider string, read func(ctx context.Context) (*cloudAccount, error)) Factory {
+	return func() Enricher {
+		return &cloudEnricher{provider: provider, read: read}
+	}
+}
+
+func (e *cloudEnricher) Name() string {
+	return e.provider
+}
+
+func (e *cloudEnricher) Supports(cloudProvider string) bool {
+	return strings.EqualFold(cloudProvider, e.provider)
+}
+
+func (e *cloudEnricher) Enrich(ctx context.Context, _ *model.QueryResult, _ *model.VulnerableFile,
+	enrichment *model.Enrichment) error {
+	e.once.Do(func() {
+		if e.account, e.err = e.read(ctx); e.err != nil {
+			log.Warn().Msgf("Failed to read the %s account, the results are not enriched with it: %s", e.provider, e.err)
+		}
+	})
+	if e.err != nil {
+		return e.err
+	}
+	enrichment.AccountID = e.account.id
+	if len(e.account.attributes) > 0 && enrichment.Attributes == nil {
+		enrichment.Attributes = make(map[string]string, len(e.account.attributes))
+	}
+	for key, value := range e.account.attributes {
+		enrichment.Attributes[key] = value
+	}
+	return nil
+}
+
+// readAWSAccount reads the account of the AWS credentials with aws sts get-caller-identity
+func readAWSAccount(ctx context.Context) (*cloudAccount, error) {
+	var identity struct {
+		Account string `json:"Account"`
+		Arn     string `json:"Arn"`
+	}
+	if err := runJSONCommand(ctx, &identity, "aws", "sts", "get-caller-identity", "--output", "json"); err != nil {
+		return nil, err
+	}
+	return &cloudAccount{id: identity.Account, attributes: map[string]string{"aws_caller_arn": identity.Arn}}, nil
+}
+
+// readAzureAccount reads the subscription of the Azure credentials with az account show
+func readAzureAccount(ctx context.Context) (*cloudAccount, error) {
+	var account struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		TenantID string `json:"tenantId"`
+	}
+	if err := runJSONCommand(ctx, &account, "az", "account", "show", "--output", "json"); err != nil {
+		return nil, err
+	}
+	return &cloudAccount{
+		id: account.ID,
+		attributes: map[string]string{
+			"azure_subscription_name": account.Name,
+			"azure_tenant_id":         account.TenantID,
+		},
+	}, nil
+}
+
+// readGCPAccount reads the project of the gcloud configuration with gcloud config get-value project
+func readGCPAccount(ctx context.Context) (*cloudAccount, error) {
+	output, err := runCommand(ctx, "gcloud", "config", "get-value", "project", "--quiet")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run gcloud: %w", err)
+	}
+	project := strings.TrimSpace(string(output))
+	if project == "" {
+		return nil, fmt.Errorf("no project is set in the gcloud configuration")
+	}
+	return &cloudAccount{id: project}, nil
+}
+
+// runJSONCommand runs a command of the CLI of a cloud provider and decodes its JSON output
+func runJSONCommand(ctx context.Context, target interface{}, name string, args ...string) error {
+	output, err := runCommand(ctx, name, args...)
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	if err := json.Unmarshal(output, target); err != nil {
+		return fmt.Errorf("failed to decode the output of %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package enrichment enriches the results with live cloud context, such as the account the resources are deployed
+// to, to help prioritize them
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// Timeout is the maximum duration of the enrichment of the results of a scan
+const Timeout = time.Minute
+
+// Enricher adds the live context of a cloud provider to the results, the enrichers are registered by name and
+// selected for a scan with the enrichers parameter
+type Enricher interface {
+	// Name is the name listed in the enrichment of the results
+	Name() string
+	// Supports returns true when the enricher applies to the results of the queries of the cloud provider
+	Supports(cloudProvider string) bool
+	// Enrich sets the context of the result in the enrichment, an error only skips the enricher for this result
+	Enrich(ctx context.Context, query *model.QueryResult, result *model.VulnerableFile, enrichment *model.Enrichment) error
+}
+
+// Factory creates an enricher for a scan, so the context an enricher caches is not shared between scans
+type Factory func() Enricher
+
+// factories are the registered enrichers by name, the built-in enrichers read the account of the cloud provider CLIs
+var factories = map[string]Factory{
+	"aws":   newCloudEnricher("aws", readAWSAccount),
+	"azure": newCloudEnricher("azure", readAzureAccount),
+	"gcp":   newCloudEnricher("gcp", readGCPAccount),
+}
+
+// Register registers the enricher factory with the name, replacing the enricher previously registered with it
+func Register(name string, factory Factory) {
+	factories[strings.ToLower(name)] = factory
+}
+
+// List returns the names of the registered enrichers, sorted
+func List() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Create creates the registered enrichers with the names, an error is returned for an unknown name
+func Create(names []string) ([]Enricher, error) {
+	selected := make([]Enricher, 0, len(names))
+	for _, name := range names {
+		factory, ok := factories[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown enricher '%s', available enrichers: %s", name, strings.Join(List(), ", "))
+		}
+		selected = append(selected, factory())
+	}
+	return selected, nil
+}
+
+// Enrich enriches the results of the summary with the enrichers supporting the cloud provider of their query, the
+// results without context are left without enrichment
+func Enrich(ctx context.Context, summary *model.Summary, selected []Enricher) {
+	if len(selected) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	enriched := 0
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		for j := range query.Files {
+			enrichment := &model.Enrichment{Enrichers: make([]string, 0)}
+			for _, enricher := range selected {
+				if ctx.Err() != nil {
+					log.Warn().Msgf("The enrichment timeout of %s expired, the remaining results are not enriched", Timeout)
+					return
+				}
+				if !enricher.Supports(query.CloudProvider) {
+					continue
+				}
+				if err := enricher.Enrich(ctx, query, &query.Files[j], enrichment); err != nil {
+					log.Debug().Msgf("Failed to enrich the result %s with %s: %s", query.Files[j].SimilarityID, enricher.Name(), err)
+					continue
+				}
+				enrichment.Enrichers = append(enrichment.Enrichers, enricher.Name())
+			}
+			if len(enrichment.Enrichers) > 0 {
+				query.Files[j].Enrichment = enrichment
+				enriched++
+			}
+		}
+	}
+	log.Info().Msgf("Enriched %d results", enriched)
+}
@@ -0,0 +1,102 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type existenceEnricher struct{}
+
+func (e *existenceEnricher) Name() string {
+	return "inventory"
+}
+
+func (e *existenceEnricher) Supports(cloudProvider string) bool {
+	return cloudProvider == "AWS"
+}
+
+func (e *existenceEnricher) Enrich(_ context.Context, _ *model.QueryResult, result *model.VulnerableFile,
+	enrichment *model.Enrichment) error {
+	if result.ResourceName == "" {
+		return errors.New("unknown resource")
+	}
+	exists := result.ResourceName == "logs"
+	enrichment.ResourceExists = &exists
+	return nil
+}
+
+func stubCommands(t *testing.T, outputs map[string]string) {
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	runCommand = func(_ context.Context, name string, _ ...string) ([]byte, error) {
+		output, ok := outputs[name]
+		if !ok {
+			return nil, errors.New("executable file not found in $PATH")
+		}
+		return []byte(output), nil
+	}
+}
+
+func TestCreate(t *testing.T) {
+	Register("inventory", func() Enricher { return &existenceEnricher{} })
+	t.Cleanup(func() { delete(factories, "inventory") })
+
+	require.Equal(t, []string{"aws", "azure", "gcp", "inventory"}, List())
+
+	selected, err := Create([]string{"AWS", " inventory"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.Equal(t, "aws", selected[0].Name())
+
+	_, err = Create([]string{"oci"})
+	require.EqualError(t, err, "unknown enricher 'oci', available enrichers: aws, azure, gcp, inventory")
+}
+
+func TestEnrich(t *testing.T) {
+	stubCommands(t, map[string]string{
+		"aws":    `{"UserId": "AIDA", "Account": "123456789012", "Arn": "arn:aws:iam::123456789012:user/ci"}`,
+		"gcloud": "my-project\n",
+	})
+
+	summary := model.Summary{
+		Queries: model.QueryResultSlice{
+			{
+				CloudProvider: "AWS",
+				Files: []model.VulnerableFile{
+					{ResourceName: "logs"},
+					{ResourceName: "assets"},
+					{},
+				},
+			},
+			{CloudProvider: "GCP", Files: []model.VulnerableFile{{ResourceName: "bucket"}}},
+			{CloudProvider: "AZURE", Files: []model.VulnerableFile{{ResourceName: "account"}}},
+			{CloudProvider: "COMMON", Files: []model.VulnerableFile{{ResourceName: "pod"}}},
+		},
+	}
+
+	selected, err := Create([]string{"aws", "gcp", "azure"})
+	require.NoError(t, err)
+	Enrich(context.Background(), &summary, append(selected, &existenceEnricher{}))
+
+	exists, missing := true, false
+	awsAttributes := map[string]string{"aws_caller_arn": "arn:aws:iam::123456789012:user/ci"}
+	require.Equal(t, &model.Enrichment{
+		Enrichers:      []string{"aws", "inventory"},
+		AccountID:      "123456789012",
+		ResourceExists: &exists,
+		Attributes:     awsAttributes,
+	}, summary.Queries[0].Files[0].Enrichment)
+	require.Equal(t, &missing, summary.Queries[0].Files[1].Enrichment.ResourceExists)
+	require.Equal(t, []string{"aws"}, summary.Queries[0].Files[2].Enrichment.Enrichers)
+	require.Equal(t, &model.Enrichment{
+		Enrichers: []string{"gcp"},
+		AccountID: "my-project",
+	}, summary.Queries[1].Files[0].Enrichment)
+	// the az command is not available so the results of azure are not enriched
+	require.Nil(t, summary.Queries[2].Files[0].Enrichment)
+	require.Nil(t, summary.Queries[3].Files[0].Enrichment)
+}
@@ -0,0 +1,235 @@
+// Package generated detects the generated and vendored files, whose results are noise for the owners of a repository
+package generated
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+const (
+	gitAttributesFile = ".gitattributes"
+	// headerLines is the number of lines of a file searched for a generated code marker
+	headerLines = 5
+)
+
+var (
+	// vendoredDirs are the directories holding third party or downloaded code
+	vendoredDirs = map[string]bool{
+		"vendor":       true,
+		"node_modules": true,
+		".terraform":   true,
+	}
+
+	// markerRegex matches the comments of the generated files, e.g. "Code generated by tool. DO NOT EDIT."
+	markerRegex = regexp.MustCompile(`(?i)(\bcode generated\b|\bauto-?generated\b|@generated\b|\bdo not edit\b)`)
+
+	// linguistAttributes are the .gitattributes attributes marking a file as generated or vendored
+	linguistAttributes = []string{"linguist-generated", "linguist-vendored"}
+)
+
+// attributeRule sets, or unsets, the generated attribute of the files matching a .gitattributes pattern
+type attributeRule struct {
+	matcher   *ignore.GitIgnore
+	generated bool
+}
+
+// attributes contains the linguist rules of the .gitattributes file of a root directory, the last matching
+// rule takes precedence
+type attributes struct {
+	root  string
+	rules []attributeRule
+}
+
+// Detector detects the generated files of the scanned paths, by their directory, the linguist attributes of the
+// .gitattributes file of the scanned paths and the marker comment of their first lines
+type Detector struct {
+	roots      []string
+	attributes []attributes
+	mutex      sync.Mutex
+	cache      map[string]bool
+}
+
+// NewDetector creates a detector of the generated files of the paths, loading their .gitattributes files
+func NewDetector(paths []string) *Detector {
+	d := &Detector{
+		roots:      make([]string, 0),
+		attributes: make([]attributes, 0),
+		cache:      make(map[string]bool),
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		root, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		d.roots = append(d.roots, root)
+		rules := loadAttributes(filepath.Join(root, gitAttributesFile))
+		if len(rules) > 0 {
+			log.Debug().Msgf("Loaded %d linguist rules from the .gitattributes file of %s", len(rules), path)
+			d.attributes = append(d.attributes, attributes{root: root, rules: rules})
+		}
+	}
+	return d
+}
+
+// loadAttributes reads the rules of the .gitattributes file setting or unsetting a linguist attribute
+func loadAttributes(path string) []attributeRule {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil
+	}
+	rules := make([]attributeRule, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attribute := range fields[1:] {
+			if generated, ok := linguistValue(attribute); ok {
+				rules = append(rules, attributeRule{matcher: ignore.CompileIgnoreLines(fields[0]), generated: generated})
+			}
+		}
+	}
+	return rules
+}
+
+// linguistValue returns the value of a linguist attribute, "attr" and "attr=true" set it while "-attr" and
+// "attr=false" unset it
+func linguistValue(attribute string) (value, ok bool) {
+	name, setting, hasValue := strings.Cut(attribute, "=")
+	unset := strings.HasPrefix(name, "-")
+	name = strings.TrimPrefix(name, "-")
+	for _, linguist := range linguistAttributes {
+		if name != linguist {
+			continue
+		}
+		if unset {
+			return false, true
+		}
+		return !hasValue || strings.EqualFold(setting, "true"), true
+	}
+	return false, false
+}
+
+// IsVendoredDir returns true when the directory holds third party or downloaded code
+func IsVendoredDir(name string) bool {
+	return vendoredDirs[name]
+}
+
+// IsGenerated returns true when the file is inside a vendored directory, is marked as generated or vendored by a
+// .gitattributes file or has a generated code marker in its first lines
+func (d *Detector) IsGenerated(path string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	d.mutex.Lock()
+	generated, found := d.cache[absPath]
+	d.mutex.Unlock()
+	if found {
+		return generated
+	}
+
+	generated = d.inVendoredDir(absPath)
+	if attributeSet, matched := d.attributeValue(absPath); matched {
+		generated = attributeSet
+	} else if !generated {
+		generated = hasMarker(absPath)
+	}
+
+	d.mutex.Lock()
+	d.cache[absPath] = generated
+	d.mutex.Unlock()
+	return generated
+}
+
+// inVendoredDir returns true when a directory of the path, below the scanned path holding it, is a vendored
+// directory, so scanning a project inside a vendored directory does not mark all its files
+func (d *Detector) inVendoredDir(path string) bool {
+	dir := filepath.Dir(path)
+	for _, root := range d.roots {
+		if relPath, ok := relativePath(root, dir); ok {
+			dir = relPath
+			break
+		}
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(dir), "/") {
+		if IsVendoredDir(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeValue returns the value of the last linguist rule matching the file, matched is false when no rule
+// of the .gitattributes files matches it
+func (d *Detector) attributeValue(path string) (value, matched bool) {
+	for _, attrs := range d.attributes {
+		relPath, ok := relativePath(attrs.root, path)
+		if !ok {
+			continue
+		}
+		relPath = filepath.ToSlash(relPath)
+		for i := len(attrs.rules) - 1; i >= 0; i-- {
+			if attrs.rules[i].matcher.MatchesPath(relPath) {
+				return attrs.rules[i].generated, true
+			}
+		}
+	}
+	return false, false
+}
+
+// relativePath returns the path relative to the root, ok is false when the path is outside of the root
+func relativePath(root, path string) (relPath string, ok bool) {
+	relPath, err := filepath.Rel(root, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return relPath, true
+}
+
+// hasMarker returns true when one of the first lines of the file has a generated code marker
+func hasMarker(path string) bool {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Err(err).Msgf("Failed to close file %s", path)
+		}
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < headerLines && scanner.Scan(); i++ {
+		if markerRegex.MatchString(scanner.Text()) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGenerated marks the results of the summary found in generated files
+func (d *Detector) SetGenerated(summary *model.Summary) {
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			fileName := summary.Queries[i].Files[j].FileName
+			if originalPath, ok := summary.FilePaths[fileName]; ok {
+				fileName = originalPath
+			}
+			summary.Queries[i].Files[j].Generated = d.IsGenerated(fileName)
+		}
+	}
+}
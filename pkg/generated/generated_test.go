@@ -0,0 +1,91 @@
+package generated
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestDetector_IsGenerated(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "vendor", "project")
+	writeFiles(t, root, map[string]string{
+		".gitattributes": "# generated manifests\n" +
+			"manifests/*.yaml linguist-generated\n" +
+			"manifests/kept.yaml -linguist-generated\n" +
+			"third_party/** linguist-vendored=true\n" +
+			"docs/** linguist-documentation\n",
+		"main.tf":                       "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"modules/vendor/main.tf":        "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"node_modules/pkg/values.yaml":  "key: value\n",
+		".terraform/modules/m/main.tf":  "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"manifests/deployment.yaml":     "kind: Deployment\n",
+		"manifests/kept.yaml":           "kind: Deployment\n",
+		"third_party/chart/values.yaml": "key: value\n",
+		"docs/sample.tf":                "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"gen/go.tf":                     "// Code generated by cdktf. DO NOT EDIT.\nresource \"aws_s3_bucket\" \"b\" {}\n",
+		"gen/auto.yaml":                 "# This file is autogenerated\nkey: value\n",
+		"gen/late.yaml":                 "a: 1\nb: 2\nc: 3\nd: 4\ne: 5\n# @generated\n",
+	})
+
+	tests := []struct {
+		name string
+		file string
+		want bool
+	}{
+		{name: "file written by hand", file: "main.tf", want: false},
+		{name: "file of a vendor directory", file: "modules/vendor/main.tf", want: true},
+		{name: "file of a node_modules directory", file: "node_modules/pkg/values.yaml", want: true},
+		{name: "file of a .terraform directory", file: ".terraform/modules/m/main.tf", want: true},
+		{name: "file marked as generated", file: "manifests/deployment.yaml", want: true},
+		{name: "file unmarked by a later rule", file: "manifests/kept.yaml", want: false},
+		{name: "file marked as vendored", file: "third_party/chart/values.yaml", want: true},
+		{name: "file with another linguist attribute", file: "docs/sample.tf", want: false},
+		{name: "file with a code generated marker", file: "gen/go.tf", want: true},
+		{name: "file with an autogenerated marker", file: "gen/auto.yaml", want: true},
+		{name: "file with a marker after the header", file: "gen/late.yaml", want: false},
+	}
+
+	d := NewDetector([]string{root})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, d.IsGenerated(filepath.Join(root, filepath.FromSlash(tt.file))))
+		})
+	}
+}
+
+func TestDetector_SetGenerated(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.tf":        "resource \"aws_s3_bucket\" \"b\" {}\n",
+		"vendor/main.tf": "resource \"aws_s3_bucket\" \"b\" {}\n",
+	})
+
+	summary := model.Summary{
+		Queries: model.QueryResultSlice{
+			{
+				Files: []model.VulnerableFile{
+					{FileName: filepath.Join(root, "main.tf")},
+					{FileName: "extracted/vendor/main.tf"},
+				},
+			},
+		},
+		FilePaths: map[string]string{
+			"extracted/vendor/main.tf": filepath.Join(root, "vendor", "main.tf"),
+		},
+	}
+
+	NewDetector([]string{root}).SetGenerated(&summary)
+	require.False(t, summary.Queries[0].Files[0].Generated)
+	require.True(t, summary.Queries[0].Files[1].Generated)
+}
@@ -0,0 +1,68 @@
+package kics
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/analyzer"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/parser"
+	"github.com/pkg/errors"
+)
+
+var (
+	// errorLineRegexes match the line reported by the parsers error messages,
+	// e.g. "yaml: line 3: ..." and "main.tf:3,5-6: ..."
+	errorLineRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bline (\d+)`),
+		regexp.MustCompile(`:(\d+),\d+(-\d+(,\d+)?)?:`),
+	}
+)
+
+// trackParseFailure keeps the file that failed to be parsed or resolved, the platform it most likely
+// belongs to and the line that caused the failure, when known
+func (s *Service) trackParseFailure(filename string, err error, content []byte) {
+	if errors.Is(err, parser.ErrNotSupportedFile) {
+		return
+	}
+	line := getErrorLine(err, content)
+	s.Tracker.TrackFileParseFailure(model.ParseFailure{
+		FilePath: filename,
+		Platform: analyzer.GuessPlatform(filename),
+		Error:    err.Error(),
+		Line:     line,
+		Code:     getErrorCode(line, content),
+	})
+}
+
+// getErrorCode returns the content of the line that caused the error, trimmed of surrounding spaces
+func getErrorCode(line int, content []byte) string {
+	if line < 1 {
+		return ""
+	}
+	lines := strings.Split(string(content), "\n")
+	if line > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[line-1])
+}
+
+// getErrorLine returns the line that caused the error, -1 is returned when the line is unknown
+func getErrorLine(err error, content []byte) int {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset <= int64(len(content)) {
+		return bytes.Count(content[:syntaxErr.Offset], []byte{'\n'}) + 1
+	}
+
+	for _, regex := range errorLineRegexes {
+		if match := regex.FindStringSubmatch(err.Error()); match != nil {
+			if line, convErr := strconv.Atoi(match[1]); convErr == nil {
+				return line
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,63 @@
+package kics
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKics_getErrorLine(t *testing.T) {
+	jsonContent := []byte("{\n \"a\": 1,\n \"b\": \n}\n")
+	var document map[string]interface{}
+	jsonErr := json.Unmarshal(jsonContent, &document)
+
+	tests := []struct {
+		name    string
+		err     error
+		content []byte
+		want    int
+	}{
+		{
+			name:    "json syntax error",
+			err:     jsonErr,
+			content: jsonContent,
+			want:    4,
+		},
+		{
+			name: "yaml error",
+			err:  errors.New("failed to parse yaml: yaml: line 2: found character that cannot start any token"),
+			want: 2,
+		},
+		{
+			name: "hcl error in the same line",
+			err:  errors.New("main.tf:3,5-6: Unsupported argument; An argument named \"acl\" is not expected here."),
+			want: 3,
+		},
+		{
+			name: "hcl error across lines",
+			err:  errors.New("bad.tf:3,9-4,1: Invalid expression; Expected the start of an expression."),
+			want: 3,
+		},
+		{
+			name: "unknown line",
+			err:  errors.New("failed to resolve references"),
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getErrorLine(tt.err, tt.content))
+		})
+	}
+}
+
+func TestKics_getErrorCode(t *testing.T) {
+	content := []byte("resource \"aws_s3_bucket\" \"b\" {\n  bucket = \"x\"\n  acl = \n}\n")
+
+	require.Equal(t, "acl =", getErrorCode(3, content))
+	require.Equal(t, "", getErrorCode(-1, content))
+	require.Equal(t, "", getErrorCode(10, content))
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"sort"
 
 	sentryReport "github.com/Checkmarx/kics/internal/sentry"
@@ -24,6 +23,7 @@ func (s *Service) resolverSink(ctx context.Context, filename, scanID string, ope
 	resFiles, err := s.Resolver.Resolve(filename, kind)
 	if err != nil {
 		log.Err(err).Msgf("failed to render file content")
+		s.trackParseFailure(filename, err, nil)
 		return []string{}, err
 	}
 
@@ -37,22 +37,17 @@ func (s *Service) resolverSink(ctx context.Context, filename, scanID string, ope
 				return []string{}, nil
 			}
 			log.Err(err).Msgf("failed to parse file content")
+			s.trackParseFailure(rfile.FileName, err, rfile.Content)
 			return []string{}, nil
 		}
 
 		if kind == model.KindHELM {
-			ignoreList, errorIL := s.getOriginalIgnoreLines(rfile.FileName, rfile.OriginalData, openAPIResolveReferences, isMinified)
-			if errorIL == nil {
-				documents.IgnoreLines = ignoreList
-
-				// Need to ignore #KICS_HELM_ID Line
-				documents.CountLines = bytes.Count(rfile.OriginalData, []byte{'\n'})
-			}
-		} else {
-			documents.CountLines = bytes.Count(rfile.OriginalData, []byte{'\n'}) + 1
+			documents.IgnoreLines = mapHelmIgnoreLines(documents.IgnoreLines, rfile.LineMap)
 		}
+		documents.CountLines = bytes.Count(rfile.OriginalData, []byte{'\n'}) + 1
 
 		fileCommands := s.Parser.CommentsCommands(rfile.FileName, rfile.OriginalData)
+		ignoreRegions := model.GetIgnoreRegions(rfile.OriginalData)
 
 		for _, document := range documents.Docs {
 			_, err = json.Marshal(document)
@@ -80,10 +75,10 @@ func (s *Service) resolverSink(ctx context.Context, filename, scanID string, ope
 				Kind:              kind,
 				FilePath:          rfile.FileName,
 				Content:           string(rfile.Content),
-				HelmID:            rfile.SplitID,
+				HelmLineMap:       rfile.LineMap,
 				Commands:          fileCommands,
-				IDInfo:            rfile.IDInfo,
 				LinesIgnore:       documents.IgnoreLines,
+				IgnoreRegions:     ignoreRegions,
 				ResolvedFiles:     documents.ResolvedFiles,
 				LinesOriginalData: utils.SplitLines(string(rfile.OriginalData)),
 				IsMinified:        documents.IsMinified,
@@ -98,15 +93,16 @@ func (s *Service) resolverSink(ctx context.Context, filename, scanID string, ope
 	return resFiles.Excluded, nil
 }
 
-func (s *Service) getOriginalIgnoreLines(filename string,
-	originalFile []uint8,
-	openAPIResolveReferences, isMinified bool) (ignoreLines []int, err error) {
-	refactor := regexp.MustCompile(`.*\n?.*KICS_HELM_ID.+\n`).ReplaceAll(originalFile, []uint8{})
-	refactor = regexp.MustCompile(`{{-\s*(.*?)\s*}}`).ReplaceAll(refactor, []uint8{})
-
-	documentsOriginal, err := s.Parser.Parse(filename, refactor, openAPIResolveReferences, isMinified)
-	if err == nil {
-		ignoreLines = documentsOriginal.IgnoreLines
+// mapHelmIgnoreLines maps the lines to ignore of the rendered template to the template lines they were rendered from
+func mapHelmIgnoreLines(ignoreLines, lineMap []int) []int {
+	mapped := make([]int, 0, len(ignoreLines))
+	seen := make(map[int]bool, len(ignoreLines))
+	for _, line := range ignoreLines {
+		if line < 1 || line > len(lineMap) || lineMap[line-1] < 0 || seen[lineMap[line-1]] {
+			continue
+		}
+		seen[lineMap[line-1]] = true
+		mapped = append(mapped, lineMap[line-1]+1)
 	}
-	return
+	return mapped
 }
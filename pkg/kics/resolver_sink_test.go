@@ -184,3 +184,9 @@ func MockService(paths []string,
 		Resolver:         mockResolver,
 	}
 }
+
+func Test_MapHelmIgnoreLines(t *testing.T) {
+	lineMap := []int{-1, -1, 0, 1, 2, 3, 3, 3, 5}
+	require.Equal(t, []int{1, 4, 6}, mapHelmIgnoreLines([]int{1, 2, 3, 6, 7, 8, 9, 12}, lineMap))
+	require.Empty(t, mapHelmIgnoreLines([]int{1, 2}, lineMap))
+}
@@ -36,12 +36,16 @@ type Storage interface {
 	GetScanSummary(ctx context.Context, scanIDs []string) ([]model.SeveritySummary, error)
 }
 
-// Tracker is the interface that wraps the basic methods: TrackFileFound and TrackFileParse
+// Tracker is the interface that wraps the basic methods: TrackFileFound, TrackFileParse and TrackFileParseFailure
 // TrackFileFound should increment the number of files to be scanned
 // TrackFileParse should increment the number of files parsed successfully to be scanned
+// TrackFileParseFailure should keep the files that failed to be parsed or resolved
+// TrackFileSkipped should keep the files not parsed because the scan timeout expired
 type Tracker interface {
 	TrackFileFound(path string)
 	TrackFileParse(path string)
+	TrackFileParseFailure(failure model.ParseFailure)
+	TrackFileSkipped(path string)
 	TrackFileFoundCountLines(countLines int)
 	TrackFileParseCountLines(countLines int)
 	TrackFileIgnoreCountLines(countLines int)
@@ -145,8 +149,10 @@ func getContent(rc io.Reader, data []byte, maxSizeMB int, filename string) (*Con
 		CountLines: 0,
 	}
 
+	// a negative size sets no limit
+	limited := maxSizeMB >= 0
 	for {
-		if maxSizeMB < 0 {
+		if limited && maxSizeMB < 0 {
 			return c, errors.New("file size limit exceeded")
 		}
 		data = data[:cap(data)]
@@ -1,6 +1,7 @@
 package kics
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
@@ -20,6 +21,7 @@ import (
 	yamlParser "github.com/Checkmarx/kics/pkg/parser/yaml"
 	"github.com/Checkmarx/kics/pkg/resolver"
 	"github.com/Checkmarx/kics/pkg/resolver/helm"
+	"github.com/stretchr/testify/require"
 )
 
 // TestService tests the functions [GetVulnerabilities(), GetScanSummary(),StartScan()] and all the methods called by them
@@ -158,3 +160,19 @@ func createParserSourceProvider(path string) ([]*parser.Parser,
 
 	return mockParser, mockFilesSource, mockResolver
 }
+
+func TestService_GetContent(t *testing.T) {
+	content := bytes.Repeat([]byte("a: b\n"), mbConst/2)
+	data := make([]byte, mbConst)
+
+	_, err := getContent(bytes.NewReader(content), data, 1, "big.yaml")
+	require.Error(t, err)
+
+	got, err := getContent(bytes.NewReader(content), data, 3, "big.yaml")
+	require.NoError(t, err)
+	require.Equal(t, content, *got.Content)
+
+	got, err = getContent(bytes.NewReader(content), data, -1, "big.yaml")
+	require.NoError(t, err)
+	require.Equal(t, content, *got.Content)
+}
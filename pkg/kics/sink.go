@@ -29,6 +29,11 @@ var (
 func (s *Service) sink(ctx context.Context, filename, scanID string,
 	rc io.Reader, data []byte,
 	openAPIResolveReferences bool) error {
+	// the scan timeout expired, the remaining files are not parsed
+	if ctx.Err() != nil {
+		s.Tracker.TrackFileSkipped(filename)
+		return nil
+	}
 	s.Tracker.TrackFileFound(filename)
 	log.Debug().Msgf("Starting to process file %s", filename)
 
@@ -45,6 +50,7 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 	documents, err := s.Parser.Parse(filename, *content, openAPIResolveReferences, c.IsMinified)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse file content: %s", filename)
+		s.trackParseFailure(filename, err, *content)
 		return nil
 	}
 
@@ -57,6 +63,7 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 	s.Tracker.TrackFileFoundCountLines(linesResolved)
 
 	fileCommands := s.Parser.CommentsCommands(filename, *content)
+	ignoreRegions := model.GetIgnoreRegions(*content)
 
 	for _, document := range documents.Docs {
 		_, err = json.Marshal(document)
@@ -85,6 +92,7 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 			FilePath:          filename,
 			Commands:          fileCommands,
 			LinesIgnore:       documents.IgnoreLines,
+			IgnoreRegions:     ignoreRegions,
 			ResolvedFiles:     documents.ResolvedFiles,
 			LinesOriginalData: utils.SplitLines(documents.Content),
 			IsMinified:        documents.IsMinified,
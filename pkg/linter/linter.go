@@ -0,0 +1,156 @@
+// Package linter implements static checks over KICS query directories (query.rego and metadata.json)
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Rules identifiers reported by the linter
+const (
+	RuleMissingFile          = "missing-file"
+	RuleMissingMetadataField = "missing-metadata-field"
+	RuleInvalidMetadataValue = "invalid-metadata-value"
+	RuleMetadataStyle        = "metadata-style"
+	RuleInvalidRego          = "invalid-rego"
+	RuleMissingResultField   = "missing-result-field"
+	RuleUnusedImport         = "unused-import"
+	RuleUnknownInputRef      = "unknown-input-reference"
+	RuleDeprecatedHelper     = "deprecated-helper"
+)
+
+// Issue represents a single problem found in a query directory
+type Issue struct {
+	Rule    string `json:"rule"`
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+	Fixable bool   `json:"fixable"`
+	Fixed   bool   `json:"fixed"`
+}
+
+// Report contains all issues found by the linter and how many queries were checked
+type Report struct {
+	Queries int     `json:"queries"`
+	Issues  []Issue `json:"issues"`
+}
+
+// Remaining returns the number of issues that were not fixed
+func (r *Report) Remaining() int {
+	remaining := 0
+	for i := range r.Issues {
+		if !r.Issues[i].Fixed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// fix is a text transformation that resolves an issue when autofix is enabled
+type fix func(content string) string
+
+// finding wraps an issue and its optional fix
+type finding struct {
+	issue Issue
+	fix   fix
+}
+
+// Lint walks queriesPath looking for query directories and checks each one of them,
+// when autoFix is set, fixable issues are resolved and the files are rewritten
+func Lint(queriesPath string, autoFix bool) (*Report, error) {
+	queryDirs, err := getQueryDirs(queriesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Queries: len(queryDirs),
+		Issues:  make([]Issue, 0),
+	}
+
+	for _, queryDir := range queryDirs {
+		issues, err := lintFile(filepath.Join(queryDir, source.MetadataFileName), autoFix, lintMetadata)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, issues...)
+
+		issues, err = lintFile(filepath.Join(queryDir, source.QueryFileName), autoFix, lintRego)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, issues...)
+	}
+
+	return report, nil
+}
+
+func getQueryDirs(queriesPath string) ([]string, error) {
+	queryDirs := make([]string, 0)
+	err := filepath.Walk(queriesPath, func(p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !f.IsDir() && f.Name() == source.QueryFileName {
+			queryDirs = append(queryDirs, filepath.Dir(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get query directories")
+	}
+	sort.Strings(queryDirs)
+	return queryDirs, nil
+}
+
+func lintFile(path string, autoFix bool, check func(path, content string) []finding) ([]Issue, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Issue{{
+				Rule:    RuleMissingFile,
+				File:    path,
+				Message: "file not found",
+			}}, nil
+		}
+		return nil, err
+	}
+
+	findings := check(path, string(content))
+	issues := make([]Issue, 0, len(findings))
+	fixed := string(content)
+	for i := range findings {
+		if autoFix && findings[i].fix != nil {
+			fixed = findings[i].fix(fixed)
+			findings[i].issue.Fixed = true
+		}
+		issues = append(issues, findings[i].issue)
+	}
+
+	if fixed != string(content) {
+		log.Debug().Msgf("Writing fixes to %s", path)
+		if err := os.WriteFile(path, []byte(fixed), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+func newFinding(rule, path string, line int, message string, f fix) finding {
+	return finding{
+		issue: Issue{
+			Rule:    rule,
+			File:    path,
+			Line:    line,
+			Message: message,
+			Fixable: f != nil,
+		},
+		fix: f,
+	}
+}
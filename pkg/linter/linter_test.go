@@ -0,0 +1,168 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lintTestMetadata = `{
+  "id": "0afa6ab8-a047-48cf-be07-93a2f8c34cf7",
+  "queryName": "ALB Is Not Integrated With WAF.",
+  "severity": "medium",
+  "category": "Networking and Firewall",
+  "descriptionText": "All Application Load Balancers (ALB) must be protected with Web Application Firewall (WAF) service",
+  "descriptionUrl": "https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/wafregional_web_acl_association",
+  "platform": "Terraform",
+  "descriptionID": "4e4c668d",
+  "cloudProvider": "aws"
+}
+`
+	lintTestQuery = `package Cx
+
+import data.generic.terraform as tf_lib
+import data.generic.common as common_lib
+
+CxPolicy[result] {
+	resource := input.document[i].resource.aws_alb[name]
+	re_match("^internal", resource.name)
+
+	result := {
+		"documentId": input.document[i].id,
+		"searchKey": sprintf("aws_alb[%s]", [name]),
+		"keyExpectedValue": "expected",
+		"keyActualValue": input.foo,
+		"resourceName": tf_lib.get_resource_name(resource, name),
+	}
+}
+`
+)
+
+func writeLintTestQuery(t *testing.T) string {
+	dir := filepath.Join(t.TempDir(), "queries", "alb_is_not_integrated_with_waf")
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(lintTestMetadata), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "query.rego"), []byte(lintTestQuery), os.ModePerm))
+	return dir
+}
+
+func rulesOf(issues []Issue) map[string]int {
+	rules := make(map[string]int)
+	for i := range issues {
+		rules[issues[i].Rule]++
+	}
+	return rules
+}
+
+func TestLint(t *testing.T) {
+	dir := writeLintTestQuery(t)
+
+	report, err := Lint(filepath.Dir(dir), false)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Queries)
+	require.Equal(t, map[string]int{
+		RuleMetadataStyle:      2,
+		RuleMissingResultField: 1,
+		RuleUnusedImport:       1,
+		RuleUnknownInputRef:    1,
+		RuleDeprecatedHelper:   1,
+	}, rulesOf(report.Issues))
+	require.Equal(t, len(report.Issues), report.Remaining())
+}
+
+func TestLint_AutoFix(t *testing.T) {
+	dir := writeLintTestQuery(t)
+
+	report, err := Lint(filepath.Dir(dir), true)
+	require.NoError(t, err)
+	// missing issueType and input.foo can not be fixed automatically
+	require.Equal(t, 2, report.Remaining())
+
+	metadata, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(metadata), `"severity": "MEDIUM"`)
+	require.Contains(t, string(metadata), `"queryName": "ALB Is Not Integrated With WAF"`)
+
+	query, err := os.ReadFile(filepath.Join(dir, "query.rego"))
+	require.NoError(t, err)
+	require.NotContains(t, string(query), "common_lib")
+	require.Contains(t, string(query), `regex.match("^internal", resource.name)`)
+
+	report, err = Lint(filepath.Dir(dir), false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		RuleMissingResultField: 1,
+		RuleUnknownInputRef:    1,
+	}, rulesOf(report.Issues))
+}
+
+func TestLint_MissingFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "query")
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "query.rego"), []byte("package Cx\n\nCxPolicy[result] {\n"), os.ModePerm))
+
+	report, err := Lint(dir, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		RuleMissingFile: 1,
+		RuleInvalidRego: 1,
+	}, rulesOf(report.Issues))
+}
+
+func TestLint_DefaultQueries(t *testing.T) {
+	report, err := Lint(filepath.FromSlash("../../assets/queries/terraform/aws/alb_is_not_integrated_with_waf"), false)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Queries)
+	require.Empty(t, report.Issues)
+}
+
+func TestLintRego_InputReferences(t *testing.T) {
+	query := `package Cx
+
+CxPolicy[result] {
+	module := input.groups.terraform_modules[dir]
+	document := input.document[i]
+	input.files[j]
+
+	result := {
+		"documentId": document.id,
+		"searchKey": sprintf("module[%s]", [dir]),
+		"issueType": "MissingAttribute",
+		"keyActualValue": module,
+	}
+}
+`
+	findings := lintRego("query.rego", query)
+	require.Len(t, findings, 1)
+	require.Equal(t, RuleUnknownInputRef, findings[0].issue.Rule)
+	require.Contains(t, findings[0].issue.Message, "'input.files' is not provided by KICS")
+	require.Contains(t, findings[0].issue.Message, "input.document, input.groups")
+}
+
+func TestLintRego_ReplaceCall(t *testing.T) {
+	content := strings.Join([]string{
+		`safe_re_match(pattern, value) = re_match(pattern, value)`,
+		`CxPolicy[result] {`,
+		`	# re_match("^internal", name) is deprecated`,
+		`	re_match("^internal", input.name)`,
+		`	lib.re_match(input.name)`,
+		`	msg := "re_match(x) failed"`,
+		`	safe_re_match("^public", input.name)`,
+		`}`,
+	}, "\n")
+
+	require.Equal(t, strings.Join([]string{
+		`safe_re_match(pattern, value) = regex.match(pattern, value)`,
+		`CxPolicy[result] {`,
+		`	# re_match("^internal", name) is deprecated`,
+		`	regex.match("^internal", input.name)`,
+		`	lib.re_match(input.name)`,
+		`	msg := "re_match(x) failed"`,
+		`	safe_re_match("^public", input.name)`,
+		`}`,
+	}, "\n"), replaceCall("re_match", "regex.match")(content))
+}
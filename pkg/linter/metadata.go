@@ -0,0 +1,152 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+var (
+	validUUID = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-5][0-9a-f]{3}-[089ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	requiredMetadataFields = []string{
+		"id",
+		"queryName",
+		"severity",
+		"category",
+		"descriptionText",
+		"descriptionUrl",
+		"platform",
+		"descriptionID",
+	}
+)
+
+// lintMetadata checks the metadata.json of a query for missing fields, invalid values and style issues
+func lintMetadata(path, content string) []finding {
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &metadata); err != nil {
+		return []finding{newFinding(RuleInvalidMetadataValue, path, 0, fmt.Sprintf("failed to unmarshal metadata: %s", err), nil)}
+	}
+
+	findings := make([]finding, 0)
+
+	for _, field := range requiredMetadataFields {
+		if _, ok := metadata[field]; !ok {
+			findings = append(findings, newFinding(RuleMissingMetadataField, path, 0,
+				fmt.Sprintf("missing required field '%s'", field), nil))
+		}
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := metadata[key].(string)
+		if !ok {
+			continue
+		}
+		line := metadataFieldLine(content, key)
+		if trimmed := strings.TrimSpace(value); trimmed != value {
+			findings = append(findings, newFinding(RuleMetadataStyle, path, line,
+				fmt.Sprintf("field '%s' has leading or trailing whitespace", key), replaceMetadataValue(key, value, trimmed)))
+			value = trimmed
+		}
+		findings = append(findings, checkMetadataValue(path, line, key, value)...)
+	}
+
+	return findings
+}
+
+func checkMetadataValue(path string, line int, key, value string) []finding {
+	switch key {
+	case "id":
+		if !validUUID.MatchString(value) {
+			return []finding{newFinding(RuleInvalidMetadataValue, path, line, fmt.Sprintf("invalid UUID '%s'", value), nil)}
+		}
+	case "severity":
+		return checkEnumValue(path, line, key, value, severities())
+	case "category":
+		return checkEnumValue(path, line, key, value, mapKeys(constants.AvailableCategories))
+	case "platform":
+		return checkEnumValue(path, line, key, value, append(mapKeys(constants.AvailablePlatforms), "Common"))
+	case "queryName":
+		if strings.HasSuffix(value, ".") {
+			return []finding{newFinding(RuleMetadataStyle, path, line, "query name should not end with a period",
+				replaceMetadataValue(key, value, strings.TrimSuffix(value, ".")))}
+		}
+	case "descriptionText":
+		if value == "" {
+			return []finding{newFinding(RuleInvalidMetadataValue, path, line, "description text is empty", nil)}
+		}
+	}
+	return nil
+}
+
+// checkEnumValue validates value against an enum, values that only differ in case are reported as fixable
+func checkEnumValue(path string, line int, key, value string, allowed []string) []finding {
+	for _, a := range allowed {
+		if a == value {
+			return nil
+		}
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return []finding{newFinding(RuleMetadataStyle, path, line,
+				fmt.Sprintf("field '%s' should be written as '%s'", key, a), replaceMetadataValue(key, value, a))}
+		}
+	}
+	return []finding{newFinding(RuleInvalidMetadataValue, path, line, fmt.Sprintf("invalid %s '%s'", key, value), nil)}
+}
+
+// replaceMetadataValue returns a fix that rewrites a metadata string field keeping the file layout
+func replaceMetadataValue(key, oldValue, newValue string) fix {
+	return func(content string) string {
+		rgx := regexp.MustCompile(`("` + regexp.QuoteMeta(key) + `"\s*:\s*)` + regexp.QuoteMeta(encodeJSONString(oldValue)))
+		return rgx.ReplaceAllString(content, "${1}"+strings.ReplaceAll(encodeJSONString(newValue), "$", "$$"))
+	}
+}
+
+func encodeJSONString(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return value
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func metadataFieldLine(content, key string) int {
+	for idx, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, `"`+key+`"`) {
+			return idx + 1
+		}
+	}
+	return 0
+}
+
+func severities() []string {
+	values := make([]string, 0, len(model.AllSeverities))
+	for _, severity := range model.AllSeverities {
+		values = append(values, string(severity))
+	}
+	return values
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
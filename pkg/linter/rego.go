@@ -0,0 +1,265 @@
+package linter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/open-policy-agent/opa/ast"
+)
+
+const policyRuleName = "CxPolicy"
+
+var (
+	// requiredResultFields are the fields every CxPolicy result must provide
+	requiredResultFields = []string{
+		"documentId",
+		"searchKey",
+		"issueType",
+	}
+
+	// deprecatedReplacements maps deprecated built-ins to a drop-in replacement, used by autofix
+	deprecatedReplacements = map[string]string{
+		"re_match":         "regex.match",
+		"net.cidr_overlap": "net.cidr_contains",
+	}
+
+	// inputFields are the fields of the input given by KICS to the queries
+	inputFields = getInputFields()
+)
+
+// getInputFields returns the JSON names of the fields of model.Documents, the input of the queries
+func getInputFields() []string {
+	fields := make([]string, 0)
+	documentsType := reflect.TypeOf(model.Documents{})
+	for i := 0; i < documentsType.NumField(); i++ {
+		name := strings.Split(documentsType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isInputField(term *ast.Term) bool {
+	for _, field := range inputFields {
+		if term.Equal(ast.StringTerm(field)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintRego checks the query.rego of a query for the result contract, unused imports,
+// references to input fields not provided by KICS and deprecated built-ins usage
+func lintRego(path, content string) []finding {
+	module, err := ast.ParseModule(path, content)
+	if err != nil {
+		return []finding{newFinding(RuleInvalidRego, path, 0, fmt.Sprintf("failed to parse query: %s", err), nil)}
+	}
+
+	findings := make([]finding, 0)
+	findings = append(findings, checkResultFields(path, module)...)
+	findings = append(findings, checkUnusedImports(path, module)...)
+	findings = append(findings, checkInputReferences(path, module)...)
+	findings = append(findings, checkDeprecatedBuiltins(path, module)...)
+
+	return findings
+}
+
+func checkResultFields(path string, module *ast.Module) []finding {
+	findings := make([]finding, 0)
+	for _, rule := range module.Rules {
+		if rule.Head.Name.String() != policyRuleName {
+			continue
+		}
+		ast.WalkExprs(rule.Body, func(expr *ast.Expr) bool {
+			if !expr.IsAssignment() && !expr.IsEquality() {
+				return false
+			}
+			operands := expr.Operands()
+			if len(operands) != 2 || !operands[0].Equal(ast.VarTerm("result")) {
+				return false
+			}
+			object, ok := operands[1].Value.(ast.Object)
+			if !ok {
+				return false
+			}
+			for _, field := range requiredResultFields {
+				if object.Get(ast.StringTerm(field)) == nil {
+					findings = append(findings, newFinding(RuleMissingResultField, path, expr.Location.Row,
+						fmt.Sprintf("%s result is missing required field '%s'", policyRuleName, field), nil))
+				}
+			}
+			return false
+		})
+	}
+	return findings
+}
+
+func checkUnusedImports(path string, module *ast.Module) []finding {
+	used := make(map[string]bool)
+	for _, rule := range module.Rules {
+		ast.WalkRefs(rule, func(ref ast.Ref) bool {
+			used[ref[0].String()] = true
+			return false
+		})
+	}
+
+	findings := make([]finding, 0)
+	for _, imp := range module.Imports {
+		ref, ok := imp.Path.Value.(ast.Ref)
+		if !ok || !ref[0].Equal(ast.DefaultRootDocument) {
+			continue
+		}
+		name := imp.Name().String()
+		if used[name] {
+			continue
+		}
+		findings = append(findings, newFinding(RuleUnusedImport, path, imp.Location.Row,
+			fmt.Sprintf("import '%s' is not used", imp.Path.String()), removeImport(imp.Path.String())))
+	}
+	return findings
+}
+
+func checkInputReferences(path string, module *ast.Module) []finding {
+	findings := make([]finding, 0)
+	reported := make(map[string]bool)
+	for _, rule := range module.Rules {
+		ast.WalkRefs(rule, func(ref ast.Ref) bool {
+			if !ref[0].Equal(ast.InputRootDocument) || len(ref) < 2 {
+				return false
+			}
+			if isInputField(ref[1]) {
+				return false
+			}
+			field := ref[:2].String()
+			if !reported[field] {
+				reported[field] = true
+				findings = append(findings, newFinding(RuleUnknownInputRef, path, ref[0].Location.Row,
+					fmt.Sprintf("'%s' is not provided by KICS, queries should only reference input.%s",
+						field, strings.Join(inputFields, ", input.")), nil))
+			}
+			return false
+		})
+	}
+	return findings
+}
+
+func checkDeprecatedBuiltins(path string, module *ast.Module) []finding {
+	calls := make(map[string]int)
+	check := func(operator ast.Ref, row int) {
+		name := operator.String()
+		builtin, ok := ast.BuiltinMap[name]
+		if !ok || !builtin.IsDeprecated() {
+			return
+		}
+		if _, ok := calls[name]; !ok {
+			calls[name] = row
+		}
+	}
+
+	for _, rule := range module.Rules {
+		ast.WalkExprs(rule, func(expr *ast.Expr) bool {
+			if expr.IsCall() {
+				check(expr.Operator(), expr.Location.Row)
+			}
+			return false
+		})
+		ast.WalkTerms(rule, func(term *ast.Term) bool {
+			if call, ok := term.Value.(ast.Call); ok {
+				if operator, ok := call[0].Value.(ast.Ref); ok {
+					check(operator, term.Location.Row)
+				}
+			}
+			return false
+		})
+	}
+
+	names := make([]string, 0, len(calls))
+	for name := range calls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	findings := make([]finding, 0, len(names))
+	for _, name := range names {
+		message := fmt.Sprintf("built-in '%s' is deprecated", name)
+		var f fix
+		if replacement, ok := deprecatedReplacements[name]; ok {
+			message += fmt.Sprintf(", use '%s' instead", replacement)
+			f = replaceCall(name, replacement)
+		}
+		findings = append(findings, newFinding(RuleDeprecatedHelper, path, calls[name], message, f))
+	}
+	return findings
+}
+
+// removeImport returns a fix that removes the import statement of path
+func removeImport(path string) fix {
+	return func(content string) string {
+		lines := strings.Split(content, "\n")
+		for idx := range lines {
+			fields := strings.Fields(lines[idx])
+			if len(fields) > 1 && fields[0] == "import" && fields[1] == path {
+				return strings.Join(append(lines[:idx], lines[idx+1:]...), "\n")
+			}
+		}
+		return content
+	}
+}
+
+// replaceCall returns a fix that replaces every call of a built-in by another one, the names ending with the name of
+// the built-in, such as user functions or object methods, the comments and the strings are left untouched
+func replaceCall(name, replacement string) fix {
+	return func(content string) string {
+		var fixed strings.Builder
+		for idx := 0; idx < len(content); {
+			switch {
+			case content[idx] == '#':
+				end := strings.IndexByte(content[idx:], '\n')
+				if end < 0 {
+					end = len(content) - idx
+				}
+				fixed.WriteString(content[idx : idx+end])
+				idx += end
+			case content[idx] == '"' || content[idx] == '`':
+				end := stringEnd(content, idx)
+				fixed.WriteString(content[idx:end])
+				idx = end
+			case strings.HasPrefix(content[idx:], name+"(") && (idx == 0 || !isRefChar(content[idx-1])):
+				fixed.WriteString(replacement)
+				idx += len(name)
+			default:
+				fixed.WriteByte(content[idx])
+				idx++
+			}
+		}
+		return fixed.String()
+	}
+}
+
+// stringEnd returns the index following the string starting at start, which is a quoted string with escapes or a
+// raw string
+func stringEnd(content string, start int) int {
+	quote := content[start]
+	for idx := start + 1; idx < len(content); idx++ {
+		switch {
+		case quote == '"' && content[idx] == '\\':
+			idx++
+		case content[idx] == quote:
+			return idx + 1
+		}
+	}
+	return len(content)
+}
+
+// isRefChar returns true for the characters of the identifiers and the references, a name preceded by one of them
+// is not a call of the built-in
+func isRefChar(char byte) bool {
+	return char == '_' || char == '.' ||
+		char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z'
+}
@@ -0,0 +1,19 @@
+package model
+
+// Attestation contains the scan environment used to create the scan attestation,
+// it is only set when the attestation report is requested
+type Attestation struct {
+	Subjects        []AttestationSubject
+	QueriesPaths    []string
+	QueriesDigest   string
+	LibrariesPath   string
+	LibrariesDigest string
+	Flags           map[string]string
+	SigningKeyPath  string `json:"-"`
+}
+
+// AttestationSubject is a scanned path and the sha256 digest of its files
+type AttestationSubject struct {
+	Name   string
+	Digest string
+}
@@ -0,0 +1,30 @@
+package model
+
+// Suppression kinds, the results ignored by a kics-scan comment are suppressed in source while
+// the results excluded by their similarity ID are suppressed externally
+const (
+	SuppressionInSource = "inSource"
+	SuppressionExternal = "external"
+)
+
+// Suppression describes why a result is not reported
+type Suppression struct {
+	Kind          string
+	Justification string
+}
+
+// Baseline holds the results of a previous scan the current results are compared with
+type Baseline struct {
+	Results []BaselineResult
+}
+
+// BaselineResult is a result of a previous scan, Fingerprint is its similarity ID when known
+type BaselineResult struct {
+	Fingerprint string
+	RuleID      string
+	RuleName    string
+	Kind        string
+	FileName    string
+	Line        int
+	Message     string
+}
@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 // RemoveDuplicates removes duplicate lines from a slice of lines.
 func RemoveDuplicates(lines []int) []int {
 	seen := make(map[int]bool)
@@ -37,3 +39,93 @@ func Range(start, end int) (lines []int) {
 	}
 	return
 }
+
+// IgnoreRegion is a region of lines ignored by a kics-scan region-ignore comment, from the line of the comment to
+// the line of its region-ignore-end comment, a region without queries ignores the results of every query
+type IgnoreRegion struct {
+	QueryIDs []string
+	Start    int
+	End      int
+}
+
+// Ignores returns true when the result of the query at the line is in the region
+func (r *IgnoreRegion) Ignores(line int, queryID string) bool {
+	if line < r.Start || line > r.End {
+		return false
+	}
+	if len(r.QueryIDs) == 0 {
+		return true
+	}
+	for _, id := range r.QueryIDs {
+		if id == queryID {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchIgnoreRegion returns the first region ignoring the result of the query at the line
+func MatchIgnoreRegion(regions []IgnoreRegion, line int, queryID string) (*IgnoreRegion, bool) {
+	for i := range regions {
+		if regions[i].Ignores(line, queryID) {
+			return &regions[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetIgnoreRegions returns the regions of the kics-scan region-ignore comments of a file, written with a "#" or a
+// "//" comment token, as for generated sections of otherwise hand-written files:
+//
+//	# kics-scan region-ignore <query_id>,<query_id>
+//	...
+//	# kics-scan region-ignore-end
+//
+// the regions can be nested, an end comment closes the last region begun, an end comment without region is ignored
+// and a region without end comment extends to the end of the file
+func GetIgnoreRegions(content []byte) []IgnoreRegion {
+	regions := make([]IgnoreRegion, 0)
+	open := make([]IgnoreRegion, 0)
+	lines := strings.Split(string(content), "\n")
+	for idx, line := range lines {
+		fields := regionCommentFields(line)
+		if len(fields) < 2 || fields[0] != "kics-scan" {
+			continue
+		}
+		switch CommentCommand(fields[1]) {
+		case RegionIgnore:
+			region := IgnoreRegion{Start: idx + 1}
+			for _, field := range fields[2:] {
+				for _, id := range strings.Split(field, ",") {
+					if id = strings.TrimSpace(id); id != "" {
+						region.QueryIDs = append(region.QueryIDs, id)
+					}
+				}
+			}
+			open = append(open, region)
+		case RegionIgnoreEnd:
+			if len(open) == 0 {
+				continue
+			}
+			region := open[len(open)-1]
+			open = open[:len(open)-1]
+			region.End = idx + 1
+			regions = append(regions, region)
+		}
+	}
+	for i := range open {
+		open[i].End = len(lines)
+		regions = append(regions, open[i])
+	}
+	return regions
+}
+
+func regionCommentFields(line string) []string {
+	line = strings.TrimSpace(line)
+	for _, token := range []string{"#", "//"} {
+		if strings.HasPrefix(line, token) {
+			return strings.Fields(strings.TrimPrefix(line, token))
+		}
+	}
+	return nil
+}
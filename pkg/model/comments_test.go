@@ -109,3 +109,52 @@ func TestRange(t *testing.T) {
 		})
 	}
 }
+
+// TestGetIgnoreRegions tests the GetIgnoreRegions function and the matching of the regions.
+func TestGetIgnoreRegions(t *testing.T) {
+	content := []byte(`resource "aws_s3_bucket" "handwritten" {
+  acl = "private"
+}
+
+# kics-scan region-ignore 38c5ee0d-7f22-4260-ab72-5073048df100, a227ec01-f97a-4084-91a4-47b350c1db54
+resource "aws_s3_bucket" "generated" {
+  // kics-scan region-ignore
+  acl = "public-read"
+  // kics-scan region-ignore-end
+}
+# kics-scan region-ignore-end
+# kics-scan region-ignore-end
+
+# kics-scan region-ignore 4728cd65-a20c-49da-8b31-9c08b423e4db
+resource "aws_s3_bucket" "unterminated" {}`)
+
+	got := GetIgnoreRegions(content)
+	want := []IgnoreRegion{
+		{Start: 7, End: 9},
+		{QueryIDs: []string{"38c5ee0d-7f22-4260-ab72-5073048df100", "a227ec01-f97a-4084-91a4-47b350c1db54"}, Start: 5, End: 11},
+		{QueryIDs: []string{"4728cd65-a20c-49da-8b31-9c08b423e4db"}, Start: 14, End: 15},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetIgnoreRegions() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		line    int
+		queryID string
+		want    bool
+	}{
+		{name: "before the regions", line: 2, queryID: "38c5ee0d-7f22-4260-ab72-5073048df100", want: false},
+		{name: "listed query", line: 6, queryID: "a227ec01-f97a-4084-91a4-47b350c1db54", want: true},
+		{name: "other query", line: 6, queryID: "4728cd65-a20c-49da-8b31-9c08b423e4db", want: false},
+		{name: "region of every query", line: 8, queryID: "4728cd65-a20c-49da-8b31-9c08b423e4db", want: true},
+		{name: "region without end", line: 15, queryID: "4728cd65-a20c-49da-8b31-9c08b423e4db", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := MatchIgnoreRegion(got, tt.line, tt.queryID); ok != tt.want {
+				t.Errorf("MatchIgnoreRegion() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+// QueryDocumentation is the documentation of a query embedded in the HTML and PDF reports, so the results can be
+// reviewed without access to the online documentation
+type QueryDocumentation struct {
+	Description string
+	Rationale   string
+	Reference   string
+	// Remediation holds the distinct expected values of the results of the query
+	Remediation []string
+	// Examples are the compliant samples of the query tests
+	Examples []CodeExample
+}
+
+// CodeExample is a sample of code compliant with a query
+type CodeExample struct {
+	FileName string
+	Code     string
+	// Truncated is true when the sample was cut to the maximum number of lines of an example
+	Truncated bool
+}
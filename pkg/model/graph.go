@@ -0,0 +1,319 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+var (
+	// internetFacingTerraformTypes are the terraform resources exposed to the internet by definition
+	internetFacingTerraformTypes = map[string]bool{
+		"aws_cloudfront_distribution":           true,
+		"aws_api_gateway_rest_api":              true,
+		"aws_apigatewayv2_api":                  true,
+		"aws_eip":                               true,
+		"azurerm_public_ip":                     true,
+		"google_compute_global_forwarding_rule": true,
+	}
+	// loadBalancerTerraformTypes are the terraform load balancers, internet facing unless set as internal
+	loadBalancerTerraformTypes = map[string]bool{
+		"aws_lb":  true,
+		"aws_alb": true,
+		"aws_elb": true,
+	}
+	openCIDRs = map[string]bool{
+		"0.0.0.0/0": true,
+		"::/0":      true,
+	}
+	// workloadKinds are the kubernetes kinds whose pods are defined by spec.template
+	workloadKinds = map[string]bool{
+		"Deployment":  true,
+		"StatefulSet": true,
+		"DaemonSet":   true,
+		"ReplicaSet":  true,
+		"Job":         true,
+	}
+)
+
+// ResourceGraph is the graph of the resources found in the scanned files and their dependencies
+type ResourceGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a resource of the resource graph
+type GraphNode struct {
+	ID             string `json:"id"`
+	Platform       string `json:"platform"`
+	ResourceType   string `json:"resource_type"`
+	ResourceName   string `json:"resource_name"`
+	FileName       string `json:"file_name"`
+	InternetFacing bool   `json:"internet_facing"`
+}
+
+// GraphEdge represents a resource (From) through which another resource (To) can be reached: a terraform
+// resource to the resources referencing it, an ingress to its services and a service to its pods
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type kubernetesNode struct {
+	node     GraphNode
+	document Document
+}
+
+// CreateResourceGraph builds the resource graph of the terraform resources and the kubernetes manifests of the files
+func CreateResourceGraph(files FileMetadatas, pathExtractionMap map[string]ExtractedPathObject) *ResourceGraph {
+	graph := &ResourceGraph{
+		Nodes: make([]GraphNode, 0),
+		Edges: make([]GraphEdge, 0),
+	}
+	groups := files.Groups()
+	documents := files.ToMap()
+
+	for dir, module := range groups.TerraformModules {
+		graph.addTerraformModule(dir, module, documents, pathExtractionMap)
+	}
+	for namespace, group := range groups.KubernetesNamespaces {
+		graph.addKubernetesNamespace(namespace, group, documents, pathExtractionMap)
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From == graph.Edges[j].From {
+			return graph.Edges[i].To < graph.Edges[j].To
+		}
+		return graph.Edges[i].From < graph.Edges[j].From
+	})
+	return graph
+}
+
+func (g *ResourceGraph) addTerraformModule(dir string, module *TerraformModule, documents map[string]FileMetadata,
+	pathExtractionMap map[string]ExtractedPathObject) {
+	resolvedDir := filepath.ToSlash(resolvePath(dir, pathExtractionMap))
+	nodeID := func(address string) string {
+		return resolvedDir + ":" + address
+	}
+
+	resourceAddresses := make(map[string]bool)
+	for _, documentID := range module.Documents {
+		file := documents[documentID]
+		resources, ok := file.Document["resource"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for resourceType, namedResources := range resources {
+			namedResourcesMap, ok := namedResources.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, resource := range namedResourcesMap {
+				resourceAddresses[resourceType+"."+name] = true
+				g.Nodes = append(g.Nodes, GraphNode{
+					ID:             nodeID(resourceType + "." + name),
+					Platform:       "Terraform",
+					ResourceType:   resourceType,
+					ResourceName:   name,
+					FileName:       resolvePath(file.FilePath, pathExtractionMap),
+					InternetFacing: isInternetFacingTerraform(resourceType, resource),
+				})
+			}
+		}
+	}
+
+	for address, references := range module.References {
+		// only resources are part of the graph, data sources and modules are not
+		if !resourceAddresses[address] {
+			continue
+		}
+		// a resource is exposed through the resources it references, e.g. an instance using an open security group
+		for _, reference := range references {
+			if resourceAddresses[reference] {
+				g.Edges = append(g.Edges, GraphEdge{From: nodeID(reference), To: nodeID(address)})
+			}
+		}
+	}
+}
+
+func isInternetFacingTerraform(resourceType string, resource interface{}) bool {
+	if internetFacingTerraformTypes[resourceType] {
+		return true
+	}
+	resourceMap, ok := resource.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	switch {
+	case loadBalancerTerraformTypes[resourceType]:
+		internal, _ := resourceMap["internal"].(bool)
+		return !internal
+	case resourceType == "aws_instance":
+		public, _ := resourceMap["associate_public_ip_address"].(bool)
+		return public
+	case resourceType == "aws_security_group":
+		return hasOpenIngress(resourceMap["ingress"])
+	case resourceType == "aws_security_group_rule":
+		return resourceMap["type"] == "ingress" && hasOpenCIDR(resourceMap)
+	case resourceType == "aws_s3_bucket":
+		return resourceMap["acl"] == "public-read" || resourceMap["acl"] == "public-read-write"
+	}
+	return false
+}
+
+func hasOpenIngress(ingress interface{}) bool {
+	switch v := ingress.(type) {
+	case map[string]interface{}:
+		return hasOpenCIDR(v)
+	case []interface{}:
+		for _, rule := range v {
+			if ruleMap, ok := rule.(map[string]interface{}); ok && hasOpenCIDR(ruleMap) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasOpenCIDR(rule map[string]interface{}) bool {
+	for _, key := range []string{"cidr_blocks", "ipv6_cidr_blocks"} {
+		cidrs, ok := rule[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, cidr := range cidrs {
+			if cidrString, ok := cidr.(string); ok && openCIDRs[cidrString] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *ResourceGraph) addKubernetesNamespace(namespace string, group *KubernetesNamespace,
+	documents map[string]FileMetadata, pathExtractionMap map[string]ExtractedPathObject) {
+	nodes := make([]kubernetesNode, 0, len(group.Documents))
+	for _, documentID := range group.Documents {
+		file := documents[documentID]
+		kind, _ := file.Document["kind"].(string)
+		name := getManifestName(file.Document)
+		nodes = append(nodes, kubernetesNode{
+			node: GraphNode{
+				ID:             fmt.Sprintf("%s/%s/%s", namespace, kind, name),
+				Platform:       "Kubernetes",
+				ResourceType:   kind,
+				ResourceName:   name,
+				FileName:       resolvePath(file.FilePath, pathExtractionMap),
+				InternetFacing: isInternetFacingKubernetes(kind, file.Document),
+			},
+			document: file.Document,
+		})
+	}
+
+	for i := range nodes {
+		g.Nodes = append(g.Nodes, nodes[i].node)
+		switch nodes[i].node.ResourceType {
+		case "Service":
+			g.addServiceEdges(nodes[i], nodes)
+		case "Ingress":
+			g.addIngressEdges(nodes[i], nodes)
+		}
+	}
+}
+
+// addServiceEdges links a service to the pods and workloads matched by its selector
+func (g *ResourceGraph) addServiceEdges(service kubernetesNode, nodes []kubernetesNode) {
+	spec, _ := service.document["spec"].(map[string]interface{})
+	selector, ok := spec["selector"].(map[string]interface{})
+	if !ok || len(selector) == 0 {
+		return
+	}
+	for i := range nodes {
+		labels := getPodLabels(nodes[i].node.ResourceType, nodes[i].document)
+		if labels == nil || !matchesSelector(selector, labels) {
+			continue
+		}
+		g.Edges = append(g.Edges, GraphEdge{From: service.node.ID, To: nodes[i].node.ID})
+	}
+}
+
+// addIngressEdges links an ingress to the services used as its backends
+func (g *ResourceGraph) addIngressEdges(ingress kubernetesNode, nodes []kubernetesNode) {
+	backends := make(map[string]bool)
+	collectIngressBackends(ingress.document["spec"], backends)
+	for i := range nodes {
+		if nodes[i].node.ResourceType == "Service" && backends[nodes[i].node.ResourceName] {
+			g.Edges = append(g.Edges, GraphEdge{From: ingress.node.ID, To: nodes[i].node.ID})
+		}
+	}
+}
+
+// collectIngressBackends finds the service names of an ingress spec, supporting both
+// backend.service.name (networking.k8s.io/v1) and backend.serviceName (extensions/v1beta1)
+func collectIngressBackends(value interface{}, backends map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if serviceName, ok := v["serviceName"].(string); ok {
+			backends[serviceName] = true
+		}
+		if service, ok := v["service"].(map[string]interface{}); ok {
+			if name, ok := service["name"].(string); ok {
+				backends[name] = true
+			}
+		}
+		for _, child := range v {
+			collectIngressBackends(child, backends)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectIngressBackends(child, backends)
+		}
+	}
+}
+
+func getManifestName(document Document) string {
+	metadata, _ := document["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// getPodLabels returns the labels of the pods defined by a manifest, nil is returned for other kinds
+func getPodLabels(kind string, document Document) map[string]interface{} {
+	if kind == "Pod" {
+		metadata, _ := document["metadata"].(map[string]interface{})
+		labels, _ := metadata["labels"].(map[string]interface{})
+		return labels
+	}
+	if !workloadKinds[kind] {
+		return nil
+	}
+	spec, _ := document["spec"].(map[string]interface{})
+	template, _ := spec["template"].(map[string]interface{})
+	metadata, _ := template["metadata"].(map[string]interface{})
+	labels, _ := metadata["labels"].(map[string]interface{})
+	return labels
+}
+
+func matchesSelector(selector, labels map[string]interface{}) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func isInternetFacingKubernetes(kind string, document Document) bool {
+	switch kind {
+	case "Ingress":
+		return true
+	case "Service":
+		spec, _ := document["spec"].(map[string]interface{})
+		serviceType, _ := spec["type"].(string)
+		return serviceType == "LoadBalancer" || serviceType == "NodePort"
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateResourceGraph tests the functions [CreateResourceGraph()] and all the methods called by them
+func TestCreateResourceGraph(t *testing.T) {
+	files := FileMetadatas{
+		{
+			ID:       "lb",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("infra", "lb.tf"),
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_lb": map[string]interface{}{
+						"public": map[string]interface{}{
+							"security_groups": []interface{}{"${aws_security_group.sg.id}"},
+						},
+						"private": map[string]interface{}{"internal": true},
+					},
+					"aws_security_group": map[string]interface{}{
+						"sg": map[string]interface{}{
+							"ingress": []interface{}{
+								map[string]interface{}{"cidr_blocks": []interface{}{"10.0.0.0/8"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ID:       "instance",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("infra", "instance.tf"),
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_instance": map[string]interface{}{
+						"web": map[string]interface{}{
+							"ami":                    "${data.aws_ami.ubuntu.id}",
+							"vpc_security_group_ids": []interface{}{"${aws_security_group.sg.id}"},
+						},
+					},
+				},
+				"data": map[string]interface{}{
+					"aws_ami": map[string]interface{}{
+						"ubuntu": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:       "ingress",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{
+				"apiVersion": "networking.k8s.io/v1",
+				"kind":       "Ingress",
+				"metadata":   map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"http": map[string]interface{}{
+								"paths": []interface{}{
+									map[string]interface{}{
+										"backend": map[string]interface{}{
+											"service": map[string]interface{}{"name": "svc"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ID:       "service",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]interface{}{"name": "svc"},
+				"spec": map[string]interface{}{
+					"type":     "ClusterIP",
+					"selector": map[string]interface{}{"app": "web"},
+				},
+			},
+		},
+		{
+			ID:       "deployment",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "tier": "front"}},
+					},
+				},
+			},
+		},
+		{
+			ID:       "pod",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   map[string]interface{}{"name": "other", "labels": map[string]interface{}{"app": "other"}},
+			},
+		},
+	}
+
+	graph := CreateResourceGraph(files, map[string]ExtractedPathObject{})
+
+	require.Equal(t, []GraphNode{
+		{
+			ID: "default/Deployment/web", Platform: "Kubernetes", ResourceType: "Deployment", ResourceName: "web",
+			FileName: "k8s.yaml",
+		},
+		{
+			ID: "default/Ingress/web", Platform: "Kubernetes", ResourceType: "Ingress", ResourceName: "web",
+			FileName: "k8s.yaml", InternetFacing: true,
+		},
+		{
+			ID: "default/Pod/other", Platform: "Kubernetes", ResourceType: "Pod", ResourceName: "other",
+			FileName: "k8s.yaml",
+		},
+		{
+			ID: "default/Service/svc", Platform: "Kubernetes", ResourceType: "Service", ResourceName: "svc",
+			FileName: "k8s.yaml",
+		},
+		{
+			ID: "infra:aws_instance.web", Platform: "Terraform", ResourceType: "aws_instance", ResourceName: "web",
+			FileName: filepath.Join("infra", "instance.tf"),
+		},
+		{
+			ID: "infra:aws_lb.private", Platform: "Terraform", ResourceType: "aws_lb", ResourceName: "private",
+			FileName: filepath.Join("infra", "lb.tf"),
+		},
+		{
+			ID: "infra:aws_lb.public", Platform: "Terraform", ResourceType: "aws_lb", ResourceName: "public",
+			FileName: filepath.Join("infra", "lb.tf"), InternetFacing: true,
+		},
+		{
+			ID: "infra:aws_security_group.sg", Platform: "Terraform", ResourceType: "aws_security_group", ResourceName: "sg",
+			FileName: filepath.Join("infra", "lb.tf"),
+		},
+	}, graph.Nodes)
+
+	require.Equal(t, []GraphEdge{
+		{From: "default/Ingress/web", To: "default/Service/svc"},
+		{From: "default/Service/svc", To: "default/Deployment/web"},
+		{From: "infra:aws_security_group.sg", To: "infra:aws_instance.web"},
+		{From: "infra:aws_security_group.sg", To: "infra:aws_lb.public"},
+	}, graph.Edges)
+}
+
+// TestIsInternetFacingTerraform tests the functions [isInternetFacingTerraform()]
+func TestIsInternetFacingTerraform(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		resource     interface{}
+		want         bool
+	}{
+		{
+			name:         "cloudfront distribution",
+			resourceType: "aws_cloudfront_distribution",
+			resource:     map[string]interface{}{},
+			want:         true,
+		},
+		{
+			name:         "security group open to the world",
+			resourceType: "aws_security_group",
+			resource: map[string]interface{}{
+				"ingress": map[string]interface{}{"ipv6_cidr_blocks": []interface{}{"::/0"}},
+			},
+			want: true,
+		},
+		{
+			name:         "egress security group rule",
+			resourceType: "aws_security_group_rule",
+			resource: map[string]interface{}{
+				"type":        "egress",
+				"cidr_blocks": []interface{}{"0.0.0.0/0"},
+			},
+			want: false,
+		},
+		{
+			name:         "instance with public ip",
+			resourceType: "aws_instance",
+			resource:     map[string]interface{}{"associate_public_ip_address": true},
+			want:         true,
+		},
+		{
+			name:         "public bucket",
+			resourceType: "aws_s3_bucket",
+			resource:     map[string]interface{}{"acl": "public-read"},
+			want:         true,
+		},
+		{
+			name:         "unknown resource",
+			resourceType: "aws_sqs_queue",
+			resource:     map[string]interface{}{},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isInternetFacingTerraform(tt.resourceType, tt.resource))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package model
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultNamespace = "default"
+	namespaceKind    = "Namespace"
+)
+
+var (
+	// terraformReferenceRegex matches the addresses referenced in terraform expressions, e.g.
+	// "${aws_security_group.sg.id}", "${data.aws_iam_policy_document.doc.json}" or "${module.vpc.id}"
+	terraformReferenceRegex = regexp.MustCompile(`(?:^|[^\w.-])(data\.[\w-]+\.[\w-]+|module\.[\w-]+|[A-Za-z_][\w-]*\.[A-Za-z_][\w-]*)`)
+)
+
+// DocumentGroups groups the documents that are evaluated together, so queries can relate
+// resources defined in different documents
+type DocumentGroups struct {
+	TerraformModules     map[string]*TerraformModule     `json:"terraform_modules"`
+	KubernetesNamespaces map[string]*KubernetesNamespace `json:"kubernetes_namespaces"`
+}
+
+// TerraformModule is the combination of every terraform document in the same directory
+// References maps each resource address to the addresses it references
+type TerraformModule struct {
+	Documents  []string               `json:"documents"`
+	Resource   map[string]interface{} `json:"resource"`
+	Data       map[string]interface{} `json:"data"`
+	Module     map[string]interface{} `json:"module"`
+	Variable   map[string]interface{} `json:"variable"`
+	Output     map[string]interface{} `json:"output"`
+	References map[string][]string    `json:"references"`
+}
+
+// KubernetesNamespace is the combination of every kubernetes manifest in the same namespace,
+// Resources groups the manifests by kind
+type KubernetesNamespace struct {
+	Documents []string              `json:"documents"`
+	Resources map[string][]Document `json:"resources"`
+}
+
+// Groups builds the terraform modules and kubernetes namespaces of the documents,
+// ignored and empty documents are not grouped
+func (m FileMetadatas) Groups() *DocumentGroups {
+	groups := &DocumentGroups{
+		TerraformModules:     make(map[string]*TerraformModule),
+		KubernetesNamespaces: make(map[string]*KubernetesNamespace),
+	}
+
+	for i := range m {
+		if _, ignore := m[i].Commands["ignore"]; ignore || len(m[i].Document) == 0 {
+			continue
+		}
+		if m[i].Kind == KindTerraform {
+			groups.addTerraformDocument(&m[i])
+			continue
+		}
+		if namespace, ok := getKubernetesNamespace(m[i].Document); ok {
+			groups.addKubernetesDocument(namespace, &m[i])
+		}
+	}
+
+	for dir := range groups.TerraformModules {
+		groups.TerraformModules[dir].resolveReferences()
+	}
+
+	return groups
+}
+
+func (g *DocumentGroups) addTerraformDocument(file *FileMetadata) {
+	dir := filepath.Dir(file.FilePath)
+	module, ok := g.TerraformModules[dir]
+	if !ok {
+		module = &TerraformModule{
+			Documents:  make([]string, 0),
+			Resource:   make(map[string]interface{}),
+			Data:       make(map[string]interface{}),
+			Module:     make(map[string]interface{}),
+			Variable:   make(map[string]interface{}),
+			Output:     make(map[string]interface{}),
+			References: make(map[string][]string),
+		}
+		g.TerraformModules[dir] = module
+	}
+
+	module.Documents = append(module.Documents, file.ID)
+	// resources and data sources are identified by type and name, the other blocks only by name
+	mergeBlocks(module.Resource, file.Document["resource"], 2)
+	mergeBlocks(module.Data, file.Document["data"], 2)
+	mergeBlocks(module.Module, file.Document["module"], 1)
+	mergeBlocks(module.Variable, file.Document["variable"], 1)
+	mergeBlocks(module.Output, file.Document["output"], 1)
+}
+
+func (g *DocumentGroups) addKubernetesDocument(namespace string, file *FileMetadata) {
+	group, ok := g.KubernetesNamespaces[namespace]
+	if !ok {
+		group = &KubernetesNamespace{
+			Documents: make([]string, 0),
+			Resources: make(map[string][]Document),
+		}
+		g.KubernetesNamespaces[namespace] = group
+	}
+
+	kind, _ := file.Document["kind"].(string)
+	group.Documents = append(group.Documents, file.ID)
+	group.Resources[kind] = append(group.Resources[kind], file.Document)
+}
+
+// getKubernetesNamespace returns the namespace of a kubernetes manifest,
+// false is returned when the document is not a kubernetes manifest
+func getKubernetesNamespace(document Document) (string, bool) {
+	if _, ok := document["apiVersion"].(string); !ok {
+		return "", false
+	}
+	kind, ok := document["kind"].(string)
+	if !ok {
+		return "", false
+	}
+	metadata, ok := document["metadata"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	if kind == namespaceKind {
+		if name, ok := metadata["name"].(string); ok && name != "" {
+			return name, true
+		}
+	}
+	if namespace, ok := metadata["namespace"].(string); ok && namespace != "" {
+		return namespace, true
+	}
+	return defaultNamespace, true
+}
+
+// mergeBlocks adds the blocks of src to dst, going depth levels deep,
+// blocks defined more than once keep the first definition
+func mergeBlocks(dst map[string]interface{}, src interface{}, depth int) {
+	srcMap, ok := src.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, value := range srcMap {
+		if depth > 1 {
+			child, ok := dst[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				dst[key] = child
+			}
+			mergeBlocks(child, value, depth-1)
+			continue
+		}
+		if _, exists := dst[key]; exists {
+			log.Debug().Msgf("Duplicated terraform block %s, keeping the first definition", key)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// resolveReferences computes, for each resource and data source, the addresses of the resources,
+// data sources and modules of the module it references
+func (t *TerraformModule) resolveReferences() {
+	addresses := t.addresses()
+	for address, body := range t.blocksByAddress() {
+		references := make(map[string]bool)
+		collectReferences(body, addresses, address, references)
+		if len(references) == 0 {
+			continue
+		}
+		list := make([]string, 0, len(references))
+		for reference := range references {
+			list = append(list, reference)
+		}
+		sort.Strings(list)
+		t.References[address] = list
+	}
+}
+
+// blocksByAddress returns the resources and data sources of the module indexed by address
+func (t *TerraformModule) blocksByAddress() map[string]interface{} {
+	blocks := make(map[string]interface{})
+	for prefix, group := range map[string]map[string]interface{}{"": t.Resource, "data.": t.Data} {
+		for resourceType, resources := range group {
+			resourcesMap, ok := resources.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, body := range resourcesMap {
+				blocks[prefix+resourceType+"."+name] = body
+			}
+		}
+	}
+	return blocks
+}
+
+// addresses returns every address that can be referenced inside the module
+func (t *TerraformModule) addresses() map[string]bool {
+	addresses := make(map[string]bool)
+	for address := range t.blocksByAddress() {
+		addresses[address] = true
+	}
+	for name := range t.Module {
+		addresses["module."+name] = true
+	}
+	return addresses
+}
+
+func collectReferences(value interface{}, addresses map[string]bool, self string, references map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			collectReferences(child, addresses, self, references)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectReferences(child, addresses, self, references)
+		}
+	case string:
+		if !strings.Contains(v, ".") {
+			return
+		}
+		for _, match := range terraformReferenceRegex.FindAllStringSubmatch(v, -1) {
+			if address := match[1]; addresses[address] && address != self {
+				references[address] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileMetadatas_Groups tests the functions [Groups()] and all the methods called by them
+func TestFileMetadatas_Groups(t *testing.T) {
+	files := FileMetadatas{
+		{
+			ID:       "main",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("infra", "main.tf"),
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_instance": map[string]interface{}{
+						"web": map[string]interface{}{
+							"ami":                    "${data.aws_ami.ubuntu.id}",
+							"subnet_id":              "${module.vpc.subnet_id}",
+							"vpc_security_group_ids": []interface{}{"${aws_security_group.sg.id}"},
+							"tags":                   map[string]interface{}{"Name": "${var.name}"},
+						},
+					},
+				},
+				"data": map[string]interface{}{
+					"aws_ami": map[string]interface{}{
+						"ubuntu": map[string]interface{}{"most_recent": true},
+					},
+				},
+				"module": map[string]interface{}{
+					"vpc": map[string]interface{}{"source": "./vpc"},
+				},
+			},
+		},
+		{
+			ID:       "sg",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("infra", "sg.tf"),
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_security_group": map[string]interface{}{
+						"sg": map[string]interface{}{
+							"ingress": map[string]interface{}{"cidr_blocks": []interface{}{"0.0.0.0/0"}},
+						},
+					},
+				},
+				"variable": map[string]interface{}{
+					"name": map[string]interface{}{"default": "web"},
+				},
+			},
+		},
+		{
+			ID:       "other",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("other", "main.tf"),
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_s3_bucket": map[string]interface{}{
+						"b": map[string]interface{}{"bucket": "${aws_security_group.sg.id}"},
+					},
+				},
+			},
+		},
+		{
+			ID:       "ignored",
+			Kind:     KindTerraform,
+			FilePath: filepath.Join("infra", "ignored.tf"),
+			Commands: CommentsCommands{"ignore": ""},
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_s3_bucket": map[string]interface{}{
+						"ignored": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:       "namespace",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "web"}},
+		},
+		{
+			ID:       "service",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{"apiVersion": "v1", "kind": "Service", "metadata": map[string]interface{}{"name": "svc", "namespace": "web"}},
+		},
+		{
+			ID:       "pod",
+			Kind:     KindYAML,
+			FilePath: "k8s.yaml",
+			Document: Document{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]interface{}{"name": "pod"}},
+		},
+		{
+			ID:       "playbook",
+			Kind:     KindYAML,
+			FilePath: "playbook.yaml",
+			Document: Document{"playbooks": []interface{}{}},
+		},
+	}
+
+	groups := files.Groups()
+
+	require.Len(t, groups.TerraformModules, 2)
+	infra := groups.TerraformModules["infra"]
+	require.Equal(t, []string{"main", "sg"}, infra.Documents)
+	require.Contains(t, infra.Resource, "aws_instance")
+	require.Contains(t, infra.Resource, "aws_security_group")
+	require.NotContains(t, infra.Resource, "aws_s3_bucket")
+	require.Contains(t, infra.Data, "aws_ami")
+	require.Contains(t, infra.Module, "vpc")
+	require.Contains(t, infra.Variable, "name")
+	require.Equal(t, map[string][]string{
+		"aws_instance.web": {"aws_security_group.sg", "data.aws_ami.ubuntu", "module.vpc"},
+	}, infra.References)
+	require.Empty(t, groups.TerraformModules["other"].References)
+
+	require.Len(t, groups.KubernetesNamespaces, 2)
+	web := groups.KubernetesNamespaces["web"]
+	require.Equal(t, []string{"namespace", "service"}, web.Documents)
+	require.Len(t, web.Resources["Namespace"], 1)
+	require.Len(t, web.Resources["Service"], 1)
+	require.Equal(t, []string{"pod"}, groups.KubernetesNamespaces[defaultNamespace].Documents)
+}
+
+// TestMergeBlocks tests the functions [mergeBlocks()]
+func TestMergeBlocks(t *testing.T) {
+	dst := map[string]interface{}{
+		"aws_s3_bucket": map[string]interface{}{
+			"a": map[string]interface{}{"bucket": "first"},
+		},
+	}
+	mergeBlocks(dst, map[string]interface{}{
+		"aws_s3_bucket": map[string]interface{}{
+			"a": map[string]interface{}{"bucket": "second"},
+			"b": map[string]interface{}{"bucket": "b"},
+		},
+	}, 2)
+	mergeBlocks(dst, "not a block", 2)
+
+	require.Equal(t, map[string]interface{}{
+		"aws_s3_bucket": map[string]interface{}{
+			"a": map[string]interface{}{"bucket": "first"},
+			"b": map[string]interface{}{"bucket": "b"},
+		},
+	}, dst)
+}
@@ -0,0 +1,298 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	// terraformProviders are the cloud providers of the terraform resources, by the prefix of their type
+	terraformProviders = map[string]string{
+		"aws":          "aws",
+		"azurerm":      "azure",
+		"azuread":      "azure",
+		"azapi":        "azure",
+		"google":       "gcp",
+		"alicloud":     "alicloud",
+		"nifcloud":     "nifcloud",
+		"tencentcloud": "tencentcloud",
+	}
+	// pulumiProviders are the cloud providers of the pulumi resources, by the package of their type
+	pulumiProviders = map[string]string{
+		"aws":           "aws",
+		"aws-native":    "aws",
+		"azure":         "azure",
+		"azure-native":  "azure",
+		"gcp":           "gcp",
+		"google-native": "gcp",
+	}
+	// kubernetesPlatforms are the platforms of the kubernetes manifests extending the kubernetes API, by the
+	// domain of their API group
+	kubernetesPlatforms = map[string]string{
+		"crossplane.io":         "Crossplane",
+		"knative.dev":           "Knative",
+		"cnrm.cloud.google.com": "ConfigConnector",
+	}
+)
+
+// InventoryResource is a resource declared in the scanned files, as listed by the BoM report
+type InventoryResource struct {
+	Platform      string `json:"platform"`
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	ResourceType  string `json:"resource_type"`
+	ResourceName  string `json:"resource_name"`
+	FileName      string `json:"file_name"`
+}
+
+// inventoryExtractor returns the resources of a document and true when the document belongs to its platform
+type inventoryExtractor func(document Document) ([]InventoryResource, bool)
+
+// inventoryExtractors are tried in order, the first one recognizing the document lists its resources
+var inventoryExtractors = []inventoryExtractor{
+	terraformInventory,
+	cloudFormationInventory,
+	armInventory,
+	kubernetesInventory,
+	pulumiInventory,
+	serverlessInventory,
+	deploymentManagerInventory,
+	composeInventory,
+}
+
+// CreateInventory lists the resources declared in the documents of the files, whether they have results or not,
+// sorted by file, type and name
+func CreateInventory(files FileMetadatas, pathExtractionMap map[string]ExtractedPathObject) []InventoryResource {
+	inventory := make([]InventoryResource, 0)
+	for i := range files {
+		for _, extractor := range inventoryExtractors {
+			resources, ok := extractor(files[i].Document)
+			if !ok {
+				continue
+			}
+			fileName := resolvePath(files[i].FilePath, pathExtractionMap)
+			for j := range resources {
+				resources[j].FileName = fileName
+			}
+			inventory = append(inventory, resources...)
+			break
+		}
+	}
+
+	sort.SliceStable(inventory, func(i, j int) bool {
+		if inventory[i].FileName != inventory[j].FileName {
+			return inventory[i].FileName < inventory[j].FileName
+		}
+		if inventory[i].ResourceType != inventory[j].ResourceType {
+			return inventory[i].ResourceType < inventory[j].ResourceType
+		}
+		return inventory[i].ResourceName < inventory[j].ResourceName
+	})
+	return inventory
+}
+
+func terraformInventory(document Document) ([]InventoryResource, bool) {
+	resources, ok := document["resource"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0)
+	for resourceType, namedResources := range resources {
+		namedResourcesMap, ok := namedResources.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix, _, _ := strings.Cut(resourceType, "_")
+		for name := range namedResourcesMap {
+			inventory = append(inventory, InventoryResource{
+				Platform:      "Terraform",
+				CloudProvider: terraformProviders[prefix],
+				ResourceType:  resourceType,
+				ResourceName:  name,
+			})
+		}
+	}
+	return inventory, true
+}
+
+func cloudFormationInventory(document Document) ([]InventoryResource, bool) {
+	resources, ok := document["Resources"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0)
+	for name, resource := range resources {
+		resourceMap, _ := resource.(map[string]interface{})
+		resourceType, ok := resourceMap["Type"].(string)
+		if !ok {
+			continue
+		}
+		provider := ""
+		if strings.HasPrefix(resourceType, "AWS::") {
+			provider = "aws"
+		}
+		inventory = append(inventory, InventoryResource{
+			Platform:      "CloudFormation",
+			CloudProvider: provider,
+			ResourceType:  resourceType,
+			ResourceName:  name,
+		})
+	}
+	return inventory, true
+}
+
+func armInventory(document Document) ([]InventoryResource, bool) {
+	schema, _ := document["$schema"].(string)
+	if !strings.Contains(strings.ToLower(schema), "deploymenttemplate") {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0)
+	addARMResources(document["resources"], "", &inventory)
+	return inventory, true
+}
+
+// addARMResources lists the resources and their child resources, whose type is relative to their parent
+func addARMResources(resources interface{}, parentType string, inventory *[]InventoryResource) {
+	resourcesList, _ := resources.([]interface{})
+	for _, resource := range resourcesList {
+		resourceMap, _ := resource.(map[string]interface{})
+		resourceType, ok := resourceMap["type"].(string)
+		if !ok {
+			continue
+		}
+		if parentType != "" && !strings.Contains(resourceType, "/") {
+			resourceType = parentType + "/" + resourceType
+		}
+		name, _ := resourceMap["name"].(string)
+		*inventory = append(*inventory, InventoryResource{
+			Platform:      "AzureResourceManager",
+			CloudProvider: "azure",
+			ResourceType:  resourceType,
+			ResourceName:  name,
+		})
+		addARMResources(resourceMap["resources"], resourceType, inventory)
+	}
+}
+
+func kubernetesInventory(document Document) ([]InventoryResource, bool) {
+	apiVersion, isString := document["apiVersion"].(string)
+	kind, isKind := document["kind"].(string)
+	if !isString || !isKind {
+		return nil, false
+	}
+	resource := InventoryResource{
+		Platform:     "Kubernetes",
+		ResourceType: kind,
+		ResourceName: getManifestName(document),
+	}
+	group, _, _ := strings.Cut(apiVersion, "/")
+	for domain, platform := range kubernetesPlatforms {
+		if group == domain || strings.HasSuffix(group, "."+domain) {
+			resource.Platform = platform
+			resource.CloudProvider = kubernetesProvider(platform, group)
+			break
+		}
+	}
+	return []InventoryResource{resource}, true
+}
+
+// kubernetesProvider returns the cloud provider of the resources managed by Config Connector and by the
+// crossplane providers, e.g. s3.aws.crossplane.io
+func kubernetesProvider(platform, group string) string {
+	switch platform {
+	case "ConfigConnector":
+		return "gcp"
+	case "Crossplane":
+		for _, provider := range []string{"aws", "azure", "gcp"} {
+			if strings.Contains("."+group, "."+provider+".") {
+				return provider
+			}
+		}
+	}
+	return ""
+}
+
+func pulumiInventory(document Document) ([]InventoryResource, bool) {
+	_, hasRuntime := document["runtime"]
+	resources, ok := document["resources"].(map[string]interface{})
+	if !hasRuntime || !ok {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0)
+	for name, resource := range resources {
+		resourceMap, _ := resource.(map[string]interface{})
+		resourceType, ok := resourceMap["type"].(string)
+		if !ok {
+			continue
+		}
+		pkg, _, _ := strings.Cut(resourceType, ":")
+		inventory = append(inventory, InventoryResource{
+			Platform:      "Pulumi",
+			CloudProvider: pulumiProviders[pkg],
+			ResourceType:  resourceType,
+			ResourceName:  name,
+		})
+	}
+	return inventory, true
+}
+
+func serverlessInventory(document Document) ([]InventoryResource, bool) {
+	_, hasService := document["service"]
+	provider, hasProvider := document["provider"].(map[string]interface{})
+	if !hasService || !hasProvider {
+		return nil, false
+	}
+	providerName, _ := provider["name"].(string)
+	functions, _ := document["functions"].(map[string]interface{})
+	inventory := make([]InventoryResource, 0, len(functions))
+	for name := range functions {
+		inventory = append(inventory, InventoryResource{
+			Platform:      "ServerlessFW",
+			CloudProvider: providerName,
+			ResourceType:  "function",
+			ResourceName:  name,
+		})
+	}
+	return inventory, true
+}
+
+func deploymentManagerInventory(document Document) ([]InventoryResource, bool) {
+	resources, ok := document["resources"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0, len(resources))
+	for _, resource := range resources {
+		resourceMap, _ := resource.(map[string]interface{})
+		resourceType, isType := resourceMap["type"].(string)
+		name, isName := resourceMap["name"].(string)
+		if !isType || !isName {
+			continue
+		}
+		inventory = append(inventory, InventoryResource{
+			Platform:      "GoogleDeploymentManager",
+			CloudProvider: "gcp",
+			ResourceType:  resourceType,
+			ResourceName:  name,
+		})
+	}
+	return inventory, len(inventory) > 0
+}
+
+func composeInventory(document Document) ([]InventoryResource, bool) {
+	services, ok := document["services"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	inventory := make([]InventoryResource, 0, len(services))
+	for name, service := range services {
+		if _, ok := service.(map[string]interface{}); !ok {
+			continue
+		}
+		inventory = append(inventory, InventoryResource{
+			Platform:     "DockerCompose",
+			ResourceType: "service",
+			ResourceName: name,
+		})
+	}
+	return inventory, len(inventory) > 0
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInventory(t *testing.T) {
+	files := FileMetadatas{
+		{
+			FilePath: "main.tf",
+			Document: Document{
+				"resource": map[string]interface{}{
+					"aws_s3_bucket":           map[string]interface{}{"logs": map[string]interface{}{}},
+					"azurerm_storage_account": map[string]interface{}{"sa": map[string]interface{}{}},
+					"random_id":               map[string]interface{}{"suffix": map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			FilePath: "template.yaml",
+			Document: Document{
+				"AWSTemplateFormatVersion": "2010-09-09",
+				"Resources": map[string]interface{}{
+					"Bucket": map[string]interface{}{"Type": "AWS::S3::Bucket"},
+				},
+			},
+		},
+		{
+			FilePath: "azuredeploy.json",
+			Document: Document{
+				"$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+				"resources": []interface{}{
+					map[string]interface{}{
+						"type": "Microsoft.Sql/servers",
+						"name": "sql",
+						"resources": []interface{}{
+							map[string]interface{}{"type": "databases", "name": "db"},
+						},
+					},
+				},
+			},
+		},
+		{
+			FilePath: "deployment.yaml",
+			Document: Document{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "web"},
+			},
+		},
+		{
+			FilePath: "bucket.yaml",
+			Document: Document{
+				"apiVersion": "s3.aws.crossplane.io/v1beta1",
+				"kind":       "Bucket",
+				"metadata":   map[string]interface{}{"name": "assets"},
+			},
+		},
+		{
+			FilePath: "storage.yaml",
+			Document: Document{
+				"apiVersion": "storage.cnrm.cloud.google.com/v1beta1",
+				"kind":       "StorageBucket",
+				"metadata":   map[string]interface{}{"name": "assets"},
+			},
+		},
+		{
+			FilePath: "Pulumi.yaml",
+			Document: Document{
+				"runtime":   "yaml",
+				"resources": map[string]interface{}{"bucket": map[string]interface{}{"type": "gcp:storage:Bucket"}},
+			},
+		},
+		{
+			FilePath: "serverless.yml",
+			Document: Document{
+				"service":   "api",
+				"provider":  map[string]interface{}{"name": "aws"},
+				"functions": map[string]interface{}{"hello": map[string]interface{}{}},
+			},
+		},
+		{
+			FilePath: "vm.yaml",
+			Document: Document{
+				"resources": []interface{}{map[string]interface{}{"type": "compute.v1.instance", "name": "vm"}},
+			},
+		},
+		{
+			FilePath: "docker-compose.yml",
+			Document: Document{
+				"services": map[string]interface{}{"web": map[string]interface{}{"image": "nginx"}},
+			},
+		},
+		{
+			FilePath: "openapi.yaml",
+			Document: Document{"openapi": "3.0.0"},
+		},
+	}
+
+	want := []InventoryResource{
+		{Platform: "Pulumi", CloudProvider: "gcp", ResourceType: "gcp:storage:Bucket", ResourceName: "bucket"},
+		{Platform: "AzureResourceManager", CloudProvider: "azure", ResourceType: "Microsoft.Sql/servers", ResourceName: "sql"},
+		{Platform: "AzureResourceManager", CloudProvider: "azure", ResourceType: "Microsoft.Sql/servers/databases", ResourceName: "db"},
+		{Platform: "Crossplane", CloudProvider: "aws", ResourceType: "Bucket", ResourceName: "assets"},
+		{Platform: "Kubernetes", ResourceType: "Deployment", ResourceName: "web"},
+		{Platform: "DockerCompose", ResourceType: "service", ResourceName: "web"},
+		{Platform: "Terraform", CloudProvider: "aws", ResourceType: "aws_s3_bucket", ResourceName: "logs"},
+		{Platform: "Terraform", CloudProvider: "azure", ResourceType: "azurerm_storage_account", ResourceName: "sa"},
+		{Platform: "Terraform", ResourceType: "random_id", ResourceName: "suffix"},
+		{Platform: "ServerlessFW", CloudProvider: "aws", ResourceType: "function", ResourceName: "hello"},
+		{Platform: "ConfigConnector", CloudProvider: "gcp", ResourceType: "StorageBucket", ResourceName: "assets"},
+		{Platform: "CloudFormation", CloudProvider: "aws", ResourceType: "AWS::S3::Bucket", ResourceName: "Bucket"},
+		{Platform: "GoogleDeploymentManager", CloudProvider: "gcp", ResourceType: "compute.v1.instance", ResourceName: "vm"},
+	}
+
+	got := CreateInventory(files, map[string]ExtractedPathObject{})
+	for i := range got {
+		got[i].FileName = ""
+	}
+	require.Equal(t, want, got)
+}
@@ -0,0 +1,199 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MergeSummaries combines the summaries of scans of different paths into a single summary, the results found
+// by more than one scan are kept once. The files and lines counters are added while the queries counters keep
+// the highest value, since every scan loads the same queries, and the times span from the first start to the last end
+func MergeSummaries(summaries []Summary) Summary {
+	merged := Summary{
+		ScannedPaths:   make([]string, 0),
+		Queries:        make(QueryResultSlice, 0),
+		Bom:            make(QueryResultSlice, 0),
+		ParseFailures:  make([]ParseFailure, 0),
+		FailedQueries:  make([]FailedQuery, 0),
+		SkippedQueries: make([]SkippedQuery, 0),
+		SkippedFiles:   make([]string, 0),
+	}
+	if len(summaries) == 0 {
+		return merged
+	}
+	merged.Version = summaries[0].Version
+	merged.ScanID = summaries[0].ScanID
+	merged.LatestVersion = summaries[0].LatestVersion
+
+	queries := newMergedQueries()
+	materials := newMergedQueries()
+	scannedPaths := make(map[string]bool)
+	parseFailures := make(map[string]bool)
+	failedQueries := make(map[string]bool)
+	skippedQueries := make(map[string]bool)
+	skippedFiles := make(map[string]bool)
+	guardedFiles := make(map[GuardedFile]bool)
+
+	for i := range summaries {
+		summary := &summaries[i]
+		mergeCounters(&merged.Counters, &summary.Counters)
+		mergeTimes(&merged.Times, &summary.Times)
+		merged.Partial = merged.Partial || summary.Partial
+
+		for _, path := range summary.ScannedPaths {
+			if !scannedPaths[path] {
+				scannedPaths[path] = true
+				merged.ScannedPaths = append(merged.ScannedPaths, path)
+			}
+		}
+		for j := range summary.Queries {
+			queries.add(&summary.Queries[j])
+		}
+		for j := range summary.Bom {
+			materials.add(&summary.Bom[j])
+		}
+		for j := range summary.ParseFailures {
+			key := fmt.Sprintf("%s|%d|%s", summary.ParseFailures[j].FilePath, summary.ParseFailures[j].Line, summary.ParseFailures[j].Error)
+			if !parseFailures[key] {
+				parseFailures[key] = true
+				merged.ParseFailures = append(merged.ParseFailures, summary.ParseFailures[j])
+			}
+		}
+		for j := range summary.FailedQueries {
+			key := fmt.Sprintf("%s|%s|%s", summary.FailedQueries[j].QueryID, summary.FailedQueries[j].FilePath, summary.FailedQueries[j].Error)
+			if !failedQueries[key] {
+				failedQueries[key] = true
+				merged.FailedQueries = append(merged.FailedQueries, summary.FailedQueries[j])
+			}
+		}
+		for j := range summary.SkippedQueries {
+			if !skippedQueries[summary.SkippedQueries[j].QueryID] {
+				skippedQueries[summary.SkippedQueries[j].QueryID] = true
+				merged.SkippedQueries = append(merged.SkippedQueries, summary.SkippedQueries[j])
+			}
+		}
+		for _, file := range summary.SkippedFiles {
+			if !skippedFiles[file] {
+				skippedFiles[file] = true
+				merged.SkippedFiles = append(merged.SkippedFiles, file)
+			}
+		}
+		for _, file := range summary.GuardedFiles {
+			if !guardedFiles[file] {
+				guardedFiles[file] = true
+				merged.GuardedFiles = append(merged.GuardedFiles, file)
+			}
+		}
+	}
+
+	merged.Queries = queries.results
+	merged.Bom = materials.results
+	sortQueryResults(merged.Queries)
+	sort.Strings(merged.ScannedPaths)
+	sort.Strings(merged.SkippedFiles)
+	sort.Slice(merged.GuardedFiles, func(i, j int) bool {
+		return merged.GuardedFiles[i].FilePath < merged.GuardedFiles[j].FilePath
+	})
+	merged.SeveritySummary = mergedSeveritySummary(merged.ScanID, merged.Queries, merged.Bom)
+
+	return merged
+}
+
+func mergeCounters(merged, counters *Counters) {
+	merged.ScannedFiles += counters.ScannedFiles
+	merged.ScannedFilesLines += counters.ScannedFilesLines
+	merged.ParsedFiles += counters.ParsedFiles
+	merged.ParsedFilesLines += counters.ParsedFilesLines
+	merged.IgnoredFilesLines += counters.IgnoredFilesLines
+	merged.FailedToScanFiles += counters.FailedToScanFiles
+	merged.FailedSimilarityID += counters.FailedSimilarityID
+	if counters.TotalQueries > merged.TotalQueries {
+		merged.TotalQueries = counters.TotalQueries
+	}
+	if counters.FailedToExecuteQueries > merged.FailedToExecuteQueries {
+		merged.FailedToExecuteQueries = counters.FailedToExecuteQueries
+	}
+}
+
+func mergeTimes(merged, times *Times) {
+	if !times.Start.IsZero() && (merged.Start.IsZero() || times.Start.Before(merged.Start)) {
+		merged.Start = times.Start
+	}
+	if times.End.After(merged.End) {
+		merged.End = times.End
+	}
+}
+
+// mergedQueries groups the results of the same query and drops the results already found
+type mergedQueries struct {
+	results QueryResultSlice
+	index   map[string]int
+	seen    map[string]bool
+}
+
+func newMergedQueries() *mergedQueries {
+	return &mergedQueries{
+		results: make(QueryResultSlice, 0),
+		index:   make(map[string]int),
+		seen:    make(map[string]bool),
+	}
+}
+
+func (m *mergedQueries) add(query *QueryResult) {
+	idx, ok := m.index[query.QueryID]
+	if !ok {
+		merged := *query
+		merged.Files = make([]VulnerableFile, 0, len(query.Files))
+		merged.Truncated = false
+		merged.TotalResults = 0
+		merged.OmittedResults = 0
+		m.results = append(m.results, merged)
+		idx = len(m.results) - 1
+		m.index[query.QueryID] = idx
+	}
+
+	merged := &m.results[idx]
+	for i := range query.Files {
+		key := vulnerableFileKey(query.QueryID, &query.Files[i])
+		if m.seen[key] {
+			continue
+		}
+		m.seen[key] = true
+		merged.Files = append(merged.Files, query.Files[i])
+	}
+	if query.Truncated {
+		merged.Truncated = true
+		merged.OmittedResults += query.OmittedResults
+	}
+	if merged.Truncated {
+		merged.TotalResults = len(merged.Files) + merged.OmittedResults
+	}
+}
+
+// vulnerableFileKey identifies a result by its similarity ID, or by its location when it has none
+func vulnerableFileKey(queryID string, file *VulnerableFile) string {
+	if file.SimilarityID != "" {
+		return file.SimilarityID
+	}
+	return fmt.Sprintf("%s|%s|%d|%s", queryID, file.FileName, file.Line, file.SearchKey)
+}
+
+// mergedSeveritySummary counts the results of the merged queries, including the omitted results of the truncated queries
+func mergedSeveritySummary(scanID string, queries, materials QueryResultSlice) SeveritySummary {
+	severitySummary := SeveritySummary{
+		ScanID: scanID,
+		SeverityCounters: map[Severity]int{
+			SeverityTrace: 0, SeverityInfo: 0, SeverityLow: 0, SeverityMedium: 0, SeverityHigh: 0, SeverityCritical: 0,
+		},
+	}
+	for i := range queries {
+		results := len(queries[i].Files) + queries[i].OmittedResults
+		severitySummary.SeverityCounters[queries[i].Severity] += results
+		severitySummary.TotalCounter += results
+	}
+	for i := range materials {
+		severitySummary.SeverityCounters[SeverityTrace] += len(materials[i].Files)
+		severitySummary.TotalBOMResources += len(materials[i].Files)
+	}
+	return severitySummary
+}
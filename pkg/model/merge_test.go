@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMergeSummaries tests the merge of the summaries of scans of different paths
+func TestMergeSummaries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	frontend := Summary{
+		Version:  "2.0.0",
+		Counters: Counters{ScannedFiles: 2, ScannedFilesLines: 20, TotalQueries: 100, FailedToExecuteQueries: 1},
+		Times:    Times{Start: start.Add(time.Minute), End: start.Add(2 * time.Minute)},
+		SeveritySummary: SeveritySummary{
+			ScanID: "frontend",
+		},
+		ScannedPaths: []string{"frontend"},
+		Queries: QueryResultSlice{
+			{
+				QueryName: "Low Query",
+				QueryID:   "low",
+				Severity:  SeverityLow,
+				Files: []VulnerableFile{
+					{FileName: "frontend/Dockerfile", Line: 1, SimilarityID: "sim-1"},
+					{FileName: "frontend/Dockerfile", Line: 3, SimilarityID: "sim-2"},
+				},
+			},
+		},
+		ParseFailures: []ParseFailure{{FilePath: "frontend/bad.yaml", Line: 2, Error: "invalid"}},
+	}
+	backend := Summary{
+		Version:  "2.0.0",
+		Counters: Counters{ScannedFiles: 3, ScannedFilesLines: 30, TotalQueries: 120},
+		Times:    Times{Start: start, End: start.Add(time.Minute)},
+		SeveritySummary: SeveritySummary{
+			ScanID: "backend",
+		},
+		ScannedPaths: []string{"backend", "frontend"},
+		Queries: QueryResultSlice{
+			{
+				QueryName: "Low Query",
+				QueryID:   "low",
+				Severity:  SeverityLow,
+				Files: []VulnerableFile{
+					{FileName: "frontend/Dockerfile", Line: 1, SimilarityID: "sim-1"},
+				},
+			},
+			{
+				QueryName:      "High Query",
+				QueryID:        "high",
+				Severity:       SeverityHigh,
+				Truncated:      true,
+				TotalResults:   3,
+				OmittedResults: 2,
+				Files: []VulnerableFile{
+					{FileName: "backend/main.tf", Line: 5, SearchKey: "resource"},
+				},
+			},
+		},
+		Bom: QueryResultSlice{
+			{QueryID: "bom", Severity: SeverityTrace, Files: []VulnerableFile{{FileName: "backend/main.tf", SimilarityID: "bom-1"}}},
+		},
+		ParseFailures: []ParseFailure{{FilePath: "frontend/bad.yaml", Line: 2, Error: "invalid"}},
+		Partial:       true,
+		SkippedFiles:  []string{"backend/big.tf"},
+		GuardedFiles:  []GuardedFile{{FilePath: "backend/data.json", Reason: GuardMaxFileSize, Size: 6291456}},
+	}
+
+	merged := MergeSummaries([]Summary{frontend, backend})
+
+	require.Equal(t, "2.0.0", merged.Version)
+	require.Equal(t, "frontend", merged.ScanID)
+	require.Equal(t, Counters{ScannedFiles: 5, ScannedFilesLines: 50, TotalQueries: 120, FailedToExecuteQueries: 1}, merged.Counters)
+	require.Equal(t, Times{Start: start, End: start.Add(2 * time.Minute)}, merged.Times)
+	require.Equal(t, []string{"backend", "frontend"}, merged.ScannedPaths)
+	require.Len(t, merged.ParseFailures, 1)
+	require.True(t, merged.Partial)
+	require.Equal(t, []string{"backend/big.tf"}, merged.SkippedFiles)
+	require.Equal(t, []GuardedFile{{FilePath: "backend/data.json", Reason: GuardMaxFileSize, Size: 6291456}}, merged.GuardedFiles)
+
+	require.Len(t, merged.Queries, 2)
+	require.Equal(t, "high", merged.Queries[0].QueryID)
+	require.True(t, merged.Queries[0].Truncated)
+	require.Equal(t, 3, merged.Queries[0].TotalResults)
+	require.Equal(t, "low", merged.Queries[1].QueryID)
+	require.Len(t, merged.Queries[1].Files, 2)
+	require.False(t, merged.Queries[1].Truncated)
+
+	require.Equal(t, 5, merged.TotalCounter)
+	require.Equal(t, 3, merged.SeverityCounters[SeverityHigh])
+	require.Equal(t, 2, merged.SeverityCounters[SeverityLow])
+	require.Equal(t, 1, merged.SeverityCounters[SeverityTrace])
+	require.Equal(t, 1, merged.TotalBOMResources)
+}
+
+// TestMergeSummaries_Empty tests the merge without summaries
+func TestMergeSummaries_Empty(t *testing.T) {
+	merged := MergeSummaries(nil)
+	require.Empty(t, merged.Queries)
+	require.Empty(t, merged.ScannedPaths)
+	require.Zero(t, merged.TotalCounter)
+}
@@ -30,6 +30,9 @@ const (
 	IgnoreLine    CommentCommand = "ignore-line"
 	IgnoreBlock   CommentCommand = "ignore-block"
 	IgnoreComment CommentCommand = "ignore-comment"
+	// RegionIgnore begins a region of lines ignored for the queries listed after it, up to RegionIgnoreEnd
+	RegionIgnore    CommentCommand = "region-ignore"
+	RegionIgnoreEnd CommentCommand = "region-ignore-end"
 )
 
 // Constants to describe vulnerability's severity
@@ -128,10 +131,10 @@ type FileMetadata struct {
 	Kind              FileKind `db:"kind"`
 	FilePath          string   `db:"file_path"`
 	Content           string
-	HelmID            string
-	IDInfo            map[int]interface{}
+	HelmLineMap       []int
 	Commands          CommentsCommands
 	LinesIgnore       []int
+	IgnoreRegions     []IgnoreRegion
 	ResolvedFiles     map[string]ResolvedFile
 	LinesOriginalData *[]string
 	IsMinified        bool
@@ -203,8 +206,7 @@ type ResolvedHelm struct {
 	FileName     string
 	Content      []byte
 	OriginalData []byte
-	SplitID      string
-	IDInfo       map[int]interface{}
+	LineMap      []int
 }
 
 // Extensions represents a list of supported extensions
@@ -254,7 +256,8 @@ func (m FileMetadatas) ToMap() map[string]FileMetadata {
 
 // Documents (easyjson:json)
 type Documents struct {
-	Documents []Document `json:"document"`
+	Documents []Document      `json:"document"`
+	Groups    *DocumentGroups `json:"groups,omitempty"`
 }
 
 // Document (easyjson:json)
@@ -290,8 +293,43 @@ type AnalyzedPaths struct {
 	Types       []string
 	Exc         []string
 	ExpectedLOC int
+	Guarded     []GuardedFile
+	Discovery   DiscoveryPerformance
 }
 
+// DiscoveryPerformance is the timing of the discovery of the files to scan: the walk of the scanned paths, which
+// lists and stats the entries of the directories concurrently, and the classification of the files by platform
+type DiscoveryPerformance struct {
+	WalkMs           float64 `json:"walk_ms"`
+	ClassificationMs float64 `json:"classification_ms"`
+	Directories      int     `json:"directories"`
+	Files            int     `json:"files"`
+	ClassifiedFiles  int     `json:"classified_files"`
+	Workers          int     `json:"workers"`
+}
+
+// Performance is the timing of the steps of the scan whose duration depends on the size of the scanned paths
+type Performance struct {
+	Discovery *DiscoveryPerformance `json:"discovery,omitempty"`
+	// HashingMs is the duration of the hashing of the scanned paths for the attestation report
+	HashingMs float64 `json:"hashing_ms,omitempty"`
+}
+
+// GuardedFile is a file left out of the scan by the file-size or the file-count guard, the reason is the name of
+// the flag of the guard
+type GuardedFile struct {
+	FilePath string `json:"file_path"`
+	Reason   string `json:"reason"`
+	Size     int64  `json:"size,omitempty"`
+}
+
+const (
+	// GuardMaxFileSize is the reason of the files larger than the maximum file size
+	GuardMaxFileSize = "max-file-size"
+	// GuardMaxFiles is the reason of the files found beyond the maximum number of files
+	GuardMaxFiles = "max-files"
+)
+
 // ResolvedFileSplit is a struct that contains the information of a resolved file, the path and the lines of the file
 type ResolvedFileSplit struct {
 	Path  string
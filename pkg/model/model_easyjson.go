@@ -59,6 +59,16 @@ func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel(in *jlexer.Lexer, out
 				}
 				in.Delim(']')
 			}
+		case "groups":
+			if in.IsNull() {
+				in.Skip()
+				out.Groups = nil
+			} else {
+				if out.Groups == nil {
+					out.Groups = new(DocumentGroups)
+				}
+				easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(in, out.Groups)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -89,6 +99,11 @@ func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel(out *jwriter.Writer, i
 			out.RawByte(']')
 		}
 	}
+	if in.Groups != nil {
+		const prefix string = ",\"groups\":"
+		out.RawString(prefix)
+		easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(out, *in.Groups)
+	}
 	out.RawByte('}')
 }
 
@@ -115,7 +130,671 @@ func (v *Documents) UnmarshalJSON(data []byte) error {
 func (v *Documents) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel(l, v)
 }
-func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(in *jlexer.Lexer, out *Document) {
+func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(in *jlexer.Lexer, out *DocumentGroups) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "terraform_modules":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.TerraformModules = make(map[string]*TerraformModule)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 *TerraformModule
+					if in.IsNull() {
+						in.Skip()
+						v4 = nil
+					} else {
+						if v4 == nil {
+							v4 = new(TerraformModule)
+						}
+						easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel2(in, v4)
+					}
+					(out.TerraformModules)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "kubernetes_namespaces":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.KubernetesNamespaces = make(map[string]*KubernetesNamespace)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v5 *KubernetesNamespace
+					if in.IsNull() {
+						in.Skip()
+						v5 = nil
+					} else {
+						if v5 == nil {
+							v5 = new(KubernetesNamespace)
+						}
+						easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel3(in, v5)
+					}
+					(out.KubernetesNamespaces)[key] = v5
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(out *jwriter.Writer, in DocumentGroups) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"terraform_modules\":"
+		out.RawString(prefix[1:])
+		if in.TerraformModules == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v6First := true
+			for v6Name, v6Value := range in.TerraformModules {
+				if v6First {
+					v6First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v6Name))
+				out.RawByte(':')
+				if v6Value == nil {
+					out.RawString("null")
+				} else {
+					easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel2(out, *v6Value)
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"kubernetes_namespaces\":"
+		out.RawString(prefix)
+		if in.KubernetesNamespaces == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v7First := true
+			for v7Name, v7Value := range in.KubernetesNamespaces {
+				if v7First {
+					v7First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v7Name))
+				out.RawByte(':')
+				if v7Value == nil {
+					out.RawString("null")
+				} else {
+					easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel3(out, *v7Value)
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel3(in *jlexer.Lexer, out *KubernetesNamespace) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "documents":
+			if in.IsNull() {
+				in.Skip()
+				out.Documents = nil
+			} else {
+				in.Delim('[')
+				if out.Documents == nil {
+					if !in.IsDelim(']') {
+						out.Documents = make([]string, 0, 4)
+					} else {
+						out.Documents = []string{}
+					}
+				} else {
+					out.Documents = (out.Documents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 string
+					v8 = string(in.String())
+					out.Documents = append(out.Documents, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "resources":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Resources = make(map[string][]Document)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v9 []Document
+					if in.IsNull() {
+						in.Skip()
+						v9 = nil
+					} else {
+						in.Delim('[')
+						if v9 == nil {
+							if !in.IsDelim(']') {
+								v9 = make([]Document, 0, 8)
+							} else {
+								v9 = []Document{}
+							}
+						} else {
+							v9 = (v9)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v10 Document
+							(v10).UnmarshalEasyJSON(in)
+							v9 = append(v9, v10)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Resources)[key] = v9
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel3(out *jwriter.Writer, in KubernetesNamespace) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"documents\":"
+		out.RawString(prefix[1:])
+		if in.Documents == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Documents {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"resources\":"
+		out.RawString(prefix)
+		if in.Resources == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v13First := true
+			for v13Name, v13Value := range in.Resources {
+				if v13First {
+					v13First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v13Name))
+				out.RawByte(':')
+				if v13Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v14, v15 := range v13Value {
+						if v14 > 0 {
+							out.RawByte(',')
+						}
+						(v15).MarshalEasyJSON(out)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel2(in *jlexer.Lexer, out *TerraformModule) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "documents":
+			if in.IsNull() {
+				in.Skip()
+				out.Documents = nil
+			} else {
+				in.Delim('[')
+				if out.Documents == nil {
+					if !in.IsDelim(']') {
+						out.Documents = make([]string, 0, 4)
+					} else {
+						out.Documents = []string{}
+					}
+				} else {
+					out.Documents = (out.Documents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 string
+					v16 = string(in.String())
+					out.Documents = append(out.Documents, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "resource":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Resource = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v17 interface{}
+					if m, ok := v17.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v17.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v17 = in.Interface()
+					}
+					(out.Resource)[key] = v17
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Data = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v18 interface{}
+					if m, ok := v18.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v18.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v18 = in.Interface()
+					}
+					(out.Data)[key] = v18
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "module":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Module = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v19 interface{}
+					if m, ok := v19.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v19.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v19 = in.Interface()
+					}
+					(out.Module)[key] = v19
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "variable":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Variable = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v20 interface{}
+					if m, ok := v20.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v20.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v20 = in.Interface()
+					}
+					(out.Variable)[key] = v20
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "output":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Output = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v21 interface{}
+					if m, ok := v21.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v21.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v21 = in.Interface()
+					}
+					(out.Output)[key] = v21
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "references":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.References = make(map[string][]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v22 []string
+					if in.IsNull() {
+						in.Skip()
+						v22 = nil
+					} else {
+						in.Delim('[')
+						if v22 == nil {
+							if !in.IsDelim(']') {
+								v22 = make([]string, 0, 4)
+							} else {
+								v22 = []string{}
+							}
+						} else {
+							v22 = (v22)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v23 string
+							v23 = string(in.String())
+							v22 = append(v22, v23)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.References)[key] = v22
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel2(out *jwriter.Writer, in TerraformModule) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"documents\":"
+		out.RawString(prefix[1:])
+		if in.Documents == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v24, v25 := range in.Documents {
+				if v24 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v25))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"resource\":"
+		out.RawString(prefix)
+		if in.Resource == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v26First := true
+			for v26Name, v26Value := range in.Resource {
+				if v26First {
+					v26First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v26Name))
+				out.RawByte(':')
+				if m, ok := v26Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v26Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v26Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		if in.Data == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v27First := true
+			for v27Name, v27Value := range in.Data {
+				if v27First {
+					v27First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v27Name))
+				out.RawByte(':')
+				if m, ok := v27Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v27Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v27Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"module\":"
+		out.RawString(prefix)
+		if in.Module == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v28First := true
+			for v28Name, v28Value := range in.Module {
+				if v28First {
+					v28First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v28Name))
+				out.RawByte(':')
+				if m, ok := v28Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v28Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v28Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"variable\":"
+		out.RawString(prefix)
+		if in.Variable == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v29First := true
+			for v29Name, v29Value := range in.Variable {
+				if v29First {
+					v29First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v29Name))
+				out.RawByte(':')
+				if m, ok := v29Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v29Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v29Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"output\":"
+		out.RawString(prefix)
+		if in.Output == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v30First := true
+			for v30Name, v30Value := range in.Output {
+				if v30First {
+					v30First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v30Name))
+				out.RawByte(':')
+				if m, ok := v30Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v30Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v30Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"references\":"
+		out.RawString(prefix)
+		if in.References == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v31First := true
+			for v31Name, v31Value := range in.References {
+				if v31First {
+					v31First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v31Name))
+				out.RawByte(':')
+				if v31Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v32, v33 := range v31Value {
+						if v32 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v33))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel4(in *jlexer.Lexer, out *Document) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -125,15 +804,15 @@ func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(in *jlexer.Lexer, out
 		for !in.IsDelim('}') {
 			key := string(in.String())
 			in.WantColon()
-			var v4 interface{}
-			if m, ok := v4.(easyjson.Unmarshaler); ok {
+			var v34 interface{}
+			if m, ok := v34.(easyjson.Unmarshaler); ok {
 				m.UnmarshalEasyJSON(in)
-			} else if m, ok := v4.(json.Unmarshaler); ok {
+			} else if m, ok := v34.(json.Unmarshaler); ok {
 				_ = m.UnmarshalJSON(in.Raw())
 			} else {
-				v4 = in.Interface()
+				v34 = in.Interface()
 			}
-			(*out)[key] = v4
+			(*out)[key] = v34
 			in.WantComma()
 		}
 		in.Delim('}')
@@ -142,26 +821,26 @@ func easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(out *jwriter.Writer, in Document) {
+func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel4(out *jwriter.Writer, in Document) {
 	if in == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
 		out.RawString(`null`)
 	} else {
 		out.RawByte('{')
-		v5First := true
-		for v5Name, v5Value := range in {
-			if v5First {
-				v5First = false
+		v35First := true
+		for v35Name, v35Value := range in {
+			if v35First {
+				v35First = false
 			} else {
 				out.RawByte(',')
 			}
-			out.String(string(v5Name))
+			out.String(string(v35Name))
 			out.RawByte(':')
-			if m, ok := v5Value.(easyjson.Marshaler); ok {
+			if m, ok := v35Value.(easyjson.Marshaler); ok {
 				m.MarshalEasyJSON(out)
-			} else if m, ok := v5Value.(json.Marshaler); ok {
+			} else if m, ok := v35Value.(json.Marshaler); ok {
 				out.Raw(m.MarshalJSON())
 			} else {
-				out.Raw(json.Marshal(v5Value))
+				out.Raw(json.Marshal(v35Value))
 			}
 		}
 		out.RawByte('}')
@@ -171,23 +850,23 @@ func easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v Document) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(&w, v)
+	easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Document) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel1(w, v)
+	easyjsonC80ae7adEncodeGithubComCheckmarxKicsPkgModel4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Document) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(&r, v)
+	easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Document) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel1(l, v)
+	easyjsonC80ae7adDecodeGithubComCheckmarxKicsPkgModel4(l, v)
 }
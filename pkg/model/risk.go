@@ -0,0 +1,188 @@
+package model
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// riskExposureFactor multiplies the score of the resources exposed to the internet
+	riskExposureFactor = 1.5
+	// riskDefaultCategoryFactor is the factor of the categories missing from riskCategoryFactors
+	riskDefaultCategoryFactor = 1.0
+	// riskUnknownResource is the resource type and name set by the queries whose result has no resource
+	riskUnknownResource = "n/a"
+)
+
+var (
+	// riskSeverityWeights are the weights of the results by severity, the info and trace results carry no risk
+	riskSeverityWeights = map[Severity]float64{
+		SeverityCritical: 10,
+		SeverityHigh:     7,
+		SeverityMedium:   4,
+		SeverityLow:      1,
+	}
+	// riskCategoryFactors weight the results by how exploitable their category is
+	riskCategoryFactors = map[string]float64{
+		"Access Control":          1.5,
+		"Secret Management":       1.5,
+		"Networking and Firewall": 1.3,
+		"Encryption":              1.2,
+		"Insecure Configurations": 1.2,
+		"Insecure Defaults":       1.2,
+		"Supply-Chain":            1.2,
+		"Availability":            0.9,
+		"Backup":                  0.8,
+		"Build Process":           0.8,
+		"Resource Management":     0.8,
+		"Observability":           0.7,
+		"Best Practices":          0.6,
+		"Structure and Semantics": 0.5,
+		"Bill Of Materials":       0,
+	}
+	// riskExposureRegex matches the query names and actual values of the results exposing a resource to the internet
+	riskExposureRegex = regexp.MustCompile(
+		`(?i)0\.0\.0\.0/0|::/0|\bpublic(ly)?\b|\binternet\b|\banonymous\b|\ball ?users\b|\bunrestricted\b|\bopen to\b`)
+)
+
+// RiskSummary ranks the files and the resources with results by their risk score, highest first
+type RiskSummary struct {
+	Files     []FileRisk     `json:"files"`
+	Resources []ResourceRisk `json:"resources"`
+}
+
+// FileRisk is the risk score of a file, the sum of the scores of its resources
+type FileRisk struct {
+	FileName  string  `json:"file_name"`
+	Score     float64 `json:"score"`
+	Resources int     `json:"resources"`
+	Results   int     `json:"results"`
+}
+
+// ResourceRisk is the risk score of a resource, the sum of the severity weight of its results multiplied by the
+// factor of their category, multiplied again when a result exposes the resource to the internet
+type ResourceRisk struct {
+	FileName        string  `json:"file_name"`
+	Resource        string  `json:"resource"`
+	Score           float64 `json:"score"`
+	InternetExposed bool    `json:"internet_exposed"`
+	Results         int     `json:"results"`
+}
+
+// CreateRiskSummary computes the risk score of the resources and files of the results, the results carrying no
+// risk are left out
+func CreateRiskSummary(queries QueryResultSlice) *RiskSummary {
+	type resourceKey struct {
+		fileName string
+		resource string
+	}
+	resources := make(map[resourceKey]*ResourceRisk)
+	for i := range queries {
+		query := &queries[i]
+		weight := riskSeverityWeights[query.Severity] * riskCategoryFactor(query.Category)
+		if weight == 0 {
+			continue
+		}
+		for j := range query.Files {
+			file := &query.Files[j]
+			key := resourceKey{fileName: file.FileName, resource: riskResource(file)}
+			resource, ok := resources[key]
+			if !ok {
+				resource = &ResourceRisk{FileName: key.fileName, Resource: key.resource}
+				resources[key] = resource
+			}
+			resource.Score += weight
+			resource.Results++
+			resource.InternetExposed = resource.InternetExposed ||
+				riskExposureRegex.MatchString(query.QueryName+" "+file.KeyActualValue)
+		}
+	}
+
+	risk := &RiskSummary{
+		Files:     make([]FileRisk, 0),
+		Resources: make([]ResourceRisk, 0, len(resources)),
+	}
+	files := make(map[string]*FileRisk)
+	for _, resource := range resources {
+		if resource.InternetExposed {
+			resource.Score *= riskExposureFactor
+		}
+		file, ok := files[resource.FileName]
+		if !ok {
+			file = &FileRisk{FileName: resource.FileName}
+			files[resource.FileName] = file
+		}
+		file.Score += resource.Score
+		file.Resources++
+		file.Results += resource.Results
+		resource.Score = roundScore(resource.Score)
+		risk.Resources = append(risk.Resources, *resource)
+	}
+	for _, file := range files {
+		file.Score = roundScore(file.Score)
+		risk.Files = append(risk.Files, *file)
+	}
+
+	sort.Slice(risk.Resources, func(i, j int) bool {
+		if risk.Resources[i].Score != risk.Resources[j].Score {
+			return risk.Resources[i].Score > risk.Resources[j].Score
+		}
+		if risk.Resources[i].FileName != risk.Resources[j].FileName {
+			return risk.Resources[i].FileName < risk.Resources[j].FileName
+		}
+		return risk.Resources[i].Resource < risk.Resources[j].Resource
+	})
+	sort.Slice(risk.Files, func(i, j int) bool {
+		if risk.Files[i].Score != risk.Files[j].Score {
+			return risk.Files[i].Score > risk.Files[j].Score
+		}
+		return risk.Files[i].FileName < risk.Files[j].FileName
+	})
+	return risk
+}
+
+func riskCategoryFactor(category string) float64 {
+	if factor, ok := riskCategoryFactors[category]; ok {
+		return factor
+	}
+	return riskDefaultCategoryFactor
+}
+
+// riskResource returns the resource of a result, its type and name when the query sets them, or else the first
+// element of its search key when it names the resource, e.g. aws_s3_bucket[logs] or name={{create bucket}}, and
+// the first two elements otherwise, e.g. resource.aws_s3_bucket[logs] or Resources.LogsBucket
+func riskResource(file *VulnerableFile) string {
+	if file.ResourceType != "" && file.ResourceType != riskUnknownResource {
+		if file.ResourceName == "" || file.ResourceName == riskUnknownResource {
+			return file.ResourceType
+		}
+		return file.ResourceType + "." + file.ResourceName
+	}
+
+	// the dots inside brackets and braces are part of the element, e.g. name={{amazon.aws.s3_bucket}}
+	depth := 0
+	elements := 0
+	for i, char := range file.SearchKey {
+		switch char {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case '.':
+			if depth > 0 {
+				continue
+			}
+			if elements++; elements == 2 || strings.HasSuffix(file.SearchKey[:i], "]") ||
+				strings.HasSuffix(file.SearchKey[:i], "}") {
+				return file.SearchKey[:i]
+			}
+		}
+	}
+	return strings.TrimSpace(file.SearchKey)
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
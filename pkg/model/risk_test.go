@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRiskSummary(t *testing.T) {
+	queries := QueryResultSlice{
+		{
+			QueryName: "Security Group With Unrestricted Access To SSH",
+			Severity:  SeverityHigh,
+			Category:  "Networking and Firewall",
+			Files: []VulnerableFile{
+				{
+					FileName:       "network/main.tf",
+					ResourceType:   "aws_security_group",
+					ResourceName:   "ssh",
+					SearchKey:      "aws_security_group[ssh].ingress",
+					KeyActualValue: "'ingress' accepts the port 22 from '0.0.0.0/0'",
+				},
+			},
+		},
+		{
+			QueryName: "S3 Bucket Without Server-side-encryption",
+			Severity:  SeverityMedium,
+			Category:  "Encryption",
+			Files: []VulnerableFile{
+				{FileName: "storage/main.tf", SearchKey: "resource.aws_s3_bucket[logs]", KeyActualValue: "'server_side_encryption_configuration' is undefined"},
+				{FileName: "storage/template.yaml", SearchKey: "Resources.Logs.Properties", KeyActualValue: "'BucketEncryption' is undefined"},
+			},
+		},
+		{
+			QueryName: "S3 Bucket Logging Disabled",
+			Severity:  SeverityLow,
+			Category:  "Observability",
+			Files: []VulnerableFile{
+				{FileName: "storage/main.tf", SearchKey: "resource.aws_s3_bucket[logs].logging", KeyActualValue: "'logging' is undefined"},
+			},
+		},
+		{
+			QueryName: "Resource Not Using Tags",
+			Severity:  SeverityInfo,
+			Category:  "Best Practices",
+			Files: []VulnerableFile{
+				{FileName: "network/vpc.tf", SearchKey: "aws_vpc[main]", KeyActualValue: "'tags' is undefined"},
+			},
+		},
+	}
+
+	risk := CreateRiskSummary(queries)
+
+	require.Equal(t, []ResourceRisk{
+		{FileName: "network/main.tf", Resource: "aws_security_group.ssh", Score: 13.7, InternetExposed: true, Results: 1},
+		{FileName: "storage/main.tf", Resource: "resource.aws_s3_bucket[logs]", Score: 5.5, Results: 2},
+		{FileName: "storage/template.yaml", Resource: "Resources.Logs", Score: 4.8, Results: 1},
+	}, risk.Resources)
+	require.Equal(t, []FileRisk{
+		{FileName: "network/main.tf", Score: 13.7, Resources: 1, Results: 1},
+		{FileName: "storage/main.tf", Score: 5.5, Resources: 1, Results: 2},
+		{FileName: "storage/template.yaml", Score: 4.8, Resources: 1, Results: 1},
+	}, risk.Files)
+}
+
+func TestRiskResource(t *testing.T) {
+	tests := []struct {
+		name string
+		file VulnerableFile
+		want string
+	}{
+		{name: "resource_type", file: VulnerableFile{ResourceType: "aws_s3_bucket", ResourceName: "logs"}, want: "aws_s3_bucket.logs"},
+		{name: "resource_type_without_name", file: VulnerableFile{ResourceType: "Deployment"}, want: "Deployment"},
+		{name: "unknown_resource_type", file: VulnerableFile{ResourceType: "n/a", ResourceName: "n/a", SearchKey: "provider.aws"}, want: "provider.aws"},
+		{name: "search_key", file: VulnerableFile{SearchKey: "Resources.Logs.Properties.BucketName"}, want: "Resources.Logs"},
+		{name: "search_key_with_resource", file: VulnerableFile{SearchKey: "aws_s3_bucket[logs].logging"}, want: "aws_s3_bucket[logs]"},
+		{name: "search_key_with_task_name", file: VulnerableFile{SearchKey: "name={{bucket}}.{{amazon.aws.s3_bucket}}.acl"}, want: "name={{bucket}}"},
+		{name: "short_search_key", file: VulnerableFile{SearchKey: "FROM={{alpine:3.18}}"}, want: "FROM={{alpine:3.18}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, riskResource(&tt.file))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Checkmarx/kics/pkg/utils"
 	"github.com/rs/zerolog/log"
 )
 
@@ -36,6 +37,29 @@ type VulnerableFile struct {
 	Value            *string     `json:"value,omitempty"`
 	Remediation      string      `json:"remediation,omitempty"`
 	RemediationType  string      `json:"remediation_type,omitempty"`
+	Owner            string      `json:"owner,omitempty"`
+	Project          string      `json:"project,omitempty"`
+	Generated        bool        `json:"generated,omitempty"`
+	Enrichment       *Enrichment `json:"enrichment,omitempty"`
+	Blame            *Blame      `json:"blame,omitempty"`
+}
+
+// Blame is the last commit that changed the line of a result, as reported by git blame, Date is the author date
+type Blame struct {
+	Author      string `json:"author"`
+	AuthorEmail string `json:"author_email,omitempty"`
+	Commit      string `json:"commit"`
+	Date        string `json:"date"`
+}
+
+// Enrichment is the live cloud context of a result, gathered by the enrichers listed in Enrichers during the scan
+// rather than read from the scanned files, AccountID is the AWS account, the Azure subscription or the GCP project
+// and ResourceExists is only set by the enrichers that can look the resource up
+type Enrichment struct {
+	Enrichers      []string          `json:"enrichers"`
+	AccountID      string            `json:"account_id,omitempty"`
+	ResourceExists *bool             `json:"resource_exists,omitempty"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
 }
 
 // QueryResult contains a query that tested positive ID, name, severity and a list of files that tested vulnerable
@@ -60,6 +84,12 @@ type QueryResult struct {
 	CISBenchmarkName            string           `json:"cis_benchmark_name,omitempty"`
 	CISBenchmarkVersion         string           `json:"cis_benchmark_version,omitempty"`
 	Files                       []VulnerableFile `json:"files"`
+	Truncated                   bool             `json:"truncated,omitempty"`
+	TotalResults                int              `json:"total_results,omitempty"`
+	OmittedResults              int              `json:"omitted_results,omitempty"`
+	// OmittedFiles are the results left out of the reports by the limit of results per query, the exit code still
+	// takes them into account
+	OmittedFiles []VulnerableFile `json:"-"`
 }
 
 // QueryResultSlice is a slice of QueryResult
@@ -79,6 +109,35 @@ type Counters struct {
 	FailedSimilarityID     int `json:"queries_failed_to_compute_similarity_id"`
 }
 
+// ParseFailure represents a file that could not be parsed or resolved and was not scanned
+type ParseFailure struct {
+	FilePath string `json:"file_name"`
+	Platform string `json:"platform"`
+	Error    string `json:"error"`
+	Line     int    `json:"line"`
+	Code     string `json:"code,omitempty"`
+}
+
+// FailedQuery represents a query that failed to execute, the document and the file are set when the error was
+// raised by a result of the query on a document and Location is the location of the Rego error in the query
+type FailedQuery struct {
+	QueryID    string `json:"query_id"`
+	QueryName  string `json:"query_name"`
+	Platform   string `json:"platform"`
+	Error      string `json:"error"`
+	Location   string `json:"location,omitempty"`
+	DocumentID string `json:"document_id,omitempty"`
+	FilePath   string `json:"file_name,omitempty"`
+	Reproduce  string `json:"reproduce"`
+}
+
+// SkippedQuery represents a query that was not executed because the scan timeout expired
+type SkippedQuery struct {
+	QueryID   string `json:"query_id"`
+	QueryName string `json:"query_name"`
+	Platform  string `json:"platform"`
+}
+
 // Times represents an object that contains the start and end time of the scan
 type Times struct {
 	Start time.Time `json:"start"`
@@ -94,14 +153,48 @@ type VersionResponse struct {
 // Summary is a report of a single scan
 type Summary struct {
 	Version       string  `json:"kics_version,omitempty"`
+	SchemaVersion string  `json:"schema_version,omitempty"`
 	LatestVersion Version `json:"-"`
 	Counters
 	SeveritySummary
 	Times
-	ScannedPaths []string          `json:"paths"`
-	Queries      QueryResultSlice  `json:"queries"`
-	Bom          QueryResultSlice  `json:"bill_of_materials,omitempty"`
-	FilePaths    map[string]string `json:"-"`
+	ScannedPaths   []string          `json:"paths"`
+	Queries        QueryResultSlice  `json:"queries"`
+	Bom            QueryResultSlice  `json:"bill_of_materials,omitempty"`
+	ParseFailures  []ParseFailure    `json:"parse_failures,omitempty"`
+	FailedQueries  []FailedQuery     `json:"failed_queries,omitempty"`
+	Partial        bool              `json:"partial,omitempty"`
+	SkippedQueries []SkippedQuery    `json:"skipped_queries,omitempty"`
+	SkippedFiles   []string          `json:"skipped_files,omitempty"`
+	GuardedFiles   []GuardedFile     `json:"guarded_files,omitempty"`
+	Risk           *RiskSummary      `json:"risk,omitempty"`
+	Projects       []ProjectSummary  `json:"projects,omitempty"`
+	Performance    *Performance      `json:"performance,omitempty"`
+	FilePaths      map[string]string `json:"-"`
+	ResourceGraph  *ResourceGraph    `json:"-"`
+	Attestation    *Attestation      `json:"-"`
+	HTMLPageSize   int               `json:"-"`
+	// SuppressedQueries are the results not reported because of a suppression, only kept for the SARIF report
+	SuppressedQueries QueryResultSlice       `json:"-"`
+	Suppressions      map[string]Suppression `json:"-"`
+	Baseline          *Baseline              `json:"-"`
+	// QueryDocs are the documentation of the queries with results, by query ID, embedded in the HTML and PDF reports
+	QueryDocs map[string]*QueryDocumentation `json:"-"`
+	// Inventory are the resources declared in the scanned files, only kept for the BoM report
+	Inventory []InventoryResource `json:"-"`
+}
+
+// ProjectSummary is the roll-up of the results of a project, FailOn replaces the severities of --fail-on for its
+// results when set and ExitCode is the exit code of the scan of the project alone
+type ProjectSummary struct {
+	Name             string           `json:"name"`
+	Paths            []string         `json:"paths"`
+	FailOn           []string         `json:"fail_on,omitempty"`
+	SeverityCounters map[Severity]int `json:"severity_counters"`
+	TotalCounter     int              `json:"total_counter"`
+	FilesWithResults int              `json:"files_with_results"`
+	ExitCode         int              `json:"exit_code"`
+	Files            map[string]bool  `json:"-"`
 }
 
 // PathParameters - structure wraps the required fields for temporary path translation
@@ -110,6 +203,14 @@ type PathParameters struct {
 	PathExtractionMap map[string]ExtractedPathObject
 }
 
+// ReproduceParameters are the parameters of the scan kept in the commands reproducing the failed queries, the
+// queries and libraries paths are only set when they are not the default ones
+type ReproduceParameters struct {
+	QueriesPath   []string
+	LibrariesPath string
+	Types         []string
+}
+
 var (
 	queryRegex   = regexp.MustCompile(`\?([\w-]+(=[\w-]*)?(&[\w-]+(=[\w-]*)?)*)?`)
 	urlAuthRegex = regexp.MustCompile(`((ssh|https?)://)(\S+(:\S*)?@).*`)
@@ -129,22 +230,22 @@ func getRelativePath(basePath, filePath string) string {
 }
 
 func replaceIfTemporaryPath(filePath string, pathExtractionMap map[string]ExtractedPathObject) string {
-	prettyPath := filePath
 	for key, val := range pathExtractionMap {
-		if strings.Contains(filePath, key) {
-			splittedPath := strings.Split(filePath, key)
-			if !val.LocalPath {
-				// remove authentication information from the URL
-				sanitizedURL := removeURLCredentials(val.Path)
-				// remove query parameters '?key=value&key2=value'
-				return filepath.FromSlash(queryRegex.ReplaceAllString(sanitizedURL, "") + splittedPath[1])
-			}
-			prettyPath = filepath.FromSlash(filepath.Base(val.Path) + splittedPath[1])
-		} else {
-			prettyPath = filePath
+		// the Windows paths are case-insensitive, the path of a file can differ in case from its source
+		idx := utils.IndexPath(filePath, key)
+		if idx < 0 {
+			continue
+		}
+		relativePath := filePath[idx+len(key):]
+		if !val.LocalPath {
+			// remove authentication information from the URL
+			sanitizedURL := removeURLCredentials(val.Path)
+			// remove query parameters '?key=value&key2=value'
+			return filepath.FromSlash(queryRegex.ReplaceAllString(sanitizedURL, "") + relativePath)
 		}
+		return filepath.FromSlash(filepath.Base(val.Path) + relativePath)
 	}
-	return prettyPath
+	return filePath
 }
 
 func removeAllURLCredentials(pathExtractionMap map[string]ExtractedPathObject) []string {
@@ -182,6 +283,157 @@ func resolvePath(filePath string, pathExtractionMap map[string]ExtractedPathObje
 	return returnPath
 }
 
+// CreateParseFailures returns the parse failures with their paths resolved, sorted by file and line
+func CreateParseFailures(failures []ParseFailure, pathExtractionMap map[string]ExtractedPathObject) []ParseFailure {
+	parseFailures := make([]ParseFailure, 0, len(failures))
+	for i := range failures {
+		failure := failures[i]
+		failure.FilePath = resolvePath(failure.FilePath, pathExtractionMap)
+		parseFailures = append(parseFailures, failure)
+	}
+	sort.Slice(parseFailures, func(i, j int) bool {
+		if parseFailures[i].FilePath == parseFailures[j].FilePath {
+			return parseFailures[i].Line < parseFailures[j].Line
+		}
+		return parseFailures[i].FilePath < parseFailures[j].FilePath
+	})
+	return parseFailures
+}
+
+// CreateFailedQueries returns the first failure of each query with its path resolved and the command reproducing it,
+// which scans the file of the failure, or the scanned paths, with the query alone and exports the evaluated payload
+func CreateFailedQueries(failures []FailedQuery, pathParameters PathParameters, reproduce *ReproduceParameters) []FailedQuery {
+	seen := make(map[string]bool, len(failures))
+	failedQueries := make([]FailedQuery, 0, len(failures))
+	for i := range failures {
+		failure := failures[i]
+		if seen[failure.QueryName+"|"+failure.Platform] {
+			continue
+		}
+		seen[failure.QueryName+"|"+failure.Platform] = true
+
+		paths := pathParameters.ScannedPaths
+		if failure.FilePath != "" {
+			failure.FilePath = resolvePath(failure.FilePath, pathParameters.PathExtractionMap)
+			paths = []string{failure.FilePath}
+		}
+		failure.Reproduce = reproduceCommand(&failure, paths, reproduce, pathParameters.PathExtractionMap)
+		failedQueries = append(failedQueries, failure)
+	}
+	sort.Slice(failedQueries, func(i, j int) bool {
+		if failedQueries[i].QueryName == failedQueries[j].QueryName {
+			return failedQueries[i].Platform < failedQueries[j].Platform
+		}
+		return failedQueries[i].QueryName < failedQueries[j].QueryName
+	})
+	return failedQueries
+}
+
+func reproduceCommand(failure *FailedQuery, paths []string, reproduce *ReproduceParameters,
+	pathExtractionMap map[string]ExtractedPathObject) string {
+	command := "kics scan -p " + shellQuote(strings.Join(paths, ","))
+	if len(reproduce.QueriesPath) > 0 {
+		queriesPath := make([]string, 0, len(reproduce.QueriesPath))
+		for _, queryPath := range reproduce.QueriesPath {
+			queriesPath = append(queriesPath, sourcePath(queryPath, pathExtractionMap))
+		}
+		command += " -q " + shellQuote(strings.Join(queriesPath, ","))
+	}
+	if reproduce.LibrariesPath != "" {
+		command += " --libraries-path " + shellQuote(sourcePath(reproduce.LibrariesPath, pathExtractionMap))
+	}
+	if types := strings.Join(reproduce.Types, ","); types != "" {
+		command += " --type " + shellQuote(types)
+	}
+	if failure.QueryID != "" {
+		command += " --include-queries " + failure.QueryID
+	}
+	return command + " --payload-path payload.json --log-level DEBUG -v"
+}
+
+// sourcePath returns the URL of a path extracted from a remote source, the local paths are kept as they are
+func sourcePath(path string, pathExtractionMap map[string]ExtractedPathObject) string {
+	for key, val := range pathExtractionMap {
+		if !val.LocalPath && utils.IndexPath(path, key) >= 0 {
+			return replaceIfTemporaryPath(path, map[string]ExtractedPathObject{key: val})
+		}
+	}
+	return path
+}
+
+// shellQuote quotes a value for a POSIX shell, the single quotes it contains are escaped
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// CreateSkippedFiles returns the files skipped because of the scan timeout with their paths resolved, sorted
+func CreateSkippedFiles(files []string, pathExtractionMap map[string]ExtractedPathObject) []string {
+	skippedFiles := make([]string, 0, len(files))
+	for _, file := range files {
+		skippedFiles = append(skippedFiles, resolvePath(file, pathExtractionMap))
+	}
+	sort.Strings(skippedFiles)
+	return skippedFiles
+}
+
+// CreateGuardedFiles returns the files left out of the scan by the guards with their paths resolved, sorted
+func CreateGuardedFiles(files []GuardedFile, pathExtractionMap map[string]ExtractedPathObject) []GuardedFile {
+	guardedFiles := make([]GuardedFile, 0, len(files))
+	for _, file := range files {
+		file.FilePath = resolvePath(file.FilePath, pathExtractionMap)
+		guardedFiles = append(guardedFiles, file)
+	}
+	sort.Slice(guardedFiles, func(i, j int) bool {
+		return guardedFiles[i].FilePath < guardedFiles[j].FilePath
+	})
+	return guardedFiles
+}
+
+// LimitResultsPerQuery keeps, sorted by file and line, the first results of each query up to limit,
+// the queries with omitted results are marked as truncated and keep their total and omitted number of results.
+// The severity counters keep counting every result found and a limit lower than one keeps every result
+func LimitResultsPerQuery(summary *Summary, limit int) {
+	if limit < 1 {
+		return
+	}
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		if len(query.Files) <= limit {
+			continue
+		}
+		sort.SliceStable(query.Files, func(a, b int) bool {
+			if query.Files[a].FileName == query.Files[b].FileName {
+				return query.Files[a].Line < query.Files[b].Line
+			}
+			return query.Files[a].FileName < query.Files[b].FileName
+		})
+
+		query.Truncated = true
+		query.TotalResults = len(query.Files)
+		query.OmittedResults = len(query.Files) - limit
+		query.OmittedFiles = query.Files[limit:]
+		query.Files = query.Files[:limit]
+	}
+}
+
+// sortQueryResults sorts the queries by severity, from critical to trace, and by name
+func sortQueryResults(queries []QueryResult) {
+	severityOrder := map[Severity]int{
+		SeverityTrace:    5,
+		SeverityInfo:     4,
+		SeverityLow:      3,
+		SeverityMedium:   2,
+		SeverityHigh:     1,
+		SeverityCritical: 0,
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if severityOrder[queries[i].Severity] == severityOrder[queries[j].Severity] {
+			return queries[i].QueryName < queries[j].QueryName
+		}
+		return severityOrder[queries[i].Severity] < severityOrder[queries[j].Severity]
+	})
+}
+
 // CreateSummary creates a report for a single scan, based on its scanID
 func CreateSummary(counters Counters, vulnerabilities []Vulnerability,
 	scanID string, pathExtractionMap map[string]ExtractedPathObject, version Version) Summary {
@@ -249,20 +501,7 @@ func CreateSummary(counters Counters, vulnerabilities []Vulnerability,
 		severitySummary.TotalCounter += len(q[idx].Files)
 	}
 
-	severityOrder := map[Severity]int{
-		SeverityTrace:    5,
-		SeverityInfo:     4,
-		SeverityLow:      3,
-		SeverityMedium:   2,
-		SeverityHigh:     1,
-		SeverityCritical: 0,
-	}
-	sort.Slice(queries, func(i, j int) bool {
-		if severityOrder[queries[i].Severity] == severityOrder[queries[j].Severity] {
-			return queries[i].QueryName < queries[j].QueryName
-		}
-		return severityOrder[queries[i].Severity] < severityOrder[queries[j].Severity]
-	})
+	sortQueryResults(queries)
 
 	materials := make([]QueryResult, 0, len(q))
 	for idx := range q {
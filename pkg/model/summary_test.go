@@ -159,6 +159,27 @@ func TestModel_resolvePath(t *testing.T) {
 			},
 			want: filepath.FromSlash("assets/queries/dockerfile/image_version_not_explicit/test/negative.dockerfile"),
 		},
+		{
+			name: "test_with_multiple_sources",
+			args: args{
+				filePath: filepath.FromSlash("/tmp/kics-extract-2/file/vuln"),
+				pathExtractionMap: map[string]ExtractedPathObject{
+					filepath.FromSlash("/tmp/kics-extract-1"): {
+						Path:      filepath.FromSlash("/repos/first"),
+						LocalPath: true,
+					},
+					filepath.FromSlash("/tmp/kics-extract-2"): {
+						Path:      filepath.FromSlash("/repos/second"),
+						LocalPath: true,
+					},
+					filepath.FromSlash("/tmp/kics-extract-3"): {
+						Path:      filepath.FromSlash("/repos/third"),
+						LocalPath: true,
+					},
+				},
+			},
+			want: filepath.FromSlash("second/file/vuln"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -252,3 +273,108 @@ func TestRemoveAllURLCredentials(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateParseFailures(t *testing.T) {
+	failures := []ParseFailure{
+		{FilePath: "values.yaml", Error: "yaml: line 2: found character that cannot start any token", Line: 2},
+		{FilePath: "main.tf", Platform: "terraform", Error: "main.tf:8,1-2: Argument or block definition required", Line: 8},
+		{FilePath: "main.tf", Platform: "terraform", Error: "main.tf:3,9-4,1: Invalid expression", Line: 3, Code: "acl ="},
+	}
+
+	got := CreateParseFailures(failures, map[string]ExtractedPathObject{})
+
+	require.Equal(t, []ParseFailure{
+		{FilePath: "main.tf", Platform: "terraform", Error: "main.tf:3,9-4,1: Invalid expression", Line: 3, Code: "acl ="},
+		{FilePath: "main.tf", Platform: "terraform", Error: "main.tf:8,1-2: Argument or block definition required", Line: 8},
+		{FilePath: "values.yaml", Error: "yaml: line 2: found character that cannot start any token", Line: 2},
+	}, got)
+	require.Equal(t, "values.yaml", failures[0].FilePath)
+}
+
+func TestCreateFailedQueries(t *testing.T) {
+	failures := []FailedQuery{
+		{
+			QueryID: "b-id", QueryName: "B Query", Platform: "Terraform", Error: "eval_conflict_error: multiple outputs",
+			Location: "query.rego:12:3",
+		},
+		{
+			QueryID: "a-id", QueryName: "A Query", Platform: "Kubernetes", Error: "invalid search line",
+			DocumentID: "3b1f", FilePath: "deploy.yaml",
+		},
+		{QueryID: "b-id", QueryName: "B Query", Platform: "Terraform", Error: "failed to evaluate query"},
+	}
+
+	got := CreateFailedQueries(failures, PathParameters{
+		ScannedPaths:      []string{"infra", "charts"},
+		PathExtractionMap: map[string]ExtractedPathObject{},
+	}, &ReproduceParameters{})
+
+	require.Equal(t, []FailedQuery{
+		{
+			QueryID: "a-id", QueryName: "A Query", Platform: "Kubernetes", Error: "invalid search line",
+			DocumentID: "3b1f", FilePath: "deploy.yaml",
+			Reproduce: "kics scan -p 'deploy.yaml' --include-queries a-id --payload-path payload.json --log-level DEBUG -v",
+		},
+		{
+			QueryID: "b-id", QueryName: "B Query", Platform: "Terraform", Error: "eval_conflict_error: multiple outputs",
+			Location:  "query.rego:12:3",
+			Reproduce: "kics scan -p 'infra,charts' --include-queries b-id --payload-path payload.json --log-level DEBUG -v",
+		},
+	}, got)
+
+	// the custom queries are reproduced from their source, and the quotes of the paths are escaped
+	extractedQueries := filepath.FromSlash("/tmp/kics-extract-1")
+	got = CreateFailedQueries(failures[:1], PathParameters{
+		ScannedPaths: []string{"ops'infra"},
+		PathExtractionMap: map[string]ExtractedPathObject{
+			extractedQueries: {Path: "git::https://github.com/org/queries", LocalPath: false},
+		},
+	}, &ReproduceParameters{
+		QueriesPath:   []string{filepath.Join(extractedQueries, "terraform")},
+		LibrariesPath: "libraries",
+		Types:         []string{"terraform"},
+	})
+	require.Equal(t, "kics scan -p 'ops'\\''infra' -q '"+filepath.FromSlash("git::https://github.com/org/queries/terraform")+
+		"' --libraries-path 'libraries' --type 'terraform' --include-queries b-id --payload-path payload.json --log-level DEBUG -v",
+		got[0].Reproduce)
+}
+
+func TestLimitResultsPerQuery(t *testing.T) {
+	summary := Summary{
+		Queries: QueryResultSlice{
+			{
+				QueryName: "truncated",
+				Severity:  SeverityHigh,
+				Files: []VulnerableFile{
+					{FileName: "b.tf", Line: 1},
+					{FileName: "a.tf", Line: 9},
+					{FileName: "a.tf", Line: 2},
+				},
+			},
+			{
+				QueryName: "kept",
+				Severity:  SeverityLow,
+				Files:     []VulnerableFile{{FileName: "a.tf", Line: 1}},
+			},
+		},
+		SeveritySummary: SeveritySummary{
+			SeverityCounters: map[Severity]int{SeverityHigh: 3, SeverityLow: 1},
+			TotalCounter:     4,
+		},
+	}
+
+	LimitResultsPerQuery(&summary, 0)
+	require.Len(t, summary.Queries[0].Files, 3)
+	require.False(t, summary.Queries[0].Truncated)
+
+	LimitResultsPerQuery(&summary, 2)
+	require.Equal(t, []VulnerableFile{{FileName: "a.tf", Line: 2}, {FileName: "a.tf", Line: 9}}, summary.Queries[0].Files)
+	require.True(t, summary.Queries[0].Truncated)
+	require.Equal(t, 3, summary.Queries[0].TotalResults)
+	require.Equal(t, 1, summary.Queries[0].OmittedResults)
+	require.False(t, summary.Queries[1].Truncated)
+	require.Zero(t, summary.Queries[1].OmittedResults)
+	// the counters keep every result found so the exit code is not changed by the limit
+	require.Equal(t, 3, summary.SeverityCounters[SeverityHigh])
+	require.Equal(t, 4, summary.TotalCounter)
+}
@@ -0,0 +1,234 @@
+package owners
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// codeOwnersLocations are the locations, relative to the repository root, where CODEOWNERS files are searched
+var codeOwnersLocations = []string{
+	"CODEOWNERS",
+	filepath.Join(".github", "CODEOWNERS"),
+	filepath.Join(".gitlab", "CODEOWNERS"),
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// Rule associates the files matching a pattern with their owners
+type Rule struct {
+	Pattern string
+	Owners  []string
+	matcher *regexp.Regexp
+}
+
+// Ownership contains the rules of a CODEOWNERS file or a custom ownership map,
+// patterns are relative to Root and the last matching rule takes precedence
+type Ownership struct {
+	Root  string
+	Rules []Rule
+}
+
+// Find returns the CODEOWNERS file of the first scanned directory that has one,
+// an empty string is returned when none is found
+func Find(paths []string) string {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		for _, location := range codeOwnersLocations {
+			codeOwnersPath := filepath.Join(path, location)
+			if info, err := os.Stat(codeOwnersPath); err == nil && !info.IsDir() {
+				return codeOwnersPath
+			}
+		}
+	}
+	return ""
+}
+
+// Load reads a CODEOWNERS file or, when its extension is .json, .yaml or .yml, an ownership map
+// that associates each pattern to an owner or a list of owners
+func Load(path string) (*Ownership, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	ownership := &Ownership{
+		Root: getRoot(absPath),
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		ownership.Rules, err = parseOwnershipMap(content)
+	default:
+		ownership.Rules = parseCodeOwners(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ownership file %s: %w", path, err)
+	}
+
+	log.Debug().Msgf("Loaded %d ownership rules from %s", len(ownership.Rules), path)
+	return ownership, nil
+}
+
+// getRoot returns the directory the patterns of an ownership file are relative to,
+// CODEOWNERS files in .github, .gitlab and docs directories are relative to the repository root
+func getRoot(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(path) == "CODEOWNERS" {
+		switch filepath.Base(dir) {
+		case ".github", ".gitlab", "docs":
+			return filepath.Dir(dir)
+		}
+	}
+	return dir
+}
+
+// parseCodeOwners parses the CODEOWNERS syntax, sections headers are ignored
+// and rules without owners remove the ownership of the matching files
+func parseCodeOwners(content []byte) []Rule {
+	rules := make([]Rule, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		rules = append(rules, newRule(fields[0], fields[1:]))
+	}
+	return rules
+}
+
+// parseOwnershipMap parses an ownership map keeping the order in which the patterns are defined
+func parseOwnershipMap(content []byte) ([]Rule, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return []Rule{}, nil
+	}
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a map of patterns to owners")
+	}
+
+	rules := make([]Rule, 0, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		var ownersList []string
+		switch value := mapping.Content[i+1]; value.Kind {
+		case yaml.ScalarNode:
+			ownersList = strings.Fields(value.Value)
+		case yaml.SequenceNode:
+			if err := value.Decode(&ownersList); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("invalid owners of pattern %s", mapping.Content[i].Value)
+		}
+		rules = append(rules, newRule(mapping.Content[i].Value, ownersList))
+	}
+	return rules, nil
+}
+
+func newRule(pattern string, ownersList []string) Rule {
+	return Rule{
+		Pattern: pattern,
+		Owners:  ownersList,
+		matcher: compilePattern(pattern),
+	}
+}
+
+// compilePattern converts a CODEOWNERS pattern to a regular expression matching slash separated paths,
+// patterns follow the gitignore rules except that "*" does not match the content of nested directories
+func compilePattern(pattern string) *regexp.Regexp {
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// patterns with a slash other than a trailing one are relative to the root
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+
+	lastSegment := pattern[strings.LastIndex(pattern, "/")+1:]
+	switch {
+	case directory:
+		sb.WriteString("/.*")
+	case !strings.ContainsAny(lastSegment, "*?"):
+		// a name without wildcards matches both a file and the content of a directory
+		sb.WriteString("(/.*)?")
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// Owner returns the owners of a file separated by spaces, as written in the last matching rule,
+// an empty string is returned for files not owned or outside of the root directory
+func (o *Ownership) Owner(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	relPath, err := filepath.Rel(o.Root, absPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	for i := len(o.Rules) - 1; i >= 0; i-- {
+		if o.Rules[i].matcher.MatchString(relPath) {
+			return strings.Join(o.Rules[i].Owners, " ")
+		}
+	}
+	return ""
+}
+
+// SetOwners sets the owner of each result of the summary
+func (o *Ownership) SetOwners(summary *model.Summary) {
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			fileName := summary.Queries[i].Files[j].FileName
+			if originalPath, ok := summary.FilePaths[fileName]; ok {
+				fileName = originalPath
+			}
+			summary.Queries[i].Files[j].Owner = o.Owner(fileName)
+		}
+	}
+}
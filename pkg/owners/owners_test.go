@@ -0,0 +1,112 @@
+package owners
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var ownersFixture = filepath.FromSlash("../../test/fixtures/test_codeowners")
+
+func TestFind(t *testing.T) {
+	require.Equal(t, filepath.Join(ownersFixture, ".github", "CODEOWNERS"), Find([]string{"not-found", ownersFixture}))
+	require.Equal(t, "", Find([]string{filepath.Join(ownersFixture, "ownership.yaml")}))
+	require.Equal(t, "", Find([]string{"not-found"}))
+}
+
+func TestOwnership_Owner(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "codeowners",
+			path: filepath.Join(ownersFixture, ".github", "CODEOWNERS"),
+		},
+		{
+			name: "ownership map",
+			path: filepath.Join(ownersFixture, "ownership.yaml"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownership, err := Load(tt.path)
+			require.NoError(t, err)
+			require.Len(t, ownership.Rules, 5)
+
+			root := ownersFixture
+			require.Equal(t, "@org/platform", ownership.Owner(filepath.Join(root, "main.tf")))
+			require.Equal(t, "@org/infra @org/security", ownership.Owner(filepath.Join(root, "terraform", "vpc", "main.tf")))
+			require.Equal(t, "@org/k8s", ownership.Owner(filepath.Join(root, "terraform", "values.yaml")))
+			require.Equal(t, "", ownership.Owner(filepath.Join(root, "terraform", "shared", "main.tf")))
+			require.Equal(t, "@org/docs", ownership.Owner(filepath.Join(root, "docs", "main.tf")))
+			require.Equal(t, "@org/platform", ownership.Owner(filepath.Join(root, "docs", "nested", "main.tf")))
+			require.Equal(t, "", ownership.Owner(filepath.Join(root, "..", "main.tf")))
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(filepath.Join(ownersFixture, "not-found"))
+	require.Error(t, err)
+
+	_, err = parseOwnershipMap([]byte("- not a map"))
+	require.Error(t, err)
+
+	_, err = parseOwnershipMap([]byte("terraform/:\n  owner: \"@org/infra\""))
+	require.Error(t, err)
+}
+
+func TestOwnership_SetOwners(t *testing.T) {
+	ownership, err := Load(filepath.Join(ownersFixture, ".github", "CODEOWNERS"))
+	require.NoError(t, err)
+
+	resolvedPath := filepath.Join("remote", "terraform", "main.tf")
+	summary := model.Summary{
+		Queries: []model.QueryResult{
+			{
+				Files: []model.VulnerableFile{
+					{FileName: filepath.Join(ownersFixture, "k8s", "pod.yaml")},
+					{FileName: resolvedPath},
+				},
+			},
+		},
+		FilePaths: map[string]string{
+			resolvedPath: filepath.Join(ownersFixture, "terraform", "main.tf"),
+		},
+	}
+	ownership.SetOwners(&summary)
+
+	require.Equal(t, "@org/k8s", summary.Queries[0].Files[0].Owner)
+	require.Equal(t, "@org/infra @org/security", summary.Queries[0].Files[1].Owner)
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*", path: "a/b/c.tf", want: true},
+		{pattern: "*.tf", path: "a/b/c.tf", want: true},
+		{pattern: "*.tf", path: "a/b/c.yaml", want: false},
+		{pattern: "/*.tf", path: "a/c.tf", want: false},
+		{pattern: "apps/", path: "src/apps/main.tf", want: true},
+		{pattern: "/apps/", path: "src/apps/main.tf", want: false},
+		{pattern: "/apps", path: "apps/nested/main.tf", want: true},
+		{pattern: "docs/*", path: "docs/main.tf", want: true},
+		{pattern: "docs/*", path: "docs/nested/main.tf", want: false},
+		{pattern: "**/logs", path: "a/b/logs/main.tf", want: true},
+		{pattern: "infra/**/main.tf", path: "infra/a/b/main.tf", want: true},
+		{pattern: "main.t?", path: "main.tf", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, compilePattern(tt.pattern).MatchString(tt.path))
+		})
+	}
+}
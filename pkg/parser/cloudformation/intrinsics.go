@@ -0,0 +1,556 @@
+// Package cloudformation resolves the intrinsic functions of the CloudFormation templates
+package cloudformation
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	noValue             = "AWS::NoValue"
+	importValueFunction = "Fn::ImportValue"
+	conditionsMaxDepth  = 10
+)
+
+var (
+	subVariableRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+	// secretNameRegex matches the names of the parameters holding secrets, their references are kept so the
+	// queries can report the secrets given as default values
+	secretNameRegex = regexp.MustCompile(`(?i)(pass|pwd|secret|token|key|credential|auth|private)`)
+	// siblingExtensions are the extensions of the templates whose exports can be imported
+	siblingExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true, ".template": true}
+	// noValueNode is returned by the functions resolved to AWS::NoValue, the property holding it is removed
+	noValueNode = &yaml.Node{}
+)
+
+// Replacement is a value of the template replaced by the resolution of its intrinsic functions,
+// Path holds the keys and indexes from the template root and Value is nil when the value is removed
+type Replacement struct {
+	Path  []interface{}
+	Value *yaml.Node
+}
+
+// resolver evaluates the intrinsic functions whose value is known without deploying the template: the references
+// to parameters with a default value, Fn::Sub, Fn::Join, Fn::FindInMap, Fn::If with evaluable conditions,
+// Fn::Select, Fn::Split and Fn::ImportValue of the exports of the sibling templates
+type resolver struct {
+	filePath     string
+	parameters   map[string]*yaml.Node
+	mappings     *yaml.Node
+	conditions   *yaml.Node
+	exports      map[string]*yaml.Node
+	replacements []Replacement
+	importValues bool
+}
+
+// IsTemplate returns true when the root node is a CloudFormation template
+func IsTemplate(root *yaml.Node) bool {
+	root = documentRoot(root)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return false
+	}
+	resources := mappingValue(root, "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return false
+	}
+	if mappingValue(root, "AWSTemplateFormatVersion") != nil {
+		return true
+	}
+	for i := 1; i < len(resources.Content); i += 2 {
+		if resourceType := mappingValue(resources.Content[i], "Type"); resourceType != nil &&
+			strings.HasPrefix(resourceType.Value, "AWS::") {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve replaces, in place, the intrinsic functions of the resources and outputs of the template that can be
+// evaluated and returns the replacements made, the intrinsic functions that can not be evaluated are kept
+func Resolve(root *yaml.Node, filePath string) []Replacement {
+	return newResolver(root, filePath, true).resolve(documentRoot(root))
+}
+
+func newResolver(root *yaml.Node, filePath string, importValues bool) *resolver {
+	root = documentRoot(root)
+	r := &resolver{
+		filePath:     filePath,
+		parameters:   make(map[string]*yaml.Node),
+		mappings:     mappingValue(root, "Mappings"),
+		conditions:   mappingValue(root, "Conditions"),
+		replacements: make([]Replacement, 0),
+		importValues: importValues,
+	}
+	if parameters := mappingValue(root, "Parameters"); parameters != nil && parameters.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(parameters.Content); i += 2 {
+			if secretNameRegex.MatchString(parameters.Content[i].Value) {
+				continue
+			}
+			if value := parameterValue(parameters.Content[i+1]); value != nil {
+				r.parameters[parameters.Content[i].Value] = value
+			}
+		}
+	}
+	return r
+}
+
+// parameterValue returns the default value of the parameter, the NoEcho parameters and the parameters resolved
+// from the Systems Manager Parameter Store on deployment have no known value
+func parameterValue(parameter *yaml.Node) *yaml.Node {
+	if parameter.Kind != yaml.MappingNode {
+		return nil
+	}
+	if noEcho := mappingValue(parameter, "NoEcho"); noEcho != nil && strings.EqualFold(noEcho.Value, "true") {
+		return nil
+	}
+	if parameterType := mappingValue(parameter, "Type"); parameterType != nil &&
+		strings.HasPrefix(parameterType.Value, "AWS::SSM::Parameter") {
+		return nil
+	}
+	value := mappingValue(parameter, "Default")
+	if value == nil || isIntrinsic(value) {
+		return nil
+	}
+	return value
+}
+
+func (r *resolver) resolve(root *yaml.Node) []Replacement {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return r.replacements
+	}
+	for _, section := range []string{"Resources", "Outputs"} {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == section {
+				r.walkChildren(root.Content[i+1], []interface{}{section}, false)
+			}
+		}
+	}
+	return r.replacements
+}
+
+// walk resolves the children of the node and then the node itself, it returns the value replacing the node or
+// nil when the node is kept
+func (r *resolver) walk(node *yaml.Node, path []interface{}) *yaml.Node {
+	name, args, ok := intrinsic(node)
+	if !ok {
+		r.walkChildren(node, path, false)
+		return nil
+	}
+
+	if node.Tag != "" && !strings.HasPrefix(node.Tag, "!!") {
+		// the arguments of a short form function are the node content
+		r.walkChildren(node, path, true)
+	} else {
+		argsPath := append(append([]interface{}{}, path...), name)
+		if args.Kind == yaml.SequenceNode && !isIntrinsic(args) {
+			r.walkChildren(args, argsPath, true)
+		} else if replacement := r.walk(args, argsPath); replacement != nil && replacement != noValueNode {
+			r.replace(node, 1, replacement, argsPath)
+			args = replacement
+		}
+	}
+	return r.evaluate(name, args, node)
+}
+
+// walkChildren resolves the values of a mapping or the elements of a sequence, the values resolved to AWS::NoValue
+// are removed unless they are the arguments of a function. The elements are walked backwards so the paths of the
+// following replacements are not affected by the removals
+func (r *resolver) walkChildren(node *yaml.Node, path []interface{}, isArgs bool) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := len(node.Content) - 2; i >= 0; i -= 2 {
+			childPath := append(append([]interface{}{}, path...), node.Content[i].Value)
+			replacement := r.walk(node.Content[i+1], childPath)
+			switch {
+			case replacement == nil:
+			case replacement == noValueNode:
+				if !isArgs {
+					node.Content = append(node.Content[:i], node.Content[i+2:]...)
+					r.replacements = append(r.replacements, Replacement{Path: childPath})
+				}
+			default:
+				r.replace(node, i+1, replacement, childPath)
+			}
+		}
+	case yaml.SequenceNode:
+		for i := len(node.Content) - 1; i >= 0; i-- {
+			childPath := append(append([]interface{}{}, path...), i)
+			replacement := r.walk(node.Content[i], childPath)
+			switch {
+			case replacement == nil:
+			case replacement == noValueNode:
+				if !isArgs {
+					node.Content = append(node.Content[:i], node.Content[i+1:]...)
+					r.replacements = append(r.replacements, Replacement{Path: childPath})
+				}
+			default:
+				r.replace(node, i, replacement, childPath)
+			}
+		}
+	}
+}
+
+func (r *resolver) replace(parent *yaml.Node, idx int, value *yaml.Node, path []interface{}) {
+	parent.Content[idx] = value
+	r.replacements = append(r.replacements, Replacement{Path: path, Value: value})
+}
+
+// evaluate returns the value of the function or nil when it can not be evaluated, the value keeps the position
+// of the function in the template
+func (r *resolver) evaluate(name string, args, node *yaml.Node) *yaml.Node {
+	var value *yaml.Node
+	switch name {
+	case "Ref":
+		if args.Kind == yaml.ScalarNode && args.Value == noValue {
+			return noValueNode
+		}
+		value = r.ref(args)
+	case "Fn::Sub":
+		value = r.sub(args)
+	case "Fn::Join":
+		value = join(args)
+	case "Fn::FindInMap":
+		value = r.findInMap(args)
+	case "Fn::If":
+		return r.fnIf(args, node)
+	case "Fn::Select":
+		value = selectElement(args)
+	case "Fn::Split":
+		value = split(args)
+	case importValueFunction:
+		value = r.importValue(args)
+	}
+	if value == nil {
+		return nil
+	}
+	return positioned(value, node.Line, node.Column)
+}
+
+func (r *resolver) ref(args *yaml.Node) *yaml.Node {
+	if args.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return r.parameters[args.Value]
+}
+
+func (r *resolver) sub(args *yaml.Node) *yaml.Node {
+	template := args
+	variables := make(map[string]*yaml.Node)
+	if args.Kind == yaml.SequenceNode {
+		if len(args.Content) != 2 || args.Content[1].Kind != yaml.MappingNode {
+			return nil
+		}
+		template = args.Content[0]
+		for i := 0; i+1 < len(args.Content[1].Content); i += 2 {
+			variables[args.Content[1].Content[i].Value] = args.Content[1].Content[i+1]
+		}
+	}
+	if template.Kind != yaml.ScalarNode || isIntrinsic(template) {
+		return nil
+	}
+
+	resolved := true
+	value := subVariableRegex.ReplaceAllStringFunc(template.Value, func(match string) string {
+		name := match[2 : len(match)-1]
+		if strings.HasPrefix(name, "!") {
+			return "${" + name[1:] + "}"
+		}
+		variable, ok := variables[name]
+		if !ok {
+			variable = r.parameters[name]
+		}
+		if variable == nil || variable.Kind != yaml.ScalarNode || isIntrinsic(variable) {
+			resolved = false
+			return match
+		}
+		return variable.Value
+	})
+	if !resolved {
+		return nil
+	}
+	return stringNode(value)
+}
+
+func join(args *yaml.Node) *yaml.Node {
+	if args.Kind != yaml.SequenceNode || len(args.Content) != 2 || !isScalar(args.Content[0]) ||
+		args.Content[1].Kind != yaml.SequenceNode {
+		return nil
+	}
+	values := make([]string, 0, len(args.Content[1].Content))
+	for _, item := range args.Content[1].Content {
+		if !isScalar(item) {
+			return nil
+		}
+		values = append(values, item.Value)
+	}
+	return stringNode(strings.Join(values, args.Content[0].Value))
+}
+
+func (r *resolver) findInMap(args *yaml.Node) *yaml.Node {
+	if r.mappings == nil || args.Kind != yaml.SequenceNode || len(args.Content) != 3 {
+		return nil
+	}
+	value := r.mappings
+	for _, key := range args.Content {
+		if !isScalar(key) {
+			return nil
+		}
+		if value = mappingValue(value, key.Value); value == nil {
+			return nil
+		}
+	}
+	if isIntrinsic(value) {
+		return nil
+	}
+	return value
+}
+
+func (r *resolver) fnIf(args, node *yaml.Node) *yaml.Node {
+	if args.Kind != yaml.SequenceNode || len(args.Content) != 3 || !isScalar(args.Content[0]) {
+		return nil
+	}
+	condition, ok := r.condition(args.Content[0].Value, 0)
+	if !ok {
+		return nil
+	}
+	value := args.Content[2]
+	if condition {
+		value = args.Content[1]
+	}
+	if name, refArgs, ok := intrinsic(value); ok && name == "Ref" && refArgs.Value == noValue {
+		return noValueNode
+	}
+	if isIntrinsic(value) {
+		return nil
+	}
+	return positioned(value, node.Line, node.Column)
+}
+
+// condition evaluates the condition of the template, the conditions depending on pseudo parameters or on
+// parameters without a known value are not evaluated
+func (r *resolver) condition(name string, depth int) (result, ok bool) {
+	if depth >= conditionsMaxDepth {
+		return false, false
+	}
+	condition := mappingValue(r.conditions, name)
+	if condition == nil {
+		return false, false
+	}
+	return r.evaluateCondition(condition, depth+1)
+}
+
+func (r *resolver) evaluateCondition(node *yaml.Node, depth int) (result, ok bool) {
+	name, args, isFunction := intrinsic(node)
+	if !isFunction {
+		return false, false
+	}
+	switch name {
+	case "Condition":
+		return r.condition(args.Value, depth)
+	case "Fn::Equals":
+		if args.Kind != yaml.SequenceNode || len(args.Content) != 2 {
+			return false, false
+		}
+		left, right := r.conditionOperand(args.Content[0]), r.conditionOperand(args.Content[1])
+		if left == nil || right == nil {
+			return false, false
+		}
+		return left.Value == right.Value, true
+	case "Fn::Not":
+		if args.Kind != yaml.SequenceNode || len(args.Content) != 1 {
+			return false, false
+		}
+		result, ok = r.evaluateCondition(args.Content[0], depth)
+		return !result, ok
+	case "Fn::And", "Fn::Or":
+		if args.Kind != yaml.SequenceNode || len(args.Content) == 0 {
+			return false, false
+		}
+		result = name == "Fn::And"
+		for _, item := range args.Content {
+			value, ok := r.evaluateCondition(item, depth)
+			if !ok {
+				return false, false
+			}
+			if name == "Fn::And" {
+				result = result && value
+			} else {
+				result = result || value
+			}
+		}
+		return result, true
+	}
+	return false, false
+}
+
+func (r *resolver) conditionOperand(node *yaml.Node) *yaml.Node {
+	if name, args, ok := intrinsic(node); ok {
+		if name == "Ref" {
+			return r.ref(args)
+		}
+		if name == "Fn::FindInMap" {
+			return r.findInMap(args)
+		}
+		return nil
+	}
+	if !isScalar(node) {
+		return nil
+	}
+	return node
+}
+
+func selectElement(args *yaml.Node) *yaml.Node {
+	if args.Kind != yaml.SequenceNode || len(args.Content) != 2 || !isScalar(args.Content[0]) ||
+		args.Content[1].Kind != yaml.SequenceNode {
+		return nil
+	}
+	idx, err := strconv.Atoi(args.Content[0].Value)
+	if err != nil || idx < 0 || idx >= len(args.Content[1].Content) || isIntrinsic(args.Content[1].Content[idx]) {
+		return nil
+	}
+	return args.Content[1].Content[idx]
+}
+
+func split(args *yaml.Node) *yaml.Node {
+	if args.Kind != yaml.SequenceNode || len(args.Content) != 2 || !isScalar(args.Content[0]) ||
+		!isScalar(args.Content[1]) || args.Content[0].Value == "" {
+		return nil
+	}
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, value := range strings.Split(args.Content[1].Value, args.Content[0].Value) {
+		sequence.Content = append(sequence.Content, stringNode(value))
+	}
+	return sequence
+}
+
+// importValue returns the value exported with the name by one of the templates of the same directory
+func (r *resolver) importValue(args *yaml.Node) *yaml.Node {
+	if !r.importValues || !isScalar(args) {
+		return nil
+	}
+	if r.exports == nil {
+		r.exports = siblingExports(r.filePath)
+	}
+	return r.exports[args.Value]
+}
+
+func siblingExports(filePath string) map[string]*yaml.Node {
+	exports := make(map[string]*yaml.Node)
+	dir := filepath.Dir(filePath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return exports
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !siblingExtensions[strings.ToLower(filepath.Ext(path))] || filepath.Clean(path) == filepath.Clean(filePath) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			continue
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(content, &root); err != nil || !IsTemplate(&root) {
+			continue
+		}
+		log.Debug().Msgf("Reading the CloudFormation exports of %s", path)
+
+		sibling := newResolver(&root, path, false)
+		sibling.resolve(documentRoot(&root))
+		outputs := mappingValue(documentRoot(&root), "Outputs")
+		if outputs == nil || outputs.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 1; i < len(outputs.Content); i += 2 {
+			name := mappingValue(mappingValue(outputs.Content[i], "Export"), "Name")
+			value := mappingValue(outputs.Content[i], "Value")
+			if isScalar(name) && value != nil && !isIntrinsic(value) {
+				exports[name.Value] = value
+			}
+		}
+	}
+	return exports
+}
+
+// intrinsic returns the name and the arguments of the function of the node, in the short form (!Ref) or in the
+// full form (a mapping with a Ref or a Fn:: key)
+func intrinsic(node *yaml.Node) (name string, args *yaml.Node, ok bool) {
+	if node == nil {
+		return "", nil, false
+	}
+	if node.Tag != "" && strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		name = strings.TrimPrefix(node.Tag, "!")
+		if name != "Ref" && name != "Condition" {
+			name = "Fn::" + name
+		}
+		args = node
+		if node.Kind == yaml.ScalarNode {
+			args = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: node.Value, Line: node.Line, Column: node.Column}
+		}
+		return name, args, true
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) == 2 {
+		key := node.Content[0].Value
+		if key == "Ref" || key == "Condition" || strings.HasPrefix(key, "Fn::") {
+			return key, node.Content[1], true
+		}
+	}
+	return "", nil, false
+}
+
+func isIntrinsic(node *yaml.Node) bool {
+	_, _, ok := intrinsic(node)
+	return ok
+}
+
+func isScalar(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && !isIntrinsic(node)
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// positioned returns a copy of the value placed at the line and column of the function it replaces
+func positioned(value *yaml.Node, line, column int) *yaml.Node {
+	node := *value
+	node.Line = line
+	node.Column = column
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	if len(value.Content) > 0 {
+		node.Content = make([]*yaml.Node, 0, len(value.Content))
+		for _, child := range value.Content {
+			node.Content = append(node.Content, positioned(child, line, column))
+		}
+	}
+	return &node
+}
+
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return node.Content[0]
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package cloudformation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const template = `AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  Environment:
+    Type: String
+    Default: prod
+  Access:
+    Type: String
+    Default: PublicRead
+  DBPassword:
+    Type: String
+    Default: hardcoded
+  Hidden:
+    Type: String
+    NoEcho: true
+    Default: hidden
+  LatestAmi:
+    Type: AWS::SSM::Parameter::Value<AWS::EC2::Image::Id>
+    Default: /aws/service/ami
+Mappings:
+  Sizes:
+    prod:
+      Instance: m5.large
+Conditions:
+  IsProd: !Equals [!Ref Environment, prod]
+  IsDev: !Not [!Condition IsProd]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      AccessControl: !Ref Access
+      BucketName: !Sub "${Environment}-bucket-${AWS::Region}"
+      Tag: !Sub
+        - "${Name}-${Environment}-${!Literal}"
+        - Name: app
+      Owner: !Join ["-", [!Ref Environment, owner]]
+      Logging: !If [IsDev, {Prefix: dev}, !Ref AWS::NoValue]
+      Password: !Ref DBPassword
+      Secret: !Ref Hidden
+      Az: !Select [1, !Split [",", "a,b,c"]]
+      Vpc: !ImportValue network-vpc
+  Instance:
+    Type: AWS::EC2::Instance
+    Properties:
+      InstanceType:
+        Fn::FindInMap: [Sizes, !Ref Environment, Instance]
+      ImageId: !Ref LatestAmi
+      SecurityGroups:
+        - !If [IsProd, !Ref AWS::NoValue, sg-dev]
+        - sg-default
+`
+
+const networkTemplate = `AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  Vpc:
+    Type: AWS::EC2::VPC
+Outputs:
+  VpcId:
+    Value: vpc-123
+    Export:
+      Name: network-vpc
+`
+
+func resolveTemplate(t *testing.T) (*yaml.Node, []Replacement) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "template.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(template), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "network.yaml"), []byte(networkTemplate), 0600))
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(template), &root))
+	require.True(t, IsTemplate(&root))
+	return &root, Resolve(&root, path)
+}
+
+func properties(t *testing.T, root *yaml.Node, resource string) map[string]interface{} {
+	var decoded struct {
+		Resources map[string]struct {
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+	require.NoError(t, root.Decode(&decoded))
+	return decoded.Resources[resource].Properties
+}
+
+func propertyNode(root *yaml.Node, resource, property string) *yaml.Node {
+	return mappingValue(mappingValue(mappingValue(mappingValue(documentRoot(root), "Resources"), resource), "Properties"), property)
+}
+
+// TestResolve tests the resolution of the intrinsic functions of the template
+func TestResolve(t *testing.T) {
+	root, replacements := resolveTemplate(t)
+	require.NotEmpty(t, replacements)
+
+	bucket := properties(t, root, "Bucket")
+	require.Equal(t, "PublicRead", bucket["AccessControl"])
+	require.Equal(t, "app-prod-${Literal}", bucket["Tag"])
+	require.Equal(t, "prod-owner", bucket["Owner"])
+	require.Equal(t, "b", bucket["Az"])
+	require.Equal(t, "vpc-123", bucket["Vpc"])
+	require.NotContains(t, bucket, "Logging")
+
+	// the pseudo parameters, the secrets and the parameters resolved on deployment are kept
+	require.Equal(t, "!Sub", propertyNode(root, "Bucket", "BucketName").Tag)
+	require.Equal(t, "!Ref", propertyNode(root, "Bucket", "Password").Tag)
+	require.Equal(t, "!Ref", propertyNode(root, "Bucket", "Secret").Tag)
+
+	instance := properties(t, root, "Instance")
+	require.Equal(t, "m5.large", instance["InstanceType"])
+	require.Equal(t, "!Ref", propertyNode(root, "Instance", "ImageId").Tag)
+	require.Equal(t, []interface{}{"sg-default"}, instance["SecurityGroups"])
+}
+
+// TestResolve_Position tests the resolved values keep the line of the function
+func TestResolve_Position(t *testing.T) {
+	_, replacements := resolveTemplate(t)
+	for _, replacement := range replacements {
+		if len(replacement.Path) == 4 && replacement.Path[3] == "AccessControl" {
+			require.Equal(t, 30, replacement.Value.Line)
+			return
+		}
+	}
+	require.Fail(t, "AccessControl was not resolved")
+}
+
+// TestIsTemplate tests the detection of the CloudFormation templates
+func TestIsTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "format version", content: "AWSTemplateFormatVersion: '2010-09-09'\nResources: {}", want: true},
+		{name: "resource type", content: "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket", want: true},
+		{name: "no resources", content: "AWSTemplateFormatVersion: '2010-09-09'", want: false},
+		{name: "kubernetes", content: "apiVersion: v1\nkind: Pod", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var root yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.content), &root))
+			require.Equal(t, tt.want, IsTemplate(&root))
+		})
+	}
+}
@@ -0,0 +1,506 @@
+package cloudformation
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	serverlessTransform = "AWS::Serverless-2016-10-31"
+	implicitRestAPI     = "ServerlessRestApi"
+	implicitHTTPAPI     = "ServerlessHttpApi"
+	lambdaBasicRoleArn  = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+	managedPolicyPrefix = "arn:aws:iam::aws:policy/"
+)
+
+var (
+	// globalsSections are the sections of the Globals of a SAM template and the resource types they apply to
+	globalsSections = map[string]string{
+		"Function":    "AWS::Serverless::Function",
+		"Api":         "AWS::Serverless::Api",
+		"HttpApi":     "AWS::Serverless::HttpApi",
+		"SimpleTable": "AWS::Serverless::SimpleTable",
+	}
+	// eventSourceMappings are the function events polled by Lambda and the property holding the event source
+	eventSourceMappings = map[string]string{
+		"SQS":              "Queue",
+		"Kinesis":          "Stream",
+		"DynamoDB":         "Stream",
+		"MSK":              "Stream",
+		"MQ":               "Broker",
+		"DocumentDB":       "Cluster",
+		"SelfManagedKafka": "",
+	}
+	// eventRules are the function events creating an EventBridge rule
+	eventRules = map[string]bool{
+		"Schedule":        true,
+		"CloudWatchEvent": true,
+		"EventBridgeRule": true,
+	}
+)
+
+// expander generates the resources implied by the AWS::Serverless transform, the SAM resources are kept so the
+// SAM queries still see them and the generated resources are added next to them
+type expander struct {
+	resources    *yaml.Node
+	added        map[string]bool
+	replacements []Replacement
+}
+
+// IsServerlessTemplate returns true when the template declares the AWS::Serverless transform
+func IsServerlessTemplate(root *yaml.Node) bool {
+	transform := mappingValue(documentRoot(root), "Transform")
+	if transform == nil {
+		return false
+	}
+	if transform.Kind == yaml.SequenceNode {
+		for _, item := range transform.Content {
+			if item.Value == serverlessTransform {
+				return true
+			}
+		}
+		return false
+	}
+	return transform.Value == serverlessTransform
+}
+
+// ExpandServerless applies, in place, the AWS::Serverless transform of the template: the Globals are merged into
+// the SAM resources, the simple tables become DynamoDB tables and the implicit roles, permissions, event sources,
+// APIs and stages of the functions and APIs are added to the resources. It returns the replacements made
+func ExpandServerless(root *yaml.Node) []Replacement {
+	root = documentRoot(root)
+	resources := mappingValue(root, "Resources")
+	if !IsServerlessTemplate(root) || resources == nil || resources.Kind != yaml.MappingNode {
+		return nil
+	}
+	e := &expander{
+		resources:    resources,
+		added:        make(map[string]bool),
+		replacements: make([]Replacement, 0),
+	}
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		e.added[resources.Content[i].Value] = true
+	}
+
+	globals := mappingValue(root, "Globals")
+	// the resources added while expanding are not expanded themselves
+	count := len(resources.Content)
+	for i := 0; i+1 < count; i += 2 {
+		name, line, resource := resources.Content[i].Value, resources.Content[i].Line, resources.Content[i+1]
+		resourceType := mappingValue(resource, "Type")
+		if resourceType == nil {
+			continue
+		}
+		e.applyGlobals(name, resource, resourceType.Value, globals)
+		switch resourceType.Value {
+		case "AWS::Serverless::Function":
+			e.expandFunction(name, line, resource)
+		case "AWS::Serverless::Api":
+			e.expandAPI(name, line, resource)
+		case "AWS::Serverless::HttpApi":
+			e.expandHTTPAPI(name, line, resource)
+		case "AWS::Serverless::SimpleTable":
+			e.expandSimpleTable(i, name, line, resource)
+		}
+	}
+	return e.replacements
+}
+
+// applyGlobals sets the properties of the Globals section of the resource type the resource does not set, the
+// mappings set by both are merged
+func (e *expander) applyGlobals(name string, resource *yaml.Node, resourceType string, globals *yaml.Node) {
+	var section *yaml.Node
+	for key, sectionType := range globalsSections {
+		if sectionType == resourceType {
+			section = mappingValue(globals, key)
+		}
+	}
+	if section == nil || section.Kind != yaml.MappingNode || len(section.Content) == 0 {
+		return
+	}
+
+	path := []interface{}{"Resources", name, "Properties"}
+	properties := mappingValue(resource, "Properties")
+	if properties == nil {
+		properties = mapping(resource.Line)
+		mergeMapping(properties, section, resource.Line)
+		resource.Content = append(resource.Content, scalar("Properties", resource.Line), properties)
+		e.replacements = append(e.replacements, Replacement{Path: path, Value: properties})
+		return
+	}
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		key, value := section.Content[i].Value, section.Content[i+1]
+		current := mappingValue(properties, key)
+		switch {
+		case current == nil:
+			current = positioned(value, resource.Line, value.Column)
+			properties.Content = append(properties.Content, scalar(key, resource.Line), current)
+		case isMergeable(current, value):
+			mergeMapping(current, value, resource.Line)
+		default:
+			continue
+		}
+		e.replacements = append(e.replacements, Replacement{Path: append(append([]interface{}{}, path...), key), Value: current})
+	}
+}
+
+func mergeMapping(target, source *yaml.Node, line int) {
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		key, value := source.Content[i].Value, source.Content[i+1]
+		current := mappingValue(target, key)
+		switch {
+		case current == nil:
+			target.Content = append(target.Content, scalar(key, line), positioned(value, line, value.Column))
+		case isMergeable(current, value):
+			mergeMapping(current, value, line)
+		}
+	}
+}
+
+func isMergeable(current, value *yaml.Node) bool {
+	return current.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode && !isIntrinsic(current) &&
+		!isIntrinsic(value)
+}
+
+func (e *expander) expandFunction(name string, line int, function *yaml.Node) {
+	properties := mappingValue(function, "Properties")
+
+	if mappingValue(properties, "Role") == nil {
+		e.add(name+"Role", line, "AWS::IAM::Role", functionRoleProperties(name, properties, line))
+	}
+
+	if urlConfig := mappingValue(properties, "FunctionUrlConfig"); urlConfig != nil && urlConfig.Kind == yaml.MappingNode {
+		urlLine := urlConfig.Line
+		url := mapping(urlLine,
+			"TargetFunctionArn", ref(name, urlLine),
+			"AuthType", copied(mappingValue(urlConfig, "AuthType"), urlLine))
+		setIfPresent(url, urlConfig, "Cors", "InvokeMode")
+		e.add(name+"Url", urlLine, "AWS::Lambda::Url", url)
+
+		if authType := mappingValue(urlConfig, "AuthType"); authType != nil && authType.Value == "NONE" {
+			e.add(name+"UrlPublicPermissions", urlLine, "AWS::Lambda::Permission", mapping(urlLine,
+				"Action", scalar("lambda:InvokeFunctionUrl", urlLine),
+				"FunctionName", ref(name, urlLine),
+				"Principal", scalar("*", urlLine),
+				"FunctionUrlAuthType", scalar("NONE", urlLine)))
+		}
+	}
+
+	events := mappingValue(properties, "Events")
+	if events == nil || events.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(events.Content); i += 2 {
+		e.expandEvent(name, events.Content[i].Value, events.Content[i].Line, events.Content[i+1])
+	}
+}
+
+// functionRoleProperties returns the properties of the execution role SAM creates for the functions without a role,
+// the managed policies given by name get their ARN and the policy documents become inline policies
+func functionRoleProperties(name string, properties *yaml.Node, line int) *yaml.Node {
+	managed := sequence(line, scalar(lambdaBasicRoleArn, line))
+	inline := sequence(line)
+
+	policies := mappingValue(properties, "Policies")
+	items := []*yaml.Node{policies}
+	if policies != nil && policies.Kind == yaml.SequenceNode {
+		items = policies.Content
+	}
+	for _, policy := range items {
+		switch {
+		case policy == nil:
+		case isScalar(policy):
+			arn := policy.Value
+			if !strings.HasPrefix(arn, "arn:") {
+				arn = managedPolicyPrefix + arn
+			}
+			managed.Content = append(managed.Content, scalar(arn, policy.Line))
+		case isIntrinsic(policy):
+			managed.Content = append(managed.Content, positioned(policy, policy.Line, policy.Column))
+		case mappingValue(policy, "Statement") != nil:
+			inline.Content = append(inline.Content, mapping(policy.Line,
+				"PolicyName", scalar(name+"RolePolicy"+strconv.Itoa(len(inline.Content)), policy.Line),
+				"PolicyDocument", positioned(policy, policy.Line, policy.Column)))
+		}
+		// the SAM policy templates are expanded by the transform from its own policy definitions, they are not known
+	}
+
+	role := mapping(line,
+		"AssumeRolePolicyDocument", mapping(line,
+			"Version", scalar("2012-10-17", line),
+			"Statement", sequence(line, mapping(line,
+				"Effect", scalar("Allow", line),
+				"Action", sequence(line, scalar("sts:AssumeRole", line)),
+				"Principal", mapping(line, "Service", sequence(line, scalar("lambda.amazonaws.com", line)))))),
+		"ManagedPolicyArns", managed)
+	if len(inline.Content) > 0 {
+		role.Content = append(role.Content, scalar("Policies", line), inline)
+	}
+	setIfPresent(role, properties, "PermissionsBoundary", "Tags")
+	return role
+}
+
+// expandEvent adds the resources created for an event source of the function
+func (e *expander) expandEvent(function, event string, line int, node *yaml.Node) {
+	eventType := mappingValue(node, "Type")
+	if eventType == nil {
+		return
+	}
+	properties := mappingValue(node, "Properties")
+	name := function + event
+
+	switch eventType.Value {
+	case "Api":
+		if mappingValue(properties, "RestApiId") == nil {
+			e.addImplicitRestAPI(line)
+		}
+		e.addPermission(name+"Permission", function, "apigateway.amazonaws.com", line)
+	case "HttpApi":
+		if mappingValue(properties, "ApiId") == nil {
+			e.addImplicitHTTPAPI(line)
+		}
+		e.addPermission(name+"Permission", function, "apigateway.amazonaws.com", line)
+	case "S3":
+		e.addPermission(name+"Permission", function, "s3.amazonaws.com", line)
+	case "SNS":
+		e.add(name, line, "AWS::SNS::Subscription", mapping(line,
+			"Endpoint", getAtt(function, "Arn", line),
+			"Protocol", scalar("lambda", line),
+			"TopicArn", copied(mappingValue(properties, "Topic"), line)))
+		e.addPermission(name+"Permission", function, "sns.amazonaws.com", line)
+	default:
+		if eventRules[eventType.Value] {
+			rule := mapping(line,
+				"State", scalar("ENABLED", line),
+				"Targets", sequence(line, mapping(line,
+					"Arn", getAtt(function, "Arn", line),
+					"Id", scalar(name+"LambdaTarget", line))))
+			setIfPresent(rule, properties, "ScheduleExpression", "EventPattern", "EventBusName", "Name",
+				"Description")
+			if enabled := mappingValue(properties, "Enabled"); isScalar(enabled) && enabled.Value == "false" {
+				setValue(rule, "State", scalar("DISABLED", line))
+			}
+			e.add(name, line, "AWS::Events::Rule", rule)
+			e.addPermission(name+"Permission", function, "events.amazonaws.com", line)
+			return
+		}
+		if sourceProperty, ok := eventSourceMappings[eventType.Value]; ok {
+			sourceMapping := mapping(line, "FunctionName", ref(function, line))
+			if sourceProperty != "" {
+				setValue(sourceMapping, "EventSourceArn", copied(mappingValue(properties, sourceProperty), line))
+			}
+			setIfPresent(sourceMapping, properties, "BatchSize", "StartingPosition", "Enabled",
+				"MaximumBatchingWindowInSeconds", "FilterCriteria", "DestinationConfig", "SourceAccessConfigurations",
+				"Topics")
+			e.add(name, line, "AWS::Lambda::EventSourceMapping", sourceMapping)
+		}
+	}
+}
+
+func (e *expander) addPermission(name, function, principal string, line int) {
+	e.add(name, line, "AWS::Lambda::Permission", mapping(line,
+		"Action", scalar("lambda:InvokeFunction", line),
+		"FunctionName", ref(function, line),
+		"Principal", scalar(principal, line)))
+}
+
+// addImplicitRestAPI adds the REST API SAM creates for the Api events without an API, with its Prod stage
+func (e *expander) addImplicitRestAPI(line int) {
+	e.add(implicitRestAPI, line, "AWS::ApiGateway::RestApi", mapping(line,
+		"Name", scalar(implicitRestAPI, line)))
+	e.add(implicitRestAPI+"Deployment", line, "AWS::ApiGateway::Deployment", mapping(line,
+		"RestApiId", ref(implicitRestAPI, line)))
+	e.add(implicitRestAPI+"ProdStage", line, "AWS::ApiGateway::Stage", mapping(line,
+		"DeploymentId", ref(implicitRestAPI+"Deployment", line),
+		"RestApiId", ref(implicitRestAPI, line),
+		"StageName", scalar("Prod", line)))
+}
+
+// addImplicitHTTPAPI adds the HTTP API SAM creates for the HttpApi events without an API, with its default stage
+func (e *expander) addImplicitHTTPAPI(line int) {
+	e.add(implicitHTTPAPI, line, "AWS::ApiGatewayV2::Api", mapping(line,
+		"Name", scalar(implicitHTTPAPI, line),
+		"ProtocolType", scalar("HTTP", line)))
+	e.add(implicitHTTPAPI+"ApiGatewayDefaultStage", line, "AWS::ApiGatewayV2::Stage", mapping(line,
+		"ApiId", ref(implicitHTTPAPI, line),
+		"StageName", scalar("$default", line),
+		"AutoDeploy", boolean(true, line)))
+}
+
+// expandAPI adds the deployment and the stage of the REST API, the stage gets the stage settings of the API
+func (e *expander) expandAPI(name string, line int, api *yaml.Node) {
+	properties := mappingValue(api, "Properties")
+
+	stageName := copied(mappingValue(properties, "StageName"), line)
+	stageID := "Stage"
+	if isScalar(stageName) {
+		stageID = stageName.Value + stageID
+	}
+	e.add(name+"Deployment", line, "AWS::ApiGateway::Deployment", mapping(line,
+		"RestApiId", ref(name, line)))
+
+	stage := mapping(line,
+		"DeploymentId", ref(name+"Deployment", line),
+		"RestApiId", ref(name, line),
+		"StageName", stageName)
+	setIfPresent(stage, properties, "AccessLogSetting", "CacheClusterEnabled", "CacheClusterSize",
+		"ClientCertificateId", "MethodSettings", "Tags", "TracingEnabled")
+	if variables := mappingValue(properties, "Variables"); variables != nil {
+		setValue(stage, "Variables", positioned(variables, line, variables.Column))
+	}
+	e.add(name+alphanumeric(stageID), line, "AWS::ApiGateway::Stage", stage)
+}
+
+// expandHTTPAPI adds the stage of the HTTP API, the stage gets the stage settings of the API
+func (e *expander) expandHTTPAPI(name string, line int, api *yaml.Node) {
+	properties := mappingValue(api, "Properties")
+
+	stageName := mappingValue(properties, "StageName")
+	stageID := "ApiGatewayDefaultStage"
+	if isScalar(stageName) && stageName.Value != "$default" {
+		stageID = stageName.Value + "Stage"
+	}
+	stage := mapping(line,
+		"ApiId", ref(name, line),
+		"StageName", copied(stageName, line),
+		"AutoDeploy", boolean(true, line))
+	if stageName == nil {
+		setValue(stage, "StageName", scalar("$default", line))
+	}
+	setIfPresent(stage, properties, "AccessLogSettings", "DefaultRouteSettings", "RouteSettings",
+		"StageVariables", "Tags")
+	e.add(name+alphanumeric(stageID), line, "AWS::ApiGatewayV2::Stage", stage)
+}
+
+// expandSimpleTable replaces the simple table by the DynamoDB table it is deployed as, keeping its logical ID
+func (e *expander) expandSimpleTable(idx int, name string, line int, table *yaml.Node) {
+	properties := mappingValue(table, "Properties")
+
+	keyName, keyType := scalar("id", line), scalar("S", line)
+	if primaryKey := mappingValue(properties, "PrimaryKey"); primaryKey != nil {
+		if value := mappingValue(primaryKey, "Name"); value != nil {
+			keyName = positioned(value, value.Line, value.Column)
+		}
+		if value := mappingValue(primaryKey, "Type"); isScalar(value) {
+			keyType = scalar(map[string]string{"String": "S", "Number": "N", "Binary": "B"}[value.Value], value.Line)
+		}
+	}
+
+	dynamoTable := mapping(line,
+		"AttributeDefinitions", sequence(line, mapping(line, "AttributeName", keyName, "AttributeType", keyType)),
+		"KeySchema", sequence(line, mapping(line, "AttributeName", keyName, "KeyType", scalar("HASH", line))))
+	if throughput := mappingValue(properties, "ProvisionedThroughput"); throughput != nil {
+		setValue(dynamoTable, "ProvisionedThroughput", positioned(throughput, throughput.Line, throughput.Column))
+	} else {
+		setValue(dynamoTable, "BillingMode", scalar("PAY_PER_REQUEST", line))
+	}
+	setIfPresent(dynamoTable, properties, "SSESpecification", "TableName", "PointInTimeRecoverySpecification")
+	if tags := mappingValue(properties, "Tags"); tags != nil && tags.Kind == yaml.MappingNode {
+		setValue(dynamoTable, "Tags", tagList(tags))
+	}
+
+	resource := mapping(line, "Type", scalar("AWS::DynamoDB::Table", line), "Properties", dynamoTable)
+	for _, key := range []string{"Condition", "DependsOn", "DeletionPolicy", "UpdateReplacePolicy", "Metadata"} {
+		if value := mappingValue(table, key); value != nil {
+			setValue(resource, key, value)
+		}
+	}
+	e.resources.Content[idx+1] = resource
+	e.replacements = append(e.replacements, Replacement{Path: []interface{}{"Resources", name}, Value: resource})
+}
+
+// add adds the generated resource unless the template declares a resource with the same logical ID
+func (e *expander) add(name string, line int, resourceType string, properties *yaml.Node) {
+	if e.added[name] {
+		return
+	}
+	e.added[name] = true
+	resource := mapping(line, "Type", scalar(resourceType, line), "Properties", properties)
+	e.resources.Content = append(e.resources.Content, scalar(name, line), resource)
+	e.replacements = append(e.replacements, Replacement{Path: []interface{}{"Resources", name}, Value: resource})
+}
+
+// tagList converts the tags given as a mapping, as SAM does, to the list of keys and values of CloudFormation
+func tagList(tags *yaml.Node) *yaml.Node {
+	keys := make([]int, 0, len(tags.Content)/2)
+	for i := 0; i+1 < len(tags.Content); i += 2 {
+		keys = append(keys, i)
+	}
+	sort.SliceStable(keys, func(a, b int) bool { return tags.Content[keys[a]].Value < tags.Content[keys[b]].Value })
+
+	list := sequence(tags.Line)
+	for _, i := range keys {
+		list.Content = append(list.Content, mapping(tags.Content[i].Line,
+			"Key", scalar(tags.Content[i].Value, tags.Content[i].Line),
+			"Value", positioned(tags.Content[i+1], tags.Content[i+1].Line, tags.Content[i+1].Column)))
+	}
+	return list
+}
+
+// setIfPresent copies the properties of the source the target is generated from
+func setIfPresent(target, source *yaml.Node, keys ...string) {
+	for _, key := range keys {
+		if value := mappingValue(source, key); value != nil {
+			setValue(target, key, positioned(value, value.Line, value.Column))
+		}
+	}
+}
+
+func setValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, scalar(key, node.Line), value)
+}
+
+// copied returns a copy of the value, or a null value when it is not set
+func copied(value *yaml.Node, line int) *yaml.Node {
+	if value == nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: line}
+	}
+	return positioned(value, value.Line, value.Column)
+}
+
+func mapping(line int, pairs ...interface{}) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		node.Content = append(node.Content, scalar(pairs[i].(string), line), pairs[i+1].(*yaml.Node))
+	}
+	return node
+}
+
+func sequence(line int, items ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line, Content: items}
+}
+
+func scalar(value string, line int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: line}
+}
+
+func boolean(value bool, line int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value), Line: line}
+}
+
+func ref(name string, line int) *yaml.Node {
+	return mapping(line, "Ref", scalar(name, line))
+}
+
+func getAtt(name, attribute string, line int) *yaml.Node {
+	return mapping(line, "Fn::GetAtt", sequence(line, scalar(name, line), scalar(attribute, line)))
+}
+
+// alphanumeric removes the characters the logical IDs can not hold
+func alphanumeric(value string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, value)
+}
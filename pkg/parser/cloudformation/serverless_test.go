@@ -0,0 +1,144 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const serverlessTemplate = `AWSTemplateFormatVersion: "2010-09-09"
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    Runtime: python3.12
+    Tracing: Active
+    Environment:
+      Variables:
+        STAGE: prod
+Resources:
+  Handler:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+      Environment:
+        Variables:
+          TABLE: items
+      Policies:
+        - AmazonS3ReadOnlyAccess
+        - Statement:
+            - Effect: Allow
+              Action: "*"
+              Resource: "*"
+      FunctionUrlConfig:
+        AuthType: NONE
+      Events:
+        Get:
+          Type: Api
+          Properties:
+            Path: /items
+            Method: get
+        Nightly:
+          Type: Schedule
+          Properties:
+            Schedule: rate(1 day)
+            ScheduleExpression: rate(1 day)
+        Queue:
+          Type: SQS
+          Properties:
+            Queue: arn:aws:sqs:us-east-1:123456789012:queue
+            BatchSize: 10
+  Worker:
+    Type: AWS::Serverless::Function
+    Properties:
+      Role: arn:aws:iam::123456789012:role/worker
+  Api:
+    Type: AWS::Serverless::Api
+    Properties:
+      StageName: v1
+      TracingEnabled: false
+  Items:
+    Type: AWS::Serverless::SimpleTable
+    Properties:
+      PrimaryKey:
+        Name: itemId
+        Type: Number
+      Tags:
+        team: platform
+`
+
+func expand(t *testing.T, content string) (map[string]map[string]interface{}, []Replacement) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(content), &root))
+	replacements := ExpandServerless(&root)
+
+	var decoded struct {
+		Resources map[string]map[string]interface{} `yaml:"Resources"`
+	}
+	require.NoError(t, root.Decode(&decoded))
+	return decoded.Resources, replacements
+}
+
+func resourceProperties(resources map[string]map[string]interface{}, name string) map[string]interface{} {
+	properties, _ := resources[name]["Properties"].(map[string]interface{})
+	return properties
+}
+
+// TestExpandServerless tests the expansion of the SAM resources into the resources of the transform
+func TestExpandServerless(t *testing.T) {
+	resources, replacements := expand(t, serverlessTemplate)
+	require.NotEmpty(t, replacements)
+
+	// the SAM resources are kept, with the Globals merged
+	handler := resourceProperties(resources, "Handler")
+	require.Equal(t, "AWS::Serverless::Function", resources["Handler"]["Type"])
+	require.Equal(t, "Active", handler["Tracing"])
+	require.Equal(t, map[string]interface{}{"TABLE": "items", "STAGE": "prod"},
+		handler["Environment"].(map[string]interface{})["Variables"])
+
+	role := resourceProperties(resources, "HandlerRole")
+	require.Equal(t, "AWS::IAM::Role", resources["HandlerRole"]["Type"])
+	require.Equal(t, []interface{}{lambdaBasicRoleArn, "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"}, role["ManagedPolicyArns"])
+	require.Len(t, role["Policies"], 1)
+	require.NotContains(t, resources, "WorkerRole")
+
+	require.Equal(t, "AWS::Lambda::Url", resources["HandlerUrl"]["Type"])
+	require.Equal(t, "*", resourceProperties(resources, "HandlerUrlPublicPermissions")["Principal"])
+
+	require.Equal(t, "AWS::ApiGateway::RestApi", resources["ServerlessRestApi"]["Type"])
+	require.Equal(t, "Prod", resourceProperties(resources, "ServerlessRestApiProdStage")["StageName"])
+	require.Equal(t, "apigateway.amazonaws.com", resourceProperties(resources, "HandlerGetPermission")["Principal"])
+
+	require.Equal(t, "AWS::Events::Rule", resources["HandlerNightly"]["Type"])
+	require.Equal(t, "rate(1 day)", resourceProperties(resources, "HandlerNightly")["ScheduleExpression"])
+
+	queue := resourceProperties(resources, "HandlerQueue")
+	require.Equal(t, "AWS::Lambda::EventSourceMapping", resources["HandlerQueue"]["Type"])
+	require.Equal(t, "arn:aws:sqs:us-east-1:123456789012:queue", queue["EventSourceArn"])
+	require.Equal(t, 10, queue["BatchSize"])
+
+	stage := resourceProperties(resources, "Apiv1Stage")
+	require.Equal(t, "AWS::ApiGateway::Stage", resources["Apiv1Stage"]["Type"])
+	require.Equal(t, false, stage["TracingEnabled"])
+	require.Contains(t, resources, "ApiDeployment")
+
+	// the simple tables are replaced by the DynamoDB tables
+	table := resourceProperties(resources, "Items")
+	require.Equal(t, "AWS::DynamoDB::Table", resources["Items"]["Type"])
+	require.Equal(t, "PAY_PER_REQUEST", table["BillingMode"])
+	require.Equal(t, []interface{}{map[string]interface{}{"AttributeName": "itemId", "AttributeType": "N"}},
+		table["AttributeDefinitions"])
+	require.Equal(t, []interface{}{map[string]interface{}{"Key": "team", "Value": "platform"}}, table["Tags"])
+}
+
+// TestExpandServerless_WithoutTransform tests the templates without the transform are not expanded
+func TestExpandServerless_WithoutTransform(t *testing.T) {
+	resources, replacements := expand(t, `Resources:
+  Handler:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+`)
+	require.Empty(t, replacements)
+	require.Len(t, resources, 1)
+}
@@ -3,7 +3,6 @@ package docker
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/Checkmarx/kics/pkg/model"
@@ -19,6 +18,7 @@ type Parser struct {
 type Resource struct {
 	CommandList map[string][]Command `json:"command"`
 	Arguments   []Command            `json:"args"`
+	Stages      []Stage              `json:"stages"`
 }
 
 // Command is the struct for each dockerfile command
@@ -52,9 +52,7 @@ func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, e
 	from := make(map[string][]Command)
 	arguments := make([]Command, 0)
 	ignoreStruct := newIgnore()
-
-	args := make(map[string]string, 0)
-	envs := make(map[string]string, 0)
+	stages := newStageResolver()
 
 	for _, child := range parsed.AST.Children {
 		child.Value = strings.ToLower(child.Value)
@@ -86,17 +84,7 @@ func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, e
 			cmd.Value = append(cmd.Value, n.Value)
 		}
 
-		if child.Value != "arg" {
-			cmd.Value = resolveArgsAndEnvs(cmd.Value, args)
-		} else {
-			args = saveArgs(args, cmd.Value[0])
-		}
-
-		if child.Value != "env" {
-			cmd.Value = resolveArgsAndEnvs(cmd.Value, envs)
-		} else {
-			envs = saveEnvs(envs, cmd.Value)
-		}
+		cmd.Value = stages.resolve(fromValue, cmd.Cmd, cmd.Value, cmd.StartLine)
 
 		if fromValue == "" {
 			arguments = append(arguments, cmd)
@@ -109,6 +97,7 @@ func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, e
 	var resource Resource
 	resource.CommandList = from
 	resource.Arguments = arguments
+	resource.Stages = stages.stages
 
 	j, err := json.Marshal(resource)
 	if err != nil {
@@ -155,36 +144,3 @@ func (p *Parser) StringifyContent(content []byte) (string, error) {
 func (p *Parser) GetResolvedFiles() map[string]model.ResolvedFile {
 	return make(map[string]model.ResolvedFile)
 }
-
-func resolveArgsAndEnvs(values []string, args map[string]string) []string {
-	for i := range values {
-		for arg := range args {
-			ref1 := fmt.Sprintf("${%s}", arg)
-			values[i] = strings.Replace(values[i], ref1, args[arg], 1)
-			ref2 := fmt.Sprintf("$%s", arg)
-			values[i] = strings.Replace(values[i], ref2, args[arg], 1)
-		}
-	}
-
-	return values
-}
-
-func saveArgs(args map[string]string, argValue string) map[string]string {
-	value := strings.Split(argValue, "=")
-	if len(value) == 2 {
-		args[value[0]] = value[1]
-	}
-	if len(value) > 2 {
-		// to handle cases like ARG VAR=erereR=E
-		args[value[0]] = strings.Join(value[1:], "=")
-	}
-
-	return args
-}
-
-func saveEnvs(envs map[string]string, envValues []string) map[string]string {
-	if len(envValues) == 2 {
-		envs[envValues[0]] = envValues[1]
-	}
-	return envs
-}
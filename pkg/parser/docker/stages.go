@@ -0,0 +1,229 @@
+package docker
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+)
+
+// Stage is a build stage of the Dockerfile, Name is the key of the stage commands in the command list,
+// Ancestors are the keys of the parent stages from the root one and Env is the environment of the stage
+// including the environment inherited from its parent stages
+type Stage struct {
+	Name      string            `json:"name"`
+	Image     string            `json:"image"`
+	Alias     string            `json:"alias"`
+	Parent    string            `json:"parent"`
+	Ancestors []string          `json:"ancestors"`
+	Final     bool              `json:"final"`
+	Env       map[string]string `json:"env"`
+	StartLine int               `json:"_kics_line"`
+}
+
+// dockerExpandedCommands are the instructions whose variables are expanded by the Docker builder,
+// the other instructions are run by a shell so unset variables are kept as they are
+var dockerExpandedCommands = map[string]bool{
+	"add":        true,
+	"copy":       true,
+	"env":        true,
+	"expose":     true,
+	"from":       true,
+	"label":      true,
+	"stopsignal": true,
+	"user":       true,
+	"volume":     true,
+	"workdir":    true,
+	"onbuild":    true,
+}
+
+var variableRegex = regexp.MustCompile(
+	`\\?\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// stageResolver resolves the ARG and ENV substitutions following the Docker scoping rules:
+// the ARGs declared before the first FROM are only available to the FROM instructions and to the stages
+// redeclaring them, the ARGs of a stage are only available to that stage and the ENVs are inherited by
+// the stages built from it, taking precedence over the ARGs
+type stageResolver struct {
+	globalArgs map[string]string
+	stages     []Stage
+	aliases    map[string]int
+	args       map[string]string
+}
+
+func newStageResolver() *stageResolver {
+	return &stageResolver{
+		globalArgs: make(map[string]string),
+		stages:     make([]Stage, 0),
+		aliases:    make(map[string]int),
+	}
+}
+
+// resolve saves the variables declared by the instruction and returns its values with the variables substituted
+func (r *stageResolver) resolve(name, instruction string, values []string, line int) []string {
+	switch instruction {
+	case "from":
+		values = expandValues(values, r.globalArgs, true)
+		r.addStage(name, values, line)
+		return values
+	case "arg":
+		r.saveArgs(values)
+		return values
+	}
+
+	if len(r.stages) == 0 {
+		return values
+	}
+
+	vars := r.variables()
+	values = expandValues(values, vars, dockerExpandedCommands[instruction])
+	if instruction == "env" {
+		r.saveEnvs(values, vars)
+	}
+	return values
+}
+
+func (r *stageResolver) addStage(name string, values []string, line int) {
+	stage := Stage{
+		Name:      name,
+		Ancestors: make([]string, 0),
+		Env:       make(map[string]string),
+		StartLine: line,
+	}
+	if len(values) > 0 {
+		stage.Image = values[0]
+	}
+	if len(values) > 2 && strings.EqualFold(values[1], "as") {
+		stage.Alias = strings.ToLower(values[2])
+	}
+
+	if parent, ok := r.parentStage(stage.Image); ok {
+		stage.Parent = r.stages[parent].Name
+		stage.Ancestors = append(stage.Ancestors, r.stages[parent].Ancestors...)
+		stage.Ancestors = append(stage.Ancestors, stage.Parent)
+		for key, value := range r.stages[parent].Env {
+			stage.Env[key] = value
+		}
+	}
+
+	if len(r.stages) > 0 {
+		r.stages[len(r.stages)-1].Final = false
+	}
+	stage.Final = true
+	r.stages = append(r.stages, stage)
+	if stage.Alias != "" {
+		r.aliases[stage.Alias] = len(r.stages) - 1
+	}
+	r.args = make(map[string]string)
+}
+
+// parentStage returns the index of the stage referenced by the image, either by its alias or by its index
+func (r *stageResolver) parentStage(image string) (int, bool) {
+	if idx, ok := r.aliases[strings.ToLower(image)]; ok {
+		return idx, true
+	}
+	if idx, err := strconv.Atoi(image); err == nil && idx >= 0 && idx < len(r.stages) {
+		return idx, true
+	}
+	return 0, false
+}
+
+func (r *stageResolver) saveArgs(values []string) {
+	scope, vars := r.args, r.variables()
+	if len(r.stages) == 0 {
+		scope, vars = r.globalArgs, r.globalArgs
+	}
+
+	for _, value := range values {
+		key, defaultValue, hasDefault := strings.Cut(value, "=")
+		if hasDefault {
+			scope[key] = unquote(defaultValue, vars)
+		} else if globalValue, ok := r.globalArgs[key]; ok && len(r.stages) > 0 {
+			scope[key] = globalValue
+		} else {
+			continue
+		}
+		vars[key] = scope[key]
+	}
+}
+
+func (r *stageResolver) saveEnvs(values []string, vars map[string]string) {
+	env := r.stages[len(r.stages)-1].Env
+	for i := 0; i+1 < len(values); i += 2 {
+		env[values[i]] = unquote(values[i+1], vars)
+	}
+}
+
+// variables returns the variables available to the current stage
+func (r *stageResolver) variables() map[string]string {
+	vars := make(map[string]string, len(r.args))
+	for key, value := range r.args {
+		vars[key] = value
+	}
+	if len(r.stages) > 0 {
+		for key, value := range r.stages[len(r.stages)-1].Env {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// unquote removes the quotes of a variable value the way the Docker builder does
+func unquote(value string, vars map[string]string) string {
+	lex := shell.NewLex('\\')
+	unquoted, err := lex.ProcessWordWithMap(value, vars)
+	if err != nil {
+		return value
+	}
+	return unquoted
+}
+
+func expandValues(values []string, vars map[string]string, expandUnset bool) []string {
+	for i := range values {
+		values[i] = expandVariables(values[i], vars, expandUnset)
+	}
+	return values
+}
+
+// expandVariables substitutes the $VAR and ${VAR} references, including the ${VAR:-default} and
+// ${VAR:+alternative} modifiers, escaped and unknown references are kept unless expandUnset is set,
+// in which case the modifiers of unknown references are applied
+func expandVariables(value string, vars map[string]string, expandUnset bool) string {
+	return variableRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		if strings.HasPrefix(ref, `\`) {
+			return ref
+		}
+		match := variableRegex.FindStringSubmatch(ref)
+		name, modifier, word := match[1], match[2], match[3]
+		if name == "" {
+			name = match[4]
+		}
+
+		varValue, set := vars[name]
+		if modifier == "" {
+			if !set {
+				return ref
+			}
+			return varValue
+		}
+
+		if !set && !expandUnset {
+			return ref
+		}
+
+		unsetOrEmpty := !set || (strings.HasPrefix(modifier, ":") && varValue == "")
+		switch strings.TrimPrefix(modifier, ":") {
+		case "-":
+			if unsetOrEmpty {
+				return word
+			}
+			return varValue
+		default:
+			if unsetOrEmpty {
+				return ""
+			}
+			return word
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseResource(t *testing.T, content string) Resource {
+	p := &Parser{}
+	doc, _, err := p.Parse("Dockerfile", []byte(content))
+	require.NoError(t, err)
+	require.Len(t, doc, 1)
+
+	j, err := json.Marshal(doc[0])
+	require.NoError(t, err)
+	var resource Resource
+	require.NoError(t, json.Unmarshal(j, &resource))
+	return resource
+}
+
+// TestParser_Stages tests the stages metadata of the multi-stage Dockerfiles
+func TestParser_Stages(t *testing.T) {
+	resource := parseResource(t, `
+ARG BASE=alpine:3.18
+FROM ${BASE} AS builder
+ENV HOME=/build USER_NAME=builder
+USER builder
+
+FROM builder AS tester
+RUN make test
+
+FROM scratch
+COPY --from=tester /build/app /app
+`)
+
+	require.Len(t, resource.Stages, 3)
+
+	require.Equal(t, "${BASE} AS builder", resource.Stages[0].Name)
+	require.Equal(t, "alpine:3.18", resource.Stages[0].Image)
+	require.Equal(t, "builder", resource.Stages[0].Alias)
+	require.Empty(t, resource.Stages[0].Parent)
+	require.False(t, resource.Stages[0].Final)
+	require.Equal(t, map[string]string{"HOME": "/build", "USER_NAME": "builder"}, resource.Stages[0].Env)
+	require.Equal(t, 3, resource.Stages[0].StartLine)
+
+	require.Equal(t, "builder AS tester", resource.Stages[1].Name)
+	require.Equal(t, "${BASE} AS builder", resource.Stages[1].Parent)
+	require.Equal(t, []string{"${BASE} AS builder"}, resource.Stages[1].Ancestors)
+	require.Equal(t, map[string]string{"HOME": "/build", "USER_NAME": "builder"}, resource.Stages[1].Env)
+	require.False(t, resource.Stages[1].Final)
+
+	require.Equal(t, "scratch", resource.Stages[2].Name)
+	require.Empty(t, resource.Stages[2].Parent)
+	require.Empty(t, resource.Stages[2].Ancestors)
+	require.Empty(t, resource.Stages[2].Env)
+	require.True(t, resource.Stages[2].Final)
+}
+
+// TestParser_Substitutions tests the scoping of the ARG and ENV substitutions
+func TestParser_Substitutions(t *testing.T) {
+	resource := parseResource(t, `
+ARG VERSION=1.0
+ARG PORT=80
+FROM alpine:${VERSION} AS base
+ARG VERSION
+ARG APP=app APP_DIR=/opt/$APP
+ENV PORT=8080
+ENV NAME "my app"
+LABEL version=$VERSION port=${PORT} missing=${MISSING:-none} dir=$APP_DIR
+RUN echo $PORTS $HOME \$PORT ${PORT:+set}
+EXPOSE $PORT
+
+FROM base
+EXPOSE ${PORT} $APP
+USER ${NAME}
+`)
+
+	base := resource.CommandList["alpine:${VERSION} AS base"]
+	require.Len(t, base, 8)
+	require.Equal(t, []string{"alpine:1.0", "AS", "base"}, base[0].Value)
+	require.Equal(t, []string{"version", "1.0", "port", "8080", "missing", "none", "dir", "/opt/app"}, base[5].Value)
+	require.Equal(t, []string{`echo $PORTS $HOME \$PORT set`}, base[6].Value)
+	require.Equal(t, []string{"8080"}, base[7].Value)
+
+	child := resource.CommandList["base"]
+	require.Len(t, child, 3)
+	// the ARGs are scoped to their stage while the ENVs are inherited
+	require.Equal(t, []string{"8080", "$APP"}, child[1].Value)
+	require.Equal(t, []string{"my app"}, child[2].Value)
+	require.Equal(t, map[string]string{"PORT": "8080", "NAME": "my app"}, resource.Stages[1].Env)
+}
+
+// Test_expandVariables tests the substitution of the variable references
+func Test_expandVariables(t *testing.T) {
+	vars := map[string]string{"FOO": "foo", "EMPTY": ""}
+	tests := []struct {
+		name        string
+		value       string
+		expandUnset bool
+		want        string
+	}{
+		{name: "plain reference", value: "$FOO/bin", want: "foo/bin"},
+		{name: "braces reference", value: "${FOO}bar", want: "foobar"},
+		{name: "longer name", value: "$FOOBAR", want: "$FOOBAR"},
+		{name: "escaped reference", value: `\$FOO`, want: `\$FOO`},
+		{name: "every occurrence", value: "$FOO-$FOO", want: "foo-foo"},
+		{name: "default of empty", value: "${EMPTY:-x}", want: "x"},
+		{name: "default without colon of empty", value: "${EMPTY-x}", want: ""},
+		{name: "alternative", value: "${FOO:+x}", want: "x"},
+		{name: "unset kept", value: "${UNSET:-x}", want: "${UNSET:-x}"},
+		{name: "unset expanded", value: "${UNSET:-x}", expandUnset: true, want: "x"},
+		{name: "unset alternative expanded", value: "${UNSET:+x}", expandUnset: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, expandVariables(tt.value, vars, tt.expandUnset))
+		})
+	}
+}
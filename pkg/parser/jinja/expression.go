@@ -0,0 +1,647 @@
+package jinja
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var errSyntax = errors.New("invalid expression")
+
+type tokenKind int
+
+const (
+	nameToken tokenKind = iota
+	stringToken
+	numberToken
+	operatorToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// operators are the operators of the expressions, the two characters operators first
+var operators = []string{"==", "!=", "<=", ">=", "<", ">", "(", ")", "[", "]", ".", ",", "|", "~", "+", "-", "*", "/", "%"}
+
+// tokenize splits the expression into names, strings, numbers and operators
+func tokenize(expression string) []token {
+	tokens := make([]token, 0)
+	for i := 0; i < len(expression); {
+		c := rune(expression[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexRune(expression[i+1:], c)
+			if end < 0 {
+				end = len(expression) - i - 1
+			}
+			tokens = append(tokens, token{kind: stringToken, value: expression[i+1 : i+1+end]})
+			i += end + 2
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(expression) && (unicode.IsDigit(rune(expression[i])) || expression[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: numberToken, value: expression[start:i]})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(expression) && (unicode.IsLetter(rune(expression[i])) || unicode.IsDigit(rune(expression[i])) ||
+				expression[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: nameToken, value: expression[start:i]})
+		default:
+			operator := string(c)
+			for _, candidate := range operators {
+				if strings.HasPrefix(expression[i:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: operatorToken, value: operator})
+			i += len(operator)
+		}
+	}
+	return tokens
+}
+
+// parser evaluates an expression while it parses it, following the precedence of the Jinja operators
+type parser struct {
+	renderer *renderer
+	tokens   []token
+	pos      int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// accept consumes the next token when it is the name or the operator
+func (p *parser) accept(value string) bool {
+	if t, ok := p.peek(); ok && (t.kind == nameToken || t.kind == operatorToken) && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) error {
+	if !p.accept(value) {
+		return errSyntax
+	}
+	return nil
+}
+
+func (p *parser) expression() (interface{}, error) {
+	left, err := p.and()
+	for err == nil && p.accept("or") {
+		var right interface{}
+		if right, err = p.and(); err == nil {
+			left = logical(left, right, true)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) and() (interface{}, error) {
+	left, err := p.not()
+	for err == nil && p.accept("and") {
+		var right interface{}
+		if right, err = p.not(); err == nil {
+			left = logical(left, right, false)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) not() (interface{}, error) {
+	if p.accept("not") {
+		value, err := p.not()
+		if _, ok := value.(unknown); ok || err != nil {
+			return value, err
+		}
+		return !truthy(value), nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (interface{}, error) {
+	left, err := p.concatenation()
+	for err == nil {
+		t, ok := p.peek()
+		if !ok {
+			break
+		}
+		switch {
+		case t.kind == operatorToken && strings.Contains("== != <= >= < >", t.value):
+			p.pos++
+			var right interface{}
+			if right, err = p.concatenation(); err == nil {
+				left = compare(t.value, left, right)
+			}
+		case t.kind == nameToken && (t.value == "in" || t.value == "not"):
+			p.pos++
+			negate := t.value == "not"
+			if negate {
+				if err = p.expect("in"); err != nil {
+					return nil, err
+				}
+			}
+			var right interface{}
+			if right, err = p.concatenation(); err == nil {
+				left = negateIf(contains(right, left), negate)
+			}
+		case t.kind == nameToken && t.value == "is":
+			p.pos++
+			left, err = p.test(left)
+		default:
+			return left, nil
+		}
+	}
+	return left, err
+}
+
+// test evaluates the defined, none and string tests
+func (p *parser) test(value interface{}) (interface{}, error) {
+	negate := p.accept("not")
+	t, ok := p.peek()
+	if !ok || t.kind != nameToken {
+		return nil, errSyntax
+	}
+	p.pos++
+	if _, isUnknown := value.(unknown); isUnknown {
+		return value, nil
+	}
+	switch t.value {
+	case "defined":
+		return !negate, nil
+	case "undefined":
+		return negate, nil
+	case "none":
+		return negateIf(value == nil, negate), nil
+	case "string":
+		_, isString := value.(string)
+		return negateIf(isString, negate), nil
+	default:
+		return unknown{ref: t.value}, nil
+	}
+}
+
+func (p *parser) concatenation() (interface{}, error) {
+	left, err := p.additive()
+	for err == nil && p.accept("~") {
+		var right interface{}
+		if right, err = p.additive(); err == nil {
+			left = renderValue(left) + renderValue(right)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) additive() (interface{}, error) {
+	left, err := p.multiplicative()
+	for err == nil {
+		t, ok := p.peek()
+		if !ok || t.kind != operatorToken || (t.value != "+" && t.value != "-") {
+			break
+		}
+		p.pos++
+		var right interface{}
+		if right, err = p.multiplicative(); err == nil {
+			left = arithmetic(t.value, left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) multiplicative() (interface{}, error) {
+	left, err := p.filtered()
+	for err == nil {
+		t, ok := p.peek()
+		if !ok || t.kind != operatorToken || !strings.Contains("*/%", t.value) {
+			break
+		}
+		p.pos++
+		var right interface{}
+		if right, err = p.filtered(); err == nil {
+			left = arithmetic(t.value, left, right)
+		}
+	}
+	return left, err
+}
+
+// filtered evaluates the filters applied to the value, the filters other than default, lower, upper, string,
+// int and tojson keep the value
+func (p *parser) filtered() (interface{}, error) {
+	value, err := p.unary()
+	for err == nil && p.accept("|") {
+		t, ok := p.peek()
+		if !ok || t.kind != nameToken {
+			return nil, errSyntax
+		}
+		p.pos++
+		var args []interface{}
+		if p.accept("(") {
+			if args, err = p.arguments(); err != nil {
+				return nil, err
+			}
+		}
+		value = filter(t.value, value, args)
+	}
+	return value, err
+}
+
+func (p *parser) unary() (interface{}, error) {
+	if p.accept("-") {
+		value, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic("-", 0, value), nil
+	}
+	return p.postfix()
+}
+
+// postfix evaluates the attributes, the subscripts and the method calls of the value, the reference of the
+// value, e.g. properties.zone, is kept for the unknown attributes
+func (p *parser) postfix() (interface{}, error) {
+	ref := ""
+	if t, ok := p.peek(); ok && t.kind == nameToken {
+		ref = t.value
+	}
+	value, err := p.primary()
+	for err == nil {
+		switch {
+		case p.accept("."):
+			t, ok := p.peek()
+			if !ok || (t.kind != nameToken && t.kind != numberToken) {
+				return nil, errSyntax
+			}
+			p.pos++
+			if p.accept("(") {
+				var args []interface{}
+				if args, err = p.arguments(); err == nil {
+					value = method(value, t.value, args)
+				}
+			} else {
+				ref += "." + t.value
+				value = attribute(value, t.value, ref)
+			}
+		case p.accept("["):
+			var key interface{}
+			if key, err = p.expression(); err == nil {
+				if err = p.expect("]"); err == nil {
+					ref += "." + renderValue(key)
+					value = attribute(value, key, ref)
+				}
+			}
+		default:
+			return value, nil
+		}
+	}
+	return value, err
+}
+
+// arguments parses the arguments of a call, the opening parenthesis is consumed
+func (p *parser) arguments() ([]interface{}, error) {
+	args := make([]interface{}, 0)
+	for !p.accept(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func (p *parser) primary() (interface{}, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, errSyntax
+	}
+	p.pos++
+	switch t.kind {
+	case stringToken:
+		return t.value, nil
+	case numberToken:
+		if number, err := strconv.Atoi(t.value); err == nil {
+			return number, nil
+		}
+		number, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, errSyntax
+		}
+		return number, nil
+	case nameToken:
+		switch t.value {
+		case "true", "True":
+			return true, nil
+		case "false", "False":
+			return false, nil
+		case "none", "None":
+			return nil, nil
+		}
+		return p.renderer.lookup(t.value), nil
+	}
+	switch t.value {
+	case "(":
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return value, p.expect(")")
+	case "[":
+		items := make([]interface{}, 0)
+		for !p.accept("]") {
+			if len(items) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			item, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, errSyntax
+}
+
+// attribute returns the value of the key of the map or the index of the list, unknown when it is not found
+func attribute(value, key interface{}, ref string) interface{} {
+	switch v := value.(type) {
+	case unknown:
+		return unknown{ref: v.ref + "." + renderValue(key)}
+	case map[string]interface{}:
+		if found, ok := v[renderValue(key)]; ok {
+			return found
+		}
+	case []interface{}:
+		if index, ok := key.(int); ok && index >= 0 && index < len(v) {
+			return v[index]
+		} else if ok && index < 0 && -index <= len(v) {
+			return v[len(v)+index]
+		}
+	}
+	return unknown{ref: ref}
+}
+
+// method evaluates the methods of the maps and the strings used by the templates
+func method(value interface{}, name string, args []interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch name {
+		case "get":
+			if len(args) > 0 {
+				if found, ok := v[renderValue(args[0])]; ok {
+					return found
+				} else if len(args) > 1 {
+					return args[1]
+				}
+				return nil
+			}
+		case "keys", "values", "items":
+			items := make([]interface{}, 0, len(v))
+			for _, key := range sortedKeys(v) {
+				switch name {
+				case "keys":
+					items = append(items, key)
+				case "values":
+					items = append(items, v[key.(string)])
+				default:
+					items = append(items, []interface{}{key, v[key.(string)]})
+				}
+			}
+			return items
+		}
+	case string:
+		switch name {
+		case "lower":
+			return strings.ToLower(v)
+		case "upper":
+			return strings.ToUpper(v)
+		case "strip":
+			return strings.TrimSpace(v)
+		}
+	case unknown:
+		if name == "get" && len(args) > 1 {
+			return args[1]
+		}
+		return v
+	}
+	return unknown{ref: name}
+}
+
+func filter(name string, value interface{}, args []interface{}) interface{} {
+	_, isUnknown := value.(unknown)
+	switch name {
+	case "default", "d":
+		if isUnknown && len(args) > 0 {
+			return args[0]
+		}
+	case "lower", "upper":
+		if s, ok := value.(string); ok {
+			return method(s, name, nil)
+		}
+	case "string":
+		if !isUnknown {
+			return renderValue(value)
+		}
+	case "int":
+		if number, err := strconv.Atoi(renderValue(value)); err == nil {
+			return number
+		}
+	case "tojson":
+		if !isUnknown {
+			return renderValue(value)
+		}
+	}
+	return value
+}
+
+// logical evaluates the or and the and of the values, unknown when the result depends on an unknown value
+func logical(left, right interface{}, or bool) interface{} {
+	_, leftUnknown := left.(unknown)
+	_, rightUnknown := right.(unknown)
+	switch {
+	case !leftUnknown && truthy(left) == or:
+		return left
+	case leftUnknown:
+		if !rightUnknown && truthy(right) == or {
+			return right
+		}
+		return left
+	default:
+		return right
+	}
+}
+
+func compare(operator string, left, right interface{}) interface{} {
+	if u, ok := left.(unknown); ok {
+		return u
+	}
+	if u, ok := right.(unknown); ok {
+		return u
+	}
+	leftNumber, leftIsNumber := number(left)
+	rightNumber, rightIsNumber := number(right)
+	switch operator {
+	case "==":
+		if leftIsNumber && rightIsNumber {
+			return leftNumber == rightNumber
+		}
+		return fmt.Sprint(left) == fmt.Sprint(right)
+	case "!=":
+		return !compare("==", left, right).(bool)
+	}
+	if !leftIsNumber || !rightIsNumber {
+		left, right := renderValue(left), renderValue(right)
+		return map[string]bool{"<": left < right, ">": left > right, "<=": left <= right, ">=": left >= right}[operator]
+	}
+	return map[string]bool{
+		"<":  leftNumber < rightNumber,
+		">":  leftNumber > rightNumber,
+		"<=": leftNumber <= rightNumber,
+		">=": leftNumber >= rightNumber,
+	}[operator]
+}
+
+func arithmetic(operator string, left, right interface{}) interface{} {
+	if u, ok := left.(unknown); ok {
+		return u
+	}
+	if u, ok := right.(unknown); ok {
+		return u
+	}
+	leftNumber, leftIsNumber := number(left)
+	rightNumber, rightIsNumber := number(right)
+	if !leftIsNumber || !rightIsNumber {
+		if operator == "+" {
+			return concatenate(left, right)
+		}
+		return unknown{ref: renderValue(left)}
+	}
+	var result float64
+	switch operator {
+	case "+":
+		result = leftNumber + rightNumber
+	case "-":
+		result = leftNumber - rightNumber
+	case "*":
+		result = leftNumber * rightNumber
+	case "/":
+		if rightNumber == 0 {
+			return unknown{ref: renderValue(left)}
+		}
+		return leftNumber / rightNumber
+	case "%":
+		if rightNumber == 0 {
+			return unknown{ref: renderValue(left)}
+		}
+		result = float64(int(leftNumber) % int(rightNumber))
+	}
+	if _, isInt := left.(int); isInt {
+		if _, isInt := right.(int); isInt {
+			return int(result)
+		}
+	}
+	return result
+}
+
+// concatenate adds the lists or the strings
+func concatenate(left, right interface{}) interface{} {
+	leftList, leftIsList := left.([]interface{})
+	rightList, rightIsList := right.([]interface{})
+	if leftIsList && rightIsList {
+		return append(append(make([]interface{}, 0, len(leftList)+len(rightList)), leftList...), rightList...)
+	}
+	return renderValue(left) + renderValue(right)
+}
+
+func contains(container, item interface{}) interface{} {
+	switch c := container.(type) {
+	case unknown:
+		return c
+	case []interface{}:
+		for _, element := range c {
+			if compare("==", element, item) == true {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		_, ok := c[renderValue(item)]
+		return ok
+	case string:
+		return strings.Contains(c, renderValue(item))
+	}
+	return false
+}
+
+func negateIf(value interface{}, negate bool) interface{} {
+	if b, ok := value.(bool); ok && negate {
+		return !b
+	}
+	return value
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// truthy returns the truth value of a known value
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func sortedKeys(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sorted := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, key)
+	}
+	return sorted
+}
@@ -0,0 +1,410 @@
+// Package jinja renders the Jinja templates of Google Deployment Manager into YAML
+package jinja
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Extension is the extension of the Deployment Manager Jinja templates
+	Extension = ".jinja"
+
+	schemaExtension = ".schema"
+	statementStart  = "{%"
+	statementEnd    = "%}"
+	expressionStart = "{{"
+	expressionEnd   = "}}"
+	commentStart    = "{#"
+	commentEnd      = "#}"
+)
+
+// unknown is the value of an expression only known on deployment, it is rendered as its reference, e.g.
+// properties.zone, so the documents keep a value the queries can report
+type unknown struct {
+	ref string
+}
+
+// block is a statement with a body, the body is rendered when the block and its parents are active
+type block struct {
+	kind   string
+	active bool
+	// taken is true when a branch of the if block or the body of the for block was rendered
+	taken bool
+}
+
+// renderer renders a template, the lines of the template are kept so the lines of the documents match the
+// lines of the template
+type renderer struct {
+	scopes []map[string]interface{}
+	blocks []*block
+	out    strings.Builder
+}
+
+// Render renders the template, the properties are read from the configurations of the directory of the template
+// declaring a resource of its type and from the defaults of its schema. Each for loop renders its first item
+// only and the if blocks whose condition is unknown render their first branch, so the lines of the template are
+// kept. The expressions whose value is unknown, e.g. the properties without value or the environment
+// variables set on deployment, are rendered as their reference
+func Render(content []byte, filePath string) []byte {
+	properties, name := templateProperties(filePath)
+	env := map[string]interface{}{
+		"type": filepath.Base(filePath),
+	}
+	if name != "" {
+		env["name"] = name
+	}
+
+	r := &renderer{
+		scopes: []map[string]interface{}{{
+			"properties": properties,
+			"env":        env,
+		}},
+	}
+	r.render(string(content))
+	return []byte(r.out.String())
+}
+
+// render renders the template text, its tags and its comments
+func (r *renderer) render(text string) {
+	for len(text) > 0 {
+		start := nextTag(text)
+		if start < 0 {
+			r.write(text)
+			return
+		}
+		r.write(text[:start])
+		text = text[start:]
+
+		end := closingTag(text)
+		tag := text[:end]
+		text = text[end:]
+
+		r.newlines(tag)
+		switch tag[:2] {
+		case expressionStart:
+			if r.isActive() {
+				r.out.WriteString(renderValue(r.evaluate(trimTag(tag, expressionEnd))))
+			}
+		case statementStart:
+			r.statement(trimTag(tag, statementEnd))
+		}
+	}
+}
+
+// nextTag returns the index of the next tag of the text, -1 when there is none
+func nextTag(text string) int {
+	next := -1
+	for _, start := range []string{expressionStart, statementStart, commentStart} {
+		if idx := strings.Index(text, start); idx >= 0 && (next < 0 || idx < next) {
+			next = idx
+		}
+	}
+	return next
+}
+
+// closingTag returns the index following the end of the tag starting the text
+func closingTag(text string) int {
+	end := map[string]string{
+		expressionStart: expressionEnd,
+		statementStart:  statementEnd,
+		commentStart:    commentEnd,
+	}[text[:2]]
+	idx := strings.Index(text[2:], end)
+	if idx < 0 {
+		return len(text)
+	}
+	return idx + 4
+}
+
+// trimTag returns the content of the tag without its delimiters and whitespace control markers
+func trimTag(tag, end string) string {
+	tag = strings.TrimSuffix(tag[2:], end)
+	return strings.TrimSpace(strings.Trim(tag, "-+"))
+}
+
+// write writes the text when the blocks are active, otherwise it writes its lines only
+func (r *renderer) write(text string) {
+	if r.isActive() {
+		r.out.WriteString(text)
+		return
+	}
+	r.newlines(text)
+}
+
+// newlines writes the line breaks of the text
+func (r *renderer) newlines(text string) {
+	r.out.WriteString(strings.Repeat("\n", strings.Count(text, "\n")))
+}
+
+func (r *renderer) isActive() bool {
+	for _, b := range r.blocks {
+		if !b.active {
+			return false
+		}
+	}
+	return true
+}
+
+// isParentActive returns true when the blocks containing the current block are active
+func (r *renderer) isParentActive() bool {
+	for _, b := range r.blocks[:len(r.blocks)-1] {
+		if !b.active {
+			return false
+		}
+	}
+	return true
+}
+
+// statement evaluates a statement tag, the statements without a body other than set are ignored
+func (r *renderer) statement(statement string) {
+	keyword, rest, _ := strings.Cut(statement, " ")
+	rest = strings.TrimSpace(rest)
+	switch keyword {
+	case "if":
+		r.blocks = append(r.blocks, &block{kind: keyword})
+		r.branch(rest, true)
+	case "elif":
+		if r.current("if") != nil {
+			r.branch(rest, false)
+		}
+	case "else":
+		// the else branch of a for loop is rendered when its items are empty
+		if r.current("if") != nil || r.current("for") != nil {
+			b := r.blocks[len(r.blocks)-1]
+			b.active = !b.taken
+			b.taken = true
+		}
+	case "for":
+		r.loop(rest)
+	case "macro", "call", "filter", "raw":
+		r.blocks = append(r.blocks, &block{kind: keyword})
+	case "set":
+		if r.isActive() {
+			r.set(rest)
+		}
+	default:
+		if kind := strings.TrimPrefix(keyword, "end"); kind != keyword && r.current(kind) != nil {
+			r.blocks = r.blocks[:len(r.blocks)-1]
+			if kind == "for" {
+				r.scopes = r.scopes[:len(r.scopes)-1]
+			}
+		}
+	}
+}
+
+// current returns the current block when it is of the kind
+func (r *renderer) current(kind string) *block {
+	if len(r.blocks) == 0 || r.blocks[len(r.blocks)-1].kind != kind {
+		return nil
+	}
+	return r.blocks[len(r.blocks)-1]
+}
+
+// branch activates the branch of the current if block when no branch was taken and its condition is true or,
+// for the first branch only, unknown
+func (r *renderer) branch(condition string, first bool) {
+	b := r.blocks[len(r.blocks)-1]
+	if b.taken || !r.isParentActive() {
+		b.active = false
+		return
+	}
+	value := r.evaluate(condition)
+	_, isUnknown := value.(unknown)
+	b.active = (isUnknown && first) || (!isUnknown && truthy(value))
+	b.taken = b.active
+}
+
+// loop opens a for block rendering its first item, the block is inactive when the items are known to be empty
+func (r *renderer) loop(statement string) {
+	targets, iterable, found := strings.Cut(statement, " in ")
+	if idx := strings.Index(iterable, " if "); idx >= 0 {
+		iterable = iterable[:idx]
+	}
+	scope := map[string]interface{}{"loop": map[string]interface{}{"index": 1, "index0": 0, "first": true}}
+	names := strings.Split(targets, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+
+	active := found
+	var item interface{}
+	if found {
+		switch items := r.evaluate(iterable).(type) {
+		case []interface{}:
+			active = len(items) > 0
+			if active {
+				item = items[0]
+			}
+		case map[string]interface{}:
+			keys := sortedKeys(items)
+			active = len(keys) > 0
+			if active {
+				item = keys[0]
+			}
+		default:
+			item = unknown{ref: strings.TrimSpace(targets)}
+		}
+	}
+
+	if pair, ok := item.([]interface{}); ok && len(names) > 1 && len(pair) == len(names) {
+		for i, name := range names {
+			scope[name] = pair[i]
+		}
+	} else {
+		for _, name := range names {
+			scope[name] = item
+			if _, ok := item.(unknown); ok || item == nil {
+				scope[name] = unknown{ref: name}
+			}
+		}
+	}
+	r.scopes = append(r.scopes, scope)
+	r.blocks = append(r.blocks, &block{kind: "for", active: active, taken: active})
+}
+
+// set assigns the value of the expression to the variable of the current scope
+func (r *renderer) set(statement string) {
+	name, expression, found := strings.Cut(statement, "=")
+	if !found {
+		return
+	}
+	r.scopes[len(r.scopes)-1][strings.TrimSpace(name)] = r.evaluate(expression)
+}
+
+// lookup returns the value of the variable, from the innermost scope
+func (r *renderer) lookup(name string) interface{} {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if value, ok := r.scopes[i][name]; ok {
+			return value
+		}
+	}
+	return unknown{ref: name}
+}
+
+// evaluate evaluates the expression, the expressions that can not be parsed are unknown
+func (r *renderer) evaluate(expression string) interface{} {
+	p := &parser{renderer: r, tokens: tokenize(expression)}
+	value, err := p.expression()
+	if err != nil || p.pos < len(p.tokens) {
+		log.Trace().Msgf("Failed to evaluate the Jinja expression '%s'", expression)
+		return unknown{ref: strings.Join(strings.Fields(expression), "")}
+	}
+	return value
+}
+
+// renderValue renders the value as Jinja outputs it, the lists and the maps are rendered in flow style
+func renderValue(value interface{}) string {
+	switch v := value.(type) {
+	case unknown:
+		return v.ref
+	case string:
+		return v
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}, map[string]interface{}:
+		content, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(content)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// templateProperties returns the properties of the template and the name of the resource using it, the values of
+// the configuration override the defaults of the schema
+func templateProperties(filePath string) (properties map[string]interface{}, name string) {
+	properties = make(map[string]interface{})
+	var schema struct {
+		Properties map[string]map[string]interface{} `yaml:"properties"`
+	}
+	if readYAML(filePath+schemaExtension, &schema) {
+		for property, definition := range schema.Properties {
+			if value, ok := definition["default"]; ok {
+				properties[property] = value
+			}
+		}
+	}
+
+	resource := configurationResource(filePath)
+	if resource == nil {
+		return properties, ""
+	}
+	if values, ok := resource["properties"].(map[string]interface{}); ok {
+		for property, value := range values {
+			properties[property] = value
+		}
+	}
+	name, _ = resource["name"].(string)
+	return properties, name
+}
+
+// configurationResource returns the first resource of the configurations of the directory whose type is the
+// template or an import name of the template
+func configurationResource(filePath string) map[string]interface{} {
+	template := filepath.Base(filePath)
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		var configuration struct {
+			Imports []struct {
+				Path string `yaml:"path"`
+				Name string `yaml:"name"`
+			} `yaml:"imports"`
+			Resources []map[string]interface{} `yaml:"resources"`
+		}
+		if !readYAML(filepath.Join(filepath.Dir(filePath), entry.Name()), &configuration) {
+			continue
+		}
+		types := map[string]bool{}
+		for _, imported := range configuration.Imports {
+			if filepath.Base(imported.Path) == template {
+				types[imported.Path] = true
+				if imported.Name != "" {
+					types[imported.Name] = true
+				}
+			}
+		}
+		for _, resource := range configuration.Resources {
+			resourceType, _ := resource["type"].(string)
+			if types[resourceType] || filepath.Base(resourceType) == template {
+				return resource
+			}
+		}
+	}
+	return nil
+}
+
+// readYAML decodes the YAML file, it returns false when the file can not be read or decoded
+func readYAML(path string, target interface{}) bool {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal(content, target); err != nil {
+		log.Debug().Msgf("Failed to decode %s: %s", path, err)
+		return false
+	}
+	return true
+}
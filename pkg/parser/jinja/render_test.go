@@ -0,0 +1,121 @@
+package jinja
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const template = `{# A virtual machine #}
+{% set machine = "n1-standard-" ~ properties["cores"] %}
+resources:
+- name: {{ env["name"] }}-vm
+  type: compute.v1.instance
+  properties:
+    zone: {{ properties["zone"] }}
+    machineType: zones/{{ properties.zone }}/machineTypes/{{ machine }}
+    canIpForward: {{ properties["canIpForward"] }}
+    {% if properties["shielded"] and properties["secureBoot"] %}
+    shieldedInstanceConfig:
+      enableSecureBoot: true
+    {% elif properties["legacy"] %}
+    legacy: true
+    {% else %}
+    shieldedInstanceConfig: {}
+    {% endif %}
+    disks:
+    {% for disk in properties["disks"] %}
+    - deviceName: {{ disk.name | upper }}
+      boot: {{ disk.boot | default(false) }}
+    {% endfor %}
+    {% for label in properties["labels"] %}
+    labels: {{ label }}
+    {% endfor %}
+    networkInterfaces:
+    - network: {{ properties.network | default("global/networks/default") }}
+      subnetwork: {{ properties["subnetwork"] }}
+`
+
+const schema = `properties:
+  zone:
+    type: string
+    default: us-central1-a
+  cores:
+    type: integer
+    default: 1
+  canIpForward:
+    type: boolean
+    default: false
+  shielded:
+    type: boolean
+    default: false
+  labels:
+    type: array
+    default: []
+`
+
+const configuration = `imports:
+- path: templates/vm.jinja
+  name: vm
+resources:
+- name: web
+  type: vm
+  properties:
+    canIpForward: true
+    cores: 4
+    disks:
+    - name: boot
+      boot: true
+    - name: data
+`
+
+func render(t *testing.T, files map[string]string) []string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	rendered := string(Render([]byte(template), filepath.Join(dir, "vm.jinja")))
+	lines := strings.Split(rendered, "\n")
+	require.Len(t, lines, strings.Count(template, "\n")+1, "the lines of the template are kept")
+	return lines
+}
+
+// TestRender tests the rendering of a template with the properties of its configuration and its schema
+func TestRender(t *testing.T) {
+	lines := render(t, map[string]string{"vm.jinja.schema": schema, "config.yaml": configuration})
+
+	require.Empty(t, lines[0])
+	require.Empty(t, strings.TrimSpace(lines[1]))
+	require.Equal(t, "- name: web-vm", lines[3])
+	require.Equal(t, "    zone: us-central1-a", lines[6])
+	require.Equal(t, "    machineType: zones/us-central1-a/machineTypes/n1-standard-4", lines[7])
+	require.Equal(t, "    canIpForward: true", lines[8])
+	// shielded is false, the else branch is rendered
+	require.Empty(t, strings.TrimSpace(lines[10]))
+	require.Empty(t, strings.TrimSpace(lines[13]))
+	require.Equal(t, "    shieldedInstanceConfig: {}", lines[15])
+	// the first item of the loop is rendered
+	require.Equal(t, "    - deviceName: BOOT", lines[19])
+	require.Equal(t, "      boot: true", lines[20])
+	// the loops over empty items are not rendered
+	require.Empty(t, strings.TrimSpace(lines[23]))
+	require.Equal(t, "    - network: global/networks/default", lines[26])
+	require.Equal(t, "      subnetwork: properties.subnetwork", lines[27])
+}
+
+// TestRender_UnknownValues tests the values only known on deployment are rendered as their references
+func TestRender_UnknownValues(t *testing.T) {
+	lines := render(t, map[string]string{})
+
+	require.Equal(t, "- name: env.name-vm", lines[3])
+	require.Equal(t, "    zone: properties.zone", lines[6])
+	require.Equal(t, "    canIpForward: properties.canIpForward", lines[8])
+	// the first branch of the if blocks with an unknown condition is rendered
+	require.Equal(t, "      enableSecureBoot: true", lines[11])
+	require.Empty(t, strings.TrimSpace(lines[15]))
+	require.Equal(t, "    - deviceName: disk.name", lines[19])
+	require.Equal(t, "      boot: false", lines[20])
+}
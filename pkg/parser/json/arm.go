@@ -0,0 +1,201 @@
+package json
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	armDeploymentType     = "microsoft.resources/deployments"
+	armParametersSuffix   = ".parameters.json"
+	armNestedMaxDepth     = 10
+	armInnerScope         = "inner"
+	armTemplateSchemaName = "deploymenttemplate.json"
+)
+
+var (
+	armParameterReferenceRegex = regexp.MustCompile(`^\[\s*parameters\(\s*'([^']+)'\s*\)\s*\]$`)
+	// armSecureTypes are the parameter types whose values are never copied, so secrets given by a
+	// parameters file or by a deployment do not become default values
+	armSecureTypes = map[string]bool{
+		"securestring": true,
+		"secureobject": true,
+	}
+)
+
+// isARMTemplate returns true when the document is an ARM deployment template, of any scope
+func isARMTemplate(doc map[string]interface{}) bool {
+	schema, ok := doc["$schema"].(string)
+	if !ok {
+		return false
+	}
+	_, hasResources := doc["resources"]
+	return hasResources && strings.Contains(strings.ToLower(schema), armTemplateSchemaName)
+}
+
+// resolveARMTemplate evaluates the parameters file of the template and returns the template followed by
+// the templates of its nested deployments, each as its own document
+func resolveARMTemplate(doc map[string]interface{}, filePath string) []model.Document {
+	applyARMParametersFile(doc, filePath)
+
+	documents := []model.Document{doc}
+	return append(documents, extractARMNestedTemplates(doc, 0)...)
+}
+
+// applyARMParametersFile sets the values of the parameters file named after the template (<template>.parameters.json)
+// as the default values of the template parameters
+func applyARMParametersFile(doc map[string]interface{}, filePath string) {
+	parametersPath := strings.TrimSuffix(filePath, filepath.Ext(filePath)) + armParametersSuffix
+	if parametersPath == filePath {
+		return
+	}
+	content, err := os.ReadFile(filepath.Clean(parametersPath))
+	if err != nil {
+		return
+	}
+
+	var parametersFile struct {
+		Parameters map[string]map[string]interface{} `json:"parameters"`
+	}
+	if err := json.Unmarshal(content, &parametersFile); err != nil {
+		log.Warn().Msgf("Failed to parse the ARM parameters file %s: %s", parametersPath, err)
+		return
+	}
+
+	values := make(map[string]interface{}, len(parametersFile.Parameters))
+	for name, parameter := range parametersFile.Parameters {
+		// the key vault references are only resolved on deployment
+		if value, ok := parameter["value"]; ok {
+			values[name] = value
+		}
+	}
+	setARMParameterValues(doc, values)
+}
+
+// setARMParameterValues sets the values as the default values of the declared, non secure, parameters
+func setARMParameterValues(doc map[string]interface{}, values map[string]interface{}) {
+	parameters, ok := doc["parameters"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for name, value := range values {
+		parameter, ok := parameters[name].(map[string]interface{})
+		if !ok || isARMSecureParameter(parameter) {
+			continue
+		}
+		parameter["defaultValue"] = value
+	}
+}
+
+func isARMSecureParameter(parameter map[string]interface{}) bool {
+	parameterType, _ := parameter["type"].(string)
+	return armSecureTypes[strings.ToLower(parameterType)]
+}
+
+// extractARMNestedTemplates removes the inline templates of the deployments of the template, so their resources
+// are not evaluated twice, and returns them with the parameters they are deployed with
+func extractARMNestedTemplates(doc map[string]interface{}, depth int) []model.Document {
+	if depth >= armNestedMaxDepth {
+		return nil
+	}
+	resources, ok := doc["resources"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	nested := make([]model.Document, 0)
+	for _, item := range resources {
+		resource, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// child resources can declare deployments too
+		nested = append(nested, extractARMNestedTemplates(resource, depth)...)
+
+		resourceType, _ := resource["type"].(string)
+		if !strings.EqualFold(resourceType, armDeploymentType) {
+			continue
+		}
+		properties, ok := resource["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		template, ok := properties["template"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(properties, "template")
+
+		if _, ok := template["$schema"]; !ok {
+			template["$schema"] = doc["$schema"]
+		}
+		if isARMInnerScope(properties) {
+			setARMParameterValues(template, armDeploymentParameterValues(doc, properties))
+		} else {
+			// the expressions of a nested template with the outer scope are evaluated in the parent template
+			for _, key := range []string{"parameters", "variables"} {
+				if _, ok := template[key]; !ok && doc[key] != nil {
+					template[key] = doc[key]
+				}
+			}
+		}
+
+		nested = append(nested, template)
+		nested = append(nested, extractARMNestedTemplates(template, depth+1)...)
+	}
+	return nested
+}
+
+func isARMInnerScope(properties map[string]interface{}) bool {
+	options, ok := properties["expressionEvaluationOptions"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	scope, _ := options["scope"].(string)
+	return strings.EqualFold(scope, armInnerScope)
+}
+
+// armDeploymentParameterValues returns the values of the parameters given by the deployment, the references to
+// the parameters of the parent template are replaced by their default values and other expressions are discarded
+func armDeploymentParameterValues(doc, properties map[string]interface{}) map[string]interface{} {
+	deploymentParameters, ok := properties["parameters"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	parentParameters, _ := doc["parameters"].(map[string]interface{})
+
+	values := make(map[string]interface{}, len(deploymentParameters))
+	for name, item := range deploymentParameters {
+		parameter, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := parameter["value"]
+		if !ok {
+			continue
+		}
+		stringValue, isString := value.(string)
+		if !isString || !strings.HasPrefix(stringValue, "[") {
+			values[name] = value
+			continue
+		}
+		reference := armParameterReferenceRegex.FindStringSubmatch(stringValue)
+		if reference == nil {
+			continue
+		}
+		parentParameter, ok := parentParameters[reference[1]].(map[string]interface{})
+		if !ok || isARMSecureParameter(parentParameter) {
+			continue
+		}
+		if defaultValue, ok := parentParameter["defaultValue"]; ok {
+			values[name] = defaultValue
+		}
+	}
+	return values
+}
@@ -0,0 +1,163 @@
+package json
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const armTemplate = `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "httpsOnly": {"type": "bool", "defaultValue": true},
+    "location": {"type": "string"},
+    "adminPassword": {"type": "securestring"}
+  },
+  "variables": {"prefix": "app"},
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "inner",
+      "properties": {
+        "mode": "Incremental",
+        "expressionEvaluationOptions": {"scope": "inner"},
+        "parameters": {
+          "httpsTraffic": {"value": "[parameters('httpsOnly')]"},
+          "tier": {"value": "Standard"},
+          "password": {"value": "[parameters('adminPassword')]"},
+          "computed": {"value": "[concat(variables('prefix'), '-sa')]"}
+        },
+        "template": {
+          "parameters": {
+            "httpsTraffic": {"type": "bool"},
+            "tier": {"type": "string"},
+            "password": {"type": "securestring"},
+            "computed": {"type": "string"}
+          },
+          "resources": [
+            {
+              "type": "Microsoft.Storage/storageAccounts",
+              "name": "innerStorage",
+              "properties": {"supportsHttpsTrafficOnly": "[parameters('httpsTraffic')]"}
+            },
+            {
+              "type": "Microsoft.Resources/deployments",
+              "name": "deeper",
+              "properties": {
+                "template": {
+                  "resources": [{"type": "Microsoft.Network/publicIPAddresses", "name": "deeperIP"}]
+                }
+              }
+            }
+          ]
+        }
+      }
+    },
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "outer",
+      "properties": {
+        "mode": "Incremental",
+        "template": {
+          "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+          "resources": [
+            {"type": "Microsoft.Storage/storageAccounts", "name": "outerStorage"}
+          ]
+        }
+      }
+    }
+  ]
+}`
+
+func parseARM(t *testing.T, parameters string) []model.Document {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "azuredeploy.json")
+	require.NoError(t, os.WriteFile(path, []byte(armTemplate), 0600))
+	if parameters != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "azuredeploy.parameters.json"), []byte(parameters), 0600))
+	}
+
+	p := &Parser{}
+	docs, _, err := p.Parse(path, []byte(armTemplate))
+	require.NoError(t, err)
+	return docs
+}
+
+func armResources(doc model.Document) []interface{} {
+	return doc["resources"].([]interface{})
+}
+
+func armParameter(doc model.Document, name string) map[string]interface{} {
+	return doc["parameters"].(map[string]interface{})[name].(map[string]interface{})
+}
+
+// TestParser_ParseARMNestedTemplates tests the extraction of the nested templates of the ARM deployments
+func TestParser_ParseARMNestedTemplates(t *testing.T) {
+	docs := parseARM(t, "")
+	require.Len(t, docs, 4)
+
+	// the nested templates are removed from the deployments of the parent template
+	for _, resource := range armResources(docs[0]) {
+		require.NotContains(t, resource.(map[string]interface{})["properties"], "template")
+	}
+
+	inner := docs[1]
+	require.Equal(t, "innerStorage", armResources(inner)[0].(map[string]interface{})["name"])
+	require.Equal(t, docs[0]["$schema"], inner["$schema"])
+	require.Equal(t, true, armParameter(inner, "httpsTraffic")["defaultValue"])
+	require.Equal(t, "Standard", armParameter(inner, "tier")["defaultValue"])
+	require.NotContains(t, armParameter(inner, "password"), "defaultValue")
+	require.NotContains(t, armParameter(inner, "computed"), "defaultValue")
+	require.NotContains(t, inner, "variables")
+	require.Contains(t, inner, "_kics_lines")
+
+	require.Equal(t, "deeperIP", armResources(docs[2])[0].(map[string]interface{})["name"])
+
+	// the nested template with the outer scope uses the parameters and variables of the parent template
+	outer := docs[3]
+	require.Equal(t, "outerStorage", armResources(outer)[0].(map[string]interface{})["name"])
+	require.Equal(t, docs[0]["parameters"], outer["parameters"])
+	require.Equal(t, docs[0]["variables"], outer["variables"])
+}
+
+// TestParser_ParseARMParametersFile tests the evaluation of the parameters file of the ARM template
+func TestParser_ParseARMParametersFile(t *testing.T) {
+	docs := parseARM(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentParameters.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "httpsOnly": {"value": false},
+    "location": {"value": "westeurope"},
+    "adminPassword": {"value": "P@ssw0rd"},
+    "undeclared": {"value": "ignored"}
+  }
+}`)
+
+	parent := docs[0]
+	require.Equal(t, false, armParameter(parent, "httpsOnly")["defaultValue"])
+	require.Equal(t, "westeurope", armParameter(parent, "location")["defaultValue"])
+	require.NotContains(t, armParameter(parent, "adminPassword"), "defaultValue")
+	require.NotContains(t, parent["parameters"], "undeclared")
+
+	// the deployment parameters referencing the parent parameters get the values of the parameters file
+	require.Equal(t, false, armParameter(docs[1], "httpsTraffic")["defaultValue"])
+}
+
+// Test_isARMTemplate tests the detection of the ARM templates
+func Test_isARMTemplate(t *testing.T) {
+	require.True(t, isARMTemplate(map[string]interface{}{
+		"$schema":   "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#",
+		"resources": []interface{}{},
+	}))
+	require.False(t, isARMTemplate(map[string]interface{}{
+		"$schema":    "https://schema.management.azure.com/schemas/2019-04-01/deploymentParameters.json#",
+		"parameters": map[string]interface{}{},
+	}))
+	require.False(t, isARMTemplate(map[string]interface{}{"resources": []interface{}{}}))
+}
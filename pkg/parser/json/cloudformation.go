@@ -0,0 +1,79 @@
+package json
+
+import (
+	"github.com/Checkmarx/kics/pkg/parser/cloudformation"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// resolveCloudFormationTemplate resolves the intrinsic functions of a CloudFormation template and applies its
+// AWS::Serverless transform, the template is read as YAML, which JSON is a subset of, to share the resolution
+// of the YAML templates
+func resolveCloudFormationTemplate(doc map[string]interface{}, filePath string, fileContent []byte) {
+	if _, ok := doc["Resources"]; !ok {
+		return
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(fileContent, &root); err != nil || !cloudformation.IsTemplate(&root) {
+		return
+	}
+	replacements := cloudformation.Resolve(&root, filePath)
+	for _, replacement := range append(replacements, cloudformation.ExpandServerless(&root)...) {
+		var value interface{}
+		if replacement.Value != nil {
+			if err := replacement.Value.Decode(&value); err != nil {
+				log.Debug().Msgf("Failed to decode the resolved value of %v in %s: %s", replacement.Path, filePath, err)
+				continue
+			}
+		}
+		setPathValue(doc, replacement.Path, value, replacement.Value == nil)
+	}
+}
+
+// setPathValue sets the value at the path of keys and indexes, adding the missing keys, or removes it
+func setPathValue(doc map[string]interface{}, path []interface{}, value interface{}, remove bool) {
+	if len(path) == 0 {
+		return
+	}
+	var parent interface{} = doc
+	for _, step := range path[:len(path)-1] {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			key, _ := step.(string)
+			parent = container[key]
+		case []interface{}:
+			idx, ok := step.(int)
+			if !ok || idx >= len(container) {
+				return
+			}
+			parent = container[idx]
+		default:
+			return
+		}
+	}
+
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		key, _ := path[len(path)-1].(string)
+		if remove {
+			delete(container, key)
+		} else {
+			container[key] = value
+		}
+	case []interface{}:
+		idx, ok := path[len(path)-1].(int)
+		if !ok || idx >= len(container) {
+			return
+		}
+		if remove {
+			setSequence(doc, path[:len(path)-1], append(container[:idx:idx], container[idx+1:]...))
+		} else {
+			container[idx] = value
+		}
+	}
+}
+
+// setSequence replaces the sequence at the path, used when an element is removed
+func setSequence(doc map[string]interface{}, path []interface{}, sequence []interface{}) {
+	setPathValue(doc, path, sequence, false)
+}
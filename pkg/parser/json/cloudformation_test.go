@@ -0,0 +1,47 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cloudFormationTemplate = `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Parameters": {
+    "Access": {"Type": "String", "Default": "PublicRead"},
+    "Environment": {"Type": "String", "Default": "prod"}
+  },
+  "Conditions": {
+    "IsProd": {"Fn::Equals": [{"Ref": "Environment"}, "prod"]}
+  },
+  "Resources": {
+    "Bucket": {
+      "Type": "AWS::S3::Bucket",
+      "Properties": {
+        "AccessControl": {"Ref": "Access"},
+        "BucketName": {"Fn::Join": ["-", [{"Ref": "Environment"}, "bucket"]]},
+        "Region": {"Ref": "AWS::Region"},
+        "Tags": [
+          {"Fn::If": ["IsProd", {"Ref": "AWS::NoValue"}, {"Key": "env", "Value": "dev"}]},
+          {"Key": "team", "Value": "platform"}
+        ]
+      }
+    }
+  }
+}`
+
+// TestParser_ParseCloudFormationIntrinsics tests the resolution of the intrinsic functions of the JSON templates
+func TestParser_ParseCloudFormationIntrinsics(t *testing.T) {
+	p := &Parser{}
+	docs, _, err := p.Parse("template.json", []byte(cloudFormationTemplate))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	properties := docs[0]["Resources"].(map[string]interface{})["Bucket"].(map[string]interface{})["Properties"].(map[string]interface{})
+	require.Equal(t, "PublicRead", properties["AccessControl"])
+	require.Equal(t, "prod-bucket", properties["BucketName"])
+	require.Equal(t, "AWS::Region", properties["Region"].(map[string]interface{})["Ref"])
+	require.Len(t, properties["Tags"], 1)
+	require.Equal(t, "team", properties["Tags"].([]interface{})[0].(map[string]interface{})["Key"])
+}
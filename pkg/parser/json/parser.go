@@ -29,7 +29,7 @@ func (p *Parser) Resolve(fileContent []byte, filename string, resolveReferences
 }
 
 // Parse parses json file and returns it as a Document
-func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, error) {
+func (p *Parser) Parse(filePath string, fileContent []byte) ([]model.Document, []int, error) {
 	r := model.Document{}
 	err := easyjson.Unmarshal(fileContent, &r)
 	if err != nil {
@@ -41,6 +41,16 @@ func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, e
 	jLine := initializeJSONLine(fileContent)
 	kicsJSON := jLine.setLineInfo(r)
 
+	resolveCloudFormationTemplate(kicsJSON, filePath, fileContent)
+
+	if isARMTemplate(kicsJSON) {
+		return resolveARMTemplate(kicsJSON, filePath), []int{}, nil
+	}
+
+	if container, changes, ok := whatIfChanges(kicsJSON); ok {
+		return []model.Document{resolveWhatIfResult(kicsJSON, container, changes, filePath)}, []int{}, nil
+	}
+
 	// Try to parse JSON as Terraform plan
 	kicsPlan, err := parseTFPlan(kicsJSON)
 	if err != nil {
@@ -53,9 +63,9 @@ func (p *Parser) Parse(_ string, fileContent []byte) ([]model.Document, []int, e
 	return []model.Document{kicsPlan}, []int{}, nil
 }
 
-// SupportedExtensions returns extensions supported by this parser, which is json extension
+// SupportedExtensions returns extensions supported by this parser, which are json and terraform state extensions
 func (p *Parser) SupportedExtensions() []string {
-	return []string{".json"}
+	return []string{".json", ".tfstate"}
 }
 
 // GetKind returns JSON constant kind
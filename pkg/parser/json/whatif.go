@@ -0,0 +1,98 @@
+package json
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	whatIfTemplateSchema = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+	whatIfDeleteChange   = "delete"
+)
+
+// whatIfChanges returns the changes of the output of 'az deployment what-if', either the output of the CLI or the
+// result of the REST API, where the changes are under the properties of the result, false is returned when the
+// document is not a what-if result
+func whatIfChanges(doc map[string]interface{}) (container map[string]interface{}, changes []interface{}, ok bool) {
+	container = doc
+	if _, hasChanges := doc["changes"]; !hasChanges {
+		if properties, isMap := doc["properties"].(map[string]interface{}); isMap {
+			container = properties
+		}
+	}
+	if _, hasStatus := doc["status"]; !hasStatus {
+		return nil, nil, false
+	}
+	changes, ok = container["changes"].([]interface{})
+	if !ok || len(changes) == 0 {
+		return nil, nil, false
+	}
+	for _, item := range changes {
+		change, isMap := item.(map[string]interface{})
+		if !isMap {
+			return nil, nil, false
+		}
+		_, hasChangeType := change["changeType"].(string)
+		_, hasResourceID := change["resourceId"].(string)
+		if !hasChangeType || !hasResourceID {
+			return nil, nil, false
+		}
+	}
+	return container, changes, true
+}
+
+// resolveWhatIfResult maps the predicted state of the resources of a what-if result into an ARM template, so the
+// queries run against the resources as they will be deployed, the deleted resources and the resources without a
+// predicted state (ignored or unsupported) are left out
+func resolveWhatIfResult(doc, container map[string]interface{}, changes []interface{}, filePath string) model.Document {
+	if status, _ := doc["status"].(string); !strings.EqualFold(status, "succeeded") {
+		log.Warn().Msgf("The what-if operation of %s has the status %s, its changes may be incomplete", filePath, status)
+	}
+
+	changesLines := whatIfChangesLines(container)
+	resources := make([]interface{}, 0, len(changes))
+	resourcesLines := &model.LineObject{Line: changesLines.Line, Arr: []map[string]*model.LineObject{}}
+	for i, item := range changes {
+		change := item.(map[string]interface{})
+		if changeType, _ := change["changeType"].(string); strings.EqualFold(changeType, whatIfDeleteChange) {
+			continue
+		}
+		after, ok := change["after"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// the line information of the predicted state becomes the line information of the resource
+		lines, _ := after["_kics_lines"].(map[string]*model.LineObject)
+		delete(after, "_kics_lines")
+		if lines == nil {
+			lines = map[string]*model.LineObject{"_kics__default": {Line: changesLines.Line}}
+			if i < len(changesLines.Arr) {
+				lines = changesLines.Arr[i]
+			}
+		}
+
+		resources = append(resources, after)
+		resourcesLines.Arr = append(resourcesLines.Arr, lines)
+	}
+
+	return model.Document{
+		"$schema":        whatIfTemplateSchema,
+		"contentVersion": "1.0.0.0",
+		"resources":      resources,
+		"_kics_lines": map[string]*model.LineObject{
+			"_kics__default":  {Line: 0},
+			"_kics_resources": resourcesLines,
+		},
+	}
+}
+
+func whatIfChangesLines(container map[string]interface{}) *model.LineObject {
+	lines, _ := container["_kics_lines"].(map[string]*model.LineObject)
+	if changesLines, ok := lines["_kics_changes"]; ok {
+		return changesLines
+	}
+	return &model.LineObject{}
+}
@@ -0,0 +1,62 @@
+package json
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_ParseWhatIf tests the mapping of the predicted resources of a what-if result into an ARM template
+func TestParser_ParseWhatIf(t *testing.T) {
+	path := "../../../test/fixtures/arm_what_if/what-if.json"
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	p := &Parser{}
+	docs, _, err := p.Parse(path, content)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.True(t, isARMTemplate(docs[0]))
+
+	// the deleted and ignored resources are left out
+	resources := armResources(docs[0])
+	require.Len(t, resources, 2)
+	storage := resources[0].(map[string]interface{})
+	require.Equal(t, "kicsstorage", storage["name"])
+	require.NotContains(t, storage, "_kics_lines")
+	app := resources[1].(map[string]interface{})
+	require.Equal(t, "kics-app", app["name"])
+	require.Equal(t, false, app["properties"].(map[string]interface{})["httpsOnly"])
+
+	// the lines point to the predicted state of the resources in the what-if result
+	lines := docs[0]["_kics_lines"].(map[string]*model.LineObject)["_kics_resources"]
+	require.Equal(t, 2, lines.Line)
+	require.Len(t, lines.Arr, 2)
+	require.Equal(t, 9, lines.Arr[0]["_kics_name"].Line)
+	require.Equal(t, 31, lines.Arr[1]["_kics_name"].Line)
+}
+
+func Test_whatIfChanges(t *testing.T) {
+	change := map[string]interface{}{"changeType": "Create", "resourceId": "/subscriptions/id", "after": nil}
+
+	_, changes, ok := whatIfChanges(map[string]interface{}{"status": "Succeeded", "changes": []interface{}{change}})
+	require.True(t, ok)
+	require.Len(t, changes, 1)
+
+	// result of the REST API
+	_, _, ok = whatIfChanges(map[string]interface{}{
+		"status":     "Succeeded",
+		"properties": map[string]interface{}{"changes": []interface{}{change}},
+	})
+	require.True(t, ok)
+
+	_, _, ok = whatIfChanges(map[string]interface{}{"changes": []interface{}{change}})
+	require.False(t, ok)
+	_, _, ok = whatIfChanges(map[string]interface{}{
+		"status":  "Succeeded",
+		"changes": []interface{}{map[string]interface{}{"changeType": "Create"}},
+	})
+	require.False(t, ok)
+}
@@ -0,0 +1,302 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Checkmarx/kics/pkg/parser/terraform/converter"
+	"github.com/Checkmarx/kics/pkg/parser/terraform/functions"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/rs/zerolog/log"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleMetaArguments are the module block arguments that are not input variables of the module
+var moduleMetaArguments = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"count":      true,
+	"for_each":   true,
+	"providers":  true,
+	"depends_on": true,
+}
+
+// moduleCall is a module block calling a local module
+type moduleCall struct {
+	callerDir string
+	moduleDir string
+	name      string
+	body      *hclsyntax.Body
+}
+
+// moduleCallKey identifies a module call by the called module and the caller, outputs depend on both
+type moduleCallKey struct {
+	moduleDir string
+	callerDir string
+	name      string
+}
+
+// moduleIndex keeps the local module calls found in the scanned paths, so the values given by
+// the caller can be used as the module input variables and the module outputs can be used by the caller
+type moduleIndex struct {
+	paths             []string
+	terraformVarsPath string
+	once              sync.Once
+	mutex             sync.Mutex
+	callsByModule     map[string][]moduleCall
+	callsByCaller     map[string][]moduleCall
+	variablesByDir    map[string]converter.VariableMap
+	outputsByCall     map[moduleCallKey]converter.VariableMap
+}
+
+func newModuleIndex(paths []string, terraformVarsPath string) *moduleIndex {
+	return &moduleIndex{
+		paths:             paths,
+		terraformVarsPath: terraformVarsPath,
+		callsByModule:     make(map[string][]moduleCall),
+		callsByCaller:     make(map[string][]moduleCall),
+		variablesByDir:    make(map[string]converter.VariableMap),
+		outputsByCall:     make(map[moduleCallKey]converter.VariableMap),
+	}
+}
+
+// resolve adds the values given by the caller of the module in currentPath to the input variables
+// and sets the outputs of the modules called in currentPath
+func (m *moduleIndex) resolve(currentPath string) {
+	outputs := make(converter.VariableMap)
+	if m != nil && len(m.paths) > 0 {
+		m.once.Do(m.load)
+		m.mutex.Lock()
+		currentPath = filepath.Clean(currentPath)
+		if inputs := m.getModuleInputs(currentPath, map[string]bool{}); len(inputs) > 0 {
+			variables := make(converter.VariableMap)
+			if current, ok := inputVariableMap["var"]; ok && current.Type().IsObjectType() {
+				mergeMaps(variables, current.AsValueMap())
+			}
+			mergeMaps(variables, inputs)
+			inputVariableMap["var"] = cty.ObjectVal(variables)
+		}
+		outputs = m.getModuleOutputs(currentPath)
+		m.mutex.Unlock()
+	}
+	// without known outputs the module references are kept as they are written
+	if len(outputs) == 0 {
+		delete(inputVariableMap, "module")
+		return
+	}
+	inputVariableMap["module"] = cty.ObjectVal(outputs)
+}
+
+// load finds every local module call in the scanned paths, the modules downloaded by terraform init
+// to .terraform directories are skipped since they are not part of the scanned configuration
+func (m *moduleIndex) load() {
+	for _, path := range m.paths {
+		if err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() && info.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			if info.IsDir() || filepath.Ext(p) != ".tf" {
+				return nil
+			}
+			m.addModuleCalls(p)
+			return nil
+		}); err != nil {
+			log.Debug().Msgf("Failed to look for terraform module calls in %s: %s", path, err)
+		}
+	}
+
+	for dir := range m.callsByModule {
+		calls := m.callsByModule[dir]
+		sort.Slice(calls, func(i, j int) bool {
+			if calls[i].callerDir == calls[j].callerDir {
+				return calls[i].name < calls[j].name
+			}
+			return calls[i].callerDir < calls[j].callerDir
+		})
+	}
+}
+
+func (m *moduleIndex) addModuleCalls(filename string) {
+	parsedFile, err := parseFile(filename, false)
+	if err != nil || parsedFile == nil {
+		return
+	}
+	body, ok := parsedFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return
+	}
+
+	callerDir := filepath.Dir(filename)
+	for _, block := range body.Blocks {
+		if block.Type != "module" || len(block.Labels) == 0 {
+			continue
+		}
+		sourceAttr, ok := block.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+		source, diagnostics := sourceAttr.Expr.Value(nil)
+		if diagnostics.HasErrors() || source.IsNull() || !source.IsKnown() || source.Type() != cty.String {
+			continue
+		}
+		// only local modules can be resolved, remote modules are not part of the scanned paths
+		if !strings.HasPrefix(source.AsString(), "./") && !strings.HasPrefix(source.AsString(), "../") {
+			continue
+		}
+		call := moduleCall{
+			callerDir: callerDir,
+			moduleDir: filepath.Clean(filepath.Join(callerDir, filepath.FromSlash(source.AsString()))),
+			name:      block.Labels[0],
+			body:      block.Body,
+		}
+		m.callsByModule[call.moduleDir] = append(m.callsByModule[call.moduleDir], call)
+		m.callsByCaller[callerDir] = append(m.callsByCaller[callerDir], call)
+	}
+}
+
+// getVariables returns the input variables of a directory including the ones given by its caller
+func (m *moduleIndex) getVariables(dir string, visited map[string]bool) converter.VariableMap {
+	if variables, ok := m.variablesByDir[dir]; ok {
+		return variables
+	}
+	variables := m.getDirVariables(dir)
+	mergeMaps(variables, m.getModuleInputs(dir, visited))
+	m.variablesByDir[dir] = variables
+	return variables
+}
+
+// getModuleInputs returns the known values given to the module in dir by its first caller,
+// other callers are ignored since a single document is built for each file
+func (m *moduleIndex) getModuleInputs(dir string, visited map[string]bool) converter.VariableMap {
+	calls := m.callsByModule[dir]
+	if len(calls) == 0 || visited[dir] {
+		return converter.VariableMap{}
+	}
+	if len(calls) > 1 {
+		log.Debug().Msgf("Module %s is called %d times, using the values given by %s", dir, len(calls), calls[0].callerDir)
+	}
+	visited[dir] = true
+	defer delete(visited, dir)
+
+	return evaluateArguments(calls[0].body, m.getVariables(calls[0].callerDir, visited))
+}
+
+// getDirVariables returns the input variables defined in dir, when no variables file is given by flag
+// the kics_terraform_vars comment of the directory files is used
+func (m *moduleIndex) getDirVariables(dir string) converter.VariableMap {
+	terraformVarsPath := m.terraformVarsPath
+	if terraformVarsPath == "" {
+		terraformVarsPath = getDirTerraformVarsPath(dir)
+	}
+	return getVariables(dir, "", terraformVarsPath)
+}
+
+// getDirTerraformVarsPath returns the variables file given by the first kics_terraform_vars comment
+// found in the terraform files of dir
+func getDirTerraformVarsPath(dir string) string {
+	tfFiles, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return ""
+	}
+	sort.Strings(tfFiles)
+	for _, tfFile := range tfFiles {
+		content, err := os.ReadFile(filepath.Clean(tfFile))
+		if err != nil {
+			continue
+		}
+		if terraformVarsPath := getTerraformVarsPathFromComment(dir, string(content)); terraformVarsPath != "" {
+			return terraformVarsPath
+		}
+	}
+	return ""
+}
+
+// getModuleOutputs returns, by module name, the known outputs of the modules called in dir,
+// the outputs of each call are computed once since every file of dir resolves the same calls
+func (m *moduleIndex) getModuleOutputs(dir string) converter.VariableMap {
+	outputs := make(converter.VariableMap)
+	for _, call := range m.callsByCaller[dir] {
+		key := moduleCallKey{moduleDir: call.moduleDir, callerDir: call.callerDir, name: call.name}
+		moduleOutputs, ok := m.outputsByCall[key]
+		if !ok {
+			variables := m.getDirVariables(call.moduleDir)
+			mergeMaps(variables, evaluateArguments(call.body, m.getVariables(dir, map[string]bool{})))
+			moduleOutputs = getOutputs(call.moduleDir, variables)
+			m.outputsByCall[key] = moduleOutputs
+		}
+		if len(moduleOutputs) > 0 {
+			outputs[call.name] = cty.ObjectVal(moduleOutputs)
+		}
+	}
+	return outputs
+}
+
+// evaluateArguments returns the module block arguments whose value can be computed with the given variables
+func evaluateArguments(body *hclsyntax.Body, variables converter.VariableMap) converter.VariableMap {
+	arguments := make(converter.VariableMap)
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: functions.TerraformFuncs,
+	}
+	for name, attr := range body.Attributes {
+		if moduleMetaArguments[name] {
+			continue
+		}
+		if value, ok := evaluateKnown(attr.Expr, ctx); ok {
+			arguments[name] = value
+		}
+	}
+	return arguments
+}
+
+// getOutputs returns the outputs of the module in dir whose value can be computed with the given variables
+func getOutputs(dir string, variables converter.VariableMap) converter.VariableMap {
+	outputs := make(converter.VariableMap)
+	tfFiles, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return outputs
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: functions.TerraformFuncs,
+	}
+	for _, tfFile := range tfFiles {
+		parsedFile, err := parseFile(tfFile, false)
+		if err != nil || parsedFile == nil {
+			continue
+		}
+		body, ok := parsedFile.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "output" || len(block.Labels) == 0 {
+				continue
+			}
+			valueAttr, ok := block.Body.Attributes["value"]
+			if !ok {
+				continue
+			}
+			if value, ok := evaluateKnown(valueAttr.Expr, ctx); ok {
+				outputs[block.Labels[0]] = value
+			}
+		}
+	}
+	return outputs
+}
+
+// evaluateKnown evaluates an expression, false is returned when its value can not be fully computed
+func evaluateKnown(expr hclsyntax.Expression, ctx *hcl.EvalContext) (cty.Value, bool) {
+	value, diagnostics := expr.Value(ctx)
+	if diagnostics.HasErrors() || value.IsNull() || !value.IsWhollyKnown() || value.Type().HasDynamicTypes() {
+		return cty.NilVal, false
+	}
+	return value, true
+}
@@ -0,0 +1,140 @@
+package terraform
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/parser/terraform/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var modulesFixture = filepath.FromSlash("../../../test/fixtures/test_terraform_modules")
+
+// parseModuleFile resolves and parses a file, returning its document as it is given to the queries
+func parseModuleFile(t *testing.T, parser *Parser, filename string) map[string]interface{} {
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	_, err = parser.Resolve(content, filename, false)
+	require.NoError(t, err)
+	documents, _, err := parser.Parse(filename, content)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+
+	marshaled, err := json.Marshal(documents[0])
+	require.NoError(t, err)
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(marshaled, &document))
+	return document
+}
+
+func getBlock(document map[string]interface{}, keys ...string) map[string]interface{} {
+	block := document
+	for _, key := range keys {
+		block = block[key].(map[string]interface{})
+	}
+	return block
+}
+
+// Test_ModuleInputs tests that the values given by the caller are used as the module input variables
+func Test_ModuleInputs(t *testing.T) {
+	parser := NewDefaultWithParams("", []string{modulesFixture})
+	document := parseModuleFile(t, parser, filepath.Join(modulesFixture, "modules", "bucket", "main.tf"))
+
+	bucket := getBlock(document, "resource", "aws_s3_bucket", "bucket")
+	require.Equal(t, "logs", bucket["bucket"])
+	require.Equal(t, "public-read", bucket["acl"])
+	// region is given by an expression that can not be computed so the module default is kept
+	require.Equal(t, "us-east-1", bucket["region"])
+
+	t.Cleanup(func() {
+		inputVariableMap = make(converter.VariableMap)
+	})
+}
+
+// Test_ModuleOutputs tests that the known module outputs are used by the caller
+func Test_ModuleOutputs(t *testing.T) {
+	parser := NewDefaultWithParams("", []string{modulesFixture})
+	document := parseModuleFile(t, parser, filepath.Join(modulesFixture, "main.tf"))
+
+	access := getBlock(document, "resource", "aws_s3_bucket_public_access_block", "access")
+	require.Equal(t, "logs", access["bucket"])
+	require.Equal(t, false, access["block_public_policy"])
+	// outputs that depend on resource attributes are unknown and kept as written
+	require.Equal(t, "${module.bucket.id}", access["ignore_public_acls"])
+
+	// the outputs of the call are cached for the other files of the caller directory
+	require.Len(t, parser.modules.outputsByCall, 1)
+	parseModuleFile(t, parser, filepath.Join(modulesFixture, "main.tf"))
+	require.Len(t, parser.modules.outputsByCall, 1)
+
+	t.Cleanup(func() {
+		inputVariableMap = make(converter.VariableMap)
+	})
+}
+
+// Test_ModulesWithoutScanPaths tests that modules are not resolved when the scanned paths are unknown
+func Test_ModulesWithoutScanPaths(t *testing.T) {
+	parser := NewDefault()
+	document := parseModuleFile(t, parser, filepath.Join(modulesFixture, "modules", "bucket", "main.tf"))
+
+	bucket := getBlock(document, "resource", "aws_s3_bucket", "bucket")
+	require.Equal(t, "default", bucket["bucket"])
+	require.Equal(t, "private", bucket["acl"])
+
+	t.Cleanup(func() {
+		inputVariableMap = make(converter.VariableMap)
+	})
+}
+
+// Test_ModulesCallerVarsFile tests that the variables file given by the kics_terraform_vars comment of the caller
+// is used to compute the module inputs and that the modules downloaded to .terraform are not callers
+func Test_ModulesCallerVarsFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tf": `// kics_terraform_vars: custom.tfvars
+variable "name" {
+  default = "default"
+}
+
+module "bucket" {
+  source = "./bucket"
+  name   = var.name
+}
+`,
+		"custom.tfvars": `name = "custom"
+`,
+		filepath.Join("bucket", "main.tf"): `variable "name" {
+  default = "module"
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = var.name
+}
+`,
+		filepath.Join(".terraform", "modules", "other", "main.tf"): `module "bucket" {
+  source = "../../../bucket"
+  name   = "terraform-init"
+}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	index := newModuleIndex([]string{dir}, "")
+	index.load()
+	require.Len(t, index.callsByModule[filepath.Join(dir, "bucket")], 1)
+
+	parser := NewDefaultWithParams("", []string{dir})
+	document := parseModuleFile(t, parser, filepath.Join(dir, "bucket", "main.tf"))
+
+	bucket := getBlock(document, "resource", "aws_s3_bucket", "bucket")
+	require.Equal(t, "custom", bucket["bucket"])
+
+	t.Cleanup(func() {
+		inputVariableMap = make(converter.VariableMap)
+	})
+}
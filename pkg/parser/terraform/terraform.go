@@ -28,6 +28,7 @@ type Parser struct {
 	convertFunc       Converter
 	numOfRetries      int
 	terraformVarsPath string
+	modules           *moduleIndex
 }
 
 // NewDefault initializes a parser with Parser default values
@@ -45,6 +46,14 @@ func NewDefaultWithVarsPath(terraformVarsPath string) *Parser {
 	return parser
 }
 
+// NewDefaultWithParams initializes a parser with the default values using a variables path
+// and the scanned paths, used to find the callers of local modules
+func NewDefaultWithParams(terraformVarsPath string, scanPaths []string) *Parser {
+	parser := NewDefaultWithVarsPath(terraformVarsPath)
+	parser.modules = newModuleIndex(scanPaths, terraformVarsPath)
+	return parser
+}
+
 // Resolve - replace or modifies in-memory content before parsing
 func (p *Parser) Resolve(fileContent []byte, filename string, _ bool) ([]byte, error) {
 	// handle panic during resolve process
@@ -55,6 +64,7 @@ func (p *Parser) Resolve(fileContent []byte, filename string, _ bool) ([]byte, e
 		}
 	}()
 	getInputVariables(filepath.Dir(filename), string(fileContent), p.terraformVarsPath)
+	p.modules.resolve(filepath.Dir(filename))
 	getDataSourcePolicy(filepath.Dir(filename))
 	return fileContent, nil
 }
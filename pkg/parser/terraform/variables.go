@@ -88,6 +88,12 @@ func getInputVariablesFromFile(filename string) (converter.VariableMap, error) {
 }
 
 func getInputVariables(currentPath, fileContent, terraformVarsPath string) {
+	inputVariableMap["var"] = cty.ObjectVal(getVariables(currentPath, fileContent, terraformVarsPath))
+}
+
+// getVariables returns the input variables of a directory, taken from the variables default values,
+// the .tfvars files and the variables file given by terraformVarsPath or by the kics_terraform_vars comment
+func getVariables(currentPath, fileContent, terraformVarsPath string) converter.VariableMap {
 	variablesMap := make(converter.VariableMap)
 	tfFiles, err := filepath.Glob(filepath.Join(currentPath, "*.tf"))
 	if err != nil {
@@ -126,17 +132,7 @@ func getInputVariables(currentPath, fileContent, terraformVarsPath string) {
 
 	// If the flag is empty let's look for the value in the first written line of the file
 	if terraformVarsPath == "" {
-		terraformVarsPathRegex := regexp.MustCompile(`(?m)^\s*// kics_terraform_vars: ([\w/\\.:-]+)\r?\n`)
-		terraformVarsPathMatch := terraformVarsPathRegex.FindStringSubmatch(fileContent)
-		if terraformVarsPathMatch != nil {
-			// There is a path tp the variables file in the file so that will be the path to the variables tf file
-			terraformVarsPath = terraformVarsPathMatch[1]
-			// If the path contains ":" assume its a global path
-			if !strings.Contains(terraformVarsPath, ":") {
-				// If not then add the current folder path before so that the comment path can be relative
-				terraformVarsPath = filepath.Join(currentPath, terraformVarsPath)
-			}
-		}
+		terraformVarsPath = getTerraformVarsPathFromComment(currentPath, fileContent)
 	}
 
 	// If the terraformVarsPath is empty, this means that it is not in the flag
@@ -156,5 +152,23 @@ func getInputVariables(currentPath, fileContent, terraformVarsPath string) {
 		}
 	}
 
-	inputVariableMap["var"] = cty.ObjectVal(variablesMap)
+	return variablesMap
+}
+
+// getTerraformVarsPathFromComment returns the variables file given by the kics_terraform_vars comment
+// of the file content, relative paths are relative to currentPath
+func getTerraformVarsPathFromComment(currentPath, fileContent string) string {
+	terraformVarsPathRegex := regexp.MustCompile(`(?m)^\s*// kics_terraform_vars: ([\w/\\.:-]+)\r?\n`)
+	terraformVarsPathMatch := terraformVarsPathRegex.FindStringSubmatch(fileContent)
+	if terraformVarsPathMatch == nil {
+		return ""
+	}
+	// There is a path tp the variables file in the file so that will be the path to the variables tf file
+	terraformVarsPath := terraformVarsPathMatch[1]
+	// If the path contains ":" assume its a global path
+	if !strings.Contains(terraformVarsPath, ":") {
+		// If not then add the current folder path before so that the comment path can be relative
+		terraformVarsPath = filepath.Join(currentPath, terraformVarsPath)
+	}
+	return terraformVarsPath
 }
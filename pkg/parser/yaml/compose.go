@@ -0,0 +1,510 @@
+package json
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	composeEnvFile         = ".env"
+	composeProfilesEnvVar  = "COMPOSE_PROFILES"
+	composeMaskedSecret    = "<SECRET-MASKED-ON-PURPOSE>"
+	composeLinesKey        = "_kics_lines"
+	composeExtendsMaxDepth = 10
+)
+
+var (
+	composeVariableRegex = regexp.MustCompile(
+		`\$(?:\$|\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-?+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+	composeSecretNameRegex = regexp.MustCompile(`(?i)(pass|pwd|secret|token|key|credential|auth|private)`)
+	// composeSequencesMerged are the service sequences concatenated by extends instead of overridden
+	composeSequencesMerged = map[string]bool{
+		"cap_add":        true,
+		"cap_drop":       true,
+		"devices":        true,
+		"dns":            true,
+		"dns_search":     true,
+		"expose":         true,
+		"external_links": true,
+		"ports":          true,
+		"security_opt":   true,
+		"tmpfs":          true,
+		"volumes":        true,
+	}
+	// composeMappingsMerged are the service mappings merged by key even when written as sequences
+	composeMappingsMerged = map[string]bool{
+		"environment": true,
+		"labels":      true,
+	}
+)
+
+// composeResolver resolves the docker compose documents into their effective service definitions:
+// the variables are interpolated with the .env file of the project, the env_file contents are added to
+// the service environment, the extends are merged and the services of inactive profiles are removed,
+// the values of the variables with secret names are masked
+type composeResolver struct {
+	dir         string
+	env         map[string]string
+	profiles    map[string]bool
+	loadedFiles map[string]map[string]interface{}
+}
+
+func newComposeResolver(filePath string, profiles []string) *composeResolver {
+	dir := filepath.Dir(filePath)
+	resolver := &composeResolver{
+		dir:         dir,
+		env:         readComposeEnvFile(filepath.Join(dir, composeEnvFile)),
+		profiles:    make(map[string]bool),
+		loadedFiles: make(map[string]map[string]interface{}),
+	}
+
+	resolver.activateProfiles(profiles)
+	if len(resolver.profiles) == 0 {
+		resolver.activateProfiles(strings.Split(resolver.env[composeProfilesEnvVar], ","))
+	}
+	return resolver
+}
+
+func (r *composeResolver) activateProfiles(profiles []string) {
+	for _, profile := range profiles {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			r.profiles[profile] = true
+		}
+	}
+}
+
+// isComposeDocument returns true if the document has services with an image, a build or an extends
+func isComposeDocument(doc model.Document) bool {
+	services, ok := doc["services"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, service := range services {
+		serviceMap, ok := service.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"image", "build", "extends"} {
+			if _, ok := serviceMap[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveComposeDocuments resolves the docker compose documents of the file
+func resolveComposeDocuments(documents []model.Document, filePath string, profiles []string) []model.Document {
+	var resolver *composeResolver
+	for _, doc := range documents {
+		if !isComposeDocument(doc) {
+			continue
+		}
+		if resolver == nil {
+			resolver = newComposeResolver(filePath, profiles)
+		}
+		resolver.resolve(doc, filePath)
+	}
+	return documents
+}
+
+func (r *composeResolver) resolve(doc model.Document, filePath string) {
+	for key, value := range doc {
+		if key != composeLinesKey {
+			doc[key] = r.interpolate(value)
+		}
+	}
+
+	services := doc["services"].(map[string]interface{})
+	for name := range services {
+		r.resolveExtends(name, services, filePath, 0)
+	}
+
+	for name, service := range services {
+		serviceMap, ok := service.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !r.isActive(serviceMap) {
+			log.Debug().Msgf("Docker compose service '%s' ignored, its profiles are not active: %s", name, filePath)
+			delete(services, name)
+			continue
+		}
+		r.resolveEnvFiles(serviceMap)
+	}
+}
+
+// interpolate substitutes the variables of the string values, the keys are not interpolated
+func (r *composeResolver) interpolate(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return r.interpolateString(typed)
+	case map[string]interface{}:
+		for key, val := range typed {
+			if key != composeLinesKey {
+				typed[key] = r.interpolate(val)
+			}
+		}
+	case []interface{}:
+		for i := range typed {
+			typed[i] = r.interpolate(typed[i])
+		}
+	}
+	return value
+}
+
+// interpolateString substitutes the $VAR and ${VAR} references, including the ${VAR:-default}, ${VAR-default},
+// ${VAR:+alternative}, ${VAR+alternative} and ${VAR:?error} modifiers, '$$' escapes a '$' and the references
+// to unknown variables without a default are kept as they are
+func (r *composeResolver) interpolateString(value string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+	return composeVariableRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		match := composeVariableRegex.FindStringSubmatch(ref)
+		name, modifier, word := match[1], match[2], match[3]
+		if name == "" {
+			name = match[4]
+		}
+
+		varValue, set := r.env[name]
+		varValue = maskComposeSecret(name, varValue)
+		unsetOrEmpty := !set || (strings.HasPrefix(modifier, ":") && varValue == "")
+
+		switch strings.TrimPrefix(modifier, ":") {
+		case "-":
+			if unsetOrEmpty {
+				return word
+			}
+		case "+":
+			if unsetOrEmpty {
+				return ""
+			}
+			return word
+		default:
+			if unsetOrEmpty {
+				return ref
+			}
+		}
+		return varValue
+	})
+}
+
+// resolveExtends merges the service it extends into the service, the service definition of the extended
+// service is the one of the compose file given by extends or the one of the same file
+func (r *composeResolver) resolveExtends(name string, services map[string]interface{}, filePath string,
+	depth int) map[string]interface{} {
+	service, ok := services[name].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	extends, ok := service["extends"]
+	if !ok {
+		return service
+	}
+	if depth > composeExtendsMaxDepth {
+		log.Warn().Msgf("Docker compose service '%s' extends too many services: %s", name, filePath)
+		return service
+	}
+
+	baseName, baseServices, basePath := "", services, filePath
+	switch typed := extends.(type) {
+	case string:
+		baseName = typed
+	case map[string]interface{}:
+		baseName, _ = typed["service"].(string)
+		switch baseFile := typed["file"].(type) {
+		case string:
+			basePath = filepath.Join(filepath.Dir(filePath), baseFile)
+			baseServices = r.loadServices(basePath)
+		case map[string]interface{}:
+			// the file was already replaced by its content by the file resolver
+			basePath = filePath + "#extends." + name
+			baseServices, _ = baseFile["services"].(map[string]interface{})
+		}
+	}
+	if baseName == "" || (baseName == name && basePath == filePath) {
+		log.Warn().Msgf("Docker compose service '%s' has an invalid extends: %s", name, filePath)
+		return service
+	}
+
+	base := r.resolveExtends(baseName, baseServices, basePath, depth+1)
+	if base == nil {
+		log.Warn().Msgf("Docker compose service '%s' extends an unknown service '%s': %s", name, baseName, basePath)
+		return service
+	}
+	if basePath != filePath {
+		// the lines of the other file do not match the lines of this file
+		base = stripComposeLines(base).(map[string]interface{})
+	}
+
+	merged := mergeComposeMaps(copyComposeValue(base).(map[string]interface{}), service)
+	delete(merged, "extends")
+	services[name] = merged
+	return merged
+}
+
+// loadServices reads and interpolates the services of another compose file
+func (r *composeResolver) loadServices(path string) map[string]interface{} {
+	if services, ok := r.loadedFiles[path]; ok {
+		return services
+	}
+	services := make(map[string]interface{})
+	r.loadedFiles[path] = services
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		log.Warn().Msgf("Failed to read the extended docker compose file %s: %s", path, err)
+		return services
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		log.Warn().Msgf("Failed to parse the extended docker compose file %s: %s", path, err)
+		return services
+	}
+	if fileServices, ok := convert(doc).(map[string]interface{})["services"].(map[string]interface{}); ok {
+		for name, service := range fileServices {
+			services[name] = r.interpolate(service)
+		}
+	}
+	return services
+}
+
+// isActive returns true if the service has no profiles, if no profile is activated or if any of its profiles is active
+func (r *composeResolver) isActive(service map[string]interface{}) bool {
+	profiles, ok := service["profiles"].([]interface{})
+	if !ok || len(profiles) == 0 || len(r.profiles) == 0 {
+		return true
+	}
+	for _, profile := range profiles {
+		if name, ok := profile.(string); ok && r.profiles[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEnvFiles adds the variables of the env_file files to the service environment,
+// the variables of the environment take precedence
+func (r *composeResolver) resolveEnvFiles(service map[string]interface{}) {
+	var paths []string
+	switch typed := service["env_file"].(type) {
+	case string:
+		paths = append(paths, typed)
+	case []interface{}:
+		for _, item := range typed {
+			switch envFile := item.(type) {
+			case string:
+				paths = append(paths, envFile)
+			case map[string]interface{}:
+				if path, ok := envFile["path"].(string); ok {
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+
+	environment := make(map[string]interface{})
+	for _, path := range paths {
+		for key, value := range readComposeEnvFile(filepath.Join(r.dir, path)) {
+			environment[key] = maskComposeSecret(key, value)
+		}
+	}
+	for key, value := range composeMapping(service["environment"]) {
+		environment[key] = value
+	}
+	if lines, ok := service["environment"].(map[string]interface{}); ok && lines[composeLinesKey] != nil {
+		environment[composeLinesKey] = lines[composeLinesKey]
+	}
+	service["environment"] = environment
+}
+
+func maskComposeSecret(key, value string) string {
+	if value != "" && composeSecretNameRegex.MatchString(key) {
+		return composeMaskedSecret
+	}
+	return value
+}
+
+// readComposeEnvFile reads the KEY=VALUE lines of an env file, a missing file has no variables
+func readComposeEnvFile(path string) map[string]string {
+	env := make(map[string]string)
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return env
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Err(err).Msgf("failed to close the env file %s", path)
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			continue
+		}
+		env[key] = unquoteComposeEnvValue(strings.TrimSpace(value))
+	}
+	return env
+}
+
+func unquoteComposeEnvValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	if idx := strings.Index(value, " #"); idx != -1 {
+		return strings.TrimSpace(value[:idx])
+	}
+	return value
+}
+
+// composeMapping returns the mapping of a compose value written either as a mapping or as a KEY=VALUE sequence
+func composeMapping(value interface{}) map[string]interface{} {
+	mapping := make(map[string]interface{})
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, val := range typed {
+			if key != composeLinesKey {
+				mapping[key] = val
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			if entry, ok := item.(string); ok {
+				key, val, _ := strings.Cut(entry, "=")
+				mapping[key] = val
+			}
+		}
+	}
+	return mapping
+}
+
+// mergeComposeMaps merges the override into the base following the compose extends rules
+func mergeComposeMaps(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		baseValue, ok := base[key]
+		if !ok {
+			base[key] = value
+			continue
+		}
+
+		switch {
+		case key == composeLinesKey:
+			base[key] = mergeComposeLines(baseValue, value)
+		case composeMappingsMerged[key]:
+			merged := composeMapping(baseValue)
+			for k, v := range composeMapping(value) {
+				merged[k] = v
+			}
+			if lines, ok := value.(map[string]interface{}); ok && lines[composeLinesKey] != nil {
+				merged[composeLinesKey] = lines[composeLinesKey]
+			}
+			base[key] = merged
+		case composeSequencesMerged[key]:
+			base[key] = mergeComposeSequences(baseValue, value)
+		default:
+			baseMap, baseIsMap := baseValue.(map[string]interface{})
+			valueMap, valueIsMap := value.(map[string]interface{})
+			if baseIsMap && valueIsMap {
+				base[key] = mergeComposeMaps(baseMap, valueMap)
+			} else {
+				base[key] = value
+			}
+		}
+	}
+	return base
+}
+
+func mergeComposeLines(base, override interface{}) interface{} {
+	baseLines, ok := base.(map[string]*model.LineObject)
+	overrideLines, ok2 := override.(map[string]*model.LineObject)
+	if !ok || !ok2 {
+		return override
+	}
+	merged := make(map[string]*model.LineObject, len(baseLines)+len(overrideLines))
+	for key, value := range baseLines {
+		merged[key] = value
+	}
+	for key, value := range overrideLines {
+		merged[key] = value
+	}
+	return merged
+}
+
+func mergeComposeSequences(base, override interface{}) interface{} {
+	baseSeq, ok := base.([]interface{})
+	overrideSeq, ok2 := override.([]interface{})
+	if !ok || !ok2 {
+		return override
+	}
+	merged := make([]interface{}, 0, len(baseSeq)+len(overrideSeq))
+	seen := make(map[string]bool)
+	for _, item := range append(append([]interface{}{}, baseSeq...), overrideSeq...) {
+		if str, ok := item.(string); ok {
+			if seen[str] {
+				continue
+			}
+			seen[str] = true
+		}
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+func copyComposeValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, val := range typed {
+			copied[key] = copyComposeValue(val)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i := range typed {
+			copied[i] = copyComposeValue(typed[i])
+		}
+		return copied
+	}
+	return value
+}
+
+func stripComposeLines(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(typed))
+		for key, val := range typed {
+			if key != composeLinesKey {
+				stripped[key] = stripComposeLines(val)
+			}
+		}
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, len(typed))
+		for i := range typed {
+			stripped[i] = stripComposeLines(typed[i])
+		}
+		return stripped
+	}
+	return value
+}
@@ -0,0 +1,93 @@
+package json
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var composeFixture = filepath.FromSlash("../../../test/fixtures/test_compose_resolution/docker-compose.yml")
+
+func parseCompose(t *testing.T, profiles []string) map[string]interface{} {
+	content, err := os.ReadFile(composeFixture)
+	require.NoError(t, err)
+
+	p := NewWithComposeProfiles(profiles)
+	docs, _, err := p.Parse(composeFixture, content)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	return docs[0]["services"].(map[string]interface{})
+}
+
+// TestParser_ParseCompose tests the resolution of the docker compose documents
+func TestParser_ParseCompose(t *testing.T) {
+	services := parseCompose(t, nil)
+
+	// the worker service is in the jobs profile and COMPOSE_PROFILES of the .env file only activates web
+	require.Contains(t, services, "web")
+	require.Contains(t, services, "db")
+	require.NotContains(t, services, "worker")
+
+	web := services["web"].(map[string]interface{})
+	require.Equal(t, "nginx:1.25", web["image"])
+	require.Equal(t, true, web["privileged"])
+	require.Equal(t, []interface{}{"80:80", "443:443"}, web["ports"])
+	require.NotContains(t, web, "extends")
+
+	environment := web["environment"].(map[string]interface{})
+	require.Equal(t, "info", environment["LOG_LEVEL"])
+	require.Equal(t, "us-east-1", environment["REGION"])
+	require.Equal(t, composeMaskedSecret, environment["API_TOKEN"])
+	require.Equal(t, "postgres://app:<SECRET-MASKED-ON-PURPOSE>@db/app", environment["DATABASE_URL"])
+	require.Contains(t, environment, "_kics_lines")
+
+	db := services["db"].(map[string]interface{})
+	require.Equal(t, "postgres:16", db["image"])
+	require.Equal(t, "echo $HOME ${UNKNOWN}", db["command"])
+}
+
+// TestParser_ParseComposeProfiles tests the activation of the docker compose profiles
+func TestParser_ParseComposeProfiles(t *testing.T) {
+	services := parseCompose(t, []string{"jobs"})
+	require.NotContains(t, services, "web")
+	require.Contains(t, services, "db")
+
+	worker := services["worker"].(map[string]interface{})
+	require.Equal(t, "postgres:16", worker["image"])
+	require.Equal(t, "echo $HOME ${UNKNOWN}", worker["command"])
+}
+
+// Test_isComposeDocument tests the detection of the docker compose documents
+func Test_isComposeDocument(t *testing.T) {
+	require.True(t, isComposeDocument(model.Document{
+		"services": map[string]interface{}{"web": map[string]interface{}{"image": "nginx"}},
+	}))
+	require.False(t, isComposeDocument(model.Document{
+		"services": map[string]interface{}{"web": map[string]interface{}{"port": 80}},
+	}))
+	require.False(t, isComposeDocument(model.Document{"apiVersion": "v1", "kind": "Service"}))
+}
+
+// Test_readComposeEnvFile tests the parsing of the env files
+func Test_readComposeEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte(`
+# comment
+A=1
+export B='two words'
+C="quoted # not a comment"
+D=value # comment
+INVALID
+`), 0600))
+
+	require.Equal(t, map[string]string{
+		"A": "1",
+		"B": "two words",
+		"C": "quoted # not a comment",
+		"D": "value",
+	}, readComposeEnvFile(path))
+	require.Empty(t, readComposeEnvFile(filepath.Join(t.TempDir(), "missing.env")))
+}
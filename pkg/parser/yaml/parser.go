@@ -2,10 +2,13 @@ package json
 
 import (
 	"bytes"
+	"path/filepath"
 
 	"github.com/Checkmarx/kics/pkg/parser/utils"
 
 	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/parser/cloudformation"
+	"github.com/Checkmarx/kics/pkg/parser/jinja"
 	"github.com/Checkmarx/kics/pkg/resolver/file"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -14,11 +17,26 @@ import (
 
 // Parser defines a parser type
 type Parser struct {
-	resolvedFiles map[string]model.ResolvedFile
+	resolvedFiles   map[string]model.ResolvedFile
+	composeProfiles []string
+}
+
+// NewWithComposeProfiles initializes a parser activating the given docker compose profiles,
+// if no profile is given the COMPOSE_PROFILES variable of the .env file is used
+func NewWithComposeProfiles(composeProfiles []string) *Parser {
+	return &Parser{
+		composeProfiles: composeProfiles,
+	}
 }
 
 // Resolve - replace or modifies in-memory content before parsing
 func (p *Parser) Resolve(fileContent []byte, filename string, resolveReferences bool) ([]byte, error) {
+	// the Deployment Manager templates are rendered, keeping their lines
+	if filepath.Ext(filename) == jinja.Extension {
+		p.resolvedFiles = nil
+		return jinja.Render(fileContent, filename), nil
+	}
+
 	// Resolve files passed as arguments with file resolver (e.g. file://)
 	res := file.NewResolver(yaml.Unmarshal, yaml.Marshal, p.SupportedExtensions())
 	resolvedFilesCache := make(map[string]file.ResolvedFile)
@@ -37,13 +55,19 @@ func (p *Parser) Parse(filePath string, fileContent []byte) ([]model.Document, [
 	var documents []model.Document
 	dec := yaml.NewDecoder(bytes.NewReader(fileContent))
 
-	doc := emptyDocument()
-	for dec.Decode(doc) == nil {
-		if len(*doc) > 0 {
+	var node yaml.Node
+	for dec.Decode(&node) == nil {
+		// the intrinsic functions are resolved on the nodes, so the short form functions keep their tags
+		if cloudformation.IsTemplate(&node) {
+			cloudformation.Resolve(&node, filePath)
+			cloudformation.ExpandServerless(&node)
+		}
+		doc := emptyDocument()
+		if err := node.Decode(doc); err == nil && len(*doc) > 0 {
 			documents = append(documents, *doc)
 		}
 
-		doc = emptyDocument()
+		node = yaml.Node{}
 	}
 
 	if len(documents) == 0 {
@@ -52,7 +76,9 @@ func (p *Parser) Parse(filePath string, fileContent []byte) ([]model.Document, [
 
 	linesToIgnore := model.NewIgnore.GetLines()
 
-	return convertKeysToString(addExtraInfo(documents, filePath)), linesToIgnore, nil
+	documents = convertKeysToString(addExtraInfo(documents, filePath))
+
+	return resolveComposeDocuments(documents, filePath, p.composeProfiles), linesToIgnore, nil
 }
 
 // convertKeysToString goes through every document to convert map[interface{}]interface{}
@@ -92,9 +118,10 @@ func convert(value interface{}) interface{} {
 	return value
 }
 
-// SupportedExtensions returns extensions supported by this parser, which are yaml and yml extension
+// SupportedExtensions returns extensions supported by this parser, which are yaml, yml and the jinja extension of
+// the Deployment Manager templates
 func (p *Parser) SupportedExtensions() []string {
-	return []string{".yaml", ".yml"}
+	return []string{".yaml", ".yml", jinja.Extension}
 }
 
 // SupportedTypes returns types supported by this parser, which are ansible, cloudFormation, k8s
@@ -104,6 +131,7 @@ func (p *Parser) SupportedTypes() map[string]bool {
 		"cloudformation":          true,
 		"kubernetes":              true,
 		"crossplane":              true,
+		"configconnector":         true,
 		"knative":                 true,
 		"openapi":                 true,
 		"googledeploymentmanager": true,
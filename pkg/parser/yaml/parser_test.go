@@ -20,7 +20,7 @@ func TestParser_GetKind(t *testing.T) {
 // TestParser_SupportedExtensions tests the functions [SupportedExtensions()] and all the methods called by them
 func TestParser_SupportedExtensions(t *testing.T) {
 	p := &Parser{}
-	require.Equal(t, []string{".yaml", ".yml"}, p.SupportedExtensions())
+	require.Equal(t, []string{".yaml", ".yml", ".jinja"}, p.SupportedExtensions())
 }
 
 // TestParser_SupportedExtensions tests the functions [SupportedTypes()] and all the methods called by them
@@ -32,6 +32,7 @@ func TestParser_SupportedTypes(t *testing.T) {
 		"cloudformation":          true,
 		"kubernetes":              true,
 		"crossplane":              true,
+		"configconnector":         true,
 		"knative":                 true,
 		"openapi":                 true,
 		"googledeploymentmanager": true,
@@ -19,6 +19,10 @@ import (
 
 const (
 	charsLimitPerLine = 255
+	// riskiestLimit is the number of riskiest files and resources printed
+	riskiestLimit = 5
+	// guardedLimit is the number of files left out by the guards printed
+	guardedLimit = 20
 )
 
 var (
@@ -122,6 +126,11 @@ func PrintResult(summary *model.Summary, printer *Printer, usingCustomQueries bo
 			fmt.Println("Note: this is an experimental query")
 		}
 
+		if summary.Queries[idx].Truncated {
+			fmt.Printf("Note: results truncated, showing %d of %d\n",
+				len(summary.Queries[idx].Files), summary.Queries[idx].TotalResults)
+		}
+
 		if !printer.minimal {
 			if summary.Queries[idx].CISDescriptionID != "" {
 				fmt.Printf("%s %s\n", printer.Bold("Description ID:"), summary.Queries[idx].CISDescriptionIDFormatted)
@@ -155,6 +164,11 @@ func PrintResult(summary *model.Summary, printer *Printer, usingCustomQueries bo
 		}
 		printFiles(&summary.Queries[idx], printer)
 	}
+	printParseFailures(summary.ParseFailures, printer)
+	printSkipped(summary, printer)
+	printGuarded(summary.GuardedFiles, printer)
+	printRisk(summary.Risk, printer)
+	printProjects(summary.Projects, printer)
 	fmt.Printf("\nResults Summary:\n")
 	printSeverityCounter(model.SeverityCritical, summary.SeveritySummary.SeverityCounters[model.SeverityCritical], printer.Critical)
 	printSeverityCounter(model.SeverityHigh, summary.SeveritySummary.SeverityCounters[model.SeverityHigh], printer.High)
@@ -175,6 +189,110 @@ func PrintResult(summary *model.Summary, printer *Printer, usingCustomQueries bo
 	return nil
 }
 
+func printParseFailures(parseFailures []model.ParseFailure, printer *Printer) {
+	if len(parseFailures) == 0 {
+		return
+	}
+	fmt.Printf("%s %d\n", printer.Bold("Files Failed To Parse:"), len(parseFailures))
+	for idx := range parseFailures {
+		location := parseFailures[idx].FilePath
+		if parseFailures[idx].Line > 0 {
+			location = fmt.Sprintf("%s:%s", location, printer.Success.Sprint(parseFailures[idx].Line))
+		}
+		if parseFailures[idx].Platform != "" {
+			location = fmt.Sprintf("%s (%s)", location, parseFailures[idx].Platform)
+		}
+		fmt.Printf("\t[%d]: %s\n", idx+1, location)
+		if !printer.minimal {
+			fmt.Printf("\t\t%s\n", parseFailures[idx].Error)
+			if parseFailures[idx].Code != "" {
+				fmt.Printf("\t\t%s\n", parseFailures[idx].Code)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+func printSkipped(summary *model.Summary, printer *Printer) {
+	if !summary.Partial {
+		return
+	}
+	fmt.Printf("%s skipped %d queries and %d files\n", printer.Bold("Partial Results, Scan Timeout Expired:"),
+		len(summary.SkippedQueries), len(summary.SkippedFiles))
+	if !printer.minimal {
+		for idx := range summary.SkippedQueries {
+			fmt.Printf("\t%s (%s)\n", summary.SkippedQueries[idx].QueryName, summary.SkippedQueries[idx].Platform)
+		}
+		for idx := range summary.SkippedFiles {
+			fmt.Printf("\t%s\n", summary.SkippedFiles[idx])
+		}
+	}
+	fmt.Println()
+}
+
+// printGuarded prints the files left out of the scan by the file size and file count guards, only the first ones
+// are listed when the output is not minimal
+func printGuarded(guarded []model.GuardedFile, printer *Printer) {
+	if len(guarded) == 0 {
+		return
+	}
+	fmt.Printf("%s %d files were not scanned, use --force to scan them\n",
+		printer.Bold("File Guards Exceeded:"), len(guarded))
+	if !printer.minimal {
+		for idx := 0; idx < len(guarded) && idx < guardedLimit; idx++ {
+			fmt.Printf("\t%s (%s)\n", guarded[idx].FilePath, guarded[idx].Reason)
+		}
+		if len(guarded) > guardedLimit {
+			fmt.Printf("\t... and %d more files\n", len(guarded)-guardedLimit)
+		}
+	}
+	fmt.Println()
+}
+
+// printRisk prints the riskiest files and, when the output is not minimal, the riskiest resources
+func printRisk(risk *model.RiskSummary, printer *Printer) {
+	if risk == nil || len(risk.Files) == 0 {
+		return
+	}
+	fmt.Printf("%s\n", printer.Bold("Riskiest Files:"))
+	for idx := 0; idx < len(risk.Files) && idx < riskiestLimit; idx++ {
+		fmt.Printf("\t[%d]: %s, Score: %.1f, Results: %d\n",
+			idx+1, risk.Files[idx].FileName, risk.Files[idx].Score, risk.Files[idx].Results)
+	}
+	if !printer.minimal {
+		fmt.Printf("%s\n", printer.Bold("Riskiest Resources:"))
+		for idx := 0; idx < len(risk.Resources) && idx < riskiestLimit; idx++ {
+			exposed := ""
+			if risk.Resources[idx].InternetExposed {
+				exposed = ", Internet Exposed"
+			}
+			fmt.Printf("\t[%d]: %s (%s), Score: %.1f%s\n", idx+1, risk.Resources[idx].Resource,
+				risk.Resources[idx].FileName, risk.Resources[idx].Score, exposed)
+		}
+	}
+	fmt.Println()
+}
+
+// printProjects prints the results of each project and whether it passes its exit evaluation
+func printProjects(projects []model.ProjectSummary, printer *Printer) {
+	if len(projects) == 0 {
+		return
+	}
+	fmt.Printf("%s\n", printer.Bold("Projects Summary:"))
+	for idx := range projects {
+		counters := projects[idx].SeverityCounters
+		status := printer.Success.Sprint("PASSED")
+		if projects[idx].ExitCode != 0 {
+			status = printer.High.Sprintf("FAILED (exit code %d)", projects[idx].ExitCode)
+		}
+		fmt.Printf("\t%s: %s, CRITICAL: %d, HIGH: %d, MEDIUM: %d, LOW: %d, INFO: %d, TOTAL: %d\n",
+			projects[idx].Name, status, counters[model.SeverityCritical], counters[model.SeverityHigh],
+			counters[model.SeverityMedium], counters[model.SeverityLow], counters[model.SeverityInfo],
+			projects[idx].TotalCounter)
+	}
+	fmt.Println()
+}
+
 func printSeverityCounter(severity string, counter int, printColor color.RGBColor) {
 	fmt.Printf("%s: %d\n", printColor.Sprint(severity), counter)
 }
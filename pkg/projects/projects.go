@@ -0,0 +1,207 @@
+// Package projects rolls up the results of a scan by project, so a single scan of a monorepo can gate each of its
+// projects independently
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+	ignore "github.com/sabhiram/go-gitignore"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Directories groups the results by the top-level directory of the scanned paths instead of a project manifest
+	Directories = "directories"
+	// rootProject is the project of the files placed at the root of a scanned directory
+	rootProject = "."
+)
+
+// failOnSeverities are the severities accepted in the fail_on list of a project
+var failOnSeverities = map[string]bool{
+	"critical": true,
+	"high":     true,
+	"medium":   true,
+	"low":      true,
+	"info":     true,
+}
+
+// Project is a project of the manifest, its paths are gitignore patterns relative to the manifest directory and
+// FailOn, when set, replaces the severities of --fail-on for its results
+type Project struct {
+	Name    string   `yaml:"name" json:"name"`
+	Paths   []string `yaml:"paths" json:"paths"`
+	FailOn  []string `yaml:"fail_on" json:"fail_on"`
+	matcher *ignore.GitIgnore
+}
+
+// Manifest lists the projects of a repository, a file belongs to the first project with a matching path
+type Manifest struct {
+	Root     string    `yaml:"-" json:"-"`
+	Projects []Project `yaml:"projects" json:"projects"`
+}
+
+// Load reads a JSON or YAML project manifest
+func Load(path string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{Root: filepath.Dir(absPath)}
+	if err := yaml.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse project manifest %s: %w", path, err)
+	}
+
+	names := make(map[string]bool, len(manifest.Projects))
+	for i := range manifest.Projects {
+		project := &manifest.Projects[i]
+		if project.Name == "" || names[project.Name] {
+			return nil, fmt.Errorf("invalid project manifest %s: project %d has an empty or duplicated name", path, i+1)
+		}
+		names[project.Name] = true
+		if len(project.Paths) == 0 {
+			return nil, fmt.Errorf("invalid project manifest %s: project %s has no paths", path, project.Name)
+		}
+		for j, severity := range project.FailOn {
+			if !failOnSeverities[strings.ToLower(severity)] {
+				return nil, fmt.Errorf("invalid project manifest %s: unknown fail_on severity %s of project %s",
+					path, severity, project.Name)
+			}
+			project.FailOn[j] = strings.ToLower(severity)
+		}
+		project.matcher = ignore.CompileIgnoreLines(project.Paths...)
+	}
+
+	log.Debug().Msgf("Loaded %d projects from %s", len(manifest.Projects), path)
+	return manifest, nil
+}
+
+// Project returns the project of a file, nil is returned for the files matching no project or outside of the
+// manifest directory
+func (m *Manifest) Project(path string) *Project {
+	relPath, ok := relativePath(m.Root, path)
+	if !ok {
+		return nil
+	}
+	for i := range m.Projects {
+		if m.Projects[i].matcher.MatchesPath(relPath) {
+			return &m.Projects[i]
+		}
+	}
+	return nil
+}
+
+// SetProjects sets the project of each result of the summary and rolls up the results of each project, the
+// manifest groups the results by its projects and, when nil, they are grouped by the top-level directory of the
+// scanned paths, the summary counters must not be limited yet so every result is counted
+func SetProjects(summary *model.Summary, manifest *Manifest) {
+	projects := make(map[string]*model.ProjectSummary)
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			file := &summary.Queries[i].Files[j]
+			fileName := file.FileName
+			if originalPath, ok := summary.FilePaths[fileName]; ok {
+				fileName = originalPath
+			}
+
+			var project *model.ProjectSummary
+			if manifest != nil {
+				project = manifest.projectSummary(projects, fileName)
+			} else {
+				project = directoryProjectSummary(projects, summary.ScannedPaths, fileName)
+			}
+			if project == nil {
+				continue
+			}
+			file.Project = project.Name
+			project.SeverityCounters[summary.Queries[i].Severity]++
+			project.TotalCounter++
+			project.Files[file.FileName] = true
+		}
+	}
+
+	summary.Projects = make([]model.ProjectSummary, 0, len(projects))
+	for _, project := range projects {
+		project.FilesWithResults = len(project.Files)
+		summary.Projects = append(summary.Projects, *project)
+	}
+	sort.Slice(summary.Projects, func(i, j int) bool {
+		return summary.Projects[i].Name < summary.Projects[j].Name
+	})
+}
+
+func (m *Manifest) projectSummary(projects map[string]*model.ProjectSummary, fileName string) *model.ProjectSummary {
+	project := m.Project(fileName)
+	if project == nil {
+		return nil
+	}
+	if summary, ok := projects[project.Name]; ok {
+		return summary
+	}
+	projects[project.Name] = newProjectSummary(project.Name, project.Paths, project.FailOn)
+	return projects[project.Name]
+}
+
+// directoryProjectSummary returns the project of the top-level directory of the scanned path containing the file,
+// the files placed at the root of a scanned directory form the "." project
+func directoryProjectSummary(projects map[string]*model.ProjectSummary, scannedPaths []string,
+	fileName string) *model.ProjectSummary {
+	for _, scannedPath := range scannedPaths {
+		relPath, ok := relativePath(scannedPath, fileName)
+		if !ok {
+			continue
+		}
+		name, path := rootProject, scannedPath
+		if idx := strings.Index(relPath, "/"); idx >= 0 {
+			name = relPath[:idx]
+			path = filepath.Join(scannedPath, name)
+		}
+		if summary, ok := projects[name]; ok {
+			return summary
+		}
+		projects[name] = newProjectSummary(name, []string{filepath.ToSlash(path)}, nil)
+		return projects[name]
+	}
+	return nil
+}
+
+func newProjectSummary(name string, paths, failOn []string) *model.ProjectSummary {
+	return &model.ProjectSummary{
+		Name:   name,
+		Paths:  paths,
+		FailOn: failOn,
+		SeverityCounters: map[model.Severity]int{
+			model.SeverityTrace: 0, model.SeverityInfo: 0, model.SeverityLow: 0,
+			model.SeverityMedium: 0, model.SeverityHigh: 0, model.SeverityCritical: 0,
+		},
+		Files: make(map[string]bool),
+	}
+}
+
+// relativePath returns the slash separated path of a file relative to the root, false is returned when the file
+// is outside of the root
+func relativePath(root, path string) (string, bool) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	relPath, err := filepath.Rel(absRoot, absPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(relPath), true
+}
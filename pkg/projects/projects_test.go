@@ -0,0 +1,127 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var projectsFixture = filepath.FromSlash("../../test/fixtures/test_projects")
+
+func TestManifest_Project(t *testing.T) {
+	manifest, err := Load(filepath.Join(projectsFixture, "projects.yaml"))
+	require.NoError(t, err)
+	require.Len(t, manifest.Projects, 3)
+	require.Equal(t, []string{"high", "critical"}, manifest.Projects[0].FailOn)
+
+	root := projectsFixture
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: filepath.Join(root, "services", "payments", "main.tf"), want: "payments"},
+		{path: filepath.Join(root, "services", "users", "deployment.yaml"), want: "services"},
+		{path: filepath.Join(root, "terraform", "vpc.yaml"), want: "infra"},
+		{path: filepath.Join(root, "modules", "main.tf"), want: "infra"},
+		{path: filepath.Join(root, "docs", "values.yaml"), want: ""},
+		{path: filepath.Join(root, "..", "main.tf"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := ""
+			if project := manifest.Project(tt.path); project != nil {
+				got = project.Name
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(filepath.Join(projectsFixture, "not-found"))
+	require.Error(t, err)
+
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{name: "invalid", manifest: "projects: not a list"},
+		{name: "empty_name", manifest: "projects:\n  - paths: [api/]"},
+		{name: "duplicated_name", manifest: "projects:\n  - name: api\n    paths: [api/]\n  - name: api\n    paths: [web/]"},
+		{name: "no_paths", manifest: "projects:\n  - name: api"},
+		{name: "unknown_severity", manifest: "projects:\n  - name: api\n    paths: [api/]\n    fail_on: [blocker]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "projects.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.manifest), 0o600))
+			_, err := Load(path)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSetProjects(t *testing.T) {
+	root := projectsFixture
+	newSummary := func() model.Summary {
+		return model.Summary{
+			ScannedPaths: []string{root},
+			Queries: model.QueryResultSlice{
+				{
+					Severity: model.SeverityHigh,
+					Files: []model.VulnerableFile{
+						{FileName: filepath.Join(root, "services", "payments", "main.tf")},
+						{FileName: filepath.Join(root, "services", "users", "main.tf")},
+					},
+				},
+				{
+					Severity: model.SeverityLow,
+					Files: []model.VulnerableFile{
+						{FileName: filepath.Join(root, "services", "payments", "main.tf")},
+						{FileName: filepath.Join(root, "docs", "values.yaml")},
+						{FileName: filepath.Join(root, "main.yaml")},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("manifest", func(t *testing.T) {
+		manifest, err := Load(filepath.Join(root, "projects.yaml"))
+		require.NoError(t, err)
+		summary := newSummary()
+		SetProjects(&summary, manifest)
+
+		require.Len(t, summary.Projects, 2)
+		require.Equal(t, "payments", summary.Projects[0].Name)
+		require.Equal(t, []string{"high", "critical"}, summary.Projects[0].FailOn)
+		require.Equal(t, 1, summary.Projects[0].SeverityCounters[model.SeverityHigh])
+		require.Equal(t, 1, summary.Projects[0].SeverityCounters[model.SeverityLow])
+		require.Equal(t, 2, summary.Projects[0].TotalCounter)
+		require.Equal(t, 1, summary.Projects[0].FilesWithResults)
+		require.Equal(t, "services", summary.Projects[1].Name)
+		require.Equal(t, 1, summary.Projects[1].TotalCounter)
+		require.Equal(t, "", summary.Queries[1].Files[1].Project)
+	})
+
+	t.Run("directories", func(t *testing.T) {
+		summary := newSummary()
+		SetProjects(&summary, nil)
+
+		names := make([]string, 0, len(summary.Projects))
+		for i := range summary.Projects {
+			names = append(names, summary.Projects[i].Name)
+		}
+		require.Equal(t, []string{".", "docs", "services"}, names)
+		require.Equal(t, []string{filepath.ToSlash(filepath.Join(root, "services"))}, summary.Projects[2].Paths)
+		require.Equal(t, 3, summary.Projects[2].TotalCounter)
+		require.Equal(t, 2, summary.Projects[2].FilesWithResults)
+		require.Equal(t, "services", summary.Queries[0].Files[1].Project)
+		require.Equal(t, ".", summary.Queries[1].Files[2].Project)
+	})
+}
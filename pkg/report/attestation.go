@@ -0,0 +1,46 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+	"github.com/rs/zerolog/log"
+)
+
+const attestationExtension = ".intoto.json"
+
+// PrintAttestationReport prints the scan attestation, a DSSE envelope holding an in-toto statement,
+// in the given path and filename with the given body
+func PrintAttestationReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, "attestation-") {
+		filename = "attestation-" + filename
+	}
+	filename = strings.TrimSuffix(filename, jsonExtension) + attestationExtension
+
+	var attestation *model.Attestation
+	if s, ok := body.(*model.Summary); ok {
+		attestation = s.Attestation
+	}
+	// an attestation without the digests of the scan would not prove anything, the other reports are still written
+	if attestation == nil {
+		log.Error().Msg("The scan attestation is not available, the attestation report is not written")
+		return nil
+	}
+
+	summary, err := getSummary(body)
+	if err != nil {
+		return err
+	}
+	summary.Attestation = attestation
+
+	if attestation.SigningKeyPath == "" {
+		log.Warn().Msg("No attestation key was provided, the attestation report is marked as unsigned")
+	}
+
+	envelope, err := reportModel.SignAttestationStatement(reportModel.BuildAttestationStatement(&summary), attestation.SigningKeyPath)
+	if err != nil {
+		return err
+	}
+	return ExportJSONReport(path, filename, envelope)
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintAttestationReport(t *testing.T) {
+	summary := test.SummaryMock
+	summary.Attestation = &model.Attestation{
+		Subjects: []model.AttestationSubject{{Name: "./test/fixtures", Digest: "3f1f"}},
+	}
+
+	tests := []struct {
+		name     string
+		body     interface{}
+		filename string
+		want     string
+		written  bool
+	}{
+		{
+			name:     "print attestation report",
+			body:     &summary,
+			filename: "output",
+			want:     "attestation-output.intoto.json",
+			written:  true,
+		},
+		{
+			name:     "print attestation report without attestation",
+			body:     test.SummaryMock,
+			filename: "output2.json",
+			want:     "attestation-output2.intoto.json",
+			written:  false,
+		},
+	}
+
+	path := filepath.Join(os.TempDir(), "testdir")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			err := PrintAttestationReport(path, test.filename, test.body)
+			require.NoError(t, err)
+
+			if !test.written {
+				require.NoFileExists(t, filepath.Join(path, test.want))
+				return
+			}
+			content, err := os.ReadFile(filepath.Join(path, test.want))
+			require.NoError(t, err)
+			require.Contains(t, string(content), `"payloadType": "application/vnd.in-toto+json"`)
+			os.RemoveAll(path)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+)
+
+// PrintBoMReport prints the inventory of the resources declared in the scanned files in the given path and
+// filename with the given body
+func PrintBoMReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, "bom-") {
+		filename = "bom-" + filename
+	}
+
+	summary := &model.Summary{}
+	if s, ok := body.(*model.Summary); ok {
+		summary = s
+	}
+	return ExportJSONReport(path, filename, reportModel.BuildBoMReport(summary))
+}
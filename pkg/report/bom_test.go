@@ -0,0 +1,66 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintBoMReport(t *testing.T) {
+	summary := test.SummaryMock
+	summary.Inventory = []model.InventoryResource{
+		{Platform: "Terraform", CloudProvider: "aws", ResourceType: "aws_s3_bucket", ResourceName: "logs", FileName: "main.tf"},
+		{Platform: "Terraform", CloudProvider: "aws", ResourceType: "aws_s3_bucket", ResourceName: "assets", FileName: "main.tf"},
+		{Platform: "Kubernetes", ResourceType: "Deployment", ResourceName: "web", FileName: "deployment.yaml"},
+	}
+
+	tests := []struct {
+		name      string
+		body      interface{}
+		filename  string
+		want      string
+		resources int
+		platforms []reportModel.BoMPlatform
+	}{
+		{
+			name:      "print bom report",
+			body:      &summary,
+			filename:  "output",
+			want:      "bom-output.json",
+			resources: 3,
+			platforms: []reportModel.BoMPlatform{
+				{Platform: "Kubernetes", Resources: 1},
+				{Platform: "Terraform", CloudProvider: "aws", Resources: 2},
+			},
+		},
+		{
+			name:      "print bom report without inventory",
+			body:      test.SummaryMock,
+			filename:  "output2",
+			want:      "bom-output2.json",
+			resources: 0,
+			platforms: []reportModel.BoMPlatform{},
+		},
+	}
+
+	path := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, PrintBoMReport(path, tt.filename, tt.body))
+
+			content, err := os.ReadFile(filepath.Join(path, tt.want))
+			require.NoError(t, err)
+			var report reportModel.BoMReport
+			require.NoError(t, json.Unmarshal(content, &report))
+			require.Equal(t, tt.resources, report.TotalResources)
+			require.Len(t, report.Resources, tt.resources)
+			require.Equal(t, tt.platforms, report.Platforms)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package report
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// EncryptionAge encrypts the reports for age recipients
+	EncryptionAge = "age"
+	// EncryptionAES encrypts the reports with AES-256-GCM using the key of a key file
+	EncryptionAES = "aes"
+
+	ageExtension = ".age"
+	aesExtension = ".enc"
+	aesKeySize   = 32
+)
+
+// Encryption encrypts the report files before they are written in the output path
+type Encryption struct {
+	kind       string
+	recipients []age.Recipient
+	key        []byte
+}
+
+// ParseEncryption parses the encryption provided as age:<recipient>[,<recipient>] or aes:<key file>, the key file
+// holds a 32 bytes key either raw or hex encoded
+func ParseEncryption(value string) (*Encryption, error) {
+	kind, argument, found := strings.Cut(value, ":")
+	kind, argument = strings.ToLower(strings.TrimSpace(kind)), strings.TrimSpace(argument)
+	if !found || argument == "" {
+		return nil, fmt.Errorf("invalid output encryption '%s', expected age:<recipient> or aes:<key file>", value)
+	}
+
+	switch kind {
+	case EncryptionAge:
+		recipients := make([]age.Recipient, 0)
+		for _, recipient := range strings.Split(argument, ",") {
+			parsed, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+			if err != nil {
+				return nil, fmt.Errorf("invalid age recipient '%s': %w", recipient, err)
+			}
+			recipients = append(recipients, parsed)
+		}
+		return &Encryption{kind: kind, recipients: recipients}, nil
+	case EncryptionAES:
+		key, err := readAESKey(argument)
+		if err != nil {
+			return nil, err
+		}
+		return &Encryption{kind: kind, key: key}, nil
+	default:
+		return nil, fmt.Errorf("unknown output encryption '%s', expected age or aes", kind)
+	}
+}
+
+// readAESKey reads the key of the key file, a hex encoded key is decoded
+func readAESKey(path string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the encryption key file %s: %w", path, err)
+	}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) == hex.EncodedLen(aesKeySize) {
+		if decoded, err := hex.DecodeString(string(trimmed)); err == nil {
+			return decoded, nil
+		}
+	}
+	if len(content) != aesKeySize {
+		return nil, fmt.Errorf("the encryption key file %s must hold a %d bytes key, raw or hex encoded", path, aesKeySize)
+	}
+	return content, nil
+}
+
+// Extension returns the extension appended to the names of the encrypted files
+func (e *Encryption) Extension() string {
+	if e.kind == EncryptionAge {
+		return ageExtension
+	}
+	return aesExtension
+}
+
+// Encrypt writes the encrypted content, the AES content is written as the 12 bytes nonce followed by the
+// ciphertext and its authentication tag
+func (e *Encryption) Encrypt(w io.Writer, content []byte) error {
+	if e.kind == EncryptionAge {
+		encrypted, err := age.Encrypt(w, e.recipients...)
+		if err != nil {
+			return err
+		}
+		if _, err := encrypted.Write(content); err != nil {
+			return err
+		}
+		return encrypted.Close()
+	}
+
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	_, err = w.Write(gcm.Seal(nonce, nonce, content, nil))
+	return err
+}
+
+// EncryptReports encrypts the files written in the staging directory to the output directory, keeping their
+// relative paths and appending the extension of the encryption to their names
+func EncryptReports(stagingDir, outputDir string, encryption *Encryption) error {
+	return filepath.Walk(stagingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relative, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+
+		fullPath := filepath.Join(outputDir, relative+encryption.Extension())
+		if err := os.MkdirAll(filepath.Dir(fullPath), os.ModePerm); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(filepath.Clean(fullPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+		if err != nil {
+			return err
+		}
+		defer closeFile(fullPath, filepath.Base(fullPath), f)
+
+		if err := encryption.Encrypt(f, content); err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", relative, err)
+		}
+		log.Debug().Msgf("Encrypted %s with %s", relative, encryption.kind)
+		return nil
+	})
+}
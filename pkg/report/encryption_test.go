@@ -0,0 +1,149 @@
+package report
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEncryption(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	hexKey := filepath.Join(dir, "hex.key")
+	require.NoError(t, os.WriteFile(hexKey, []byte(strings.Repeat("ab", aesKeySize)+"\n"), 0600))
+	rawKey := filepath.Join(dir, "raw.key")
+	require.NoError(t, os.WriteFile(rawKey, bytes.Repeat([]byte{1}, aesKeySize), 0600))
+	shortKey := filepath.Join(dir, "short.key")
+	require.NoError(t, os.WriteFile(shortKey, []byte("short"), 0600))
+
+	tests := []struct {
+		name      string
+		value     string
+		extension string
+		wantErr   bool
+	}{
+		{
+			name:      "age recipient",
+			value:     "age:" + identity.Recipient().String(),
+			extension: ageExtension,
+		},
+		{
+			name:      "age recipients",
+			value:     "AGE:" + identity.Recipient().String() + ", " + identity.Recipient().String(),
+			extension: ageExtension,
+		},
+		{
+			name:      "hex encoded aes key",
+			value:     "aes:" + hexKey,
+			extension: aesExtension,
+		},
+		{
+			name:      "raw aes key",
+			value:     "aes:" + rawKey,
+			extension: aesExtension,
+		},
+		{
+			name:    "invalid age recipient",
+			value:   "age:age1invalid",
+			wantErr: true,
+		},
+		{
+			name:    "aes key of the wrong size",
+			value:   "aes:" + shortKey,
+			wantErr: true,
+		},
+		{
+			name:    "missing aes key file",
+			value:   "aes:" + filepath.Join(dir, "missing.key"),
+			wantErr: true,
+		},
+		{
+			name:    "unknown encryption",
+			value:   "gpg:key",
+			wantErr: true,
+		},
+		{
+			name:    "missing argument",
+			value:   "age",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encryption, err := ParseEncryption(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.extension, encryption.Extension())
+		})
+	}
+}
+
+func TestEncryptReports(t *testing.T) {
+	content := []byte(`{"queries": [{"query_name": "Passwords And Secrets - Generic Password"}]}`)
+
+	t.Run("age", func(t *testing.T) {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		encryption, err := ParseEncryption("age:" + identity.Recipient().String())
+		require.NoError(t, err)
+
+		outputDir := encryptStagedReport(t, encryption, content)
+		encrypted, err := os.Open(filepath.Join(outputDir, "results.json.age"))
+		require.NoError(t, err)
+		defer encrypted.Close()
+
+		decrypted, err := age.Decrypt(encrypted, identity)
+		require.NoError(t, err)
+		got, err := io.ReadAll(decrypted)
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+
+	t.Run("aes", func(t *testing.T) {
+		key := bytes.Repeat([]byte{7}, aesKeySize)
+		keyPath := filepath.Join(t.TempDir(), "report.key")
+		require.NoError(t, os.WriteFile(keyPath, []byte(hex.EncodeToString(key)), 0600))
+		encryption, err := ParseEncryption("aes:" + keyPath)
+		require.NoError(t, err)
+
+		outputDir := encryptStagedReport(t, encryption, content)
+		encrypted, err := os.ReadFile(filepath.Join(outputDir, "results.json.enc"))
+		require.NoError(t, err)
+
+		block, err := aes.NewCipher(key)
+		require.NoError(t, err)
+		gcm, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+		got, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+}
+
+// encryptStagedReport encrypts a report written in a staging directory and returns the output directory, which
+// must only hold the encrypted report
+func encryptStagedReport(t *testing.T, encryption *Encryption, content []byte) string {
+	stagingDir, outputDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(stagingDir, "results.json"), content, 0600))
+	require.NoError(t, EncryptReports(stagingDir, outputDir, encryption))
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "results.json"+encryption.Extension(), entries[0].Name())
+	return outputDir
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+)
+
+// PrintGraphReport prints the resource graph annotated with the findings in the given path and
+// filename with the given body, both in JSON and in the DOT language
+func PrintGraphReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, "graph-") {
+		filename = "graph-" + filename
+	}
+	filename = strings.TrimSuffix(filename, jsonExtension)
+
+	report := reportModel.BuildGraphReport(&model.Summary{})
+	if body != "" {
+		var resourceGraph *model.ResourceGraph
+		if s, ok := body.(*model.Summary); ok {
+			resourceGraph = s.ResourceGraph
+		}
+		summary, err := getSummary(body)
+		if err != nil {
+			return err
+		}
+		summary.ResourceGraph = resourceGraph
+
+		report = reportModel.BuildGraphReport(&summary)
+	}
+
+	if err := ExportJSONReport(path, filename+jsonExtension, report); err != nil {
+		return err
+	}
+	return exportDOTReport(path, filename+".dot", report.ToDOT())
+}
+
+func exportDOTReport(path, filename, body string) error {
+	fullPath := filepath.Join(path, filename)
+	f, err := os.OpenFile(filepath.Clean(fullPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer closeFile(fullPath, filename, f)
+
+	_, err = f.WriteString(body)
+	return err
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintGraphReport(t *testing.T) {
+	summary := model.Summary{
+		Queries: []model.QueryResult{
+			{
+				QueryName: "ALB protocol is HTTP",
+				QueryID:   "de7f5e83-da88-4046-871f-ea18504b1d43",
+				Severity:  model.SeverityHigh,
+				Files: []model.VulnerableFile{
+					{FileName: "positive.tf", Line: 25, SearchKey: "aws_alb_listener[front_end].default_action.redirect"},
+				},
+			},
+		},
+	}
+	summary.ResourceGraph = &model.ResourceGraph{
+		Nodes: []model.GraphNode{
+			{ID: ".:aws_alb_listener.front_end", Platform: "Terraform", ResourceType: "aws_alb_listener", ResourceName: "front_end", FileName: "positive.tf"},
+		},
+		Edges: []model.GraphEdge{},
+	}
+
+	tests := []struct {
+		name     string
+		body     interface{}
+		filename string
+		contains string
+	}{
+		{
+			name:     "print graph report",
+			body:     &summary,
+			filename: "output",
+			contains: "ALB protocol is HTTP",
+		},
+		{
+			name:     "print graph report without resource graph",
+			body:     test.SummaryMock,
+			filename: "output2",
+			contains: `"nodes": []`,
+		},
+	}
+
+	path := filepath.Join(os.TempDir(), "testdir")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			err := PrintGraphReport(path, test.filename, test.body)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(path, "graph-"+test.filename+".json"))
+			require.NoError(t, err)
+			require.Contains(t, string(content), test.contains)
+			require.FileExists(t, filepath.Join(path, "graph-"+test.filename+".dot"))
+			os.RemoveAll(path)
+		})
+	}
+}
@@ -3,12 +3,14 @@ package report
 import (
 	"bytes"
 	_ "embed" // used for embedding report static files
+	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/model"
 	"github.com/rs/zerolog/log"
 	"github.com/tdewolff/minify/v2"
 	minifyCSS "github.com/tdewolff/minify/v2/css"
@@ -76,22 +78,145 @@ func getVersion() string {
 	return constants.Version
 }
 
+// htmlPage is the data of a page of the HTML report, the report is split into an index, listing the
+// pages, and the pages of each severity when it has more results than the page size of the summary
+type htmlPage struct {
+	model.Summary
+	Platforms string
+	Title     string
+	Index     string
+	Previous  string
+	Next      string
+	Pages     []htmlPageLink
+}
+
+// htmlPageLink is the link from the index to a page with the results of a severity
+type htmlPageLink struct {
+	Severity model.Severity
+	Name     string
+	First    int
+	Last     int
+}
+
 // PrintHTMLReport creates a report file on HTML format
 func PrintHTMLReport(path, filename string, body interface{}) error {
-	if !strings.HasSuffix(filename, ".html") {
-		filename += ".html"
+	filename = strings.TrimSuffix(filename, ".html")
+
+	// the shared functions are not modified since the reports are generated concurrently
+	htmlFuncs := template.FuncMap{
+		"includeSVG": includeSVG,
+		"includeCSS": includeCSS,
+		"includeJS":  includeJS,
+		"getPaths":   getPaths,
+		"getVersion": getVersion,
+	}
+	t := template.Must(template.New("report.tmpl").Funcs(templateFuncs).Funcs(htmlFuncs).Parse(htmlTemplate))
+
+	var summary *model.Summary
+	switch s := body.(type) {
+	case *model.Summary:
+		summary = s
+	case model.Summary:
+		summary = &s
+	default:
+		return writeHTMLFile(t, path, filename+".html", body)
+	}
+
+	index := htmlPage{
+		Summary:   *summary,
+		Platforms: getPlatforms(summary.Queries),
+	}
+	if summary.HTMLPageSize <= 0 || countResults(summary.Queries) <= summary.HTMLPageSize {
+		return writeHTMLFile(t, path, filename+".html", index)
 	}
 
-	templateFuncs["includeSVG"] = includeSVG
-	templateFuncs["includeCSS"] = includeCSS
-	templateFuncs["includeJS"] = includeJS
-	templateFuncs["getPaths"] = getPaths
-	templateFuncs["getPlatforms"] = getPlatforms
-	templateFuncs["getVersion"] = getVersion
+	pages := splitHTMLPages(summary, filename)
+	index.Queries = model.QueryResultSlice{}
+	for idx := range pages {
+		index.Pages = append(index.Pages, pages[idx].link)
+	}
+	if err := writeHTMLFile(t, path, filename+".html", index); err != nil {
+		return err
+	}
 
-	fullPath := filepath.Join(path, filename)
-	t := template.Must(template.New("report.tmpl").Funcs(templateFuncs).Parse(htmlTemplate))
+	for idx := range pages {
+		page := htmlPage{
+			Summary:   *summary,
+			Platforms: index.Platforms,
+			Title:     fmt.Sprintf("%s results %d to %d", pages[idx].link.Severity, pages[idx].link.First, pages[idx].link.Last),
+			Index:     filename + ".html",
+		}
+		page.Queries = pages[idx].queries
+		page.ParseFailures = nil
+		page.FailedQueries = nil
+		if idx > 0 {
+			page.Previous = pages[idx-1].link.Name
+		}
+		if idx < len(pages)-1 {
+			page.Next = pages[idx+1].link.Name
+		}
+		if err := writeHTMLFile(t, path, pages[idx].link.Name, page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type htmlPageResults struct {
+	link    htmlPageLink
+	queries model.QueryResultSlice
+}
+
+// splitHTMLPages splits the results of each severity into pages of at most HTMLPageSize results,
+// keeping the order of the queries, a query with more results than the page size spans several pages
+func splitHTMLPages(summary *model.Summary, filename string) []htmlPageResults {
+	pageSize := summary.HTMLPageSize
+	pages := make([]htmlPageResults, 0)
+	for _, severity := range model.AllSeverities {
+		pageNumber, results := 0, 0
+		for idx := range summary.Queries {
+			if summary.Queries[idx].Severity != severity {
+				continue
+			}
+			for files := summary.Queries[idx].Files; len(files) > 0; {
+				if results%pageSize == 0 {
+					pageNumber++
+					pages = append(pages, htmlPageResults{
+						link: htmlPageLink{
+							Severity: severity,
+							Name:     fmt.Sprintf("%s-%s-%d.html", filename, strings.ToLower(string(severity)), pageNumber),
+							First:    results + 1,
+						},
+						queries: model.QueryResultSlice{},
+					})
+				}
+				current := &pages[len(pages)-1]
+				size := pageSize - results%pageSize
+				if size > len(files) {
+					size = len(files)
+				}
+				query := summary.Queries[idx]
+				query.Files = files[:size]
+				current.queries = append(current.queries, query)
+				results += size
+				current.link.Last = results
+				files = files[size:]
+			}
+		}
+	}
+	return pages
+}
 
+func countResults(queries model.QueryResultSlice) int {
+	count := 0
+	for idx := range queries {
+		count += len(queries[idx].Files)
+	}
+	return count
+}
+
+func writeHTMLFile(t *template.Template, path, filename string, data interface{}) error {
+	fullPath := filepath.Join(path, filename)
 	f, err := os.OpenFile(filepath.Clean(fullPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return err
@@ -99,7 +224,7 @@ func PrintHTMLReport(path, filename string, body interface{}) error {
 	defer closeFile(fullPath, filename, f)
 	var buffer bytes.Buffer
 
-	err = t.Execute(&buffer, body)
+	err = t.Execute(&buffer, data)
 	if err != nil {
 		return err
 	}
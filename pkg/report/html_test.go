@@ -62,3 +62,74 @@ func TestPrintHTMLReport(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintHTMLReport_Pages(t *testing.T) {
+	dir := t.TempDir()
+	summary := test.SummaryMock
+	summary.HTMLPageSize = 1
+
+	require.NoError(t, PrintHTMLReport(dir, "results", &summary))
+
+	index, err := os.ReadFile(filepath.Join(dir, "results.html"))
+	require.NoError(t, err)
+	pages := splitHTMLPages(&summary, "results")
+	require.Len(t, pages, countResults(summary.Queries))
+	for idx := range pages {
+		require.Contains(t, string(index), pages[idx].link.Name)
+		content, err := os.ReadFile(filepath.Join(dir, pages[idx].link.Name))
+		require.NoError(t, err)
+		require.Contains(t, string(content), `href="results.html"`)
+		_, err = html.Parse(strings.NewReader(string(content)))
+		require.NoError(t, err)
+	}
+}
+
+func TestPrintHTMLReport_QueryDocs(t *testing.T) {
+	dir := t.TempDir()
+	summary := test.SummaryMock
+	summary.QueryDocs = map[string]*model.QueryDocumentation{
+		summary.Queries[0].QueryID: {
+			Description: "query description",
+			Rationale:   "query rationale",
+			Remediation: []string{"expected value"},
+			Examples:    []model.CodeExample{{FileName: "negative.tf", Code: "resource \"aws_s3_bucket\" \"b\" {}"}},
+		},
+	}
+
+	require.NoError(t, PrintHTMLReport(dir, "results", &summary))
+
+	content, err := os.ReadFile(filepath.Join(dir, "results.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "query rationale")
+	require.Contains(t, string(content), "<li>expected value</li>")
+	require.Contains(t, string(content), "Compliant example (negative.tf)")
+	require.Equal(t, 1, strings.Count(string(content), `class="query-docs"`))
+}
+
+func TestSplitHTMLPages(t *testing.T) {
+	files := func(n int) []model.VulnerableFile {
+		return make([]model.VulnerableFile, n)
+	}
+	summary := &model.Summary{
+		Queries: model.QueryResultSlice{
+			{QueryName: "high 1", Severity: model.SeverityHigh, Files: files(3)},
+			{QueryName: "high 2", Severity: model.SeverityHigh, Files: files(2)},
+			{QueryName: "low 1", Severity: model.SeverityLow, Files: files(1)},
+		},
+		HTMLPageSize: 2,
+	}
+
+	pages := splitHTMLPages(summary, "results")
+
+	require.Equal(t, []htmlPageLink{
+		{Severity: model.SeverityHigh, Name: "results-high-1.html", First: 1, Last: 2},
+		{Severity: model.SeverityHigh, Name: "results-high-2.html", First: 3, Last: 4},
+		{Severity: model.SeverityHigh, Name: "results-high-3.html", First: 5, Last: 5},
+		{Severity: model.SeverityLow, Name: "results-low-1.html", First: 1, Last: 1},
+	}, []htmlPageLink{pages[0].link, pages[1].link, pages[2].link, pages[3].link})
+	require.Len(t, pages[1].queries, 2)
+	require.Equal(t, "high 1", pages[1].queries[0].QueryName)
+	require.Len(t, pages[1].queries[0].Files, 1)
+	require.Equal(t, "high 2", pages[1].queries[1].QueryName)
+	require.Len(t, pages[1].queries[1].Files, 1)
+}
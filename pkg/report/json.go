@@ -19,6 +19,7 @@ func PrintJSONReport(path, filename string, body interface{}) error {
 			summary.Queries[idx].CISRationaleText = ""
 		}
 		summary.Version = constants.Version
+		summary.SchemaVersion = JSONSchemaVersion
 		body = summary
 	}
 
@@ -0,0 +1,251 @@
+package model
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+const (
+	inTotoStatementType = "https://in-toto.io/Statement/v1"
+	// AttestationPayloadType is the DSSE payload type of in-toto statements
+	AttestationPayloadType = "application/vnd.in-toto+json"
+	// AttestationPredicateType is the predicate type of the statements describing a KICS scan
+	AttestationPredicateType = "https://kics.io/attestation/scan/v1"
+	kicsURI                  = "https://github.com/Checkmarx/kics"
+)
+
+// AttestationEnvelope is a DSSE envelope holding the base64 encoded statement and its signatures
+type AttestationEnvelope struct {
+	PayloadType string                 `json:"payloadType"`
+	Payload     string                 `json:"payload"`
+	Signatures  []AttestationSignature `json:"signatures"`
+}
+
+// AttestationSignature is a signature of the envelope payload, KeyID is the sha256 digest
+// of the DER encoded public key
+type AttestationSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// AttestationStatement is an in-toto statement whose subjects are the scanned paths
+type AttestationStatement struct {
+	Type          string                   `json:"_type"`
+	Subject       []AttestationSubject     `json:"subject"`
+	PredicateType string                   `json:"predicateType"`
+	Predicate     AttestationScanPredicate `json:"predicate"`
+}
+
+// AttestationSubject is a scanned path and its digests
+type AttestationSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// AttestationScanPredicate describes the scanner, the query library and the flags
+// a scan was run with, and the summary of its results
+type AttestationScanPredicate struct {
+	Scanner    AttestationScanner    `json:"scanner"`
+	Invocation AttestationInvocation `json:"invocation"`
+	Metadata   AttestationMetadata   `json:"metadata"`
+	Summary    AttestationSummary    `json:"summary"`
+}
+
+// AttestationScanner is the KICS version and the queries and libraries used by the scan
+type AttestationScanner struct {
+	URI       string              `json:"uri"`
+	Version   string              `json:"version"`
+	Queries   AttestationResource `json:"queries"`
+	Libraries AttestationResource `json:"libraries"`
+}
+
+// AttestationResource is a set of files and the digest of their content, Paths is empty for embedded files
+type AttestationResource struct {
+	Paths  []string          `json:"paths"`
+	Digest map[string]string `json:"digest"`
+}
+
+// AttestationInvocation contains the flags set for the scan
+type AttestationInvocation struct {
+	Flags map[string]string `json:"flags"`
+}
+
+// AttestationMetadata contains the identification and the duration of the scan, Signed is false
+// when no attestation key was given and the envelope has no signatures
+type AttestationMetadata struct {
+	ScanID     string    `json:"scan_id"`
+	StartedOn  time.Time `json:"started_on"`
+	FinishedOn time.Time `json:"finished_on"`
+	Partial    bool      `json:"partial"`
+	Signed     bool      `json:"signed"`
+}
+
+// AttestationSummary contains the counters of the scan
+type AttestationSummary struct {
+	model.Counters
+	SeverityCounters map[model.Severity]int `json:"severity_counters"`
+	TotalCounter     int                    `json:"total_counter"`
+}
+
+// BuildAttestationStatement builds the in-toto statement of the scan, the digests and the flags
+// are only set when the summary has the attestation metadata
+func BuildAttestationStatement(summary *model.Summary) *AttestationStatement {
+	attestation := summary.Attestation
+	if attestation == nil {
+		attestation = &model.Attestation{}
+	}
+
+	statement := &AttestationStatement{
+		Type:          inTotoStatementType,
+		Subject:       make([]AttestationSubject, 0, len(attestation.Subjects)),
+		PredicateType: AttestationPredicateType,
+		Predicate: AttestationScanPredicate{
+			Scanner: AttestationScanner{
+				URI:     kicsURI,
+				Version: constants.Version,
+				Queries: AttestationResource{
+					Paths:  append([]string{}, attestation.QueriesPaths...),
+					Digest: sha256Digest(attestation.QueriesDigest),
+				},
+				Libraries: AttestationResource{
+					Paths:  make([]string, 0, 1),
+					Digest: sha256Digest(attestation.LibrariesDigest),
+				},
+			},
+			Invocation: AttestationInvocation{
+				Flags: make(map[string]string, len(attestation.Flags)),
+			},
+			Metadata: AttestationMetadata{
+				ScanID:     summary.ScanID,
+				StartedOn:  summary.Start,
+				FinishedOn: summary.End,
+				Partial:    summary.Partial,
+			},
+			Summary: AttestationSummary{
+				Counters:         summary.Counters,
+				SeverityCounters: summary.SeverityCounters,
+				TotalCounter:     summary.TotalCounter,
+			},
+		},
+	}
+
+	for _, subject := range attestation.Subjects {
+		statement.Subject = append(statement.Subject, AttestationSubject{
+			Name:   subject.Name,
+			Digest: sha256Digest(subject.Digest),
+		})
+	}
+	if attestation.LibrariesPath != "" {
+		statement.Predicate.Scanner.Libraries.Paths = append(statement.Predicate.Scanner.Libraries.Paths, attestation.LibrariesPath)
+	}
+	for name, value := range attestation.Flags {
+		statement.Predicate.Invocation.Flags[name] = value
+	}
+
+	return statement
+}
+
+func sha256Digest(digest string) map[string]string {
+	if digest == "" {
+		return map[string]string{}
+	}
+	return map[string]string{"sha256": digest}
+}
+
+// SignAttestationStatement wraps the statement in a DSSE envelope signed with the PEM encoded private key
+// found in keyPath, when keyPath is empty the envelope has no signatures and the statement is marked as unsigned
+func SignAttestationStatement(statement *AttestationStatement, keyPath string) (*AttestationEnvelope, error) {
+	statement.Predicate.Metadata.Signed = keyPath != ""
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &AttestationEnvelope{
+		PayloadType: AttestationPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  make([]AttestationSignature, 0, 1),
+	}
+	if keyPath == "" {
+		return envelope, nil
+	}
+
+	signer, err := loadSigningKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	sig, err := sign(signer, PreAuthEncoding(AttestationPayloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := sha256.Sum256(publicKey)
+	envelope.Signatures = append(envelope.Signatures, AttestationSignature{
+		KeyID: hex.EncodeToString(keyID[:]),
+		Sig:   base64.StdEncoding.EncodeToString(sig),
+	})
+	return envelope, nil
+}
+
+// PreAuthEncoding returns the DSSE pre-authentication encoding of the payload, the message that is signed
+func PreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// loadSigningKey reads a PKCS #8, SEC 1 (EC) or PKCS #1 (RSA) private key
+func loadSigningKey(keyPath string) (crypto.Signer, error) {
+	content, err := os.ReadFile(filepath.Clean(keyPath))
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the PEM attestation key %s", keyPath)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the attestation key %s: %w", keyPath, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported attestation key type %T", key)
+	}
+	return signer, nil
+}
+
+// sign signs the message, Ed25519 keys sign the message itself while ECDSA and RSA keys sign its sha256 digest
+func sign(signer crypto.Signer, message []byte) ([]byte, error) {
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		return signer.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(message)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+var attestationMock = &model.Attestation{
+	Subjects: []model.AttestationSubject{
+		{Name: "./test/fixtures", Digest: "3f1f"},
+	},
+	QueriesPaths:    []string{"./assets/queries"},
+	QueriesDigest:   "a1b2",
+	LibrariesDigest: "c3d4",
+	Flags:           map[string]string{"path": "[./test/fixtures]"},
+}
+
+func TestBuildAttestationStatement(t *testing.T) {
+	summary := test.SummaryMock
+	summary.Attestation = attestationMock
+
+	statement := BuildAttestationStatement(&summary)
+
+	require.Equal(t, inTotoStatementType, statement.Type)
+	require.Equal(t, AttestationPredicateType, statement.PredicateType)
+	require.Equal(t, []AttestationSubject{
+		{Name: "./test/fixtures", Digest: map[string]string{"sha256": "3f1f"}},
+	}, statement.Subject)
+	require.Equal(t, AttestationResource{
+		Paths:  []string{"./assets/queries"},
+		Digest: map[string]string{"sha256": "a1b2"},
+	}, statement.Predicate.Scanner.Queries)
+	require.Equal(t, AttestationResource{
+		Paths:  []string{},
+		Digest: map[string]string{"sha256": "c3d4"},
+	}, statement.Predicate.Scanner.Libraries)
+	require.Equal(t, attestationMock.Flags, statement.Predicate.Invocation.Flags)
+	require.Equal(t, summary.Counters, statement.Predicate.Summary.Counters)
+	require.Equal(t, summary.TotalCounter, statement.Predicate.Summary.TotalCounter)
+}
+
+func TestBuildAttestationStatement_WithoutAttestation(t *testing.T) {
+	summary := test.SummaryMock
+	statement := BuildAttestationStatement(&summary)
+	require.Empty(t, statement.Subject)
+	require.Empty(t, statement.Predicate.Scanner.Queries.Digest)
+	require.Empty(t, statement.Predicate.Invocation.Flags)
+}
+
+func TestSignAttestationStatement(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed25519DER, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeKey := func(name, blockType string, der []byte) string {
+		keyPath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return keyPath
+	}
+
+	summary := test.SummaryMock
+	summary.Attestation = attestationMock
+	statement := BuildAttestationStatement(&summary)
+
+	tests := []struct {
+		name    string
+		keyPath string
+		verify  func(t *testing.T, message, sig []byte)
+	}{
+		{
+			name:    "sign with ecdsa key",
+			keyPath: writeKey("ecdsa.pem", "EC PRIVATE KEY", ecdsaDER),
+			verify: func(t *testing.T, message, sig []byte) {
+				digest := sha256.Sum256(message)
+				require.True(t, ecdsa.VerifyASN1(&ecdsaKey.PublicKey, digest[:], sig))
+			},
+		},
+		{
+			name:    "sign with ed25519 key",
+			keyPath: writeKey("ed25519.pem", "PRIVATE KEY", ed25519DER),
+			verify: func(t *testing.T, message, sig []byte) {
+				require.True(t, ed25519.Verify(ed25519Key.Public().(ed25519.PublicKey), message, sig))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := SignAttestationStatement(statement, tt.keyPath)
+			require.NoError(t, err)
+			require.Equal(t, AttestationPayloadType, envelope.PayloadType)
+			require.Len(t, envelope.Signatures, 1)
+
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			require.NoError(t, err)
+			var decoded AttestationStatement
+			require.NoError(t, json.Unmarshal(payload, &decoded))
+			require.Equal(t, statement.Subject, decoded.Subject)
+			require.True(t, decoded.Predicate.Metadata.Signed)
+
+			sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			require.NoError(t, err)
+			tt.verify(t, PreAuthEncoding(AttestationPayloadType, payload), sig)
+		})
+	}
+}
+
+func TestSignAttestationStatement_WithoutKey(t *testing.T) {
+	summary := test.SummaryMock
+	envelope, err := SignAttestationStatement(BuildAttestationStatement(&summary), "")
+	require.NoError(t, err)
+	require.Empty(t, envelope.Signatures)
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	require.NoError(t, err)
+	var decoded AttestationStatement
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	require.False(t, decoded.Predicate.Metadata.Signed)
+}
+
+func TestSignAttestationStatement_InvalidKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0600))
+
+	summary := test.SummaryMock
+	_, err := SignAttestationStatement(BuildAttestationStatement(&summary), keyPath)
+	require.Error(t, err)
+}
+
+func TestPreAuthEncoding(t *testing.T) {
+	require.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		string(PreAuthEncoding("http://example.com/HelloWorld", []byte("hello world"))))
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// BoMReport is the inventory of the resources declared in the scanned files, whether they have results or not
+type BoMReport struct {
+	TotalResources int                       `json:"total_resources"`
+	Platforms      []BoMPlatform             `json:"platforms"`
+	Resources      []model.InventoryResource `json:"resources"`
+}
+
+// BoMPlatform is the number of resources of a platform and cloud provider
+type BoMPlatform struct {
+	Platform      string `json:"platform"`
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	Resources     int    `json:"resources"`
+}
+
+// BuildBoMReport builds the BoM report of the inventory of the summary, with the number of resources of each
+// platform and cloud provider
+func BuildBoMReport(summary *model.Summary) *BoMReport {
+	report := &BoMReport{
+		Platforms: make([]BoMPlatform, 0),
+		Resources: make([]model.InventoryResource, 0, len(summary.Inventory)),
+	}
+	report.Resources = append(report.Resources, summary.Inventory...)
+	report.TotalResources = len(report.Resources)
+
+	counters := make(map[BoMPlatform]int)
+	for i := range report.Resources {
+		counters[BoMPlatform{
+			Platform:      report.Resources[i].Platform,
+			CloudProvider: report.Resources[i].CloudProvider,
+		}]++
+	}
+	for platform, resources := range counters {
+		platform.Resources = resources
+		report.Platforms = append(report.Platforms, platform)
+	}
+	sort.Slice(report.Platforms, func(i, j int) bool {
+		if report.Platforms[i].Platform != report.Platforms[j].Platform {
+			return report.Platforms[i].Platform < report.Platforms[j].Platform
+		}
+		return report.Platforms[i].CloudProvider < report.Platforms[j].CloudProvider
+	})
+	return report
+}
@@ -0,0 +1,191 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+var graphSeverityColors = map[model.Severity]string{
+	model.SeverityCritical: "#ff0000",
+	model.SeverityHigh:     "#bb2124",
+	model.SeverityMedium:   "#ff7213",
+	model.SeverityLow:      "#edd57e",
+	model.SeverityInfo:     "#5bc0de",
+	model.SeverityTrace:    "#cccccc",
+}
+
+// GraphReport is the resource graph annotated with the findings of each resource
+type GraphReport struct {
+	Nodes []GraphReportNode `json:"nodes"`
+	Edges []model.GraphEdge `json:"edges"`
+}
+
+// GraphReportNode is a resource of the graph report, Reachable is true when the resource
+// is internet facing or can be reached from an internet facing resource
+type GraphReportNode struct {
+	model.GraphNode
+	Reachable bool           `json:"reachable"`
+	Severity  model.Severity `json:"severity,omitempty"`
+	Findings  []GraphFinding `json:"findings"`
+}
+
+// GraphFinding is a result of a query found in a resource of the graph report
+type GraphFinding struct {
+	QueryID   string         `json:"query_id"`
+	QueryName string         `json:"query_name"`
+	Severity  model.Severity `json:"severity"`
+	Line      int            `json:"line"`
+}
+
+// BuildGraphReport builds the graph report, an empty graph is returned when the summary has no resource graph
+func BuildGraphReport(summary *model.Summary) *GraphReport {
+	report := &GraphReport{
+		Nodes: make([]GraphReportNode, 0),
+		Edges: make([]model.GraphEdge, 0),
+	}
+	if summary.ResourceGraph == nil {
+		return report
+	}
+
+	nodesByFile := make(map[string][]int)
+	for i := range summary.ResourceGraph.Nodes {
+		report.Nodes = append(report.Nodes, GraphReportNode{
+			GraphNode: summary.ResourceGraph.Nodes[i],
+			Findings:  make([]GraphFinding, 0),
+		})
+		nodesByFile[summary.ResourceGraph.Nodes[i].FileName] = append(nodesByFile[summary.ResourceGraph.Nodes[i].FileName], i)
+	}
+	report.Edges = append(report.Edges, summary.ResourceGraph.Edges...)
+
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			file := &summary.Queries[i].Files[j]
+			for _, idx := range nodesByFile[file.FileName] {
+				if !matchesNode(&report.Nodes[idx].GraphNode, file) {
+					continue
+				}
+				report.Nodes[idx].addFinding(GraphFinding{
+					QueryID:   summary.Queries[i].QueryID,
+					QueryName: summary.Queries[i].QueryName,
+					Severity:  summary.Queries[i].Severity,
+					Line:      file.Line,
+				})
+				break
+			}
+		}
+	}
+
+	report.markReachable()
+	return report
+}
+
+// matchesNode checks if the result was found in the resource, using the resource type and name when
+// set by the query or the search key otherwise
+func matchesNode(node *model.GraphNode, file *model.VulnerableFile) bool {
+	if file.ResourceType == node.ResourceType && file.ResourceName == node.ResourceName {
+		return true
+	}
+	if node.Platform == "Kubernetes" {
+		return strings.Contains(file.SearchKey, "metadata.name={{"+node.ResourceName+"}}")
+	}
+	return strings.Contains(file.SearchKey, node.ResourceType+"["+node.ResourceName+"]")
+}
+
+func (n *GraphReportNode) addFinding(finding GraphFinding) {
+	n.Findings = append(n.Findings, finding)
+	if n.Severity == "" || severityRank(finding.Severity) < severityRank(n.Severity) {
+		n.Severity = finding.Severity
+	}
+}
+
+func severityRank(severity model.Severity) int {
+	for i := range model.AllSeverities {
+		if model.AllSeverities[i] == severity {
+			return i
+		}
+	}
+	return len(model.AllSeverities)
+}
+
+// markReachable marks the resources that can be reached, following the edges from their source
+// to their target, from an internet facing resource
+func (r *GraphReport) markReachable() {
+	indexes := make(map[string]int, len(r.Nodes))
+	queue := make([]string, 0)
+	for i := range r.Nodes {
+		indexes[r.Nodes[i].ID] = i
+		if r.Nodes[i].InternetFacing {
+			r.Nodes[i].Reachable = true
+			queue = append(queue, r.Nodes[i].ID)
+		}
+	}
+
+	targets := make(map[string][]string)
+	for _, edge := range r.Edges {
+		targets[edge.From] = append(targets[edge.From], edge.To)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, target := range targets[current] {
+			idx, ok := indexes[target]
+			if !ok || r.Nodes[idx].Reachable {
+				continue
+			}
+			r.Nodes[idx].Reachable = true
+			queue = append(queue, target)
+		}
+	}
+}
+
+// ToDOT returns the graph report in the DOT language, internet facing resources are drawn as
+// double octagons, reachable resources with a bold border and resources with findings are
+// filled with the color of their highest severity
+func (r *GraphReport) ToDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph kics {\n\trankdir=LR;\n\tnode [shape=box, style=rounded];\n")
+
+	nodes := make([]GraphReportNode, len(r.Nodes))
+	copy(nodes, r.Nodes)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	for i := range nodes {
+		attributes := []string{
+			fmt.Sprintf("label=%s", dotQuote(fmt.Sprintf("%s\n%s\n%d finding(s)",
+				nodes[i].ResourceType+"."+nodes[i].ResourceName, nodes[i].FileName, len(nodes[i].Findings)))),
+		}
+		styles := []string{"rounded"}
+		if nodes[i].InternetFacing {
+			attributes = append(attributes, "shape=doubleoctagon")
+		}
+		if nodes[i].Reachable {
+			styles = append(styles, "bold")
+		}
+		if color, ok := graphSeverityColors[nodes[i].Severity]; ok {
+			styles = append(styles, "filled")
+			attributes = append(attributes, fmt.Sprintf("fillcolor=%s", dotQuote(color)))
+		}
+		attributes = append(attributes, fmt.Sprintf("style=%s", dotQuote(strings.Join(styles, ","))))
+		fmt.Fprintf(&sb, "\t%s [%s];\n", dotQuote(nodes[i].ID), strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range r.Edges {
+		fmt.Fprintf(&sb, "\t%s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + value + `"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+var resourceGraphMock = &model.ResourceGraph{
+	Nodes: []model.GraphNode{
+		{ID: ".:aws_alb.front_end", Platform: "Terraform", ResourceType: "aws_alb", ResourceName: "front_end", FileName: "positive.tf", InternetFacing: true},
+		{ID: ".:aws_alb_listener.front_end", Platform: "Terraform", ResourceType: "aws_alb_listener", ResourceName: "front_end", FileName: "positive.tf"},
+		{ID: ".:aws_sqs_queue.queue", Platform: "Terraform", ResourceType: "aws_sqs_queue", ResourceName: "queue", FileName: "positive.tf"},
+	},
+	Edges: []model.GraphEdge{
+		{From: ".:aws_alb.front_end", To: ".:aws_alb_listener.front_end"},
+	},
+}
+
+func TestBuildGraphReport(t *testing.T) {
+	summary := test.SummaryMock
+	summary.ResourceGraph = resourceGraphMock
+
+	report := BuildGraphReport(&summary)
+
+	require.Len(t, report.Nodes, 3)
+	require.Equal(t, resourceGraphMock.Edges, report.Edges)
+
+	require.True(t, report.Nodes[0].Reachable)
+	require.Empty(t, report.Nodes[0].Findings)
+	require.Empty(t, report.Nodes[0].Severity)
+
+	require.True(t, report.Nodes[1].Reachable)
+	require.Equal(t, model.Severity(model.SeverityHigh), report.Nodes[1].Severity)
+	require.Equal(t, []GraphFinding{
+		{QueryID: "de7f5e83-da88-4046-871f-ea18504b1d43", QueryName: "ALB protocol is HTTP", Severity: model.SeverityHigh, Line: 25},
+		{QueryID: "de7f5e83-da88-4046-871f-ea18504b1d43", QueryName: "ALB protocol is HTTP", Severity: model.SeverityHigh, Line: 19},
+	}, report.Nodes[1].Findings)
+
+	require.False(t, report.Nodes[2].Reachable)
+	require.Empty(t, report.Nodes[2].Findings)
+}
+
+func TestBuildGraphReport_WithoutResourceGraph(t *testing.T) {
+	summary := test.SummaryMock
+	report := BuildGraphReport(&summary)
+	require.Empty(t, report.Nodes)
+	require.Empty(t, report.Edges)
+}
+
+func TestGraphReport_ToDOT(t *testing.T) {
+	summary := test.SummaryMock
+	summary.ResourceGraph = resourceGraphMock
+
+	dot := BuildGraphReport(&summary).ToDOT()
+
+	require.Equal(t, `digraph kics {
+	rankdir=LR;
+	node [shape=box, style=rounded];
+	".:aws_alb.front_end" [label="aws_alb.front_end\npositive.tf\n0 finding(s)", shape=doubleoctagon, style="rounded,bold"];
+	".:aws_alb_listener.front_end" [label="aws_alb_listener.front_end\npositive.tf\n2 finding(s)", fillcolor="#bb2124", style="rounded,bold,filled"];
+	".:aws_sqs_queue.queue" [label="aws_sqs_queue.queue\npositive.tf\n0 finding(s)", style="rounded"];
+	".:aws_alb.front_end" -> ".:aws_alb_listener.front_end";
+}
+`, dot)
+}
+
+func TestBuildGraphReport_ReachableDirection(t *testing.T) {
+	summary := test.SummaryMock
+	summary.ResourceGraph = &model.ResourceGraph{
+		Nodes: []model.GraphNode{
+			{ID: "a", Platform: "Terraform", InternetFacing: true},
+			{ID: "b", Platform: "Terraform"},
+			{ID: "c", Platform: "Terraform"},
+		},
+		Edges: []model.GraphEdge{
+			{From: "a", To: "b"},
+			{From: "c", To: "a"},
+		},
+	}
+
+	report := BuildGraphReport(&summary)
+
+	require.True(t, report.Nodes[0].Reachable)
+	require.True(t, report.Nodes[1].Reachable)
+	// c is only connected to a by an edge pointing to it, so it is not reached
+	require.False(t, report.Nodes[2].Reachable)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// UnownedResults is the owner used to group the results of files without an owner
+const UnownedResults = "unowned"
+
+// OwnerReport groups the results of the files owned by the same owners
+type OwnerReport struct {
+	Owner            string                 `json:"owner"`
+	SeverityCounters map[model.Severity]int `json:"severity_counters"`
+	TotalCounter     int                    `json:"total_counter"`
+	Results          []OwnerResult          `json:"results"`
+}
+
+// OwnerResult is a result owned by the owners of an owner report
+type OwnerResult struct {
+	QueryID      string         `json:"query_id"`
+	QueryName    string         `json:"query_name"`
+	Severity     model.Severity `json:"severity"`
+	Platform     string         `json:"platform"`
+	FileName     string         `json:"file_name"`
+	Line         int            `json:"line"`
+	SimilarityID string         `json:"similarity_id"`
+}
+
+// BuildOwnersReport builds the owners report, sorted by owner with the unowned results last
+func BuildOwnersReport(summary *model.Summary) []OwnerReport {
+	reports := make(map[string]*OwnerReport)
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			owner := summary.Queries[i].Files[j].Owner
+			if owner == "" {
+				owner = UnownedResults
+			}
+			report, ok := reports[owner]
+			if !ok {
+				report = &OwnerReport{
+					Owner:            owner,
+					SeverityCounters: make(map[model.Severity]int),
+					Results:          make([]OwnerResult, 0),
+				}
+				reports[owner] = report
+			}
+			report.SeverityCounters[summary.Queries[i].Severity]++
+			report.TotalCounter++
+			report.Results = append(report.Results, OwnerResult{
+				QueryID:      summary.Queries[i].QueryID,
+				QueryName:    summary.Queries[i].QueryName,
+				Severity:     summary.Queries[i].Severity,
+				Platform:     summary.Queries[i].Platform,
+				FileName:     summary.Queries[i].Files[j].FileName,
+				Line:         summary.Queries[i].Files[j].Line,
+				SimilarityID: summary.Queries[i].Files[j].SimilarityID,
+			})
+		}
+	}
+
+	ownersReport := make([]OwnerReport, 0, len(reports))
+	for _, report := range reports {
+		ownersReport = append(ownersReport, *report)
+	}
+	sort.Slice(ownersReport, func(i, j int) bool {
+		if (ownersReport[i].Owner == UnownedResults) != (ownersReport[j].Owner == UnownedResults) {
+			return ownersReport[j].Owner == UnownedResults
+		}
+		return ownersReport[i].Owner < ownersReport[j].Owner
+	})
+	return ownersReport
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOwnersReport(t *testing.T) {
+	summary := model.Summary{
+		Queries: []model.QueryResult{
+			{
+				QueryName: "ALB protocol is HTTP",
+				QueryID:   "de7f5e83-da88-4046-871f-ea18504b1d43",
+				Severity:  model.SeverityHigh,
+				Platform:  "Terraform",
+				Files: []model.VulnerableFile{
+					{FileName: "positive.tf", Line: 25, SimilarityID: "a", Owner: "@org/infra"},
+					{FileName: "positive.tf", Line: 19, SimilarityID: "b"},
+				},
+			},
+			{
+				QueryName: "Container Running As Root",
+				QueryID:   "cf34805e-3872-4c08-bf92-6ff7bb0cfadb",
+				Severity:  model.SeverityMedium,
+				Platform:  "Kubernetes",
+				Files: []model.VulnerableFile{
+					{FileName: "pod.yaml", Line: 3, SimilarityID: "c", Owner: "@org/k8s"},
+					{FileName: "pod.yaml", Line: 9, SimilarityID: "d", Owner: "@org/infra"},
+				},
+			},
+		},
+	}
+
+	got := BuildOwnersReport(&summary)
+
+	require.Equal(t, []OwnerReport{
+		{
+			Owner:            "@org/infra",
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1, model.SeverityMedium: 1},
+			TotalCounter:     2,
+			Results: []OwnerResult{
+				{
+					QueryID: "de7f5e83-da88-4046-871f-ea18504b1d43", QueryName: "ALB protocol is HTTP", Severity: model.SeverityHigh,
+					Platform: "Terraform", FileName: "positive.tf", Line: 25, SimilarityID: "a",
+				},
+				{
+					QueryID: "cf34805e-3872-4c08-bf92-6ff7bb0cfadb", QueryName: "Container Running As Root", Severity: model.SeverityMedium,
+					Platform: "Kubernetes", FileName: "pod.yaml", Line: 9, SimilarityID: "d",
+				},
+			},
+		},
+		{
+			Owner:            "@org/k8s",
+			SeverityCounters: map[model.Severity]int{model.SeverityMedium: 1},
+			TotalCounter:     1,
+			Results: []OwnerResult{
+				{
+					QueryID: "cf34805e-3872-4c08-bf92-6ff7bb0cfadb", QueryName: "Container Running As Root", Severity: model.SeverityMedium,
+					Platform: "Kubernetes", FileName: "pod.yaml", Line: 3, SimilarityID: "c",
+				},
+			},
+		},
+		{
+			Owner:            UnownedResults,
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1},
+			TotalCounter:     1,
+			Results: []OwnerResult{
+				{
+					QueryID: "de7f5e83-da88-4046-871f-ea18504b1d43", QueryName: "ALB protocol is HTTP", Severity: model.SeverityHigh,
+					Platform: "Terraform", FileName: "positive.tf", Line: 19, SimilarityID: "b",
+				},
+			},
+		},
+	}, got)
+}
@@ -144,12 +144,22 @@ type sarifLocation struct {
 	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
 }
 
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
 type sarifResult struct {
-	ResultRuleID    string          `json:"ruleId"`
-	ResultRuleIndex int             `json:"ruleIndex"`
-	ResultKind      string          `json:"kind"`
-	ResultMessage   sarifMessage    `json:"message"`
-	ResultLocations []sarifLocation `json:"locations"`
+	ResultRuleID              string             `json:"ruleId"`
+	ResultRuleIndex           int                `json:"ruleIndex"`
+	ResultKind                string             `json:"kind"`
+	ResultMessage             sarifMessage       `json:"message"`
+	ResultLocations           []sarifLocation    `json:"locations"`
+	ResultPartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	ResultBaselineState       string             `json:"baselineState,omitempty"`
+	ResultSuppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	ResultProperties          sarifProperties    `json:"properties,omitempty"`
 }
 
 type taxonomyDefinitions struct {
@@ -190,8 +200,11 @@ type SarifRun struct {
 // SarifReport represents a usable sarif report reference
 type SarifReport interface {
 	BuildSarifIssue(issue *model.QueryResult) string
+	BuildSarifSuppressedIssue(issue *model.QueryResult, suppressions map[string]model.Suppression) string
 	RebuildTaxonomies(cwes []string, guids map[string]string)
 	GetGUIDFromRelationships(idx int, cweID string) string
+	GetRuleIndex(ruleID string) int
+	ApplyBaseline(baseline *model.Baseline)
 }
 
 type sarifReport struct {
@@ -565,6 +578,24 @@ func (sr *sarifReport) RebuildTaxonomies(cwes []string, guids map[string]string)
 
 // BuildSarifIssue creates a new entries in Results (one for each file) and new entry in Rules and Taxonomy if necessary
 func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
+	return sr.buildSarifResults(issue, nil)
+}
+
+// BuildSarifSuppressedIssue creates the entries in Results of the suppressed results of the issue,
+// each with a SARIF suppression describing why it is not reported
+func (sr *sarifReport) BuildSarifSuppressedIssue(issue *model.QueryResult, suppressions map[string]model.Suppression) string {
+	if suppressions == nil {
+		suppressions = make(map[string]model.Suppression)
+	}
+	return sr.buildSarifResults(issue, suppressions)
+}
+
+// GetRuleIndex returns the index of the rule in the driver rules, -1 if it does not exist
+func (sr *sarifReport) GetRuleIndex(ruleID string) int {
+	return sr.findSarifRuleIndex(ruleID)
+}
+
+func (sr *sarifReport) buildSarifResults(issue *model.QueryResult, suppressions map[string]model.Suppression) string {
 	if len(issue.Files) > 0 {
 		metadata := ruleMetadata{
 			queryID:          issue.QueryID,
@@ -610,6 +641,29 @@ func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
 					},
 				},
 			}
+			if issue.Files[idx].SimilarityID != "" {
+				result.ResultPartialFingerprints = map[string]string{
+					sarifFingerprintKey: issue.Files[idx].SimilarityID,
+				}
+			}
+			if blame := issue.Files[idx].Blame; blame != nil {
+				result.ResultProperties = sarifProperties{
+					"blame": blame,
+				}
+			}
+			if suppressions != nil {
+				suppression := suppressions[issue.Files[idx].SimilarityID]
+				if suppression.Kind == "" {
+					suppression.Kind = model.SuppressionExternal
+				}
+				result.ResultSuppressions = []sarifSuppression{
+					{
+						Kind:          suppression.Kind,
+						Status:        "accepted",
+						Justification: suppression.Justification,
+					},
+				}
+			}
 			sr.Runs[0].Results = append(sr.Runs[0].Results, result)
 		}
 		return issue.CWE
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// sarifFingerprintKey is the partial fingerprint holding the similarity ID of the result
+const sarifFingerprintKey = "kicsSimilarityId/v1"
+
+// SARIF baseline states
+const (
+	baselineStateNew       = "new"
+	baselineStateUnchanged = "unchanged"
+	baselineStateAbsent    = "absent"
+)
+
+type sarifBaselineRun struct {
+	Tool struct {
+		Driver struct {
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// ReadBaseline reads the results of a previous scan from a SARIF report or from a KICS JSON report
+func ReadBaseline(path string) (*model.Baseline, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse the baseline %s: %w", path, err)
+	}
+
+	baseline := &model.Baseline{Results: make([]model.BaselineResult, 0)}
+	switch {
+	case fields["runs"] != nil:
+		var runs []sarifBaselineRun
+		if err := json.Unmarshal(fields["runs"], &runs); err != nil {
+			return nil, fmt.Errorf("failed to parse the SARIF baseline %s: %w", path, err)
+		}
+		for i := range runs {
+			baseline.Results = append(baseline.Results, getSarifBaselineResults(&runs[i])...)
+		}
+	case fields["kics_version"] != nil:
+		var summary model.Summary
+		if err := json.Unmarshal(content, &summary); err != nil {
+			return nil, fmt.Errorf("failed to parse the JSON baseline %s: %w", path, err)
+		}
+		baseline.Results = getSummaryBaselineResults(&summary)
+	default:
+		return nil, fmt.Errorf("the baseline %s is neither a SARIF report nor a KICS JSON report", path)
+	}
+
+	return baseline, nil
+}
+
+func getSarifBaselineResults(run *sarifBaselineRun) []model.BaselineResult {
+	ruleNames := make(map[string]string, len(run.Tool.Driver.Rules))
+	for i := range run.Tool.Driver.Rules {
+		ruleNames[run.Tool.Driver.Rules[i].RuleID] = run.Tool.Driver.Rules[i].RuleName
+	}
+
+	results := make([]model.BaselineResult, 0, len(run.Results))
+	for i := range run.Results {
+		result := &run.Results[i]
+		// the absent results of the baseline were already fixed
+		if result.ResultBaselineState == baselineStateAbsent {
+			continue
+		}
+		baselineResult := model.BaselineResult{
+			Fingerprint: result.ResultPartialFingerprints[sarifFingerprintKey],
+			RuleID:      result.ResultRuleID,
+			RuleName:    ruleNames[result.ResultRuleID],
+			Kind:        result.ResultKind,
+			Message:     result.ResultMessage.Text,
+		}
+		if len(result.ResultLocations) > 0 {
+			baselineResult.FileName = result.ResultLocations[0].PhysicalLocation.ArtifactLocation.ArtifactURI
+			baselineResult.Line = result.ResultLocations[0].PhysicalLocation.Region.StartLine
+		}
+		results = append(results, baselineResult)
+	}
+	return results
+}
+
+func getSummaryBaselineResults(summary *model.Summary) []model.BaselineResult {
+	results := make([]model.BaselineResult, 0)
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		kind := "fail"
+		if severityLevelEquivalence[query.Severity] == "none" {
+			kind = "informational"
+		}
+		for j := range query.Files {
+			line := query.Files[j].Line
+			if line < 1 {
+				line = 1
+			}
+			results = append(results, model.BaselineResult{
+				Fingerprint: query.Files[j].SimilarityID,
+				RuleID:      query.QueryID,
+				RuleName:    query.QueryName,
+				Kind:        kind,
+				FileName:    query.Files[j].FileName,
+				Line:        line,
+				Message:     query.Files[j].KeyActualValue,
+			})
+		}
+	}
+	return results
+}
+
+func baselineLocationKey(ruleID, fileName string, line int) string {
+	return fmt.Sprintf("%s|%s|%d", ruleID, fileName, line)
+}
+
+// ApplyBaseline sets the baseline state of the results, a result found in the baseline by its similarity ID,
+// or by its rule and location when the baseline has no similarity IDs, is unchanged and the other ones are new,
+// the results of the baseline no longer found are added as absent
+func (sr *sarifReport) ApplyBaseline(baseline *model.Baseline) {
+	if baseline == nil {
+		return
+	}
+
+	byFingerprint := make(map[string]int, len(baseline.Results))
+	byLocation := make(map[string]int, len(baseline.Results))
+	for i := range baseline.Results {
+		if baseline.Results[i].Fingerprint != "" {
+			byFingerprint[baseline.Results[i].Fingerprint] = i
+		} else {
+			byLocation[baselineLocationKey(baseline.Results[i].RuleID, baseline.Results[i].FileName, baseline.Results[i].Line)] = i
+		}
+	}
+
+	matched := make([]bool, len(baseline.Results))
+	for i := range sr.Runs[0].Results {
+		result := &sr.Runs[0].Results[i]
+		idx, found := byFingerprint[result.ResultPartialFingerprints[sarifFingerprintKey]]
+		if !found && len(result.ResultLocations) > 0 {
+			location := result.ResultLocations[0].PhysicalLocation
+			idx, found = byLocation[baselineLocationKey(result.ResultRuleID, location.ArtifactLocation.ArtifactURI, location.Region.StartLine)]
+		}
+		if found && !matched[idx] {
+			matched[idx] = true
+			result.ResultBaselineState = baselineStateUnchanged
+		} else {
+			result.ResultBaselineState = baselineStateNew
+		}
+	}
+
+	for i := range baseline.Results {
+		if !matched[i] {
+			sr.Runs[0].Results = append(sr.Runs[0].Results, sr.buildAbsentResult(&baseline.Results[i]))
+		}
+	}
+}
+
+func (sr *sarifReport) buildAbsentResult(baselineResult *model.BaselineResult) sarifResult {
+	ruleIndex := sr.findSarifRuleIndex(baselineResult.RuleID)
+	if ruleIndex < 0 {
+		// the rule has no result in this scan, it is only described by the baseline
+		name := baselineResult.RuleName
+		if name == "" {
+			name = baselineResult.RuleID
+		}
+		sr.Runs[0].Tool.Driver.Rules = append(sr.Runs[0].Tool.Driver.Rules, sarifRule{
+			RuleID:               baselineResult.RuleID,
+			RuleName:             name,
+			RuleShortDescription: sarifMessage{Text: name},
+			RuleFullDescription:  sarifMessage{Text: name},
+			HelpURI:              "https://docs.kics.io/",
+		})
+		ruleIndex = len(sr.Runs[0].Tool.Driver.Rules) - 1
+	}
+
+	kind := baselineResult.Kind
+	if kind == "" {
+		kind = "fail"
+	}
+	message := baselineResult.Message
+	if message == "" {
+		message = sr.Runs[0].Tool.Driver.Rules[ruleIndex].RuleName
+	}
+	line := baselineResult.Line
+	if line < 1 {
+		line = 1
+	}
+
+	result := sarifResult{
+		ResultRuleID:    baselineResult.RuleID,
+		ResultRuleIndex: ruleIndex,
+		ResultKind:      kind,
+		ResultMessage:   sarifMessage{Text: message},
+		ResultLocations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{ArtifactURI: baselineResult.FileName},
+					Region:           sarifRegion{StartLine: line},
+				},
+			},
+		},
+		ResultBaselineState: baselineStateAbsent,
+	}
+	if baselineResult.Fingerprint != "" {
+		result.ResultPartialFingerprints = map[string]string{sarifFingerprintKey: baselineResult.Fingerprint}
+	}
+	return result
+}
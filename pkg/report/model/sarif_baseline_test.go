@@ -0,0 +1,102 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var baselineQuery = model.QueryResult{
+	QueryName: "Privileged Containers",
+	QueryID:   "ae5b6871-7f45-42e0-bb4c-ab300c4d2026",
+	Severity:  model.SeverityHigh,
+	Platform:  "DockerCompose",
+	Files: []model.VulnerableFile{
+		{FileName: "docker-compose.yml", Line: 5, SimilarityID: "sim-1", KeyActualValue: "privileged is true"},
+		{FileName: "docker-compose.yml", Line: 12, SimilarityID: "sim-2", KeyActualValue: "privileged is true"},
+	},
+}
+
+func writeBaseline(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "baseline")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// TestReadBaseline tests the reading of the previous scan results from SARIF and KICS JSON reports
+func TestReadBaseline(t *testing.T) {
+	sarif := writeBaseline(t, `{"runs": [{"tool": {"driver": {"rules": [{"id": "q1", "name": "Query One"}]}},
+		"results": [
+			{"ruleId": "q1", "kind": "fail", "message": {"text": "m1"}, "partialFingerprints": {"kicsSimilarityId/v1": "sim-1"},
+				"locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.yml"}, "region": {"startLine": 3}}}]},
+			{"ruleId": "q1", "kind": "fail", "message": {"text": "m2"}, "baselineState": "absent",
+				"locations": [{"physicalLocation": {"artifactLocation": {"uri": "b.yml"}, "region": {"startLine": 4}}}]}
+		]}]}`)
+	baseline, err := ReadBaseline(sarif)
+	require.NoError(t, err)
+	require.Equal(t, []model.BaselineResult{
+		{Fingerprint: "sim-1", RuleID: "q1", RuleName: "Query One", Kind: "fail", FileName: "a.yml", Line: 3, Message: "m1"},
+	}, baseline.Results)
+
+	kicsJSON := writeBaseline(t, `{"kics_version": "development", "queries": [{"query_name": "Query One", "query_id": "q1",
+		"severity": "INFO", "files": [{"file_name": "a.yml", "similarity_id": "sim-1", "line": 0, "actual_value": "m1"}]}]}`)
+	baseline, err = ReadBaseline(kicsJSON)
+	require.NoError(t, err)
+	require.Equal(t, []model.BaselineResult{
+		{Fingerprint: "sim-1", RuleID: "q1", RuleName: "Query One", Kind: "informational", FileName: "a.yml", Line: 1, Message: "m1"},
+	}, baseline.Results)
+
+	_, err = ReadBaseline(writeBaseline(t, `{"results": []}`))
+	require.Error(t, err)
+	_, err = ReadBaseline(filepath.Join(t.TempDir(), "missing.sarif"))
+	require.Error(t, err)
+}
+
+// TestApplyBaseline tests the baseline state of the results compared with a previous scan
+func TestApplyBaseline(t *testing.T) {
+	sarif := NewSarifReport().(*sarifReport)
+	query := baselineQuery
+	sarif.BuildSarifIssue(&query)
+	sarif.ApplyBaseline(&model.Baseline{Results: []model.BaselineResult{
+		{Fingerprint: "sim-1", RuleID: query.QueryID, FileName: "docker-compose.yml", Line: 4},
+		{Fingerprint: "sim-3", RuleID: query.QueryID, FileName: "docker-compose.yml", Line: 20, Message: "fixed"},
+		{RuleID: "removed", RuleName: "Removed Query", FileName: "main.tf", Line: 2},
+	}})
+
+	results := sarif.Runs[0].Results
+	require.Len(t, results, 4)
+	require.Equal(t, baselineStateUnchanged, results[0].ResultBaselineState)
+	require.Equal(t, baselineStateNew, results[1].ResultBaselineState)
+
+	require.Equal(t, baselineStateAbsent, results[2].ResultBaselineState)
+	require.Equal(t, "fixed", results[2].ResultMessage.Text)
+	require.Equal(t, 0, results[2].ResultRuleIndex)
+	require.Equal(t, map[string]string{sarifFingerprintKey: "sim-3"}, results[2].ResultPartialFingerprints)
+
+	require.Equal(t, baselineStateAbsent, results[3].ResultBaselineState)
+	require.Equal(t, "removed", sarif.Runs[0].Tool.Driver.Rules[results[3].ResultRuleIndex].RuleID)
+	require.Equal(t, "Removed Query", results[3].ResultMessage.Text)
+	require.Nil(t, results[3].ResultPartialFingerprints)
+}
+
+// TestBuildSarifSuppressedIssue tests the SARIF suppressions of the suppressed results
+func TestBuildSarifSuppressedIssue(t *testing.T) {
+	sarif := NewSarifReport().(*sarifReport)
+	query := baselineQuery
+	sarif.BuildSarifSuppressedIssue(&query, map[string]model.Suppression{
+		"sim-1": {Kind: model.SuppressionInSource, Justification: "ignored by a kics-scan comment"},
+	})
+
+	results := sarif.Runs[0].Results
+	require.Len(t, results, 2)
+	require.Equal(t, []sarifSuppression{
+		{Kind: model.SuppressionInSource, Status: "accepted", Justification: "ignored by a kics-scan comment"},
+	}, results[0].ResultSuppressions)
+	require.Equal(t, model.SuppressionExternal, results[1].ResultSuppressions[0].Kind)
+	require.Equal(t, map[string]string{sarifFingerprintKey: "sim-2"}, results[1].ResultPartialFingerprints)
+	require.Equal(t, 0, sarif.GetRuleIndex(query.QueryID))
+	require.Equal(t, -1, sarif.GetRuleIndex("unknown"))
+}
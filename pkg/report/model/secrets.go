@@ -0,0 +1,70 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// SecretsReport is the report of the secrets command
+type SecretsReport struct {
+	Version      string         `json:"kics_version"`
+	FilesScanned int            `json:"files_scanned"`
+	LinesScanned int            `json:"lines_scanned"`
+	SecretsFound int            `json:"secrets_found"`
+	Start        time.Time      `json:"start"`
+	End          time.Time      `json:"end"`
+	Secrets      []SecretResult `json:"secrets"`
+}
+
+// SecretResult is a secret found by the secrets command, Code is the line of the secret with the secret masked
+type SecretResult struct {
+	RuleID       string `json:"rule_id"`
+	RuleName     string `json:"rule_name"`
+	FileName     string `json:"file_name"`
+	Line         int    `json:"line"`
+	SimilarityID string `json:"similarity_id"`
+	Code         string `json:"code"`
+}
+
+// BuildSecretsReport builds the secrets report, sorted by file and line
+func BuildSecretsReport(results []model.Vulnerability, filesScanned, linesScanned int, start, end time.Time) *SecretsReport {
+	report := &SecretsReport{
+		Version:      constants.Version,
+		FilesScanned: filesScanned,
+		LinesScanned: linesScanned,
+		SecretsFound: len(results),
+		Start:        start,
+		End:          end,
+		Secrets:      make([]SecretResult, 0, len(results)),
+	}
+
+	for i := range results {
+		secret := SecretResult{
+			RuleID:       results[i].QueryID,
+			RuleName:     results[i].QueryName,
+			FileName:     results[i].FileName,
+			Line:         results[i].Line,
+			SimilarityID: results[i].SimilarityID,
+		}
+		if results[i].VulnLines != nil {
+			for _, line := range *results[i].VulnLines {
+				if line.Position == results[i].Line {
+					secret.Code = line.Line
+				}
+			}
+		}
+		report.Secrets = append(report.Secrets, secret)
+	}
+
+	sort.SliceStable(report.Secrets, func(i, j int) bool {
+		if report.Secrets[i].FileName != report.Secrets[j].FileName {
+			return report.Secrets[i].FileName < report.Secrets[j].FileName
+		}
+		return report.Secrets[i].Line < report.Secrets[j].Line
+	})
+
+	return report
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Checkmarx/kics/internal/constants"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSecretsReport(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Second)
+	results := []model.Vulnerability{
+		{
+			QueryID:      "487f4be7-3fd9-4506-a07a-eae252180c08",
+			QueryName:    "Passwords And Secrets - Generic Password",
+			FileName:     "config.env",
+			Line:         4,
+			SimilarityID: "b",
+			VulnLines: &[]model.CodeLine{
+				{Position: 3, Line: "user = admin"},
+				{Position: 4, Line: "password = <SECRET-MASKED-ON-PURPOSE>"},
+			},
+		},
+		{
+			QueryID:      "83ab47ff-381d-48cd-bac5-fb32222f54af",
+			QueryName:    "Passwords And Secrets - AWS Secret Key",
+			FileName:     "config.env",
+			Line:         2,
+			SimilarityID: "a",
+		},
+	}
+
+	got := BuildSecretsReport(results, 3, 20, start, end)
+
+	require.Equal(t, &SecretsReport{
+		Version:      constants.Version,
+		FilesScanned: 3,
+		LinesScanned: 20,
+		SecretsFound: 2,
+		Start:        start,
+		End:          end,
+		Secrets: []SecretResult{
+			{
+				RuleID: "83ab47ff-381d-48cd-bac5-fb32222f54af", RuleName: "Passwords And Secrets - AWS Secret Key",
+				FileName: "config.env", Line: 2, SimilarityID: "a",
+			},
+			{
+				RuleID: "487f4be7-3fd9-4506-a07a-eae252180c08", RuleName: "Passwords And Secrets - Generic Password",
+				FileName: "config.env", Line: 4, SimilarityID: "b", Code: "password = <SECRET-MASKED-ON-PURPOSE>",
+			},
+		},
+	}, got)
+}
@@ -0,0 +1,25 @@
+package report
+
+import (
+	"strings"
+
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
+)
+
+// PrintOwnersReport prints the results grouped by owner in the given path and filename with the given body
+func PrintOwnersReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, "owners-") {
+		filename = "owners-" + filename
+	}
+
+	if body != "" {
+		summary, err := getSummary(body)
+		if err != nil {
+			return err
+		}
+
+		body = reportModel.BuildOwnersReport(&summary)
+	}
+
+	return ExportJSONReport(path, filename, body)
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintOwnersReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		caseTest jsonCaseTest
+	}{
+		{
+			name: "print owners report",
+			caseTest: jsonCaseTest{
+				summary:  test.SummaryMock,
+				path:     filepath.Join(os.TempDir(), "testdir"),
+				filename: "output",
+			},
+		},
+		{
+			name: "print owners report critical",
+			caseTest: jsonCaseTest{
+				summary:  test.SummaryMockCritical,
+				path:     filepath.Join(os.TempDir(), "testdir"),
+				filename: "output2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := os.MkdirAll(test.caseTest.path, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			err := PrintOwnersReport(test.caseTest.path, test.caseTest.filename, test.caseTest.summary)
+			require.NoError(t, err)
+
+			require.FileExists(t, filepath.Join(test.caseTest.path, "owners-"+test.caseTest.filename+".json"))
+			os.RemoveAll(test.caseTest.path)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	_ "embed" // used for embedding report static files
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Checkmarx/kics/internal/constants"
@@ -65,7 +66,7 @@ func createQueryEntryMetadataField(m pdf.Maroto, label, value string, textSize i
 	})
 }
 
-func createQueriesTable(m pdf.Maroto, queries []model.QueryResult) error {
+func createQueriesTable(m pdf.Maroto, queries []model.QueryResult, queryDocs map[string]*model.QueryDocumentation) error {
 	for i := range queries {
 		m.SetBackgroundColor(color.NewWhite())
 		queryName := queries[i].QueryName
@@ -129,6 +130,9 @@ func createQueriesTable(m pdf.Maroto, queries []model.QueryResult) error {
 		} else {
 			createDescription(m, description)
 		}
+		if docs := queryDocs[queries[i].QueryID]; docs != nil {
+			createDocumentation(m, docs)
+		}
 		createResultsTable(m, &queries[i])
 	}
 	return nil
@@ -159,6 +163,69 @@ func createDescription(m pdf.Maroto, description string) {
 	})
 }
 
+// createDocumentation adds the embedded documentation of the query, the description is already on the query rows
+func createDocumentation(m pdf.Maroto, docs *model.QueryDocumentation) {
+	if docs.Rationale != "" {
+		createDocumentationLabel(m, "Rationale")
+		createDocumentationText(m, docs.Rationale)
+	}
+	if len(docs.Remediation) > 0 {
+		createDocumentationLabel(m, "Remediation")
+		for _, remediation := range docs.Remediation {
+			createDocumentationText(m, "- "+remediation)
+		}
+	}
+	for idx := range docs.Examples {
+		label := fmt.Sprintf("Compliant example (%s)", docs.Examples[idx].FileName)
+		if docs.Examples[idx].Truncated {
+			label = fmt.Sprintf("Compliant example (%s, truncated)", docs.Examples[idx].FileName)
+		}
+		createDocumentationLabel(m, label)
+		m.SetBackgroundColor(grayColor)
+		for _, line := range strings.Split(docs.Examples[idx].Code, "\n") {
+			m.Row(rowXSmall, func() {
+				m.Col(colFullPage, func() {
+					m.Text(line, props.Text{
+						Family:      consts.Courier,
+						Size:        smallTextSize,
+						Align:       consts.Left,
+						Extrapolate: true,
+					})
+				})
+			})
+		}
+		m.SetBackgroundColor(color.NewWhite())
+	}
+	m.Row(colFive, func() {
+		m.ColSpace(0)
+	})
+}
+
+func createDocumentationLabel(m pdf.Maroto, label string) {
+	m.Row(colFive, func() {
+		m.Col(colFullPage, func() {
+			m.Text(label, props.Text{
+				Size:        float64(textSize),
+				Align:       consts.Left,
+				Style:       consts.Bold,
+				Extrapolate: false,
+			})
+		})
+	})
+}
+
+func createDocumentationText(m pdf.Maroto, text string) {
+	m.Row(getRowLength(text), func() {
+		m.Col(colFullPage, func() {
+			m.Text(text, props.Text{
+				Size:        defaultTextSize,
+				Align:       consts.Left,
+				Extrapolate: false,
+			})
+		})
+	})
+}
+
 func createCISRows(m pdf.Maroto, query *model.QueryResult) {
 	cisID := query.CISDescriptionIDFormatted
 	description := query.CISDescriptionTextFormatted
@@ -312,7 +379,7 @@ func PrintPdfReport(path, filename string, body interface{}) error {
 
 	m.Line(1.0)
 
-	err := createQueriesTable(m, summary.Queries)
+	err := createQueriesTable(m, summary.Queries, summary.QueryDocs)
 	if err != nil {
 		return err
 	}
@@ -3,15 +3,23 @@ package report
 import (
 	"strings"
 
+	"github.com/Checkmarx/kics/pkg/model"
 	reportModel "github.com/Checkmarx/kics/pkg/report/model"
 )
 
-// PrintSarifReport creates a report file on sarif format, fetching the ID and GUID from relationships to be inputted to taxonomies field
+// PrintSarifReport creates a report file on sarif format, fetching the ID and GUID from relationships to be inputted to taxonomies field,
+// the suppressed results are reported with their suppressions and the baseline state is set when a baseline is given
 func PrintSarifReport(path, filename string, body interface{}) error {
 	if !strings.HasSuffix(filename, ".sarif") {
 		filename += ".sarif"
 	}
 	if body != "" {
+		var suppressedQueries model.QueryResultSlice
+		var suppressions map[string]model.Suppression
+		var baseline *model.Baseline
+		if s, ok := body.(*model.Summary); ok {
+			suppressedQueries, suppressions, baseline = s.SuppressedQueries, s.Suppressions, s.Baseline
+		}
 		summary, err := getSummary(body)
 		if err != nil {
 			return err
@@ -28,7 +36,15 @@ func PrintSarifReport(path, filename string, body interface{}) error {
 				auxGUID[x] = guid
 			}
 		}
+		for idx := range suppressedQueries {
+			x := sarifReport.BuildSarifSuppressedIssue(&suppressedQueries[idx], suppressions)
+			if _, ok := auxGUID[x]; len(x) > 0 && !ok {
+				auxID = append(auxID, x)
+				auxGUID[x] = sarifReport.GetGUIDFromRelationships(sarifReport.GetRuleIndex(suppressedQueries[idx].QueryID), x)
+			}
+		}
 		sarifReport.RebuildTaxonomies(auxID, auxGUID)
+		sarifReport.ApplyBaseline(baseline)
 		body = sarifReport
 	}
 
@@ -0,0 +1,17 @@
+package report
+
+import (
+	_ "embed" // Embed the JSON report schema
+)
+
+// JSONSchemaVersion is the version of the JSON Schema of the JSON report, the minor version is increased
+// when a field is added and the major version when a field is removed, renamed or changes type
+const JSONSchemaVersion = "1.1.0"
+
+//go:embed schema/results.json
+var jsonSchema string
+
+// GetJSONSchema returns the JSON Schema of the JSON report
+func GetJSONSchema() string {
+	return jsonSchema
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/test"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestGetJSONSchema_Version(t *testing.T) {
+	var schema struct {
+		ID         string `json:"$id"`
+		Properties struct {
+			SchemaVersion struct {
+				Const string `json:"const"`
+			} `json:"schema_version"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(GetJSONSchema()), &schema))
+	require.Equal(t, JSONSchemaVersion, schema.Properties.SchemaVersion.Const)
+	require.Contains(t, schema.ID, JSONSchemaVersion)
+}
+
+func TestGetJSONSchema_ValidatesJSONReport(t *testing.T) {
+	summaries := []model.Summary{test.SummaryMock, test.SummaryMockCritical, test.SummaryMockCWE, test.SimpleSummaryMockAsff}
+	dir := t.TempDir()
+	for idx := range summaries {
+		t.Run(fmt.Sprintf("JSON report %d", idx), func(t *testing.T) {
+			filename := fmt.Sprintf("schema%d", idx)
+			require.NoError(t, PrintJSONReport(dir, filename, summaries[idx]))
+			content, err := os.ReadFile(filepath.Join(dir, filename+".json"))
+			require.NoError(t, err)
+
+			result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(GetJSONSchema()), gojsonschema.NewBytesLoader(content))
+			require.NoError(t, err)
+			require.True(t, result.Valid(), "%v", result.Errors())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"sort"
+	"strings"
+)
+
+// Writer writes the report of a format in the output path, the body is the *model.Summary of the scan, or an
+// empty string when no file was scanned, and the filename has no extension
+type Writer interface {
+	Write(path, filename string, body interface{}) error
+}
+
+// WriterFunc adapts a report function to the Writer interface
+type WriterFunc func(path, filename string, body interface{}) error
+
+// Write writes the report with the report function
+func (f WriterFunc) Write(path, filename string, body interface{}) error {
+	return f(path, filename, body)
+}
+
+// writers are the registered report writers by format
+var writers = map[string]Writer{
+	"json":        WriterFunc(PrintJSONReport),
+	"sarif":       WriterFunc(PrintSarifReport),
+	"html":        WriterFunc(PrintHTMLReport),
+	"glsast":      WriterFunc(PrintGitlabSASTReport),
+	"pdf":         WriterFunc(PrintPdfReport),
+	"sonarqube":   WriterFunc(PrintSonarQubeReport),
+	"cyclonedx":   WriterFunc(PrintCycloneDxReport),
+	"junit":       WriterFunc(PrintJUnitReport),
+	"asff":        WriterFunc(PrintASFFReport),
+	"csv":         WriterFunc(PrintCSVReport),
+	"codeclimate": WriterFunc(PrintCodeClimateReport),
+	"graph":       WriterFunc(PrintGraphReport),
+	"owners":      WriterFunc(PrintOwnersReport),
+	"attestation": WriterFunc(PrintAttestationReport),
+	"bom":         WriterFunc(PrintBoMReport),
+}
+
+// RegisterWriter registers the writer of the format, replacing the writer previously registered with it, a
+// downstream build registers its writers before the console is executed so --report-formats accepts their format
+func RegisterWriter(format string, writer Writer) {
+	writers[strings.ToLower(format)] = writer
+}
+
+// LookupWriter returns the writer registered with the format
+func LookupWriter(format string) (Writer, bool) {
+	writer, ok := writers[strings.ToLower(format)]
+	return writer, ok
+}
+
+// ListFormats returns the registered formats, sorted
+func ListFormats() []string {
+	formats := make([]string, 0, len(writers))
+	for format := range writers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
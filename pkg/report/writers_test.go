@@ -0,0 +1,47 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type plainWriter struct{}
+
+func (w *plainWriter) Write(path, filename string, body interface{}) error {
+	return os.WriteFile(filepath.Join(path, filename+".txt"), []byte("plain"), 0600)
+}
+
+func TestRegisterWriter(t *testing.T) {
+	RegisterWriter("Plain", &plainWriter{})
+	t.Cleanup(func() { delete(writers, "plain") })
+
+	require.Contains(t, ListFormats(), "plain")
+	writer, ok := LookupWriter("PLAIN")
+	require.True(t, ok)
+
+	dir := t.TempDir()
+	require.NoError(t, writer.Write(dir, "results", ""))
+	require.FileExists(t, filepath.Join(dir, "results.txt"))
+
+	_, ok = LookupWriter("unknown")
+	require.False(t, ok)
+}
+
+func TestRegisterWriter_Override(t *testing.T) {
+	original := writers["csv"]
+	t.Cleanup(func() { writers["csv"] = original })
+
+	written := false
+	RegisterWriter("csv", WriterFunc(func(path, filename string, body interface{}) error {
+		written = true
+		return nil
+	}))
+
+	writer, ok := LookupWriter("csv")
+	require.True(t, ok)
+	require.NoError(t, writer.Write(t.TempDir(), "results", ""))
+	require.True(t, written)
+}
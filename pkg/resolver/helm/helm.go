@@ -58,7 +58,7 @@ func runInstall(args []string, client *action.Install,
 
 	excluded := getExcluded(chartRequested, cp)
 
-	chartRequested = setID(chartRequested)
+	chartRequested = setLineMarkers(chartRequested, new(int))
 
 	if instErr := checkIfInstallable(chartRequested); instErr != nil {
 		return nil, []string{}, instErr
@@ -96,37 +96,88 @@ func newClient() *action.Install {
 	return client
 }
 
-// setID will add auxiliary lines for each template as well as its dependencies
-func setID(chartReq *chart.Chart) *chart.Chart {
+// setLineMarkers will add the line markers to each template as well as its dependencies
+func setLineMarkers(chartReq *chart.Chart, nextID *int) *chart.Chart {
 	for _, temp := range chartReq.Templates {
-		temp = addID(temp)
-		if temp != nil {
-			continue
+		if isMarkedTemplate(temp.Name) {
+			temp.Data = addLineMarkers(temp.Data, *nextID)
+			*nextID++
 		}
 	}
 	for _, dep := range chartReq.Dependencies() {
-		dep = setID(dep)
-		if dep != nil {
-			continue
-		}
+		setLineMarkers(dep, nextID)
 	}
 	return chartReq
 }
 
-// addID will add auxiliary lines used to detect line
-// one for each "apiVersion:" where the id will be the line
-func addID(file *chart.File) *chart.File {
-	split := strings.Split(string(file.Data), "\n")
-	for i := 0; i < len(split); i++ {
-		if strings.Contains(split[i], "apiVersion:") {
-			split = append(split, "")
-			copy(split[i+1:], split[i:])
-			split[i] = fmt.Sprintf("# KICS_HELM_ID_%d:", i)
+// isMarkedTemplate returns true for the templates rendering manifests, the helpers defining the templates used by
+// include are left unmarked so the lines they render are mapped to the line of the include
+func isMarkedTemplate(name string) bool {
+	base := filepath.Base(name)
+	return !strings.HasPrefix(base, "_") && base != "NOTES.txt"
+}
+
+// addLineMarkers will append a line marker, as a YAML comment holding the template ID and the line number, to each
+// line of the template ending outside of an action, the document separators and the lines ending with a right
+// trim marker are left unmarked since the marker would break the split or stop the trim
+func addLineMarkers(data []byte, templateID int) []byte {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r", ""), "\n")
+	lexer := &templateLexer{}
+	for i, line := range lines {
+		lexer.scan(line)
+		trimmed := strings.TrimRight(line, " \t")
+		if lexer.inText() && trimmed != "" && trimmed != "---" && !strings.HasSuffix(trimmed, "-}}") {
+			lines[i] = fmt.Sprintf("%s %s%d_%d#", line, kicsHelmLine, templateID, i)
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// templateLexer keeps track of the actions, comments and raw strings spanning multiple lines of a template
+type templateLexer struct {
+	inAction  bool
+	inComment bool
+	quote     byte
+}
+
+func (l *templateLexer) inText() bool {
+	return !l.inAction && !l.inComment && l.quote == 0
+}
+
+func (l *templateLexer) scan(line string) {
+	for i := 0; i < len(line); i++ {
+		switch {
+		case l.inComment:
+			if strings.HasPrefix(line[i:], "*/") {
+				l.inComment = false
+				i++
+			}
+		case l.quote != 0:
+			if line[i] == '\\' && l.quote == '"' {
+				i++
+			} else if line[i] == l.quote {
+				l.quote = 0
+			}
+		case l.inAction:
+			if strings.HasPrefix(line[i:], "}}") {
+				l.inAction = false
+				i++
+			} else if line[i] == '"' || line[i] == '`' {
+				l.quote = line[i]
+			}
+		case strings.HasPrefix(line[i:], "{{"):
+			l.inAction = true
 			i++
+			if strings.HasPrefix(strings.TrimLeft(strings.TrimPrefix(line[i+1:], "-"), " "), "/*") {
+				l.inComment = true
+				i += strings.Index(line[i+1:], "/*") + 2
+			}
 		}
 	}
-	file.Data = []byte(strings.Join(split, "\n"))
-	return file
+	// a double quoted string can not span multiple lines
+	if l.quote == '"' {
+		l.quote = 0
+	}
 }
 
 // getExcluded will return all files rendered to be excluded from scan
@@ -20,17 +20,21 @@ type Resolver struct {
 
 // splitManifest keeps the information of the manifest splitted by source
 type splitManifest struct {
-	path       string
-	content    []byte
-	original   []byte
-	splitID    string
-	splitIDMap map[int]interface{}
+	path     string
+	content  []byte
+	original []byte
+	lineMap  []int
 }
 
 const (
-	kicsHelmID = "# KICS_HELM_ID_"
+	kicsHelmLine = "#KICS_HELM_LINE_"
+	// sourceHeaderLines is the number of lines of the source header of each rendered manifest
+	sourceHeaderLines = 2
 )
 
+// lineMarkerRegex matches the line markers added to the templates, with the template ID and the line number
+var lineMarkerRegex = regexp.MustCompile(` ?#KICS_HELM_LINE_(\d+)_(\d+)#`)
+
 // Resolve will render the passed helm chart and return its content ready for parsing
 func (r *Resolver) Resolve(filePath string) (model.ResolvedFiles, error) {
 	// handle panic during resolve process
@@ -59,8 +63,7 @@ func (r *Resolver) Resolve(filePath string) (model.ResolvedFiles, error) {
 			FileName:     origpath,
 			Content:      split.content,
 			OriginalData: split.original,
-			SplitID:      split.splitID,
-			IDInfo:       split.splitIDMap,
+			LineMap:      split.lineMap,
 		})
 	}
 	return rfiles, nil
@@ -91,45 +94,84 @@ func splitManifestYAML(template *release.Release) (*[]splitManifest, error) {
 	sources = updateName(sources, template.Chart, template.Chart.Name())
 	var splitedManifest []splitManifest
 	splitedSource := strings.Split(template.Manifest, "---") // split manifest by '---'
-	origData := toMap(sources)
+	origData, templateIDs := toMap(sources)
 	for _, splited := range splitedSource {
-		var lineID string
-		for _, line := range strings.Split(splited, "\n") {
-			if strings.Contains(line, kicsHelmID) {
-				lineID = line // get auxiliary line id
-				break
-			}
-		}
 		path := strings.Split(strings.TrimPrefix(splited, "\n# Source: "), "\n") // get source of split yaml
 		// ignore auxiliary files used to render chart
 		if path[0] == "" {
 			continue
 		}
-		if origData[filepath.FromSlash(path[0])] == nil {
+		original, ok := origData[filepath.FromSlash(path[0])]
+		if !ok {
+			continue
+		}
+		// the source header is blanked, keeping the lines, so it is not taken as part of the comments of the manifest
+		body := strings.TrimPrefix(strings.ReplaceAll(splited, "\r", ""), "\n# Source: "+path[0])
+		content, lineMap := mapLines("\n"+body, templateIDs[filepath.FromSlash(path[0])])
+		// ignore the splits left with the line markers only
+		if strings.TrimSpace(content) == "" {
 			continue
 		}
-		idMap, err := getIDMap(origData[filepath.FromSlash(path[0])])
-		if err != nil {
-			return nil, err
+		for i := 0; i < sourceHeaderLines && i < len(lineMap); i++ {
+			lineMap[i] = -1
 		}
 		splitedManifest = append(splitedManifest, splitManifest{
-			path:       path[0],
-			content:    []byte(strings.ReplaceAll(splited, "\r", "")),
-			original:   origData[filepath.FromSlash(path[0])], // get original data from template
-			splitID:    lineID,
-			splitIDMap: idMap,
+			path:     path[0],
+			content:  []byte(content),
+			original: original, // get original data from template
+			lineMap:  lineMap,
 		})
 	}
 	return &splitedManifest, nil
 }
 
-// toMap will convert to map original data having the path as it's key
-func toMap(files []*chart.File) map[string][]byte {
-	mapFiles := make(map[string][]byte)
+// toMap will convert to map original data having the path as it's key, without the line markers, as well as
+// the template IDs of the line markers
+func toMap(files []*chart.File) (origData map[string][]byte, templateIDs map[string]string) {
+	origData = make(map[string][]byte)
+	templateIDs = make(map[string]string)
 	for _, file := range files {
-		mapFiles[file.Name] = []byte(strings.ReplaceAll(string(file.Data), "\r", ""))
+		data := string(file.Data)
+		if marker := lineMarkerRegex.FindStringSubmatch(data); marker != nil {
+			templateIDs[file.Name] = marker[1]
+		}
+		origData[file.Name] = []byte(lineMarkerRegex.ReplaceAllString(strings.ReplaceAll(data, "\r", ""), ""))
+	}
+	return origData, templateIDs
+}
+
+// mapLines removes the line markers of the rendered split and maps each of its lines to the template line it
+// was rendered from, the lines rendered by an action spanning multiple lines, e.g. nindent or toYaml, take the
+// line of the marker closing them, and the lines that map to no template line are set to -1
+func mapLines(content, templateID string) (string, []int) {
+	lines := strings.Split(content, "\n")
+	lineMap := make([]int, len(lines))
+	pending := make([]int, 0)
+	last := -1
+	for i, line := range lines {
+		templateLine := -1
+		for _, marker := range lineMarkerRegex.FindAllStringSubmatch(line, -1) {
+			if marker[1] == templateID {
+				templateLine, _ = strconv.Atoi(marker[2])
+				break
+			}
+		}
+		lines[i] = lineMarkerRegex.ReplaceAllString(line, "")
+		if templateLine == -1 {
+			pending = append(pending, i)
+			continue
+		}
+		for _, pendingLine := range pending {
+			lineMap[pendingLine] = templateLine
+		}
+		pending = pending[:0]
+		lineMap[i] = templateLine
+		last = templateLine
+	}
+	for _, pendingLine := range pending {
+		lineMap[pendingLine] = last
 	}
-	return mapFiles
+	return strings.Join(lines, "\n"), lineMap
 }
 
 // updateName will update the templates name as well as its dependencies
@@ -147,36 +189,6 @@ func updateName(template []*chart.File, charts *chart.Chart, name string) []*cha
 	return template
 }
 
-// getIdMap will construct a map with ids with the corresponding lines as keys
-// for use in detector
-func getIDMap(originalData []byte) (map[int]interface{}, error) {
-	ids := make(map[int]interface{})
-	mapLines := make(map[int]int)
-	idHelm := -1
-	for line, stringLine := range strings.Split(string(originalData), "\n") {
-		if strings.Contains(stringLine, kicsHelmID) {
-			id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(stringLine, kicsHelmID), ":"))
-			if err != nil {
-				return nil, err
-			}
-			if idHelm == -1 {
-				idHelm = id
-				mapLines[line] = line
-			} else {
-				ids[idHelm] = mapLines
-				mapLines = make(map[int]int)
-				idHelm = id
-				mapLines[line] = line
-			}
-		} else if idHelm != -1 {
-			mapLines[line] = line
-		}
-	}
-	ids[idHelm] = mapLines
-
-	return ids, nil
-}
-
 func getPathSeparator(path string) string {
 	if matched, err := regexp.MatchString(`[a-zA-Z0-9_\/-]+(\[a-zA-Z0-9_\/-]+)*`, path); matched && err == nil {
 		return "/"
@@ -3,6 +3,7 @@ package helm
 import (
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Checkmarx/kics/pkg/model"
@@ -39,13 +40,10 @@ func TestHelm_Resolve(t *testing.T) { //nolint
 			want: model.ResolvedFiles{
 				File: []model.ResolvedHelm{
 					{
-						SplitID:  "# KICS_HELM_ID_0:",
 						FileName: filepath.FromSlash("../../../test/fixtures/test_helm/templates/service.yaml"),
-						IDInfo: map[int]interface{}{0: map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 4,
-							5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 10: 10, 11: 11, 12: 12, 13: 13, 14: 14, 15: 15, 16: 16}},
+						LineMap:  []int{-1, -1, 0, 1, 2, 3, 4, 5, 5, 5, 5, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 14, 14},
 						Content: []byte(`
-# Source: test_helm/templates/service.yaml
-# KICS_HELM_ID_0:
+
 apiVersion: v1
 kind: Service
 metadata:
@@ -67,8 +65,7 @@ spec:
     app.kubernetes.io/name: test_helm
     app.kubernetes.io/instance: kics-helm
 `),
-						OriginalData: []byte(`# KICS_HELM_ID_0:
-apiVersion: v1
+						OriginalData: []byte(`apiVersion: v1
 kind: Service
 metadata:
   name: {{ include "test_helm.fullname" . }}
@@ -106,12 +103,9 @@ spec:
 				File: []model.ResolvedHelm{
 					{
 						FileName: filepath.FromSlash("../../../test/fixtures/test_helm_subchart/templates/serviceaccount.yaml"),
-						SplitID:  "# KICS_HELM_ID_1:",
-						IDInfo: map[int]interface{}{1: map[int]int{1: 1, 2: 2, 3: 3, 4: 4,
-							5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 10: 10, 11: 11, 12: 12, 13: 13}},
+						LineMap:  []int{-1, -1, 1, 2, 3, 4, 5, 6, 6, 6, 6, 6, 6},
 						Content: []byte(`
-# Source: test_helm_subchart/templates/serviceaccount.yaml
-# KICS_HELM_ID_1:
+
 apiVersion: v1
 kind: ServiceAccount
 metadata:
@@ -124,7 +118,6 @@ metadata:
     app.kubernetes.io/managed-by: Helm
 `),
 						OriginalData: []byte(`{{- if .Values.serviceAccount.create -}}
-# KICS_HELM_ID_1:
 apiVersion: v1
 kind: ServiceAccount
 metadata:
@@ -140,12 +133,9 @@ metadata:
 					},
 					{
 						FileName: filepath.FromSlash("../../../test/fixtures/test_helm_subchart/charts/subchart/templates/service.yaml"),
-						SplitID:  "# KICS_HELM_ID_0:",
-						IDInfo: map[int]interface{}{0: map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 4,
-							5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 10: 10, 11: 11, 12: 12, 13: 13, 14: 14, 15: 15, 16: 16}},
+						LineMap:  []int{-1, -1, 0, 1, 2, 3, 4, 5, 5, 5, 5, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 14, 14},
 						Content: []byte(`
-# Source: test_helm_subchart/charts/subchart/templates/service.yaml
-# KICS_HELM_ID_0:
+
 apiVersion: v1
 kind: Service
 metadata:
@@ -167,8 +157,7 @@ spec:
     app.kubernetes.io/name: subchart
     app.kubernetes.io/instance: kics-helm
 `),
-						OriginalData: []byte(`# KICS_HELM_ID_0:
-apiVersion: v1
+						OriginalData: []byte(`apiVersion: v1
 kind: Service
 metadata:
   name: {{ include "subchart.fullname" . }}
@@ -206,3 +195,76 @@ spec:
 		})
 	}
 }
+
+func TestHelm_ResolveLineMapping(t *testing.T) {
+	res := &Resolver{}
+	got, err := res.Resolve(filepath.FromSlash("../../../test/fixtures/test_helm_mapping"))
+	require.NoError(t, err)
+	require.Len(t, got.File, 1)
+
+	// the rendered lines and the template lines they are expected to be mapped to
+	tests := map[string]string{
+		"apiVersion: apps/v1":                       "apiVersion: apps/v1",
+		"name: kics-helm-mapping":                   "name: {{ .Release.Name }}-mapping",
+		"app.kubernetes.io/instance: kics-helm":     `{{- include "test_helm_mapping.labels" . | nindent 4 }}`,
+		`checksum/test_helm_mapping: "true"`:        `{{ tpl .Values.annotation . }}: "true"`,
+		"replicas: 1":                               "replicas: 1",
+		"hostNetwork: true":                         "hostNetwork: true",
+		"runAsNonRoot: true":                        "{{- toYaml .Values.podSecurityContext | nindent 8 }}",
+		"- name: sidecar":                           "- name: {{ .name }}",
+		`image: "busybox:1.36"`:                     "image: {{ .image | quote }}",
+		"cpu: 50m":                                  "{{- toYaml .resources | nindent 12 }}",
+		"app.kubernetes.io/name: test_helm_mapping": `{{- include "test_helm_mapping.labels" . | nindent 4 }}`,
+	}
+
+	file := got.File[0]
+	renderedLines := strings.Split(string(file.Content), "\n")
+	templateLines := strings.Split(string(file.OriginalData), "\n")
+	require.Len(t, file.LineMap, len(renderedLines))
+	require.NotContains(t, string(file.Content), kicsHelmLine)
+	require.NotContains(t, string(file.OriginalData), kicsHelmLine)
+	for rendered, template := range tests {
+		t.Run(rendered, func(t *testing.T) {
+			for i, line := range renderedLines {
+				if strings.TrimSpace(line) == rendered {
+					require.GreaterOrEqual(t, file.LineMap[i], 0)
+					require.Equal(t, template, strings.TrimSpace(templateLines[file.LineMap[i]]))
+					return
+				}
+			}
+			t.Fatalf("rendered line %q not found", rendered)
+		})
+	}
+}
+
+func TestHelm_AddLineMarkers(t *testing.T) {
+	template := `---
+apiVersion: v1
+{{- if .Values.enabled -}}
+kind: ConfigMap
+{{- end }}
+{{/*
+a comment: {{ .Values.ignored }}
+*/}}
+data:
+  script: {{ ` + "`" + `echo
+    done` + "`" + ` }}
+
+  key: "{{ .Values.key }}"
+`
+	want := `---
+apiVersion: v1 #KICS_HELM_LINE_3_1#
+{{- if .Values.enabled -}}
+kind: ConfigMap #KICS_HELM_LINE_3_3#
+{{- end }} #KICS_HELM_LINE_3_4#
+{{/*
+a comment: {{ .Values.ignored }}
+*/}} #KICS_HELM_LINE_3_7#
+data: #KICS_HELM_LINE_3_8#
+  script: {{ ` + "`" + `echo
+    done` + "`" + ` }} #KICS_HELM_LINE_3_10#
+
+  key: "{{ .Values.key }}" #KICS_HELM_LINE_3_12#
+`
+	require.Equal(t, want, string(addLineMarkers([]byte(template), 3)))
+}
@@ -75,13 +75,10 @@ func TestResolver_Resolve(t *testing.T) {
 			want: model.ResolvedFiles{
 				File: []model.ResolvedHelm{
 					{
-						SplitID:  "# KICS_HELM_ID_0:",
 						FileName: filepath.FromSlash("../../test/fixtures/test_helm/templates/service.yaml"),
-						IDInfo: map[int]interface{}{0: map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 4,
-							5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 10: 10, 11: 11, 12: 12, 13: 13, 14: 14, 15: 15, 16: 16}},
+						LineMap:  []int{-1, -1, 0, 1, 2, 3, 4, 5, 5, 5, 5, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 14, 14},
 						Content: []byte(`
-# Source: test_helm/templates/service.yaml
-# KICS_HELM_ID_0:
+
 apiVersion: v1
 kind: Service
 metadata:
@@ -103,8 +100,7 @@ spec:
     app.kubernetes.io/name: test_helm
     app.kubernetes.io/instance: kics-helm
 `),
-						OriginalData: []byte(`# KICS_HELM_ID_0:
-apiVersion: v1
+						OriginalData: []byte(`apiVersion: v1
 kind: Service
 metadata:
   name: {{ include "test_helm.fullname" . }}
@@ -0,0 +1,156 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/Checkmarx/kics/assets"
+	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+// createAttestation gathers the scan environment described by the attestation report: the digests of
+// the scanned paths, of the queries and of the libraries, and the flags the scan was run with
+func (c *Client) createAttestation(extractedPaths provider.ExtractedPath) (*model.Attestation, error) {
+	attestation := &model.Attestation{
+		Subjects:       make([]model.AttestationSubject, 0, len(extractedPaths.ExtractionMap)),
+		QueriesPaths:   c.ScanParams.QueriesPath,
+		Flags:          c.ScanParams.Flags,
+		SigningKeyPath: c.ScanParams.AttestationKeyPath,
+	}
+
+	for localPath, extracted := range extractedPaths.ExtractionMap {
+		digest, err := digestPaths(localPath)
+		if err != nil {
+			return nil, err
+		}
+		attestation.Subjects = append(attestation.Subjects, model.AttestationSubject{
+			Name:   extracted.Path,
+			Digest: digest,
+		})
+	}
+	sort.Slice(attestation.Subjects, func(i, j int) bool {
+		return attestation.Subjects[i].Name < attestation.Subjects[j].Name
+	})
+
+	var err error
+	if attestation.QueriesDigest, err = digestPaths(c.ScanParams.QueriesPath...); err != nil {
+		return nil, err
+	}
+
+	// libraries given by the user are merged with the embedded ones so both are part of the digest
+	h := sha256.New()
+	for _, embedded := range assets.GetEmbeddedLibraries() {
+		if err := writeTreeDigest(h, embedded, "libraries", "embedded/"); err != nil {
+			return nil, err
+		}
+	}
+	if c.ScanParams.ChangedDefaultLibrariesPath {
+		attestation.LibrariesPath = c.ScanParams.LibrariesPath
+		if err := writePathDigest(h, c.ScanParams.LibrariesPath); err != nil {
+			return nil, err
+		}
+	}
+	attestation.LibrariesDigest = hex.EncodeToString(h.Sum(nil))
+
+	return attestation, nil
+}
+
+// digestPaths returns the sha256 digest of the files of the given paths
+func digestPaths(paths ...string) (string, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		if err := writePathDigest(h, path); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writePathDigest(h hash.Hash, path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return writeTreeDigest(h, os.DirFS(filepath.Dir(absPath)), filepath.Base(absPath), "")
+	}
+	return writeTreeDigest(h, os.DirFS(absPath), ".", "")
+}
+
+// writeTreeDigest writes the path, relative to root, and the sha256 digest of the content of each file
+// of the tree, in lexical order, so both renaming and changing a file change the digest, the files are
+// hashed concurrently
+func writeTreeDigest(h hash.Hash, fsys fs.FS, root, prefix string) error {
+	paths := make([]string, 0)
+	if err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	digests := make([][]byte, len(paths))
+	errs := make([]error, len(paths))
+	sem := make(chan struct{}, hashWorkers())
+	var wg sync.WaitGroup
+	for i := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			digests[i], errs[i] = digestFile(fsys, paths[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, path := range paths {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if _, err := fmt.Fprintf(h, "%s%s %x\n", prefix, path, digests[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func digestFile(fsys fs.FS, path string) ([]byte, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fileHash := sha256.New()
+	if _, err := io.Copy(fileHash, f); err != nil {
+		return nil, err
+	}
+	return fileHash.Sum(nil), nil
+}
+
+// hashWorkers returns the number of files hashed concurrently, the hashing is bound by the reads of the files
+func hashWorkers() int {
+	return 4 * runtime.GOMAXPROCS(0)
+}
@@ -0,0 +1,73 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DigestPaths(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_s3_bucket" "b" {}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "vars.tf"), []byte(`variable "name" {}`), 0600))
+
+	digest, err := digestPaths(dir)
+	require.NoError(t, err)
+	require.Len(t, digest, 64)
+
+	again, err := digestPaths(dir)
+	require.NoError(t, err)
+	require.Equal(t, digest, again, "the digest should not change when the files are the same")
+
+	fileDigest, err := digestPaths(filepath.Join(dir, "main.tf"))
+	require.NoError(t, err)
+	require.NotEqual(t, digest, fileDigest)
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "main.tf"), filepath.Join(dir, "bucket.tf")))
+	renamed, err := digestPaths(dir)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, renamed, "the digest should change when a file is renamed")
+
+	_, err = digestPaths(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func Test_CreateAttestation(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_s3_bucket" "b" {}`), 0600))
+
+	c := &Client{
+		ScanParams: &Parameters{
+			QueriesPath:        []string{dir},
+			AttestationKeyPath: "key.pem",
+			Flags:              map[string]string{"path": dir},
+		},
+	}
+	attestation, err := c.createAttestation(provider.ExtractedPath{
+		Path: []string{dir},
+		ExtractionMap: map[string]model.ExtractedPathObject{
+			dir: {Path: "git::https://github.com/Checkmarx/kics", LocalPath: false},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, attestation.Subjects, 1)
+	require.Equal(t, "git::https://github.com/Checkmarx/kics", attestation.Subjects[0].Name)
+	require.Equal(t, attestation.QueriesDigest, attestation.Subjects[0].Digest)
+	require.Len(t, attestation.LibrariesDigest, 64)
+	require.Empty(t, attestation.LibrariesPath)
+	require.Equal(t, "key.pem", attestation.SigningKeyPath)
+	require.Equal(t, map[string]string{"path": dir}, attestation.Flags)
+}
+
+func Test_IsReportRequested(t *testing.T) {
+	c := &Client{ScanParams: &Parameters{ReportFormats: []string{"json", "Attestation"}}}
+	require.True(t, c.isReportRequested("attestation"))
+	require.True(t, c.isReportRequested("json"))
+	require.False(t, c.isReportRequested("graph"))
+}
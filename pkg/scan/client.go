@@ -7,19 +7,37 @@ import (
 	"github.com/Checkmarx/kics/internal/storage"
 	"github.com/Checkmarx/kics/internal/tracker"
 	"github.com/Checkmarx/kics/pkg/descriptions"
+	descModel "github.com/Checkmarx/kics/pkg/descriptions/model"
+	"github.com/Checkmarx/kics/pkg/engine"
+	"github.com/Checkmarx/kics/pkg/engine/secrets"
+	"github.com/Checkmarx/kics/pkg/enrichment"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/owners"
 	consolePrinter "github.com/Checkmarx/kics/pkg/printer"
 	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/projects"
+	"github.com/Checkmarx/kics/pkg/report"
+	"github.com/Checkmarx/kics/pkg/webhook"
 	"github.com/rs/zerolog/log"
 )
 
 // Parameters represents all available scan parameters
 type Parameters struct {
+	Blame                       bool
+	Categories                  []string
 	CloudProvider               []string
+	CodeOwnersPath              string
+	ComposeProfiles             []string
+	DecisionLogPath             string
 	DisableFullDesc             bool
+	EncryptOutput               string
+	Enrichers                   []string
 	ExcludeCategories           []string
+	ExcludeGenerated            bool
 	ExcludePaths                []string
 	ExcludeQueries              []string
 	ExcludeResults              []string
+	ExcludeSearchKeys           []string
 	ExcludeSeverities           []string
 	ExperimentalQueries         bool
 	IncludeQueries              []string
@@ -28,17 +46,24 @@ type Parameters struct {
 	OutputPath                  string
 	Path                        []string
 	PayloadPath                 string
+	PayloadRedaction            string
 	PreviewLines                int
+	Projects                    string
+	PruneQueries                bool
+	RiskScore                   bool
 	QueriesPath                 []string
 	LibrariesPath               string
 	ReportFormats               []string
+	SarifBaselinePath           string
 	Platform                    []string
 	ExcludePlatform             []string
 	TerraformVarsPath           string
 	QueryExecTimeout            int
+	ScanTimeout                 time.Duration
 	LineInfoPayload             bool
 	DisableSecrets              bool
 	SecretsRegexesPath          string
+	SecretsEngine               string
 	ChangedDefaultQueryPath     bool
 	ChangedDefaultLibrariesPath bool
 	ScanID                      string
@@ -47,7 +72,23 @@ type Parameters struct {
 	OpenAPIResolveReferences    bool
 	ParallelScanFlag            int
 	MaxFileSizeFlag             int
+	MaxFilesFlag                int
+	Force                       bool
+	MaxResultsPerQuery          int
 	UseNewSeverities            bool
+	StrictParsing               bool
+	Offline                     bool
+	DescriptionsEndpoint        string
+	DescriptionsAuthHeader      string
+	VersionCheckEndpoint        string
+	VersionCheckAuthHeader      string
+	DisableVersionCheck         bool
+	AttestationKeyPath          string
+	HTMLPageSize                int
+	EmbedQueryDocs              bool
+	WebhookURL                  string
+	WebhookSecret               string
+	Flags                       map[string]string
 }
 
 // Client represents a scan client
@@ -59,6 +100,46 @@ type Client struct {
 	ExcludeResultsMap map[string]bool
 	Printer           *consolePrinter.Printer
 	ProBarBuilder     *progress.PbBuilder
+	ownership         *owners.Ownership
+	keyExclusions     []engine.KeyExclusion
+	encryption        *report.Encryption
+	enrichers         []enrichment.Enricher
+	secretsEngine     string
+	projects          *projects.Manifest
+	webhook           *webhook.Webhook
+	guardedFiles      []model.GuardedFile
+	discovery         *model.DiscoveryPerformance
+	payloadRedaction  string
+}
+
+// descriptionsClient creates the client requesting the descriptions and version check endpoints
+func (p *Parameters) descriptionsClient() *descriptions.Client {
+	return descriptions.NewClient(
+		descModel.Endpoint{
+			BaseURL:    p.DescriptionsEndpoint,
+			AuthHeader: p.DescriptionsAuthHeader,
+		},
+		descModel.Endpoint{
+			BaseURL:    p.VersionCheckEndpoint,
+			AuthHeader: p.VersionCheckAuthHeader,
+		},
+	)
+}
+
+// maxFileSize returns the maximum size in MB of the files to scan, --force sets no limit
+func (p *Parameters) maxFileSize() int {
+	if p.Force {
+		return -1
+	}
+	return p.MaxFileSizeFlag
+}
+
+// maxFiles returns the maximum number of files to scan, --force sets no limit
+func (p *Parameters) maxFiles() int {
+	if p.Force {
+		return 0
+	}
+	return p.MaxFilesFlag
 }
 
 // NewClient initializes the client with all the required parameters
@@ -69,7 +150,74 @@ func NewClient(params *Parameters, proBarBuilder *progress.PbBuilder, customPrin
 		return nil, err
 	}
 
-	descriptions.CheckVersion(t)
+	if params.Offline {
+		if err := checkOffline(params); err != nil {
+			return nil, err
+		}
+	}
+	// the version is left unknown when it is not checked, so no update message is printed
+	if !params.Offline && !params.DisableVersionCheck {
+		descriptions.CheckVersion(params.descriptionsClient(), t)
+	}
+
+	// the ownership file given by the user is loaded before the scan so an invalid file fails fast
+	var ownership *owners.Ownership
+	if params.CodeOwnersPath != "" {
+		if ownership, err = owners.Load(params.CodeOwnersPath); err != nil {
+			log.Err(err).Msgf("Failed to load the ownership file %s", params.CodeOwnersPath)
+			return nil, err
+		}
+	}
+
+	keyExclusions, err := engine.ParseKeyExclusions(params.ExcludeSearchKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// the encryption is parsed before the scan so an invalid recipient or key file fails fast
+	var encryption *report.Encryption
+	if params.EncryptOutput != "" {
+		if encryption, err = report.ParseEncryption(params.EncryptOutput); err != nil {
+			return nil, err
+		}
+	}
+
+	enrichers, err := enrichment.Create(params.Enrichers)
+	if err != nil {
+		return nil, err
+	}
+
+	secretsEngine, err := secrets.ParseEngine(params.SecretsEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadRedaction, err := parsePayloadRedaction(params.PayloadRedaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project manifest is loaded before the scan so an invalid manifest fails fast
+	var projectManifest *projects.Manifest
+	if params.Projects != "" && params.Projects != projects.Directories {
+		if projectManifest, err = projects.Load(params.Projects); err != nil {
+			log.Err(err).Msgf("Failed to load the project manifest %s", params.Projects)
+			return nil, err
+		}
+	}
+
+	var scanWebhook *webhook.Webhook
+	if params.WebhookURL != "" {
+		if scanWebhook, err = webhook.New(params.WebhookURL, params.WebhookSecret); err != nil {
+			return nil, err
+		}
+	} else if params.WebhookSecret != "" {
+		log.Warn().Msg("Ignoring the webhook secret since no webhook url is set")
+	}
+
+	if params.Force {
+		log.Warn().Msg("The file size and file count guards are disabled by --force")
+	}
 
 	store := storage.NewMemoryStorage()
 
@@ -82,6 +230,14 @@ func NewClient(params *Parameters, proBarBuilder *progress.PbBuilder, customPrin
 		Storage:           store,
 		ExcludeResultsMap: excludeResultsMap,
 		Printer:           customPrint,
+		ownership:         ownership,
+		keyExclusions:     keyExclusions,
+		encryption:        encryption,
+		enrichers:         enrichers,
+		secretsEngine:     secretsEngine,
+		projects:          projectManifest,
+		webhook:           scanWebhook,
+		payloadRedaction:  payloadRedaction,
 	}, nil
 }
 
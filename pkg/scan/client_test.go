@@ -1,6 +1,8 @@
 package scan
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,3 +31,52 @@ func Test_ClientError(t *testing.T) {
 	require.Nil(t, client)
 	require.Error(t, err)
 }
+
+func Test_ClientCodeOwnersPath(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "owners.yaml")
+	require.NoError(t, os.WriteFile(validPath, []byte("\"*.tf\": \"@infra\"\n"), 0644))
+	invalidPath := filepath.Join(dir, "owners.json")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("{"), 0644))
+
+	tests := []struct {
+		name           string
+		codeOwnersPath string
+		wantErr        bool
+	}{
+		{
+			name:           "valid ownership map",
+			codeOwnersPath: validPath,
+		},
+		{
+			name:           "missing ownership file",
+			codeOwnersPath: filepath.Join(dir, "CODEOWNERS"),
+			wantErr:        true,
+		},
+		{
+			name:           "unparsable ownership map",
+			codeOwnersPath: invalidPath,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				PreviewLines:        3,
+				ExcludeResults:      []string{},
+				CodeOwnersPath:      tt.codeOwnersPath,
+				DisableVersionCheck: true,
+			}
+
+			client, err := NewClient(params, nil, nil)
+			if tt.wantErr {
+				require.Nil(t, client)
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, client.ownership)
+		})
+	}
+}
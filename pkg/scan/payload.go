@@ -0,0 +1,60 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/scanner"
+	"github.com/rs/zerolog/log"
+)
+
+// GeneratePayload parses the scan paths without executing any query and returns
+// the documents the engine would feed to the queries as input, redacted by the payload redaction policy
+func (c *Client) GeneratePayload(ctx context.Context) (model.Documents, error) {
+	extractedPaths, err := c.extractAndAnalyzePaths(ctx, provider.ExtractedPath{}, provider.ExtractedPath{})
+	if err != nil {
+		log.Err(err)
+		return model.Documents{}, err
+	}
+
+	if len(extractedPaths.Path) == 0 {
+		return model.Documents{Documents: []model.Document{}}, nil
+	}
+
+	querySource := source.NewFilesystemSource(
+		c.ScanParams.QueriesPath,
+		c.ScanParams.Platform,
+		c.ScanParams.CloudProvider,
+		c.ScanParams.LibrariesPath,
+		c.ScanParams.ExperimentalQueries)
+
+	services, err := c.createService(
+		nil,
+		nil,
+		extractedPaths.Path,
+		c.Tracker,
+		c.Storage,
+		querySource,
+	)
+	if err != nil {
+		log.Err(err)
+		return model.Documents{}, err
+	}
+
+	if err := scanner.PrepareSources(ctx, c.ScanParams.ScanID, c.ScanParams.OpenAPIResolveReferences, services); err != nil {
+		log.Err(err)
+		return model.Documents{}, err
+	}
+
+	files, err := c.Storage.GetFiles(ctx, c.ScanParams.ScanID)
+	if err != nil {
+		log.Err(err)
+		return model.Documents{}, err
+	}
+
+	documents := files.Combine(c.ScanParams.LineInfoPayload)
+	documents.Groups = files.Groups()
+	return c.redactPayload(documents)
+}
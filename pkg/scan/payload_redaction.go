@@ -0,0 +1,184 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Checkmarx/kics/pkg/engine/secrets"
+	"github.com/Checkmarx/kics/pkg/model"
+)
+
+const (
+	// PayloadRedactionSecrets masks the values matched by the secrets rules, the default policy
+	PayloadRedactionSecrets = "secrets"
+	// PayloadRedactionFullValues replaces every value by a placeholder of its type, the structure is kept
+	PayloadRedactionFullValues = "full-values"
+
+	secretMask        = "<SECRET-MASKED-ON-PURPOSE>"
+	redactedValueMask = "<VALUE-REDACTED-ON-PURPOSE>"
+)
+
+// parsePayloadRedaction returns the redaction policy of the payload, the secrets are masked when no policy is set,
+// there is no policy exporting the payload without masking the secrets
+func parsePayloadRedaction(value string) (string, error) {
+	policy := strings.ToLower(strings.TrimSpace(value))
+	switch policy {
+	case "":
+		return PayloadRedactionSecrets, nil
+	case PayloadRedactionSecrets, PayloadRedactionFullValues:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid payload redaction %s, expected %s or %s",
+			value, PayloadRedactionSecrets, PayloadRedactionFullValues)
+	}
+}
+
+// payloadRedactor copies the documents of the payload with their values redacted, the documents evaluated by the
+// queries are never modified
+type payloadRedactor struct {
+	fullValues bool
+	allowRules []secrets.AllowRule
+	rules      []secrets.RegexQuery
+}
+
+// redactPayload returns the documents to export with the redaction policy of the client applied
+func (c *Client) redactPayload(documents model.Documents) (model.Documents, error) {
+	allowRules, rules, err := loadSecretsRules(c.ScanParams.SecretsRegexesPath)
+	if err != nil {
+		return model.Documents{}, err
+	}
+	redactor := &payloadRedactor{
+		fullValues: c.payloadRedaction == PayloadRedactionFullValues,
+		allowRules: allowRules,
+		rules:      rules,
+	}
+
+	redacted := model.Documents{Documents: make([]model.Document, 0, len(documents.Documents))}
+	for _, document := range documents.Documents {
+		redacted.Documents = append(redacted.Documents, redactor.document(document))
+	}
+	if documents.Groups != nil {
+		redacted.Groups = redactor.groups(documents.Groups)
+	}
+	return redacted, nil
+}
+
+// document redacts a document, the id and the file of the document are kept
+func (r *payloadRedactor) document(document model.Document) model.Document {
+	redacted := make(model.Document, len(document))
+	for key, value := range document {
+		if key == "id" || key == "file" {
+			redacted[key] = value
+			continue
+		}
+		redacted[key] = r.value(key, "", value)
+	}
+	return redacted
+}
+
+func (r *payloadRedactor) groups(groups *model.DocumentGroups) *model.DocumentGroups {
+	redacted := &model.DocumentGroups{
+		TerraformModules:     make(map[string]*model.TerraformModule, len(groups.TerraformModules)),
+		KubernetesNamespaces: make(map[string]*model.KubernetesNamespace, len(groups.KubernetesNamespaces)),
+	}
+	for dir, module := range groups.TerraformModules {
+		redacted.TerraformModules[dir] = &model.TerraformModule{
+			Documents:  module.Documents,
+			Resource:   r.object("resource", module.Resource),
+			Data:       r.object("data", module.Data),
+			Module:     r.object("module", module.Module),
+			Variable:   r.object("variable", module.Variable),
+			Output:     r.object("output", module.Output),
+			References: module.References,
+		}
+	}
+	for name, namespace := range groups.KubernetesNamespaces {
+		resources := make(map[string][]model.Document, len(namespace.Resources))
+		for kind, documents := range namespace.Resources {
+			resources[kind] = make([]model.Document, 0, len(documents))
+			for _, document := range documents {
+				resources[kind] = append(resources[kind], r.document(document))
+			}
+		}
+		redacted.KubernetesNamespaces[name] = &model.KubernetesNamespace{
+			Documents: namespace.Documents,
+			Resources: resources,
+		}
+	}
+	return redacted
+}
+
+func (r *payloadRedactor) object(objectKey string, object map[string]interface{}) map[string]interface{} {
+	if object == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		// the line information is kept, so the payload can still be used to debug the lines of the results
+		if strings.HasPrefix(key, "_kics_") {
+			redacted[key] = value
+			continue
+		}
+		redacted[key] = r.value(key, objectKey, value)
+	}
+	return redacted
+}
+
+// value redacts the value of a key of an object, the elements of an array are redacted as values of the key of the
+// array and the key of the object is kept since it often names the value, e.g. the name of a variable
+func (r *payloadRedactor) value(key, objectKey string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.object(key, v)
+	case model.Document:
+		return model.Document(r.object(key, v))
+	case []interface{}:
+		redacted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			redacted = append(redacted, r.value(key, objectKey, item))
+		}
+		return redacted
+	case string:
+		if r.fullValues {
+			return redactedValueMask
+		}
+		if r.isSecret(key, v) || (objectKey != "" && r.isSecret(objectKey, v)) {
+			return secretMask
+		}
+		return v
+	case bool:
+		if r.fullValues {
+			return false
+		}
+		return v
+	case nil:
+		return nil
+	}
+
+	if kind := reflect.ValueOf(value).Kind(); kind >= reflect.Int && kind <= reflect.Float64 {
+		if r.fullValues {
+			return 0
+		}
+		return value
+	}
+	// the values of other types are redacted in their JSON form, so none is exported unredacted
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var generic interface{}
+	if err := json.Unmarshal(content, &generic); err != nil {
+		return nil
+	}
+	return r.value(key, objectKey, generic)
+}
+
+// isSecret returns true when the secrets rules match the value written as a JSON attribute of its key
+func (r *payloadRedactor) isSecret(key, value string) bool {
+	line := fmt.Sprintf(`"%s": "%s"`, key, value)
+	lines := []model.CodeLine{{Line: line}}
+	hideSecret(&lines, &r.allowRules, &r.rules)
+	return lines[0].Line != line
+}
@@ -0,0 +1,129 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func payloadDocuments() model.Documents {
+	return model.Documents{
+		Documents: []model.Document{
+			{
+				"id":   "0c7b2b6e-6f0b-4a36-9d35-d2b1a8c3f1b5",
+				"file": "main.tf",
+				"variable": map[string]interface{}{
+					"db_password": map[string]interface{}{"default": "s3cr3tP4ssw0rd!"},
+				},
+				"resource": map[string]interface{}{
+					"aws_db_instance": map[string]interface{}{
+						"db": map[string]interface{}{
+							"password":            "s3cr3tP4ssw0rd!",
+							"allocated_storage":   20,
+							"publicly_accessible": true,
+							"tags":                map[string]interface{}{"team": "payments"},
+							"security_groups":     []interface{}{"sg-0123"},
+							"_kics_lines":         map[string]*model.LineObject{"_kics_password": {Line: 6}},
+						},
+					},
+				},
+			},
+		},
+		Groups: &model.DocumentGroups{
+			TerraformModules: map[string]*model.TerraformModule{
+				".": {
+					Documents: []string{"0c7b2b6e-6f0b-4a36-9d35-d2b1a8c3f1b5"},
+					Variable: map[string]interface{}{
+						"db_password": map[string]interface{}{"default": "s3cr3tP4ssw0rd!"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_parsePayloadRedaction(t *testing.T) {
+	policy, err := parsePayloadRedaction("")
+	require.NoError(t, err)
+	require.Equal(t, PayloadRedactionSecrets, policy)
+
+	policy, err = parsePayloadRedaction("Full-Values")
+	require.NoError(t, err)
+	require.Equal(t, PayloadRedactionFullValues, policy)
+
+	_, err = parsePayloadRedaction("partial")
+	require.Error(t, err)
+
+	// the secrets are always masked
+	_, err = parsePayloadRedaction("none")
+	require.Error(t, err)
+}
+
+func Test_RedactPayload(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		wantPassword  interface{}
+		wantVariable  interface{}
+		wantTeam      interface{}
+		wantStorage   interface{}
+		wantPublic    interface{}
+		wantGroupItem interface{}
+	}{
+		{
+			name:          "secrets",
+			policy:        PayloadRedactionSecrets,
+			wantPassword:  secretMask,
+			wantVariable:  secretMask,
+			wantTeam:      "payments",
+			wantStorage:   20,
+			wantPublic:    true,
+			wantGroupItem: secretMask,
+		},
+		{
+			name:          "full_values",
+			policy:        PayloadRedactionFullValues,
+			wantPassword:  redactedValueMask,
+			wantVariable:  redactedValueMask,
+			wantTeam:      redactedValueMask,
+			wantStorage:   0,
+			wantPublic:    false,
+			wantGroupItem: redactedValueMask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents := payloadDocuments()
+			c := &Client{ScanParams: &Parameters{}, payloadRedaction: tt.policy}
+
+			got, err := c.redactPayload(documents)
+			require.NoError(t, err)
+
+			document := got.Documents[0]
+			require.Equal(t, "main.tf", document["file"])
+			require.Equal(t, "0c7b2b6e-6f0b-4a36-9d35-d2b1a8c3f1b5", document["id"])
+
+			instances := document["resource"].(map[string]interface{})["aws_db_instance"].(map[string]interface{})
+			db := instances["db"].(map[string]interface{})
+			require.Equal(t, tt.wantPassword, db["password"])
+			require.Equal(t, tt.wantTeam, db["tags"].(map[string]interface{})["team"])
+			require.Equal(t, tt.wantStorage, db["allocated_storage"])
+			require.Equal(t, tt.wantPublic, db["publicly_accessible"])
+			require.Len(t, db["security_groups"], 1)
+			require.Equal(t, 6, db["_kics_lines"].(map[string]*model.LineObject)["_kics_password"].Line)
+
+			variable := document["variable"].(map[string]interface{})["db_password"].(map[string]interface{})
+			require.Equal(t, tt.wantVariable, variable["default"])
+
+			module := got.Groups.TerraformModules["."]
+			require.Equal(t, []string{"0c7b2b6e-6f0b-4a36-9d35-d2b1a8c3f1b5"}, module.Documents)
+			require.Equal(t, tt.wantGroupItem, module.Variable["db_password"].(map[string]interface{})["default"])
+
+			// the documents evaluated by the queries are not modified
+			original := documents.Documents[0]["resource"].(map[string]interface{})["aws_db_instance"].(map[string]interface{})
+			require.Equal(t, "s3cr3tP4ssw0rd!", original["db"].(map[string]interface{})["password"])
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package scan
+
+import (
+	"context"
+	"testing"
+
+	consolePrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GeneratePayload(t *testing.T) {
+	tests := []struct {
+		name              string
+		scanParams        Parameters
+		expectedDocuments int
+		expectedLines     bool
+	}{
+		{
+			name: "should generate payload from cloudformation file",
+			scanParams: Parameters{
+				Path:            []string{"./../../test/fixtures/test_scan_cloudfront_logging_disabled/test/positive1.yaml"},
+				Platform:        []string{""},
+				ExcludePlatform: []string{""},
+				PreviewLines:    3,
+				MaxFileSizeFlag: 100,
+				ScanID:          "console",
+			},
+			expectedDocuments: 1,
+		},
+		{
+			name: "should generate payload with line information",
+			scanParams: Parameters{
+				Path:            []string{"./../../test/fixtures/test_scan_cloudfront_logging_disabled/test/positive1.yaml"},
+				Platform:        []string{"CloudFormation"},
+				ExcludePlatform: []string{""},
+				PreviewLines:    3,
+				MaxFileSizeFlag: 100,
+				LineInfoPayload: true,
+				ScanID:          "console",
+			},
+			expectedDocuments: 1,
+			expectedLines:     true,
+		},
+		{
+			name: "should generate empty payload when no platform matches",
+			scanParams: Parameters{
+				Path:            []string{"./../../test/fixtures/test_scan_cloudfront_logging_disabled/test/positive1.yaml"},
+				Platform:        []string{"Dockerfile"},
+				ExcludePlatform: []string{""},
+				PreviewLines:    3,
+				MaxFileSizeFlag: 100,
+				ScanID:          "console",
+			},
+			expectedDocuments: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(&tt.scanParams, &progress.PbBuilder{}, &consolePrinter.Printer{})
+			require.NoError(t, err)
+
+			documents, err := c.GeneratePayload(context.Background())
+			require.NoError(t, err)
+			require.Len(t, documents.Documents, tt.expectedDocuments)
+
+			for _, document := range documents.Documents {
+				require.Contains(t, document, "id")
+				require.Contains(t, document, "file")
+				_, hasLines := document["_kics_lines"]
+				require.Equal(t, tt.expectedLines, hasLines)
+			}
+		})
+	}
+}
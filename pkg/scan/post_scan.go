@@ -1,6 +1,7 @@
 package scan
 
 import (
+	"context"
 	_ "embed" // Embed kics CLI img and scan-flags
 	"os"
 	"path/filepath"
@@ -9,12 +10,19 @@ import (
 	"time"
 
 	consoleHelpers "github.com/Checkmarx/kics/internal/console/helpers"
+	"github.com/Checkmarx/kics/pkg/blame"
 	"github.com/Checkmarx/kics/pkg/descriptions"
 	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/engine/source"
+	"github.com/Checkmarx/kics/pkg/enrichment"
+	"github.com/Checkmarx/kics/pkg/generated"
 	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/owners"
 	consolePrinter "github.com/Checkmarx/kics/pkg/printer"
 	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/projects"
 	"github.com/Checkmarx/kics/pkg/report"
+	reportModel "github.com/Checkmarx/kics/pkg/report/model"
 	"github.com/rs/zerolog/log"
 )
 
@@ -36,10 +44,19 @@ func (c *Client) getSummary(results []model.Vulnerability, end time.Time, pathPa
 		End:   end,
 	}
 
-	if c.ScanParams.DisableFullDesc {
+	summary.ParseFailures = model.CreateParseFailures(c.Tracker.ParseFailures, pathParameters.PathExtractionMap)
+	summary.FailedQueries = model.CreateFailedQueries(c.Tracker.FailedQueries, pathParameters, c.reproduceParameters())
+	c.setSkipped(&summary, pathParameters)
+	c.setGuarded(&summary, pathParameters)
+	c.setPerformance(&summary)
+
+	switch {
+	case c.ScanParams.DisableFullDesc:
 		log.Warn().Msg("Skipping descriptions because provided disable flag is set")
-	} else {
-		err := descriptions.RequestAndOverrideDescriptions(&summary)
+	case c.ScanParams.Offline:
+		log.Info().Msg("Skipping descriptions because offline mode is enabled")
+	default:
+		err := descriptions.RequestAndOverrideDescriptions(c.ScanParams.descriptionsClient(), &summary)
 		if err != nil {
 			log.Warn().Msgf("Unable to get descriptions: %s", err)
 			log.Warn().Msgf("Using default descriptions")
@@ -49,6 +66,101 @@ func (c *Client) getSummary(results []model.Vulnerability, end time.Time, pathPa
 	return summary
 }
 
+// setSkipped marks the summary as partial when the scan timeout expired before all files were
+// parsed and all queries executed, a file is only skipped when no parser has read it
+func (c *Client) setSkipped(summary *model.Summary, pathParameters model.PathParameters) {
+	skippedFiles := make([]string, 0, len(c.Tracker.BagOfFilesSkipped))
+	for file := range c.Tracker.BagOfFilesSkipped {
+		if _, found := c.Tracker.BagOfFilesFound[file]; !found {
+			skippedFiles = append(skippedFiles, file)
+		}
+	}
+	if len(skippedFiles) == 0 && len(c.Tracker.SkippedQueries) == 0 {
+		return
+	}
+
+	summary.Partial = true
+	summary.SkippedFiles = model.CreateSkippedFiles(skippedFiles, pathParameters.PathExtractionMap)
+	// queries of platforms shared by multiple parsers are skipped once by each of them
+	seenQueries := make(map[model.SkippedQuery]bool, len(c.Tracker.SkippedQueries))
+	for _, query := range c.Tracker.SkippedQueries {
+		if !seenQueries[query] {
+			seenQueries[query] = true
+			summary.SkippedQueries = append(summary.SkippedQueries, query)
+		}
+	}
+	sort.Slice(summary.SkippedQueries, func(i, j int) bool {
+		return summary.SkippedQueries[i].QueryName < summary.SkippedQueries[j].QueryName
+	})
+	log.Warn().Msgf("Scan timeout of %s expired, %d queries and %d files were skipped and the results are partial",
+		c.ScanParams.ScanTimeout, len(summary.SkippedQueries), len(summary.SkippedFiles))
+}
+
+// setGuarded reports the files left out of the scan by the file size and file count guards
+func (c *Client) setGuarded(summary *model.Summary, pathParameters model.PathParameters) {
+	if len(c.guardedFiles) == 0 {
+		return
+	}
+	summary.GuardedFiles = model.CreateGuardedFiles(c.guardedFiles, pathParameters.PathExtractionMap)
+	log.Warn().Msgf("%d files were not scanned because of the --max-file-size and --max-files guards, "+
+		"use --force to scan them", len(summary.GuardedFiles))
+}
+
+// reproduceParameters returns the parameters of the scan the failed queries are reproduced with, the custom queries
+// and libraries, which are the most likely to fail, and the scanned types
+func (c *Client) reproduceParameters() *model.ReproduceParameters {
+	reproduce := &model.ReproduceParameters{}
+	if c.ScanParams.ChangedDefaultQueryPath {
+		reproduce.QueriesPath = c.ScanParams.QueriesPath
+	}
+	if c.ScanParams.ChangedDefaultLibrariesPath {
+		reproduce.LibrariesPath = c.ScanParams.LibrariesPath
+	}
+	for _, platform := range c.ScanParams.Platform {
+		if platform != "" {
+			reproduce.Types = append(reproduce.Types, platform)
+		}
+	}
+	return reproduce
+}
+
+// setPerformance reports the timing of the discovery of the files to scan
+func (c *Client) setPerformance(summary *model.Summary) {
+	if c.discovery == nil {
+		return
+	}
+	summary.Performance = &model.Performance{Discovery: c.discovery}
+}
+
+// isReportRequested checks if the report format was requested, formats are case insensitive as in the flag validation
+func (c *Client) isReportRequested(format string) bool {
+	for _, reportFormat := range c.ScanParams.ReportFormats {
+		if strings.EqualFold(reportFormat, format) {
+			return true
+		}
+	}
+	return false
+}
+
+// setOwners sets the owner of each result using the ownership file given by the user or,
+// when none is given, the CODEOWNERS file of the scanned paths, which only logs a warning when
+// it can not be loaded since the user did not ask for it
+func (c *Client) setOwners(summary *model.Summary, paths []string) {
+	ownership := c.ownership
+	if ownership == nil {
+		ownershipPath := owners.Find(paths)
+		if ownershipPath == "" {
+			return
+		}
+		var err error
+		if ownership, err = owners.Load(ownershipPath); err != nil {
+			log.Warn().Msgf("Failed to load the CODEOWNERS file %s, the results owners are not set: %s", ownershipPath, err)
+			return
+		}
+	}
+	ownership.SetOwners(summary)
+}
+
 func (c *Client) resolveOutputs(
 	summary *model.Summary,
 	documents model.Documents,
@@ -62,21 +174,50 @@ func (c *Client) resolveOutputs(
 		return err
 	}
 	if c.ScanParams.PayloadPath != "" {
-		if err := report.ExportJSONReport(
-			filepath.Dir(c.ScanParams.PayloadPath),
-			filepath.Base(c.ScanParams.PayloadPath),
-			documents,
-		); err != nil {
+		payload, err := c.redactPayload(documents)
+		if err != nil {
+			return err
+		}
+		if err := c.writeOutput(filepath.Dir(c.ScanParams.PayloadPath), func(path string) error {
+			return report.ExportJSONReport(path, filepath.Base(c.ScanParams.PayloadPath), payload)
+		}); err != nil {
 			return err
 		}
 	}
 
-	return printOutput(
-		c.ScanParams.OutputPath,
-		c.ScanParams.OutputName,
-		summary, c.ScanParams.ReportFormats,
-		proBarBuilder,
-	)
+	if c.ScanParams.OutputPath == "" {
+		return nil
+	}
+	return c.writeOutput(c.ScanParams.OutputPath, func(path string) error {
+		return printOutput(
+			path,
+			c.ScanParams.OutputName,
+			summary, c.ScanParams.ReportFormats,
+			proBarBuilder,
+		)
+	})
+}
+
+// writeOutput writes the files in the output path or, when the output is encrypted, in a staging directory
+// whose files are encrypted to the output path so the reports are never written in clear in the output path
+func (c *Client) writeOutput(outputPath string, write func(path string) error) error {
+	if c.encryption == nil {
+		return write(outputPath)
+	}
+	stagingDir, err := os.MkdirTemp("", "kics-output-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			log.Warn().Msgf("Failed to remove the staging directory %s: %s", stagingDir, err)
+		}
+	}()
+
+	if err := write(stagingDir); err != nil {
+		return err
+	}
+	return report.EncryptReports(stagingDir, outputPath, c.encryption)
 }
 
 func printOutput(outputPath, filename string, body interface{}, formats []string, proBarBuilder progress.PbBuilder) error {
@@ -94,6 +235,80 @@ func printOutput(outputPath, filename string, body interface{}, formats []string
 	return err
 }
 
+// setSuppressed sets the suppressed results of the summary, they are only kept for the SARIF report
+func (c *Client) setSuppressed(summary *model.Summary, scanResults *Results, pathParameters model.PathParameters) {
+	if len(scanResults.Suppressed) == 0 {
+		return
+	}
+	suppressed := model.CreateSummary(model.Counters{}, scanResults.Suppressed, c.ScanParams.ScanID,
+		pathParameters.PathExtractionMap, c.Tracker.Version)
+	summary.SuppressedQueries = suppressed.Queries
+	summary.Suppressions = scanResults.Suppressions
+}
+
+// setBaseline sets the results of the previous scan the SARIF results are compared with
+func (c *Client) setBaseline(summary *model.Summary) error {
+	if c.ScanParams.SarifBaselinePath == "" {
+		return nil
+	}
+	baseline, err := reportModel.ReadBaseline(c.ScanParams.SarifBaselinePath)
+	if err != nil {
+		log.Err(err).Msgf("Failed to read the baseline %s", c.ScanParams.SarifBaselinePath)
+		return err
+	}
+	summary.Baseline = baseline
+	return nil
+}
+
+// setQueryDocs sets the documentation of the queries with results when it is embedded in the HTML or PDF report:
+// the description and the reference of the query, the CIS rationale when the full descriptions were requested,
+// the distinct expected values of the results and the compliant samples of the query tests
+func (c *Client) setQueryDocs(summary *model.Summary) {
+	if !c.ScanParams.EmbedQueryDocs || (!c.isReportRequested("html") && !c.isReportRequested("pdf")) {
+		return
+	}
+	ids := make(map[string]bool, len(summary.Queries))
+	for idx := range summary.Queries {
+		ids[summary.Queries[idx].QueryID] = true
+	}
+	examples := source.ReadQueryExamples(c.ScanParams.QueriesPath, ids)
+
+	summary.QueryDocs = make(map[string]*model.QueryDocumentation, len(summary.Queries))
+	for idx := range summary.Queries {
+		query := &summary.Queries[idx]
+		docs := &model.QueryDocumentation{
+			Description: query.Description,
+			Rationale:   query.CISRationaleText,
+			Reference:   query.QueryURI,
+			Remediation: make([]string, 0),
+			Examples:    examples[query.QueryID],
+		}
+		expected := make(map[string]bool)
+		for i := range query.Files {
+			if value := query.Files[i].KeyExpectedValue; value != "" && !expected[value] {
+				expected[value] = true
+				docs.Remediation = append(docs.Remediation, value)
+			}
+		}
+		summary.QueryDocs[query.QueryID] = docs
+	}
+}
+
+// setResourceGraph sets the resource graph of the summary, it is only built when requested
+// since it groups every scanned document
+func (c *Client) setResourceGraph(summary *model.Summary, scanResults *Results) {
+	if c.isReportRequested("graph") {
+		summary.ResourceGraph = model.CreateResourceGraph(scanResults.Files, scanResults.ExtractedPaths.ExtractionMap)
+	}
+}
+
+// setInventory sets the resources declared in the scanned files when the BoM report is requested
+func (c *Client) setInventory(summary *model.Summary, scanResults *Results) {
+	if c.isReportRequested("bom") {
+		summary.Inventory = model.CreateInventory(scanResults.Files, scanResults.ExtractedPaths.ExtractionMap)
+	}
+}
+
 // postScan is responsible for the output results
 func (c *Client) postScan(scanResults *Results) error {
 	if scanResults == nil {
@@ -115,10 +330,65 @@ func (c *Client) postScan(scanResults *Results) error {
 		}
 	}
 	sort.Strings(c.ScanParams.Path)
-	summary := c.getSummary(scanResults.Results, time.Now(), model.PathParameters{
+	pathParameters := model.PathParameters{
 		ScannedPaths:      c.ScanParams.Path,
 		PathExtractionMap: scanResults.ExtractedPaths.ExtractionMap,
-	})
+	}
+	summary := c.getSummary(scanResults.Results, time.Now(), pathParameters)
+
+	// the risk is scored before the results are limited so the omitted results are scored too
+	if c.ScanParams.RiskScore {
+		summary.Risk = model.CreateRiskSummary(summary.Queries)
+	}
+
+	// the results are rolled up by project before they are limited so every result is counted
+	if c.ScanParams.Projects != "" {
+		projects.SetProjects(&summary, c.projects)
+	}
+
+	model.LimitResultsPerQuery(&summary, c.ScanParams.MaxResultsPerQuery)
+	summary.HTMLPageSize = c.ScanParams.HTMLPageSize
+
+	c.setOwners(&summary, scanResults.ExtractedPaths.Path)
+
+	// the lines are blamed before the extraction folders are deleted, the results of the files outside of a git
+	// repository are not annotated
+	if c.ScanParams.Blame {
+		blame.SetBlame(context.Background(), &summary)
+	}
+
+	generated.NewDetector(scanResults.ExtractedPaths.Path).SetGenerated(&summary)
+
+	consoleHelpers.SetProjectsExitCode(&summary)
+
+	enrichment.Enrich(context.Background(), &summary, c.enrichers)
+
+	c.setResourceGraph(&summary, scanResults)
+
+	c.setInventory(&summary, scanResults)
+
+	c.setQueryDocs(&summary)
+
+	c.setSuppressed(&summary, scanResults, pathParameters)
+
+	if err := c.setBaseline(&summary); err != nil {
+		return err
+	}
+
+	// the scanned paths are hashed before the extraction folders are deleted, a failure only
+	// prevents the attestation report from being written
+	if c.isReportRequested("attestation") {
+		hashingStart := time.Now()
+		attestation, err := c.createAttestation(scanResults.ExtractedPaths)
+		if err != nil {
+			log.Err(err).Msg("Failed to create the scan attestation")
+		}
+		summary.Attestation = attestation
+		if summary.Performance == nil {
+			summary.Performance = &model.Performance{}
+		}
+		summary.Performance.HashingMs = float64(time.Since(hashingStart).Microseconds()) / 1000
+	}
 
 	if err := c.resolveOutputs(
 		&summary,
@@ -129,6 +399,13 @@ func (c *Client) postScan(scanResults *Results) error {
 		return err
 	}
 
+	// the webhook is notified once the reports are written, a failure is logged without failing the scan
+	if c.webhook != nil {
+		if err := c.webhook.Notify(context.Background(), &summary); err != nil {
+			log.Err(err).Msg("Failed to notify the webhook")
+		}
+	}
+
 	deleteExtractionFolder(scanResults.ExtractedPaths.ExtractionMap)
 
 	logger := consolePrinter.NewLogger(nil)
@@ -138,6 +415,11 @@ func (c *Client) postScan(scanResults *Results) error {
 
 	contributionAppeal(c.Printer, c.ScanParams.QueriesPath)
 
+	// errors take precedence over results so "KICS failed" can be distinguished from "insecure code"
+	if exitCode := consoleHelpers.ErrorsExitCode(&summary, c.ScanParams.StrictParsing); consoleHelpers.ShowError("errors") && exitCode != 0 {
+		os.Exit(exitCode)
+	}
+
 	exitCode := consoleHelpers.ResultsExitCode(&summary)
 	if consoleHelpers.ShowError("results") && exitCode != 0 {
 		os.Exit(exitCode)
@@ -1,8 +1,10 @@
 package scan
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/Checkmarx/kics/pkg/model"
 	"github.com/Checkmarx/kics/pkg/printer"
 	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/Checkmarx/kics/pkg/report"
 	"github.com/stretchr/testify/require"
 )
 
@@ -122,6 +125,53 @@ func Test_GetSummary(t *testing.T) {
 	}
 }
 
+func Test_SetSkipped(t *testing.T) {
+	ciTracker, err := tracker.NewTracker(3)
+	require.NoError(t, err)
+	c := &Client{
+		ScanParams: &Parameters{ScanTimeout: time.Minute},
+		Tracker:    ciTracker,
+	}
+
+	summary := model.Summary{}
+	c.setSkipped(&summary, model.PathParameters{})
+	require.False(t, summary.Partial)
+	require.Nil(t, summary.SkippedQueries)
+	require.Nil(t, summary.SkippedFiles)
+
+	secondQuery := model.SkippedQuery{QueryID: "2", QueryName: "B", Platform: "Terraform"}
+	firstQuery := model.SkippedQuery{QueryID: "1", QueryName: "A", Platform: "Terraform"}
+	ciTracker.TrackQuerySkipped(secondQuery, 1)
+	ciTracker.TrackQuerySkipped(firstQuery, 1)
+	ciTracker.TrackQuerySkipped(secondQuery, 1)
+	ciTracker.TrackFileFound("parsed.tf")
+	ciTracker.TrackFileSkipped("parsed.tf")
+	ciTracker.TrackFileSkipped("skipped.tf")
+
+	c.setSkipped(&summary, model.PathParameters{})
+	require.True(t, summary.Partial)
+	require.Equal(t, []model.SkippedQuery{firstQuery, secondQuery}, summary.SkippedQueries)
+	require.Equal(t, []string{"skipped.tf"}, summary.SkippedFiles)
+}
+
+func Test_SetGuarded(t *testing.T) {
+	c := &Client{ScanParams: &Parameters{}}
+
+	summary := model.Summary{}
+	c.setGuarded(&summary, model.PathParameters{})
+	require.Nil(t, summary.GuardedFiles)
+
+	c.guardedFiles = []model.GuardedFile{
+		{FilePath: "main.tf", Reason: model.GuardMaxFiles},
+		{FilePath: "data.json", Reason: model.GuardMaxFileSize, Size: 6291456},
+	}
+	c.setGuarded(&summary, model.PathParameters{})
+	require.Equal(t, []model.GuardedFile{
+		{FilePath: "data.json", Reason: model.GuardMaxFileSize, Size: 6291456},
+		{FilePath: "main.tf", Reason: model.GuardMaxFiles},
+	}, summary.GuardedFiles)
+}
+
 func Test_PrintOutput(t *testing.T) {
 
 	tests := []struct {
@@ -335,3 +385,145 @@ func Test_resolveOutputs(t *testing.T) {
 		})
 	}
 }
+
+func Test_WriteOutput(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "report.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(strings.Repeat("0f", 32)), 0600))
+	encryption, err := report.ParseEncryption("aes:" + keyPath)
+	require.NoError(t, err)
+
+	outputDir := t.TempDir()
+	c := Client{encryption: encryption}
+	var stagingDir string
+	err = c.writeOutput(outputDir, func(path string) error {
+		stagingDir = path
+		return os.WriteFile(filepath.Join(path, "results.json"), []byte("{}"), 0600)
+	})
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(outputDir, "results.json.enc"))
+	require.NoFileExists(t, filepath.Join(outputDir, "results.json"))
+	require.NoDirExists(t, stagingDir)
+}
+
+func Test_SetResourceGraph(t *testing.T) {
+	tests := []struct {
+		name          string
+		reportFormats []string
+		wantGraph     bool
+	}{
+		{
+			name:          "graph requested",
+			reportFormats: []string{"json", "Graph"},
+			wantGraph:     true,
+		},
+		{
+			name:          "graph not requested",
+			reportFormats: []string{"json"},
+			wantGraph:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanParams := Parameters{
+				Path:                    []string{filepath.Join("..", "..", "test", "fixtures", "test_scan_graph")},
+				QueriesPath:             []string{filepath.Join("..", "..", "assets", "queries", "terraform", "aws", "security_group_with_unrestricted_access_to_ssh")},
+				ReportFormats:           tt.reportFormats,
+				PreviewLines:            3,
+				Platform:                []string{"Terraform"},
+				CloudProvider:           []string{"aws"},
+				ChangedDefaultQueryPath: true,
+				MaxFileSizeFlag:         100,
+				QueryExecTimeout:        60,
+			}
+			c, err := NewClient(&scanParams, &progress.PbBuilder{}, &printer.Printer{})
+			require.NoError(t, err)
+
+			scanResults, err := c.executeScan(context.Background())
+			require.NoError(t, err)
+
+			summary := model.Summary{}
+			c.setResourceGraph(&summary, scanResults)
+			if !tt.wantGraph {
+				require.Nil(t, summary.ResourceGraph)
+				return
+			}
+			require.NotNil(t, summary.ResourceGraph)
+			require.Len(t, summary.ResourceGraph.Nodes, 2)
+			nodeID := filepath.ToSlash(scanParams.Path[0]) + ":"
+			require.Equal(t, []model.GraphEdge{
+				{From: nodeID + "aws_security_group.web", To: nodeID + "aws_instance.app"},
+			}, summary.ResourceGraph.Edges)
+		})
+	}
+}
+
+func Test_SetInventory(t *testing.T) {
+	scanParams := Parameters{
+		Path:                    []string{filepath.Join("..", "..", "test", "fixtures", "test_scan_graph")},
+		QueriesPath:             []string{filepath.Join("..", "..", "assets", "queries", "terraform", "aws", "security_group_with_unrestricted_access_to_ssh")},
+		ReportFormats:           []string{"json", "BoM"},
+		PreviewLines:            3,
+		Platform:                []string{"Terraform"},
+		CloudProvider:           []string{"aws"},
+		ChangedDefaultQueryPath: true,
+		MaxFileSizeFlag:         100,
+		QueryExecTimeout:        60,
+	}
+	c, err := NewClient(&scanParams, &progress.PbBuilder{}, &printer.Printer{})
+	require.NoError(t, err)
+
+	scanResults, err := c.executeScan(context.Background())
+	require.NoError(t, err)
+
+	summary := model.Summary{}
+	c.setInventory(&summary, scanResults)
+	require.Len(t, summary.Inventory, 2)
+	for _, resource := range summary.Inventory {
+		require.Equal(t, "Terraform", resource.Platform)
+		require.Equal(t, "aws", resource.CloudProvider)
+	}
+
+	c.ScanParams.ReportFormats = []string{"json"}
+	summary = model.Summary{}
+	c.setInventory(&summary, scanResults)
+	require.Nil(t, summary.Inventory)
+}
+
+func Test_SetQueryDocs(t *testing.T) {
+	queryID := "d929c031-078f-4241-b802-e224656ad890"
+	newSummary := func() model.Summary {
+		return model.Summary{
+			Queries: model.QueryResultSlice{
+				{
+					QueryID:     queryID,
+					Description: "query description",
+					QueryURI:    "https://swagger.io/docs/specification/data-models/data-types/",
+					Files: []model.VulnerableFile{
+						{KeyExpectedValue: "format should be valid"},
+						{KeyExpectedValue: "format should be valid"},
+					},
+				},
+			},
+		}
+	}
+	c := &Client{ScanParams: &Parameters{
+		QueriesPath:    []string{filepath.Join("..", "..", "assets", "queries", "openAPI", "general", "invalid_format")},
+		ReportFormats:  []string{"json"},
+		EmbedQueryDocs: true,
+	}}
+
+	summary := newSummary()
+	c.setQueryDocs(&summary)
+	require.Nil(t, summary.QueryDocs)
+
+	c.ScanParams.ReportFormats = []string{"html"}
+	summary = newSummary()
+	c.setQueryDocs(&summary)
+	docs := summary.QueryDocs[queryID]
+	require.NotNil(t, docs)
+	require.Equal(t, "query description", docs.Description)
+	require.Equal(t, []string{"format should be valid"}, docs.Remediation)
+	require.Len(t, docs.Examples, 2)
+}
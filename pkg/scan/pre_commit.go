@@ -0,0 +1,66 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// runGit runs a git command and returns its output
+var runGit = func(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, "git", args...).Output() //nolint:gosec
+}
+
+// StagedFiles returns the root of the git repository of the working directory and the paths of its staged files,
+// the deleted files are left out since there is nothing to scan
+func StagedFiles(ctx context.Context) (root string, files []string, err error) {
+	output, err := runGit(ctx, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find the git repository of the working directory: %w", err)
+	}
+	root = strings.TrimSpace(string(output))
+
+	output, err = runGit(ctx, "-C", root, "diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list the staged files: %w", err)
+	}
+	files = make([]string, 0)
+	for _, name := range bytes.Split(output, []byte{0}) {
+		if len(name) > 0 {
+			files = append(files, filepath.Join(root, filepath.FromSlash(string(name))))
+		}
+	}
+	return root, files, nil
+}
+
+// ScanPreCommit scans the files of the scan paths with the queries referencing the types they declare and returns
+// the summary of the results, neither the descriptions nor the reports are written
+func (c *Client) ScanPreCommit(ctx context.Context) (*model.Summary, error) {
+	c.ScanStartTime = time.Now()
+
+	scanResults, err := c.executeScan(ctx)
+	if err != nil {
+		log.Err(err).Msg("failed to scan the staged files")
+		return nil, err
+	}
+	if scanResults == nil {
+		scanResults = &Results{
+			Results: []model.Vulnerability{},
+		}
+	}
+	defer deleteExtractionFolder(scanResults.ExtractedPaths.ExtractionMap)
+
+	pathParameters := model.PathParameters{
+		ScannedPaths:      c.ScanParams.Path,
+		PathExtractionMap: scanResults.ExtractedPaths.ExtractionMap,
+	}
+	summary := c.getSummary(scanResults.Results, time.Now(), pathParameters)
+	return &summary, nil
+}
@@ -0,0 +1,58 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stubGit(t *testing.T, outputs map[string]string) {
+	original := runGit
+	t.Cleanup(func() { runGit = original })
+	runGit = func(_ context.Context, args ...string) ([]byte, error) {
+		output, ok := outputs[strings.Join(args, " ")]
+		if !ok {
+			return nil, errors.New("fatal: not a git repository")
+		}
+		return []byte(output), nil
+	}
+}
+
+func Test_StagedFiles(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "repo")
+	diff := "-C " + root + " diff --cached --name-only --diff-filter=ACMR -z"
+
+	t.Run("staged files", func(t *testing.T) {
+		stubGit(t, map[string]string{
+			"rev-parse --show-toplevel": root + "\n",
+			diff:                        "main.tf\x00modules/s3 bucket/main.tf\x00",
+		})
+		gotRoot, files, err := StagedFiles(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, root, gotRoot)
+		require.Equal(t, []string{
+			filepath.Join(root, "main.tf"),
+			filepath.Join(root, "modules", "s3 bucket", "main.tf"),
+		}, files)
+	})
+
+	t.Run("nothing staged", func(t *testing.T) {
+		stubGit(t, map[string]string{
+			"rev-parse --show-toplevel": root + "\n",
+			diff:                        "",
+		})
+		_, files, err := StagedFiles(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("not a git repository", func(t *testing.T) {
+		stubGit(t, map[string]string{})
+		_, _, err := StagedFiles(context.Background())
+		require.ErrorContains(t, err, "failed to find the git repository")
+	})
+}
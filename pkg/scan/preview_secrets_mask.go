@@ -11,33 +11,43 @@ import (
 )
 
 func maskPreviewLines(secretsPath string, scanResults *Results) error {
-	secretsRegexRulesContent, err := getSecretsRegexRules(secretsPath)
+	allowRules, rules, err := loadSecretsRules(secretsPath)
 	if err != nil {
 		return err
 	}
 
+	for i := range scanResults.Results {
+		item := scanResults.Results[i]
+		hideSecret(item.VulnLines, &allowRules, &rules)
+	}
+	return nil
+}
+
+// loadSecretsRules compiles the allow rules and the rules of the secrets regex rules file, the KICS rules are used
+// when no path is given
+func loadSecretsRules(secretsPath string) ([]secrets.AllowRule, []secrets.RegexQuery, error) {
+	secretsRegexRulesContent, err := getSecretsRegexRules(secretsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var allRegexQueries secrets.RegexRuleStruct
 
 	err = json.Unmarshal([]byte(secretsRegexRulesContent), &allRegexQueries)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	allowRules, err := secrets.CompileRegex(allRegexQueries.AllowRules)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	rules, err := compileRegexQueries(allRegexQueries.Rules)
 	if err != nil {
-		return err
-	}
-
-	for i := range scanResults.Results {
-		item := scanResults.Results[i]
-		hideSecret(item.VulnLines, &allowRules, &rules)
+		return nil, nil, err
 	}
-	return nil
+	return allowRules, rules, nil
 }
 
 func compileRegexQueries(allRegexQueries []secrets.RegexQuery) ([]secrets.RegexQuery, error) {
@@ -33,12 +33,15 @@ type Results struct {
 	ExtractedPaths provider.ExtractedPath
 	Files          model.FileMetadatas
 	FailedQueries  map[string]error
+	Suppressed     []model.Vulnerability
+	Suppressions   map[string]model.Suppression
 }
 
 type executeScanParameters struct {
 	services       []*kics.Service
 	inspector      *engine.Inspector
 	extractedPaths provider.ExtractedPath
+	decisionLog    *engine.DecisionLog
 }
 
 func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
@@ -79,6 +82,26 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 		return nil, err
 	}
 
+	var decisionLog *engine.DecisionLog
+	if c.ScanParams.DecisionLogPath != "" {
+		if decisionLog, err = engine.NewDecisionLog(c.ScanParams.DecisionLogPath); err != nil {
+			log.Err(err).Msgf("Failed to create the decision log %s", c.ScanParams.DecisionLogPath)
+			return nil, err
+		}
+		inspector.EnableDecisionLog(decisionLog)
+	}
+
+	inspector.ExcludeResultsByKey(c.keyExclusions)
+
+	if c.ScanParams.PruneQueries {
+		inspector.PruneQueries()
+	}
+
+	// the suppressed results are only reported by the SARIF report
+	if c.isReportRequested("sarif") {
+		inspector.KeepSuppressedResults()
+	}
+
 	secretsRegexRulesContent, err := getSecretsRegexRules(c.ScanParams.SecretsRegexesPath)
 	if err != nil {
 		return nil, err
@@ -100,6 +123,10 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 		log.Err(err)
 		return nil, err
 	}
+	secretsInspector.ExcludeResultsByKey(c.keyExclusions)
+	if c.secretsEngine != "" {
+		secretsInspector.UseExternalEngine(c.secretsEngine)
+	}
 
 	services, err := c.createService(
 		inspector,
@@ -122,6 +149,7 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 		services:       services,
 		inspector:      inspector,
 		extractedPaths: extractedPaths,
+		decisionLog:    decisionLog,
 	}, nil
 }
 
@@ -137,7 +165,24 @@ func (c *Client) executeScan(ctx context.Context) (*Results, error) {
 		return nil, nil
 	}
 
-	if err = scanner.PrepareAndScan(ctx, c.ScanParams.ScanID, c.ScanParams.OpenAPIResolveReferences, *c.ProBarBuilder,
+	if executeScanParameters.decisionLog != nil {
+		defer func() {
+			if err := executeScanParameters.decisionLog.Close(); err != nil {
+				log.Err(err).Msg("Failed to close the decision log")
+			}
+		}()
+	}
+
+	// the scan timeout is measured from the start of the scan, once expired the files not yet parsed
+	// and the queries not yet executed are skipped and the results found so far are kept
+	scanCtx := ctx
+	if c.ScanParams.ScanTimeout > 0 {
+		var cancel context.CancelFunc
+		scanCtx, cancel = context.WithDeadline(ctx, c.ScanStartTime.Add(c.ScanParams.ScanTimeout))
+		defer cancel()
+	}
+
+	if err = scanner.PrepareAndScan(scanCtx, c.ScanParams.ScanID, c.ScanParams.OpenAPIResolveReferences, *c.ProBarBuilder,
 		executeScanParameters.services); err != nil {
 		log.Err(err)
 		return nil, err
@@ -161,11 +206,15 @@ func (c *Client) executeScan(ctx context.Context) (*Results, error) {
 		return nil, err
 	}
 
+	suppressed, suppressions := executeScanParameters.inspector.GetSuppressedResults()
+
 	return &Results{
 		Results:        results,
 		ExtractedPaths: executeScanParameters.extractedPaths,
 		Files:          files,
 		FailedQueries:  failedQueries,
+		Suppressed:     suppressed,
+		Suppressions:   suppressions,
 	}, nil
 }
 
@@ -199,7 +248,8 @@ func (c *Client) createQueryFilter() *source.QueryInspectorParameters {
 	}
 
 	includeQueries := source.IncludeQueries{
-		ByIDs: c.ScanParams.IncludeQueries,
+		ByIDs:        c.ScanParams.IncludeQueries,
+		ByCategories: c.ScanParams.Categories,
 	}
 
 	queryFilter := source.QueryInspectorParameters{
@@ -227,8 +277,8 @@ func (c *Client) createService(
 
 	combinedParser, err := parser.NewBuilder().
 		Add(&jsonParser.Parser{}).
-		Add(&yamlParser.Parser{}).
-		Add(terraformParser.NewDefaultWithVarsPath(c.ScanParams.TerraformVarsPath)).
+		Add(yamlParser.NewWithComposeProfiles(c.ScanParams.ComposeProfiles)).
+		Add(terraformParser.NewDefaultWithParams(c.ScanParams.TerraformVarsPath, paths)).
 		Add(&dockerParser.Parser{}).
 		Add(&protoParser.Parser{}).
 		Add(&buildahParser.Parser{}).
@@ -260,7 +310,7 @@ func (c *Client) createService(
 				SecretsInspector: secretsInspector,
 				Tracker:          t,
 				Resolver:         combinedResolver,
-				MaxFileSize:      c.ScanParams.MaxFileSizeFlag,
+				MaxFileSize:      c.ScanParams.maxFileSize(),
 			},
 		)
 	}
@@ -0,0 +1,251 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/engine/provider"
+	"github.com/Checkmarx/kics/pkg/engine/secrets"
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/Checkmarx/kics/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	megabyte = 1024 * 1024
+	// binarySniffLength is the number of bytes checked for a NUL byte to detect binary files
+	binarySniffLength = 8000
+)
+
+// SecretsResults represents the result of a scan run only by the secrets engine
+type SecretsResults struct {
+	Results      []model.Vulnerability
+	FilesScanned int
+	LinesScanned int
+	Start        time.Time
+	End          time.Time
+}
+
+// ScanSecrets runs only the secrets engine, every text file of the scan paths is read line by line
+// without being parsed, so no platform is detected and no Rego query is loaded
+func (c *Client) ScanSecrets(ctx context.Context) (*SecretsResults, error) {
+	c.ScanStartTime = time.Now()
+
+	regexRulesContent, err := getSecretsRegexRules(c.ScanParams.SecretsRegexesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	inspector, err := secrets.NewInspector(
+		ctx,
+		c.ExcludeResultsMap,
+		c.Tracker,
+		c.createQueryFilter(),
+		false,
+		c.ScanParams.QueryExecTimeout,
+		regexRulesContent,
+		len(c.ScanParams.SecretsRegexesPath) > 0,
+	)
+	if err != nil {
+		log.Err(err).Msg("failed to load the secrets rules")
+		return nil, err
+	}
+	if c.secretsEngine != "" {
+		inspector.UseExternalEngine(c.secretsEngine)
+	}
+
+	files, err := c.readSecretsFiles()
+	if err != nil {
+		log.Err(err).Msg("failed to read the files to scan for secrets")
+		return nil, err
+	}
+
+	currentQuery := make(chan int64, inspector.GetQueriesLength())
+	results, err := inspector.Inspect(ctx, c.ScanParams.Path, files, currentQuery)
+	if err != nil {
+		log.Err(err).Msg("failed to scan the files for secrets")
+		return nil, err
+	}
+
+	return &SecretsResults{
+		Results:      results,
+		FilesScanned: c.Tracker.FoundFiles,
+		LinesScanned: c.Tracker.FoundCountLines,
+		Start:        c.ScanStartTime,
+		End:          time.Now(),
+	}, nil
+}
+
+// readSecretsFiles reads the text files of the scan paths, skipping the excluded paths,
+// the .git directories, the binary files and the files bigger than the maximum file size
+func (c *Client) readSecretsFiles() (model.FileMetadatas, error) {
+	excluded, err := getSecretsExcludedPaths(c.ScanParams.ExcludePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(model.FileMetadatas, 0)
+	for _, scanPath := range c.ScanParams.Path {
+		err := filepath.WalkDir(scanPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if isExcludedPath(path, excluded) || (d.IsDir() && d.Name() == ".git") {
+				log.Debug().Msgf("Path ignored: %s", path)
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			file, err := c.readSecretsFile(filepath.ToSlash(path))
+			if err != nil || file == nil {
+				return err
+			}
+			files = append(files, *file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func (c *Client) readSecretsFile(path string) (*model.FileMetadata, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if c.ScanParams.MaxFileSizeFlag >= 0 && info.Size() > int64(c.ScanParams.MaxFileSizeFlag)*megabyte {
+		log.Debug().Msgf("File ignored, size limit exceeded: %s", path)
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	sniff := content
+	if len(sniff) > binarySniffLength {
+		sniff = sniff[:binarySniffLength]
+	}
+	if bytes.IndexByte(sniff, 0) != -1 {
+		log.Debug().Msgf("File ignored, binary content: %s", path)
+		return nil, nil
+	}
+
+	originalData := strings.ReplaceAll(string(content), "\r\n", "\n")
+	lines := utils.SplitLines(originalData)
+	c.Tracker.TrackFileFound(path)
+	c.Tracker.TrackFileFoundCountLines(len(*lines))
+
+	kind := model.FileKind("")
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		kind = model.KindJSON
+	}
+
+	return &model.FileMetadata{
+		ID:                uuid.New().String(),
+		ScanID:            c.ScanParams.ScanID,
+		OriginalData:      originalData,
+		Kind:              kind,
+		FilePath:          path,
+		Commands:          secretsCommentsCommands(*lines),
+		LinesIgnore:       secretsIgnoreLines(*lines),
+		IgnoreRegions:     model.GetIgnoreRegions([]byte(originalData)),
+		LinesOriginalData: lines,
+	}, nil
+}
+
+// secretsCommentsCommands gets the kics-scan commands of the comments in the file beginning,
+// any of the '#', '//' and ';' comment tokens is accepted since the file type is not detected
+func secretsCommentsCommands(lines []string) model.CommentsCommands {
+	commands := make(model.CommentsCommands)
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+		if !isCommentLine(line) {
+			break
+		}
+		if !model.KICSCommentRgxp.MatchString(line) {
+			continue
+		}
+		fields := strings.Fields(model.KICSCommentRgxp.ReplaceAllString(line, ""))
+		if len(fields) == 0 {
+			continue
+		}
+		command := strings.SplitN(fields[0], "=", 2)
+		if len(command) > 1 {
+			commands[command[0]] = command[1]
+		} else {
+			commands[command[0]] = ""
+		}
+	}
+	return commands
+}
+
+// secretsIgnoreLines returns the lines of the 'kics-scan ignore-line' comments and the lines following them,
+// 'kics-scan ignore-block' requires the file to be parsed so it is not supported
+func secretsIgnoreLines(lines []string) []int {
+	ignoreLines := make([]int, 0)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if !isCommentLine(line) || !model.KICSCommentRgxp.MatchString(line) {
+			continue
+		}
+		fields := strings.Fields(model.KICSCommentRgxp.ReplaceAllString(line, ""))
+		if len(fields) > 0 && model.CommentCommand(fields[0]) == model.IgnoreLine {
+			ignoreLines = append(ignoreLines, model.Range(i+1, i+2)...)
+		}
+	}
+	return ignoreLines
+}
+
+func isCommentLine(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") || strings.HasPrefix(line, ";")
+}
+
+// getSecretsExcludedPaths returns the absolute paths matched by the exclude paths expressions
+func getSecretsExcludedPaths(excludePaths []string) (map[string]bool, error) {
+	excluded := make(map[string]bool)
+	for _, expression := range excludePaths {
+		if expression == "" {
+			continue
+		}
+		paths, err := provider.GetExcludePaths(expression)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return nil, err
+			}
+			excluded[absPath] = true
+		}
+	}
+	return excluded, nil
+}
+
+func isExcludedPath(path string, excluded map[string]bool) bool {
+	if len(excluded) == 0 {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return excluded[absPath]
+}
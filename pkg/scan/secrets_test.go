@@ -0,0 +1,109 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	consolePrinter "github.com/Checkmarx/kics/pkg/printer"
+	"github.com/Checkmarx/kics/pkg/progress"
+	"github.com/stretchr/testify/require"
+)
+
+const secretsFixture = "./../../test/fixtures/test_secrets_command"
+
+func Test_ScanSecrets(t *testing.T) {
+	// the .git directories and binary files are created at runtime since they can not be committed as fixtures
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".git", "config"), []byte(`password = "s3cr3tP@ssw0rd123"`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "image.bin"), []byte("\x00\x01password = \"s3cr3tP@ssw0rd123\""), 0600))
+
+	tests := []struct {
+		name          string
+		scanParams    Parameters
+		expectedFiles int
+		expectedLines []int
+	}{
+		{
+			name: "should find the secrets not ignored",
+			scanParams: Parameters{
+				Path: []string{secretsFixture},
+			},
+			expectedFiles: 2,
+			expectedLines: []int{3, 4},
+		},
+		{
+			name: "should not find secrets in excluded paths",
+			scanParams: Parameters{
+				Path:         []string{secretsFixture},
+				ExcludePaths: []string{secretsFixture + "/config.env"},
+			},
+			expectedFiles: 1,
+			expectedLines: []int{},
+		},
+		{
+			name: "should not find secrets in .git directories and binary files",
+			scanParams: Parameters{
+				Path: []string{tmpDir},
+			},
+			expectedFiles: 0,
+			expectedLines: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.scanParams.PreviewLines = 3
+			tt.scanParams.MaxFileSizeFlag = 5
+			tt.scanParams.QueryExecTimeout = 60
+			tt.scanParams.DisableVersionCheck = true
+			tt.scanParams.ScanID = "console"
+
+			c, err := NewClient(&tt.scanParams, &progress.PbBuilder{}, &consolePrinter.Printer{})
+			require.NoError(t, err)
+
+			results, err := c.ScanSecrets(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedFiles, results.FilesScanned)
+
+			lines := make([]int, 0, len(results.Results))
+			for i := range results.Results {
+				lines = append(lines, results.Results[i].Line)
+			}
+			require.ElementsMatch(t, tt.expectedLines, lines)
+		})
+	}
+}
+
+func Test_secretsCommentsCommands(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  model.CommentsCommands
+	}{
+		{
+			name:  "should get the commands of any comment token",
+			lines: []string{"", "# kics-scan ignore", "// kics-scan disable=487f4be7-3fd9-4506-a07a-eae252180c08", "password = x"},
+			want:  model.CommentsCommands{"ignore": "", "disable": "487f4be7-3fd9-4506-a07a-eae252180c08"},
+		},
+		{
+			name:  "should stop at the first line that is not a comment",
+			lines: []string{"password = x", "# kics-scan ignore"},
+			want:  model.CommentsCommands{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, secretsCommentsCommands(tt.lines))
+		})
+	}
+}
+
+func Test_secretsIgnoreLines(t *testing.T) {
+	lines := []string{"user = admin", "  ; kics-scan ignore-line", "password = x", "token = y"}
+	require.Equal(t, []int{2, 3}, secretsIgnoreLines(lines))
+}
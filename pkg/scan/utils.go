@@ -28,6 +28,11 @@ func (c *Client) prepareAndAnalyzePaths(ctx context.Context) (provider.Extracted
 		return provider.ExtractedPath{}, err
 	}
 
+	return c.extractAndAnalyzePaths(ctx, queryExPaths, libExPaths)
+}
+
+// extractAndAnalyzePaths extracts the scan paths and analyzes them to determine the platforms to scan
+func (c *Client) extractAndAnalyzePaths(ctx context.Context, queryExPaths, libExPaths provider.ExtractedPath) (provider.ExtractedPath, error) {
 	regularPaths, kuberneterPaths := extractPathType(c.ScanParams.Path)
 
 	kuberneterExPaths, err := provider.GetKuberneterSources(ctx, kuberneterPaths, c.ScanParams.OutputPath)
@@ -44,7 +49,6 @@ func (c *Client) prepareAndAnalyzePaths(ctx context.Context) (provider.Extracted
 	if len(allPaths.Path) == 0 {
 		return provider.ExtractedPath{}, nil
 	}
-	log.Info().Msgf("Total files in the project: %d", getTotalFiles(allPaths.Path))
 
 	a := &analyzer.Analyzer{
 		Paths:             allPaths.Path,
@@ -53,7 +57,9 @@ func (c *Client) prepareAndAnalyzePaths(ctx context.Context) (provider.Extracted
 		Exc:               c.ScanParams.ExcludePaths,
 		GitIgnoreFileName: ".gitignore",
 		ExcludeGitIgnore:  c.ScanParams.ExcludeGitIgnore,
-		MaxFileSize:       c.ScanParams.MaxFileSizeFlag,
+		ExcludeGenerated:  c.ScanParams.ExcludeGenerated,
+		MaxFileSize:       c.ScanParams.maxFileSize(),
+		MaxFiles:          c.ScanParams.maxFiles(),
 	}
 
 	pathTypes, errAnalyze := analyzePaths(a)
@@ -61,11 +67,15 @@ func (c *Client) prepareAndAnalyzePaths(ctx context.Context) (provider.Extracted
 	if errAnalyze != nil {
 		return provider.ExtractedPath{}, errAnalyze
 	}
+	// the files are counted by the discovery of the analyzer, so the scanned paths are only walked once
+	log.Info().Msgf("Total files in the project: %d", pathTypes.Discovery.Files)
+	c.discovery = &pathTypes.Discovery
 
 	if len(pathTypes.Types) == 0 {
 		return provider.ExtractedPath{}, nil
 	}
 
+	c.guardedFiles = pathTypes.Guarded
 	c.ScanParams.Platform = pathTypes.Types
 	c.ScanParams.ExcludePaths = pathTypes.Exc
 
@@ -198,6 +208,31 @@ func logLoadingQueriesType(types []string) {
 	log.Info().Msgf("Loading queries of type: %s", strings.Join(types, ", "))
 }
 
+// checkOffline returns an error when a path given to the scan requires network access
+func checkOffline(params *Parameters) error {
+	paths := make([]string, 0, len(params.Path)+len(params.QueriesPath)+1)
+	paths = append(paths, params.Path...)
+	if params.ChangedDefaultQueryPath {
+		paths = append(paths, params.QueriesPath...)
+	}
+	if params.ChangedDefaultLibrariesPath {
+		paths = append(paths, params.LibrariesPath)
+	}
+
+	for _, path := range paths {
+		if kuberneterRegex.MatchString(path) || provider.IsRemoteSource(path) {
+			return fmt.Errorf("offline mode is enabled but %s requires network access, only local paths can be used", path)
+		}
+	}
+	if len(params.Enrichers) > 0 {
+		return fmt.Errorf("offline mode is enabled but the enrichers require network access")
+	}
+	if params.WebhookURL != "" {
+		return fmt.Errorf("offline mode is enabled but the webhook requires network access")
+	}
+	return nil
+}
+
 func extractPathType(paths []string) (regular, kuberneter []string) {
 	for _, path := range paths {
 		if kuberneterRegex.MatchString(path) {
@@ -235,32 +270,13 @@ func usingCustomQueries(queriesPath []string) bool {
 	return !utils.ContainsInString(filepath.Join("assets", "queries"), queriesPath)
 }
 
-// printVersionCheck - Prints and logs warning if not using KICS latest version
+// printVersionCheck - Prints and logs warning if not using KICS latest version, nothing is printed
+// when the version was not checked
 func printVersionCheck(customPrint *consolePrinter.Printer, s *model.Summary) {
-	if !s.LatestVersion.Latest {
+	if !s.LatestVersion.Latest && s.LatestVersion.LatestVersionTag != "" {
 		message := fmt.Sprintf("A new version 'v%s' of KICS is available, please consider updating", s.LatestVersion.LatestVersionTag)
 
 		fmt.Println(customPrint.VersionMessage.Sprintf(message))
 		log.Warn().Msgf(message)
 	}
 }
-
-func getTotalFiles(paths []string) int {
-	files := 0
-	for _, path := range paths {
-		if err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			if !info.IsDir() {
-				files++
-			}
-
-			return nil
-		}); err != nil {
-			log.Error().Msgf("failed to walk path %s: %s", path, err)
-		}
-	}
-	return files
-}
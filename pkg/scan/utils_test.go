@@ -96,6 +96,15 @@ func Test_PrintVersionCheck(t *testing.T) {
 			},
 			expectedOutput: "A new version 'v1.1.0' of KICS is available, please consider updating",
 		},
+		{
+			name:           "test unchecked version",
+			consolePrinter: consolePrinter.NewPrinter(true),
+			modelSummary: &model.Summary{
+				Version:       "v1.0.0",
+				LatestVersion: model.Version{},
+			},
+			expectedOutput: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -160,43 +169,6 @@ func Test_ContributionAppeal(t *testing.T) {
 
 }
 
-func Test_GetTotalFiles(t *testing.T) {
-	tests := []struct {
-		name           string
-		paths          []string
-		expectedOutput int
-	}{
-		{
-			name:           "count utils folder files",
-			paths:          []string{filepath.Join("..", "..", "pkg", "utils")},
-			expectedOutput: 17,
-		},
-		{
-			name:           "count progress folder files",
-			paths:          []string{filepath.Join("..", "..", "pkg", "progress")},
-			expectedOutput: 6,
-		},
-		{
-			name:           "count progress and utils folder files",
-			paths:          []string{filepath.Join("..", "..", "pkg", "progress"), filepath.Join("..", "..", "pkg", "utils")},
-			expectedOutput: 23,
-		},
-		{
-			name:           "count invalid folder",
-			paths:          []string{filepath.Join("pkg", "progress")},
-			expectedOutput: 0,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			v := getTotalFiles(tt.paths)
-			require.Equal(t, tt.expectedOutput, v)
-
-		})
-	}
-}
-
 func Test_LogLoadingQueriesType(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -276,6 +248,69 @@ func Test_ExtractPathType(t *testing.T) {
 	}
 }
 
+func Test_CheckOffline(t *testing.T) {
+	localPath := filepath.Join("..", "..", "test", "fixtures", "test_zip.zip")
+	tests := []struct {
+		name    string
+		params  Parameters
+		wantErr bool
+	}{
+		{
+			name:    "local paths",
+			params:  Parameters{Path: []string{filepath.Join("..", "..", "pkg", "progress"), localPath}},
+			wantErr: false,
+		},
+		{
+			name:    "git repository",
+			params:  Parameters{Path: []string{"git::https://github.com/Checkmarx/kics"}},
+			wantErr: true,
+		},
+		{
+			name:    "kuberneter",
+			params:  Parameters{Path: []string{"kuberneter::*:*:*"}},
+			wantErr: true,
+		},
+		{
+			name: "remote queries path",
+			params: Parameters{
+				Path:                    []string{localPath},
+				QueriesPath:             []string{"https://example.com/queries.zip"},
+				ChangedDefaultQueryPath: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote libraries path",
+			params: Parameters{
+				Path:                        []string{localPath},
+				LibrariesPath:               "github.com/Checkmarx/kics//assets/libraries",
+				ChangedDefaultLibrariesPath: true,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "enrichers",
+			params:  Parameters{Path: []string{localPath}, Enrichers: []string{"aws"}},
+			wantErr: true,
+		},
+		{
+			name:    "webhook",
+			params:  Parameters{Path: []string{localPath}, WebhookURL: "https://ci.example.com/hooks/kics"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOffline(&tt.params)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_CombinePaths(t *testing.T) {
 	tests := []struct {
 		name           string
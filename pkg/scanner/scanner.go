@@ -18,6 +18,19 @@ func PrepareAndScan(
 	openAPIResolveReferences bool,
 	proBarBuilder progress.PbBuilder,
 	services serviceSlice,
+) error {
+	if err := PrepareSources(ctx, scanID, openAPIResolveReferences, services); err != nil {
+		return err
+	}
+	return StartScan(ctx, scanID, proBarBuilder, services)
+}
+
+// PrepareSources will run concurrently the parsing of the sources of every service
+func PrepareSources(
+	ctx context.Context,
+	scanID string,
+	openAPIResolveReferences bool,
+	services serviceSlice,
 ) error {
 	metrics.Metric.Start("prepare_sources")
 	var wg sync.WaitGroup
@@ -41,10 +54,6 @@ func PrepareAndScan(
 	select {
 	case <-wgDone:
 		metrics.Metric.Stop()
-		err := StartScan(ctx, scanID, proBarBuilder, services)
-		if err != nil {
-			return err
-		}
 		break
 	case err := <-errCh:
 		close(errCh)
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	longPathPrefix    = `\\?\`
+	longUNCPathPrefix = `\\?\UNC\`
+	devicePathPrefix  = `\\.\`
+)
+
+// windowsPaths is true when the paths are Windows paths, which are case-insensitive and can be written as
+// extended-length or UNC paths, it is a variable so the Windows handling can be tested on every OS
+var windowsPaths = runtime.GOOS == "windows"
+
+// TrimLongPathPrefix removes the \\?\ prefix of an extended-length Windows path, \\?\UNC\server\share becoming
+// \\server\share, Go already prefixes the long paths it opens and the prefix breaks the relative paths and the
+// comparisons of the paths, the paths are returned unchanged on other OSes
+func TrimLongPathPrefix(path string) string {
+	if !windowsPaths {
+		return path
+	}
+	slashed := strings.ReplaceAll(path, "/", `\`)
+	switch {
+	case hasPrefixFold(slashed, longUNCPathPrefix):
+		return `\\` + slashed[len(longUNCPathPrefix):]
+	case strings.HasPrefix(slashed, longPathPrefix):
+		return slashed[len(longPathPrefix):]
+	}
+	return path
+}
+
+// IsUNCPath returns true for a path of a Windows network share, such as \\server\share\dir, //server/share/dir or
+// a OneDrive or SharePoint folder mapped as \\tenant.sharepoint.com@SSL\DavWWWRoot, device paths are not UNC paths
+func IsUNCPath(path string) bool {
+	if !windowsPaths {
+		return false
+	}
+	slashed := strings.ReplaceAll(TrimLongPathPrefix(path), "/", `\`)
+	if !strings.HasPrefix(slashed, `\\`) || strings.HasPrefix(slashed, longPathPrefix) ||
+		strings.HasPrefix(slashed, devicePathPrefix) {
+		return false
+	}
+	parts := strings.SplitN(slashed[2:], `\`, 3)
+	return len(parts) >= 2 && parts[0] != "" && parts[1] != ""
+}
+
+// PathKey returns the key identifying a path, to find the same path written twice, the key of a Windows path is
+// case-insensitive and does not depend on the separators nor on the extended-length prefix
+func PathKey(path string) string {
+	if !windowsPaths {
+		return filepath.Clean(path)
+	}
+	key := strings.ReplaceAll(filepath.Clean(TrimLongPathPrefix(path)), "/", `\`)
+	for len(key) > 3 && strings.HasSuffix(key, `\`) {
+		key = strings.TrimSuffix(key, `\`)
+	}
+	return strings.ToLower(key)
+}
+
+// IndexPath returns the index of the first occurrence of a path in another path, -1 when it is not found, the
+// Windows paths are compared case-insensitively
+func IndexPath(path, sub string) int {
+	if !windowsPaths {
+		return strings.Index(path, sub)
+	}
+	for i := 0; i+len(sub) <= len(path); i++ {
+		if strings.EqualFold(path[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowsPath(t *testing.T) {
+	defer func(original bool) { windowsPaths = original }(windowsPaths)
+	windowsPaths = true
+
+	tests := []struct {
+		name      string
+		path      string
+		trimmed   string
+		isUNCPath bool
+	}{
+		{
+			name:    "Should remove the prefix of an extended-length path",
+			path:    `\\?\C:\repos\infra\main.tf`,
+			trimmed: `C:\repos\infra\main.tf`,
+		},
+		{
+			name:      "Should turn an extended-length UNC path into a UNC path",
+			path:      `\\?\UNC\server\share\infra`,
+			trimmed:   `\\server\share\infra`,
+			isUNCPath: true,
+		},
+		{
+			name:      "Should keep a UNC path",
+			path:      `\\contoso.sharepoint.com@SSL\DavWWWRoot\infra`,
+			trimmed:   `\\contoso.sharepoint.com@SSL\DavWWWRoot\infra`,
+			isUNCPath: true,
+		},
+		{
+			name:      "Should find a UNC path written with slashes",
+			path:      "//server/share",
+			trimmed:   "//server/share",
+			isUNCPath: true,
+		},
+		{
+			name:    "Should not find a UNC path without its share",
+			path:    `\\server`,
+			trimmed: `\\server`,
+		},
+		{
+			name:    "Should not find a UNC path in a device path",
+			path:    `\\.\pipe\docker_engine`,
+			trimmed: `\\.\pipe\docker_engine`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.trimmed, TrimLongPathPrefix(tt.path))
+			require.Equal(t, tt.isUNCPath, IsUNCPath(tt.path))
+		})
+	}
+
+	require.Equal(t, PathKey(`C:\Repos\Infra\`), PathKey(`\\?\c:\repos\infra`))
+	require.NotEqual(t, PathKey(`C:\repos\infra`), PathKey(`C:\repos\infra2`))
+	require.Equal(t, 2, IndexPath(`C:\Temp\kics-extract-1\main.tf`, `\temp\KICS-EXTRACT-1`))
+	require.Equal(t, -1, IndexPath(`C:\Temp\main.tf`, `D:\Temp`))
+
+	windowsPaths = false
+	require.Equal(t, `\\?\C:\repos`, TrimLongPathPrefix(`\\?\C:\repos`))
+	require.False(t, IsUNCPath("//server/share"))
+	require.Equal(t, -1, IndexPath("/tmp/Main.tf", "main.tf"))
+}
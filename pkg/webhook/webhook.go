@@ -0,0 +1,120 @@
+// Package webhook notifies an HTTP endpoint when a scan finishes, so orchestration systems can react to the
+// completion of a scan without polling or parsing the logs
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the body, prefixed by "sha256=", when a secret is set
+	SignatureHeader = "X-KICS-Signature-256"
+	// EventHeader holds the event notified by the request
+	EventHeader = "X-KICS-Event"
+	// ScanCompletedEvent is the event of the requests sent when a scan finishes
+	ScanCompletedEvent = "scan.completed"
+	// signaturePrefix prefixes the signature with the name of its hash, as done by the git hosting services
+	signaturePrefix = "sha256="
+	// maxErrorBodySize limits the response body logged when the endpoint rejects the request
+	maxErrorBodySize = 512
+)
+
+// HTTPClient is the client sending the webhook requests
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPRequestClient - http client to use for the webhook requests
+var HTTPRequestClient HTTPClient = &http.Client{
+	Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+	Timeout:   20 * time.Second,
+}
+
+// Webhook is an endpoint notified with the summary of the scans, the requests are signed when a secret is set,
+// only the host of the URL is logged since the path and query of the webhook URLs often hold a token
+type Webhook struct {
+	endpoint string
+	host     string
+	secret   []byte
+}
+
+// New validates the URL of the webhook, only absolute http and https URLs are accepted
+func New(webhookURL, secret string) (*Webhook, error) {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url: %w", unwrapURLError(err))
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("invalid webhook url, expected an absolute http or https url")
+	}
+	if parsed.Scheme == "http" {
+		log.Warn().Msgf("The webhook of %s is not using https, the summary is sent unencrypted", parsed.Host)
+	}
+	return &Webhook{endpoint: webhookURL, host: parsed.Host, secret: []byte(secret)}, nil
+}
+
+// Sign returns the value of the signature header of a body, the HMAC-SHA256 of the body with the secret
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body) //nolint:errcheck
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify posts the summary of a finished scan as JSON, an error is returned when the endpoint does not answer
+// with a 2xx status
+func (w *Webhook) Notify(ctx context.Context, summary *model.Summary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, ScanCompletedEvent)
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+
+	resp, err := HTTPRequestClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the webhook request to %s: %w", w.host, unwrapURLError(err))
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Err(closeErr).Msg("Error closing the webhook response body")
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("webhook of %s answered with status %d: %s", w.host, resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	log.Info().Msgf("Scan summary sent to the webhook of %s", w.host)
+	return nil
+}
+
+// unwrapURLError returns the cause of the url errors, so the URL is not logged
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Checkmarx/kics/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "https", url: "https://ci.example.com/hooks/kics?token=abc"},
+		{name: "http", url: "http://localhost:8080/hooks"},
+		{name: "relative", url: "/hooks/kics", wantErr: true},
+		{name: "unsupported_scheme", url: "ftp://ci.example.com/hooks", wantErr: true},
+		{name: "invalid", url: "https://ci.example.com/%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.url, "")
+			if tt.wantErr {
+				require.Error(t, err)
+				require.NotContains(t, err.Error(), tt.url)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	summary := &model.Summary{
+		SeveritySummary: model.SeveritySummary{ScanID: "console"},
+		Counters:        model.Counters{ScannedFiles: 3},
+	}
+
+	tests := []struct {
+		name          string
+		secret        string
+		status        int
+		wantSignature bool
+		wantErr       bool
+	}{
+		{name: "signed", secret: "s3cr3t", status: http.StatusOK, wantSignature: true},
+		{name: "unsigned", status: http.StatusNoContent},
+		{name: "rejected", secret: "s3cr3t", status: http.StatusUnauthorized, wantSignature: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, ScanCompletedEvent, r.Header.Get(EventHeader))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var got model.Summary
+				require.NoError(t, json.Unmarshal(body, &got))
+				require.Equal(t, "console", got.ScanID)
+				require.Equal(t, 3, got.ScannedFiles)
+
+				if tt.wantSignature {
+					require.Equal(t, Sign([]byte(tt.secret), body), r.Header.Get(SignatureHeader))
+				} else {
+					require.Empty(t, r.Header.Get(SignatureHeader))
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			hook, err := New(server.URL+"/hooks/kics?token=abc", tt.secret)
+			require.NoError(t, err)
+			err = hook.Notify(context.Background(), summary)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.NotContains(t, err.Error(), "token=abc")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	require.Equal(t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Sign([]byte("key"), []byte("The quick brown fox jumps over the lazy dog")))
+}
@@ -0,0 +1,26 @@
+package Cx
+
+# conflicting is defined twice with different values, so evaluating it fails
+conflicting = "first" {
+	input.document[_].resource
+}
+
+conflicting = "second" {
+	input.document[_].resource
+}
+
+CxPolicy[result] {
+	document := input.document[i]
+	resource := document.resource.aws_s3_bucket[name]
+	conflicting == "first"
+
+	result := {
+		"documentId": document.id,
+		"resourceType": "aws_s3_bucket",
+		"resourceName": name,
+		"searchKey": sprintf("aws_s3_bucket[%s]", [name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "conflicting should be evaluated",
+		"keyActualValue": "conflicting failed to be evaluated",
+	}
+}
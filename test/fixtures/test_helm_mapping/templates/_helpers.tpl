@@ -0,0 +1,7 @@
+{{/*
+Common labels
+*/}}
+{{- define "test_helm_mapping.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
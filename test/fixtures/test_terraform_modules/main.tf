@@ -0,0 +1,16 @@
+variable "bucket_acl" {
+  default = "public-read"
+}
+
+module "bucket" {
+  source = "./modules/bucket"
+  name   = "logs"
+  acl    = var.bucket_acl
+  region = aws_s3_bucket.other.region
+}
+
+resource "aws_s3_bucket_public_access_block" "access" {
+  bucket              = module.bucket.name
+  block_public_policy = module.bucket.private
+  ignore_public_acls  = module.bucket.id
+}
@@ -0,0 +1,29 @@
+variable "name" {
+  default = "default"
+}
+
+variable "acl" {
+  default = "private"
+}
+
+variable "region" {
+  default = "us-east-1"
+}
+
+resource "aws_s3_bucket" "bucket" {
+  bucket = var.name
+  acl    = var.acl
+  region = var.region
+}
+
+output "name" {
+  value = var.name
+}
+
+output "private" {
+  value = var.acl == "private"
+}
+
+output "id" {
+  value = aws_s3_bucket.bucket.id
+}